@@ -25,11 +25,20 @@ func RunMigrations(db *gorm.DB) error {
 		&monitoringpostgres.CheckResultEntity{},
 		&monitoringpostgres.MetricEntity{},
 		&monitoringpostgres.TargetStatisticsEntity{},
+		&monitoringpostgres.SeasonalBaselineEntity{},
+		&monitoringpostgres.PingSampleEntity{},
+		&monitoringpostgres.IncidentEntity{},
+		&monitoringpostgres.IncidentEventEntity{},
+		&monitoringpostgres.MaintenanceWindowEntity{},
+		&monitoringpostgres.MonitorRunEntity{},
+		&monitoringpostgres.SLOEntity{},
+		&monitoringpostgres.MetricRollupEntity{},
 
 		// Notification system
 		&notificationpostgres.TelegramLinkingToken{},
 		&notificationpostgres.NotificationChannelEntity{},
 		&notificationpostgres.NotificationEntity{},
+		&notificationpostgres.OutboxMessageEntity{},
 	)
 
 	if err != nil {
@@ -43,6 +52,12 @@ func RunMigrations(db *gorm.DB) error {
 		log.Printf("⚠️  Error updating notification_channels table: %v", err)
 	}
 
+	// El umbral mínimo de latencia acepta 0: sin default en la columna, el valor guardado es el del dominio
+	err = db.Exec(`ALTER TABLE monitoring_targets ALTER COLUMN min_latency_threshold_ms DROP DEFAULT`).Error
+	if err != nil {
+		log.Printf("⚠️  Error updating monitoring_targets table: %v", err)
+	}
+
 	log.Println("✅ Migraciones completadas")
 	return nil
 }
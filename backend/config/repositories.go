@@ -11,10 +11,15 @@ import (
 
 // Repositories contiene todas las interfaces de repositorios
 type Repositories struct {
-	TargetRepo  domain.MonitoringTargetRepository
-	CheckRepo   domain.CheckResultRepository
-	MetricsRepo domain.MetricsRepository
-	StatsRepo   domain.TargetStatisticsRepository
+	TargetRepo   domain.MonitoringTargetRepository
+	CheckRepo    domain.CheckResultRepository
+	MetricsRepo  domain.MetricsRepository
+	StatsRepo    domain.TargetStatisticsRepository
+	SeasonalRepo domain.SeasonalBaselineRepository
+	PingRepo     domain.PingSampleRepository
+	IncidentRepo domain.IncidentRepository
+	RunRepo      domain.MonitorRunRepository
+	RollupRepo   domain.MetricRollupRepository
 
 	UserRepo       *userpostgres.UserRepository
 	CredentialRepo *securitypostgres.CredentialRepository
@@ -23,10 +28,15 @@ type Repositories struct {
 // InitRepositories inicializa todos los repositorios con la DB
 func InitRepositories(db *gorm.DB) *Repositories {
 	return &Repositories{
-		TargetRepo:  monitoringpostgres.NewPostgresMonitoringTargetRepository(db),
-		CheckRepo:   monitoringpostgres.NewPostgresCheckResultRepository(db),
-		MetricsRepo: monitoringpostgres.NewPostgresMetricsRepository(db),
-		StatsRepo:   monitoringpostgres.NewPostgresTargetStatisticsRepository(db),
+		TargetRepo:   monitoringpostgres.NewPostgresMonitoringTargetRepository(db),
+		CheckRepo:    monitoringpostgres.NewPostgresCheckResultRepository(db),
+		MetricsRepo:  monitoringpostgres.NewPostgresMetricsRepository(db),
+		StatsRepo:    monitoringpostgres.NewPostgresTargetStatisticsRepository(db),
+		SeasonalRepo: monitoringpostgres.NewPostgresSeasonalBaselineRepository(db),
+		PingRepo:     monitoringpostgres.NewPostgresPingSampleRepository(db),
+		IncidentRepo: monitoringpostgres.NewPostgresIncidentRepository(db),
+		RunRepo:      monitoringpostgres.NewPostgresMonitorRunRepository(db),
+		RollupRepo:   monitoringpostgres.NewPostgresMetricRollupRepository(db),
 
 		UserRepo:       userpostgres.NewUserRepository(db),
 		CredentialRepo: securitypostgres.NewCredentialRepository(db),
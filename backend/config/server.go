@@ -18,6 +18,12 @@ type HTTPHandler interface {
 	RegisterRoutes(router *gin.RouterGroup)
 }
 
+// PublicHTTPHandler lo implementan los handlers que además exponen rutas sin autenticación
+// (links de un solo uso abiertos desde un email, webhooks de terceros)
+type PublicHTTPHandler interface {
+	RegisterPublicRoutes(router *gin.RouterGroup)
+}
+
 // StartHTTPServer inicia el servidor HTTP con Gin en modo release
 // NO recibe Repositories - handlers ya tienen sus dependencias inyectadas
 func StartHTTPServer(port string, telemetry *observability.Telemetry, handlers ...HTTPHandler) {
@@ -62,17 +68,18 @@ func StartHTTPServer(port string, telemetry *observability.Telemetry, handlers .
 
 	// Separate public auth routes from protected API routes
 	var securityHandler *presentation.SecurityHandler
-	publicHandlers := []HTTPHandler{}
+	publicHandlers := []PublicHTTPHandler{}
 	protectedHandlers := []HTTPHandler{}
 
 	for _, handler := range handlers {
 		if sh, ok := handler.(*presentation.SecurityHandler); ok {
 			securityHandler = sh
 		} else {
-			// Check if handler should be public (e.g., webhooks)
-			// For now, we'll register webhooks separately below
 			protectedHandlers = append(protectedHandlers, handler)
 		}
+		if ph, ok := handler.(PublicHTTPHandler); ok {
+			publicHandlers = append(publicHandlers, ph)
+		}
 	}
 
 	// Auth routes (públicas - sin middleware de autenticación)
@@ -81,11 +88,11 @@ func StartHTTPServer(port string, telemetry *observability.Telemetry, handlers .
 		securityHandler.RegisterRoutes(auth)
 	}
 
-	// Public API routes (no auth) - for webhooks
+	// Public API routes (no auth) - one-time links and webhooks
 	if len(publicHandlers) > 0 {
-		publicAPI := router.Group("/api")
+		publicAPI := router.Group("/api/v1")
 		for _, handler := range publicHandlers {
-			handler.RegisterRoutes(publicAPI)
+			handler.RegisterPublicRoutes(publicAPI)
 		}
 	}
 
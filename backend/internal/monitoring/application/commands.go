@@ -1,6 +1,7 @@
 package application
 
 import (
+	"time"
 	"uptrackai/internal/monitoring/domain"
 	userdomain "uptrackai/internal/user/domain"
 )
@@ -43,4 +44,80 @@ type UpdateConfigurationCommand struct {
 	CheckIntervalSeconds int
 	AlertOnFailure       bool
 	AlertOnRecovery      bool
+	// Umbrales del analizador (nil = conservar el valor actual del target)
+	DegradationFactor     *float64
+	MinLatencyThresholdMs *int
+	MaxLatencyThresholdMs *int
+	UnstableMinPings      *int
+	UnstableMaxPings      *int
+	BaselineMetric        *string           // MEAN | P95
+	AnomalyZThreshold     *float64          // Sensibilidad del detector (menor = más sensible)
+	ErrorClassPolicy      map[string]string // Clase de error -> estado (solo las enviadas cambian)
+	// Pings crudos por sesión (nil = conservar el valor actual del target)
+	StoreRawSamples         *bool
+	RawSamplesRetentionDays *int
+	// Estados que cuentan como disponibles para el uptime (nil = conservar; UP es obligatorio)
+	AvailableStatuses []string
+}
+
+// TestTargetCommand - Mismo payload que CreateTargetCommand, pero solo para sondeo (dry-run)
+type TestTargetCommand struct {
+	UserID     userdomain.UserId
+	Name       string
+	URL        string
+	TargetType domain.TargetType
+}
+
+// Incidentes: acknowledge, comentario y cierre manual
+
+type AcknowledgeIncidentCommand struct {
+	IncidentID domain.IncidentId
+	UserID     userdomain.UserId
+	Note       string
+}
+
+type CommentIncidentCommand struct {
+	IncidentID domain.IncidentId
+	UserID     userdomain.UserId
+	Note       string
+}
+
+type ResolveIncidentCommand struct {
+	IncidentID domain.IncidentId
+	UserID     userdomain.UserId
+	Note       string
+}
+
+// Ventanas de mantenimiento (excluidas del uptime)
+
+type CreateMaintenanceWindowCommand struct {
+	TargetID domain.TargetId
+	UserID   userdomain.UserId
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   string
+}
+
+type DeleteMaintenanceWindowCommand struct {
+	TargetID domain.TargetId
+	WindowID domain.MaintenanceWindowId
+	UserID   userdomain.UserId
+}
+
+// SLOs: objetivo de disponibilidad o latencia con presupuesto de error
+
+type CreateSLOCommand struct {
+	TargetID           domain.TargetId
+	UserID             userdomain.UserId
+	Name               string
+	Kind               string  // AVAILABILITY | LATENCY
+	Objective          float64 // Porcentaje, ej: 99.9
+	WindowDays         int
+	LatencyThresholdMs int // Solo LATENCY
+}
+
+type DeleteSLOCommand struct {
+	TargetID domain.TargetId
+	SLOID    domain.SLOId
+	UserID   userdomain.UserId
 }
@@ -2,6 +2,7 @@ package application
 
 // DTOs (Data Transfer Objects)
 import (
+	"math"
 	"time"
 	"uptrackai/internal/monitoring/domain"
 )
@@ -17,6 +18,8 @@ type MonitoringTargetSummaryDTO struct {
 	LastCheckedAt    string `json:"last_checked_at,omitempty"`
 	LastResponseTime int    `json:"last_response_time,omitempty"`
 	AvgResponseTime  int    `json:"avg_response_time,omitempty"`
+	// Uptime % por ventana ("24h", "7d", "30d", "90d"); null = sin tiempo medido
+	Uptime map[string]*float64 `json:"uptime,omitempty"`
 }
 
 func ToMonitoringTargetSummaryDTO(target *domain.MonitoringTarget, stats *domain.TargetStatistics) MonitoringTargetSummaryDTO {
@@ -56,6 +59,27 @@ type MonitoringTargetDetailDTO struct {
 	LastCheckedAt    string                 `json:"last_checked_at,omitempty"`
 	LastResponseTime int                    `json:"last_response_time,omitempty"`
 	Configuration    map[string]interface{} `json:"configuration"`
+	Hosting          *HostingDTO            `json:"hosting,omitempty"` // nil hasta la primera resolución
+}
+
+// HostingDTO - Dónde está alojado el target (base offline de proveedores/ASN)
+type HostingDTO struct {
+	IPs        []string `json:"ips"`
+	Provider   string   `json:"provider,omitempty"`
+	ASN        int      `json:"asn,omitempty"`
+	ResolvedAt string   `json:"resolved_at"`
+}
+
+func ToHostingDTO(info domain.HostingInfo) *HostingDTO {
+	if !info.IsResolved() {
+		return nil
+	}
+	return &HostingDTO{
+		IPs:        info.IPs(),
+		Provider:   info.Provider(),
+		ASN:        info.ASN(),
+		ResolvedAt: info.ResolvedAt().Format(time.RFC3339),
+	}
 }
 
 func ToMonitoringTargetDetailDTO(target *domain.MonitoringTarget) MonitoringTargetDetailDTO {
@@ -76,58 +100,666 @@ func ToMonitoringTargetDetailDTO(target *domain.MonitoringTarget) MonitoringTarg
 		}(),
 		LastResponseTime: target.LastResponseTime(),
 		Configuration: map[string]interface{}{
-			"timeout_seconds":     target.Configuration().TimeoutSeconds(),
-			"retry_count":         target.Configuration().RetryCount(),
-			"retry_delay_seconds": target.Configuration().RetryDelaySeconds(),
-			"alert_on_failure":    target.Configuration().AlertOnFailure(),
-			"alert_on_recovery":   target.Configuration().AlertOnRecovery(),
+			"timeout_seconds":            target.Configuration().TimeoutSeconds(),
+			"retry_count":                target.Configuration().RetryCount(),
+			"retry_delay_seconds":        target.Configuration().RetryDelaySeconds(),
+			"alert_on_failure":           target.Configuration().AlertOnFailure(),
+			"alert_on_recovery":          target.Configuration().AlertOnRecovery(),
+			"degradation_factor":         target.Configuration().Thresholds().DegradationFactor(),
+			"min_latency_threshold_ms":   target.Configuration().Thresholds().MinLatencyThresholdMs(),
+			"max_latency_threshold_ms":   target.Configuration().Thresholds().MaxLatencyThresholdMs(),
+			"unstable_min_pings":         target.Configuration().Thresholds().UnstableMinPings(),
+			"unstable_max_pings":         target.Configuration().Thresholds().UnstableMaxPings(),
+			"baseline_metric":            string(target.Configuration().Thresholds().BaselineMetric()),
+			"anomaly_z_threshold":        target.Configuration().Thresholds().ZScoreThreshold(),
+			"store_raw_samples":          target.Configuration().RawSamplesEnabled(),
+			"raw_samples_retention_days": target.Configuration().RawSamplesRetentionDays(),
+			"error_class_policy":         toErrorClassPolicyMap(target.Configuration().Thresholds().ErrorClassPolicy()),
+			"available_statuses":         toStatusStrings(target.Configuration().AvailabilityPolicy().Statuses()),
 		},
+		Hosting: ToHostingDTO(target.HostingInfo()),
+	}
+}
+
+// toErrorClassPolicyMap expone la política efectiva (clase -> estado) de un target
+func toErrorClassPolicyMap(policy domain.ErrorClassPolicy) map[string]string {
+	effective := policy.Effective()
+	result := make(map[string]string, len(effective))
+	for class, status := range effective {
+		result[class.String()] = status.String()
 	}
+	return result
 }
 
 // MetricDTO - DTO para métricas
 type MetricDTO struct {
-	Timestamp      time.Time `json:"timestamp"`
-	ResponseTimeMs int       `json:"response_time_ms"`
+	Timestamp         time.Time `json:"timestamp"`
+	Status            string    `json:"status"`
+	Reachable         bool      `json:"reachable"`
+	ResponseTimeMs    int       `json:"response_time_ms"`
+	MaxResponseTimeMs *int      `json:"max_response_time_ms,omitempty"` // nil en métricas antiguas
+	SuccessCount      *int      `json:"success_count,omitempty"`
+	FailureCount      *int      `json:"failure_count,omitempty"`
+	SessionID         string    `json:"session_id,omitempty"`
+	ErrorClass        string    `json:"error_class,omitempty"`
 }
 
 func ToMetricDTO(checkResult *domain.CheckResult) MetricDTO {
-	return MetricDTO{
+	dto := MetricDTO{
 		Timestamp:      checkResult.Timestamp(),
+		Status:         checkResult.Status().String(),
+		Reachable:      checkResult.Reachable(),
 		ResponseTimeMs: checkResult.ResponseTimeMs(),
+		SessionID:      checkResult.SessionId().String(),
+		ErrorClass:     checkResult.ErrorClass().String(),
+	}
+	if counts := checkResult.SessionCounts(); counts != nil {
+		dto.MaxResponseTimeMs = &counts.MaxResponseTimeMs
+		dto.SuccessCount = &counts.SuccessCount
+		dto.FailureCount = &counts.FailureCount
+	}
+	return dto
+}
+
+// MetricSeriesDTO - Serie de métricas de un rango en la resolución elegida
+type MetricSeriesDTO struct {
+	TargetID   string           `json:"target_id"`
+	Resolution string           `json:"resolution"`     // raw | 1m | 1h | 1d (con step, de dónde se re-agrupó)
+	Step       string           `json:"step,omitempty"` // Ancho de bucket pedido (ej: 5m)
+	From       string           `json:"from"`
+	To         string           `json:"to"`
+	Points     []MetricPointDTO `json:"points"`
+}
+
+// MetricPointDTO - Un bucket de la serie (en raw, una sesión: count 1)
+type MetricPointDTO struct {
+	Timestamp         time.Time `json:"timestamp"`
+	Count             int64     `json:"count"`
+	FailureCount      int64     `json:"failure_count"`
+	MinResponseTimeMs int       `json:"min_response_time_ms"`
+	MaxResponseTimeMs int       `json:"max_response_time_ms"`
+	AvgResponseTimeMs float64   `json:"avg_response_time_ms"`
+	P95ResponseTimeMs int       `json:"p95_response_time_ms"`
+	SessionID         string    `json:"session_id,omitempty"`  // Solo raw
+	ErrorClass        string    `json:"error_class,omitempty"` // Solo raw
+}
+
+func ToMetricPointDTO(rollup domain.MetricRollup) MetricPointDTO {
+	return MetricPointDTO{
+		Timestamp:         rollup.BucketStart,
+		Count:             rollup.Count,
+		FailureCount:      rollup.FailureCount,
+		MinResponseTimeMs: rollup.MinResponseTimeMs,
+		MaxResponseTimeMs: rollup.MaxResponseTimeMs,
+		AvgResponseTimeMs: math.Round(rollup.AvgResponseTimeMs*100) / 100,
+		P95ResponseTimeMs: rollup.P95ResponseTimeMs,
+	}
+}
+
+// ToRawMetricPointDTO - Misma regla que los rollups: DOWN, sin tiempo o con clase de error = fallida
+func ToRawMetricPointDTO(metric *domain.CheckResult) MetricPointDTO {
+	point := MetricPointDTO{
+		Timestamp:  metric.Timestamp(),
+		Count:      1,
+		SessionID:  metric.SessionId().String(),
+		ErrorClass: metric.ErrorClass().String(),
+	}
+	if metric.IsFailedSession() {
+		point.FailureCount = 1
+		return point
+	}
+	latency := metric.ResponseTimeMs()
+	point.MinResponseTimeMs = latency
+	point.MaxResponseTimeMs = latency
+	point.AvgResponseTimeMs = float64(latency)
+	point.P95ResponseTimeMs = latency
+	return point
+}
+
+// StatusTimelineDTO - Historial de estados en tramos de ancho fijo, listo para graficar
+type StatusTimelineDTO struct {
+	TargetID string            `json:"target_id"`
+	Step     string            `json:"step"`
+	From     string            `json:"from"`
+	To       string            `json:"to"`
+	Buckets  []StatusBucketDTO `json:"buckets"`
+}
+
+type StatusBucketDTO struct {
+	Start   time.Time `json:"start"`
+	Status  string    `json:"status"` // El peor estado del tramo
+	Changes int       `json:"changes"`
+}
+
+func ToStatusBucketDTO(bucket domain.StatusBucket) StatusBucketDTO {
+	return StatusBucketDTO{
+		Start:   bucket.Start,
+		Status:  bucket.Status.String(),
+		Changes: bucket.Changes,
 	}
 }
 
 // CheckResultDTO - DTO para historial de cambios de estado
 type CheckResultDTO struct {
-	Timestamp      time.Time `json:"timestamp"`
-	Status         string    `json:"status"`
-	ResponseTimeMs int       `json:"response_time_ms"`
-	ErrorMessage   string    `json:"error_message,omitempty"`
+	Timestamp         time.Time             `json:"timestamp"`
+	Status            string                `json:"status"`
+	ResponseTimeMs    int                   `json:"response_time_ms"`
+	ErrorMessage      string                `json:"error_message,omitempty"`
+	DegradationReason *DegradationReasonDTO `json:"degradation_reason,omitempty"`
+	SessionID         string                `json:"session_id,omitempty"`  // Para pedir los pings de la sesión
+	ErrorClass        string                `json:"error_class,omitempty"` // dns | connect_refused | ... | assertion
+}
+
+// DegradationReasonDTO - Por qué se disparó un DEGRADED
+type DegradationReasonDTO struct {
+	Rule     string  `json:"rule"`
+	Metric   string  `json:"metric"`
+	Observed float64 `json:"observed"`
+	Baseline float64 `json:"baseline"`
+	Score    float64 `json:"score"`
 }
 
 func ToCheckResultDTO(checkResult *domain.CheckResult) CheckResultDTO {
-	return CheckResultDTO{
+	dto := CheckResultDTO{
 		Timestamp:      checkResult.Timestamp(),
 		Status:         string(checkResult.Status()),
 		ResponseTimeMs: checkResult.ResponseTimeMs(),
 		ErrorMessage:   checkResult.ErrorMessage(),
+		SessionID:      checkResult.SessionId().String(),
+		ErrorClass:     checkResult.ErrorClass().String(),
+	}
+
+	if reason := checkResult.DegradationReason(); reason != nil {
+		dto.DegradationReason = &DegradationReasonDTO{
+			Rule:     reason.Rule,
+			Metric:   reason.Metric,
+			Observed: reason.Observed,
+			Baseline: reason.Baseline,
+			Score:    reason.Score,
+		}
+	}
+	return dto
+}
+
+// PingSampleDTO - DTO para un ping crudo de una sesión
+type PingSampleDTO struct {
+	Sequence       int       `json:"sequence"`
+	Timestamp      time.Time `json:"timestamp"`
+	Status         string    `json:"status"`
+	ResponseTimeMs int       `json:"response_time_ms"`
+	ErrorMessage   string    `json:"error_message,omitempty"`
+	ErrorClass     string    `json:"error_class,omitempty"`
+}
+
+func ToPingSampleDTO(sample *domain.PingSample) PingSampleDTO {
+	return PingSampleDTO{
+		Sequence:       sample.Sequence(),
+		Timestamp:      sample.Timestamp(),
+		Status:         string(sample.Status()),
+		ResponseTimeMs: sample.ResponseTimeMs(),
+		ErrorMessage:   sample.ErrorMessage(),
+		ErrorClass:     sample.ErrorClass().String(),
 	}
 }
 
 // StatisticsDTO - DTO para estadísticas agregadas
 type StatisticsDTO struct {
-	TargetID          string  `json:"target_id"`
-	TotalChecks       int     `json:"total_checks"`
-	AvgResponseTimeMs int     `json:"avg_response_time_ms"`
-	SuccessRate       float64 `json:"success_rate"`
+	TargetID              string  `json:"target_id"`
+	TotalChecks           int     `json:"total_checks"`
+	AvgResponseTimeMs     int     `json:"avg_response_time_ms"`
+	P50ResponseTimeMs     int     `json:"p50_response_time_ms"`
+	P90ResponseTimeMs     int     `json:"p90_response_time_ms"`
+	P95ResponseTimeMs     int     `json:"p95_response_time_ms"`
+	P99ResponseTimeMs     int     `json:"p99_response_time_ms"`
+	PercentileSampleCount int     `json:"percentile_sample_count"` // Pings en la ventana de 7 días
+	IsFlapping            bool    `json:"is_flapping"`
+	StateChangePercent    float64 `json:"state_change_percent"` // % ponderado de cambios en las últimas sesiones
+	SuccessRate           float64 `json:"success_rate"`
+	// Uptime/SLA por ventana móvil (y "range" si se pidió un rango)
+	AvailableStatuses []string                   `json:"available_statuses"`
+	Availability      map[string]AvailabilityDTO `json:"availability"`
+	// Tendencia del p95 diario; nil mientras no haya suficientes días
+	Forecast *LatencyForecastDTO `json:"forecast"`
+}
+
+// LatencyForecastDTO - Proyección lineal del p95 diario contra el umbral de degradación
+type LatencyForecastDTO struct {
+	SampleDays          int      `json:"sample_days"`
+	SlopeMsPerDay       float64  `json:"slope_ms_per_day"`
+	CurrentP95Ms        float64  `json:"current_p95_ms"` // Valor de la recta hoy
+	ThresholdMs         *int     `json:"threshold_ms"`   // nil si el target no tiene umbral aplicable
+	HorizonDays         int      `json:"horizon_days"`
+	DaysToThreshold     *float64 `json:"days_to_threshold"` // nil si la latencia no va hacia el umbral
+	ProjectedCrossingAt string   `json:"projected_crossing_at,omitempty"`
+	AtRisk              bool     `json:"at_risk"` // El cruce cae dentro del horizonte
+}
+
+func ToLatencyForecastDTO(forecast domain.LatencyForecast, now time.Time) *LatencyForecastDTO {
+	dto := &LatencyForecastDTO{
+		SampleDays:    forecast.Samples,
+		SlopeMsPerDay: math.Round(forecast.SlopeMsPerDay*100) / 100,
+		CurrentP95Ms:  math.Round(forecast.CurrentMs*100) / 100,
+		HorizonDays:   forecast.HorizonDays,
+		AtRisk:        forecast.WillCrossWithinHorizon(),
+	}
+	if forecast.ThresholdMs > 0 {
+		threshold := forecast.ThresholdMs
+		dto.ThresholdMs = &threshold
+	}
+	if forecast.DaysToThreshold != nil {
+		days := math.Round(*forecast.DaysToThreshold*10) / 10
+		dto.DaysToThreshold = &days
+		crossing := now.Add(time.Duration(*forecast.DaysToThreshold * float64(24*time.Hour)))
+		dto.ProjectedCrossingAt = crossing.UTC().Format(time.RFC3339)
+	}
+	return dto
+}
+
+// AvailabilityDTO - Uptime de una ventana. El porcentaje es sobre el tiempo medido:
+// mantenimiento, caídas del propio monitor y tramos sin datos no cuentan.
+type AvailabilityDTO struct {
+	From                string   `json:"from"`
+	To                  string   `json:"to"`
+	UptimePercent       *float64 `json:"uptime_percent"`
+	AvailableSeconds    int64    `json:"available_seconds"`
+	DowntimeSeconds     int64    `json:"downtime_seconds"`
+	MaintenanceSeconds  int64    `json:"maintenance_seconds"`
+	SelfDowntimeSeconds int64    `json:"self_downtime_seconds"`
+	UnknownSeconds      int64    `json:"unknown_seconds"`
+}
+
+func ToAvailabilityDTO(report domain.AvailabilityReport) AvailabilityDTO {
+	dto := AvailabilityDTO{
+		From:                report.Window.Start.UTC().Format(time.RFC3339),
+		To:                  report.Window.End.UTC().Format(time.RFC3339),
+		AvailableSeconds:    int64(report.Available.Seconds()),
+		DowntimeSeconds:     int64(report.Unavailable.Seconds()),
+		MaintenanceSeconds:  int64(report.Maintenance.Seconds()),
+		SelfDowntimeSeconds: int64(report.SelfDowntime.Seconds()),
+		UnknownSeconds:      int64(report.Unknown.Seconds()),
+	}
+	if percent, ok := report.UptimePercent(); ok {
+		rounded := math.Round(percent*1000) / 1000
+		dto.UptimePercent = &rounded
+	}
+	return dto
+}
+
+// ToUptimeSummary deja solo el porcentaje de cada ventana (para la lista de targets)
+func ToUptimeSummary(availability map[string]AvailabilityDTO) map[string]*float64 {
+	summary := make(map[string]*float64, len(availability))
+	for name, dto := range availability {
+		summary[name] = dto.UptimePercent
+	}
+	return summary
+}
+
+func toStatusStrings(statuses []domain.TargetStatus) []string {
+	result := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		result = append(result, status.String())
+	}
+	return result
+}
+
+// MaintenanceWindowDTO - Ventana de mantenimiento de un target
+type MaintenanceWindowDTO struct {
+	ID        string `json:"id"`
+	TargetID  string `json:"target_id"`
+	StartsAt  string `json:"starts_at"`
+	EndsAt    string `json:"ends_at"`
+	Reason    string `json:"reason,omitempty"`
+	CreatedBy string `json:"created_by,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+func ToMaintenanceWindowDTO(window *domain.MaintenanceWindow) MaintenanceWindowDTO {
+	return MaintenanceWindowDTO{
+		ID:        window.ID().String(),
+		TargetID:  window.TargetId().String(),
+		StartsAt:  window.StartsAt().UTC().Format(time.RFC3339),
+		EndsAt:    window.EndsAt().UTC().Format(time.RFC3339),
+		Reason:    window.Reason(),
+		CreatedBy: window.CreatedBy().String(),
+		CreatedAt: window.CreatedAt().UTC().Format(time.RFC3339),
+	}
+}
+
+// SLODTO - SLO con su estado al momento. SLI y presupuesto son nil sin datos en la ventana.
+type SLODTO struct {
+	ID                   string        `json:"id"`
+	TargetID             string        `json:"target_id"`
+	Name                 string        `json:"name,omitempty"`
+	Kind                 string        `json:"kind"`
+	Objective            float64       `json:"objective"`
+	WindowDays           int           `json:"window_days"`
+	LatencyThresholdMs   int           `json:"latency_threshold_ms,omitempty"`
+	SLI                  *float64      `json:"sli"`
+	ErrorBudgetRemaining *float64      `json:"error_budget_remaining"` // % del presupuesto sin consumir (negativo = incumplido)
+	BurnRates            []BurnRateDTO `json:"burn_rates"`
+	AlertLevel           string        `json:"alert_level"`
+	AlertChangedAt       string        `json:"alert_changed_at,omitempty"`
+	CreatedAt            string        `json:"created_at"`
+}
+
+// BurnRateDTO - Tasa de quemado de una ventana (1 = se agota justo al final del SLO)
+type BurnRateDTO struct {
+	Window  string  `json:"window"`
+	Rate    float64 `json:"rate"`
+	HasData bool    `json:"has_data"`
+}
+
+func ToSLODTO(slo *domain.SLO, status domain.SLOStatus) SLODTO {
+	dto := SLODTO{
+		ID:                 slo.ID().String(),
+		TargetID:           slo.TargetId().String(),
+		Name:               slo.Name(),
+		Kind:               slo.Kind().String(),
+		Objective:          slo.Objective(),
+		WindowDays:         slo.WindowDays(),
+		LatencyThresholdMs: slo.LatencyThresholdMs(),
+		BurnRates:          make([]BurnRateDTO, 0, len(status.BurnRates)),
+		AlertLevel:         status.AlertLevel.String(),
+		CreatedAt:          slo.CreatedAt().UTC().Format(time.RFC3339),
+	}
+	if status.HasData {
+		sli := math.Round(status.SLI*1000) / 1000
+		budget := math.Round(status.BudgetRemaining*100*100) / 100
+		dto.SLI = &sli
+		dto.ErrorBudgetRemaining = &budget
+	}
+	for _, reading := range status.BurnRates {
+		dto.BurnRates = append(dto.BurnRates, BurnRateDTO{
+			Window:  reading.Window.String(),
+			Rate:    math.Round(reading.Rate*100) / 100,
+			HasData: reading.HasData,
+		})
+	}
+	if !slo.AlertChangedAt().IsZero() {
+		dto.AlertChangedAt = slo.AlertChangedAt().UTC().Format(time.RFC3339)
+	}
+	return dto
 }
 
 func ToStatisticsDTO(targetId string, stats *domain.TargetStatistics) StatisticsDTO {
 	return StatisticsDTO{
-		TargetID:          targetId,
-		TotalChecks:       stats.TotalChecksCount(),
-		AvgResponseTimeMs: stats.AvgResponseTimeMs(),
-		SuccessRate:       100.0, // TODO: calcular cuando tengamos failed_checks_count
+		TargetID:              targetId,
+		TotalChecks:           stats.TotalChecksCount(),
+		AvgResponseTimeMs:     stats.AvgResponseTimeMs(),
+		P50ResponseTimeMs:     stats.P50ResponseTimeMs(),
+		P90ResponseTimeMs:     stats.P90ResponseTimeMs(),
+		P95ResponseTimeMs:     stats.P95ResponseTimeMs(),
+		P99ResponseTimeMs:     stats.P99ResponseTimeMs(),
+		PercentileSampleCount: stats.PercentileSampleCount(),
+		IsFlapping:            stats.IsFlapping(),
+		StateChangePercent:    stats.FlapHistory().StateChangePercent(),
+		SuccessRate:           100.0, // TODO: calcular cuando tengamos failed_checks_count
+	}
+}
+
+// TLSInfoDTO - DTO para el certificado TLS del target
+type TLSInfoDTO struct {
+	Version       string    `json:"version"`
+	Issuer        string    `json:"issuer"`
+	Subject       string    `json:"subject"`
+	NotAfter      time.Time `json:"not_after"`
+	DaysRemaining int       `json:"days_remaining"`
+}
+
+// ProbeReportDTO - DTO para el resultado de un sondeo en seco (dry-run)
+type ProbeReportDTO struct {
+	Passed            bool        `json:"passed"`
+	Status            string      `json:"status"`
+	Reachable         bool        `json:"reachable"`
+	ResponseTimeMs    int         `json:"response_time_ms"`
+	MaxResponseTimeMs int         `json:"max_response_time_ms"`
+	PingCount         int         `json:"ping_count"`
+	Stable            bool        `json:"stable"`
+	HTTPStatusCode    int         `json:"http_status_code,omitempty"`
+	TLS               *TLSInfoDTO `json:"tls,omitempty"`
+	AssertionFailures []string    `json:"assertion_failures"`
+	ErrorMessage      string      `json:"error_message,omitempty"`
+	ErrorClass        string      `json:"error_class,omitempty"`
+}
+
+func ToProbeReportDTO(report *domain.ProbeReport) ProbeReportDTO {
+	var tlsInfo *TLSInfoDTO
+	if report.TLS != nil {
+		tlsInfo = &TLSInfoDTO{
+			Version:       report.TLS.Version,
+			Issuer:        report.TLS.Issuer,
+			Subject:       report.TLS.Subject,
+			NotAfter:      report.TLS.NotAfter,
+			DaysRemaining: report.TLS.DaysRemaining,
+		}
+	}
+
+	// Siempre devolver un array (no null) para simplificar el frontend
+	failures := report.AssertionFailures
+	if failures == nil {
+		failures = []string{}
+	}
+
+	return ProbeReportDTO{
+		Passed:            report.Passed(),
+		Status:            report.Status.String(),
+		Reachable:         report.Reachable,
+		ResponseTimeMs:    report.AvgResponseTimeMs,
+		MaxResponseTimeMs: report.MaxResponseTimeMs,
+		PingCount:         report.PingCount,
+		Stable:            report.Stable,
+		HTTPStatusCode:    report.HTTPStatusCode,
+		TLS:               tlsInfo,
+		AssertionFailures: failures,
+		ErrorMessage:      report.ErrorMessage,
+		ErrorClass:        report.ErrorClass.String(),
+	}
+}
+
+// IncidentEventDTO - Entrada de la línea de tiempo de un incidente
+type IncidentEventDTO struct {
+	Type   string `json:"type"` // OPENED | STATUS_CHANGED | ACKNOWLEDGED | COMMENTED | RESOLVED
+	At     string `json:"at"`
+	Status string `json:"status,omitempty"`
+	Author string `json:"author,omitempty"` // Vacío = generado por el scheduler
+	Note   string `json:"note,omitempty"`
+}
+
+// IncidentDTO - Incidente con duración, tiempos de respuesta y línea de tiempo
+type IncidentDTO struct {
+	ID                       string             `json:"id"`
+	TargetID                 string             `json:"target_id"`
+	Status                   string             `json:"status"`
+	TriggerStatus            string             `json:"trigger_status"`
+	LastStatus               string             `json:"last_status"`
+	OpenedAt                 string             `json:"opened_at"`
+	AcknowledgedAt           string             `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy           string             `json:"acknowledged_by,omitempty"`
+	ResolvedAt               string             `json:"resolved_at,omitempty"`
+	ResolvedBy               string             `json:"resolved_by,omitempty"` // Vacío en incidentes resueltos = auto-resuelto
+	DurationSeconds          int64              `json:"duration_seconds"`      // Hasta ahora si sigue abierto
+	TimeToAcknowledgeSeconds *int64             `json:"time_to_acknowledge_seconds,omitempty"`
+	TimeToResolveSeconds     *int64             `json:"time_to_resolve_seconds,omitempty"`
+	Events                   []IncidentEventDTO `json:"events"`
+}
+
+// IncidentListDTO - Incidentes filtrados más MTTA/MTTR sobre ese mismo conjunto
+type IncidentListDTO struct {
+	Incidents    []IncidentDTO `json:"incidents"`
+	Total        int           `json:"total"`
+	Open         int           `json:"open"`
+	MTTASeconds  int64         `json:"mtta_seconds"`
+	MTTRSeconds  int64         `json:"mttr_seconds"`
+	Acknowledged int           `json:"acknowledged"` // Base del MTTA
+	Resolved     int           `json:"resolved"`     // Base del MTTR
+}
+
+func ToIncidentDTO(incident *domain.Incident, now time.Time) IncidentDTO {
+	dto := IncidentDTO{
+		ID:              incident.ID().String(),
+		TargetID:        incident.TargetId().String(),
+		Status:          incident.Status().String(),
+		TriggerStatus:   incident.TriggerStatus().String(),
+		LastStatus:      incident.LastStatus().String(),
+		OpenedAt:        incident.OpenedAt().Format(time.RFC3339),
+		AcknowledgedBy:  incident.AcknowledgedBy().String(),
+		ResolvedBy:      incident.ResolvedBy().String(),
+		DurationSeconds: int64(incident.Duration(now).Seconds()),
+		Events:          make([]IncidentEventDTO, 0, len(incident.Events())),
+	}
+	if tta, ok := incident.TimeToAcknowledge(); ok {
+		seconds := int64(tta.Seconds())
+		dto.AcknowledgedAt = incident.AcknowledgedAt().Format(time.RFC3339)
+		dto.TimeToAcknowledgeSeconds = &seconds
+	}
+	if ttr, ok := incident.TimeToResolve(); ok {
+		seconds := int64(ttr.Seconds())
+		dto.ResolvedAt = incident.ResolvedAt().Format(time.RFC3339)
+		dto.TimeToResolveSeconds = &seconds
+	}
+	for _, event := range incident.Events() {
+		dto.Events = append(dto.Events, IncidentEventDTO{
+			Type:   string(event.Type()),
+			At:     event.At().Format(time.RFC3339),
+			Status: event.Status().String(),
+			Author: event.Author().String(),
+			Note:   event.Note(),
+		})
+	}
+	return dto
+}
+
+func ToIncidentListDTO(incidents []*domain.Incident, now time.Time) IncidentListDTO {
+	metrics := domain.SummarizeIncidents(incidents)
+	dtos := make([]IncidentDTO, 0, len(incidents))
+	for _, incident := range incidents {
+		dtos = append(dtos, ToIncidentDTO(incident, now))
+	}
+	return IncidentListDTO{
+		Incidents:    dtos,
+		Total:        metrics.Total,
+		Open:         metrics.Open,
+		MTTASeconds:  int64(metrics.MTTA.Seconds()),
+		MTTRSeconds:  int64(metrics.MTTR.Seconds()),
+		Acknowledged: metrics.Acknowledged,
+		Resolved:     metrics.Resolved,
+	}
+}
+
+// TimelineEntryDTO - Un hecho de la línea de tiempo (horas en UTC)
+type TimelineEntryDTO struct {
+	At       string   `json:"at"`
+	Kind     string   `json:"kind"` // FIRST_FAILURE | CONFIRMED | INCIDENT_OPENED | STATUS_CHANGE | NOTIFICATION | ACKNOWLEDGED | COMMENT | RECOVERED | RESOLVED
+	Status   string   `json:"status,omitempty"`
+	Summary  string   `json:"summary"`
+	Actor    string   `json:"actor,omitempty"`
+	Channels []string `json:"channels,omitempty"`
+}
+
+// IncidentTimelineDTO - Línea de tiempo exportable (JSON o Markdown) de un incidente
+type IncidentTimelineDTO struct {
+	IncidentID               string             `json:"incident_id"`
+	TargetID                 string             `json:"target_id"`
+	TargetName               string             `json:"target_name"`
+	TargetURL                string             `json:"target_url"`
+	Status                   string             `json:"status"`
+	OpenedAt                 string             `json:"opened_at"`
+	ResolvedAt               string             `json:"resolved_at,omitempty"`
+	DurationSeconds          int64              `json:"duration_seconds"`
+	TimeToAcknowledgeSeconds *int64             `json:"time_to_acknowledge_seconds,omitempty"`
+	TimeToResolveSeconds     *int64             `json:"time_to_resolve_seconds,omitempty"`
+	GeneratedAt              string             `json:"generated_at"`
+	Entries                  []TimelineEntryDTO `json:"entries"`
+}
+
+func ToIncidentTimelineDTO(timeline *domain.IncidentTimeline, target *domain.MonitoringTarget, now time.Time) IncidentTimelineDTO {
+	incident := timeline.Incident()
+	dto := IncidentTimelineDTO{
+		IncidentID:      incident.ID().String(),
+		TargetID:        incident.TargetId().String(),
+		TargetName:      target.Name(),
+		TargetURL:       target.Url(),
+		Status:          incident.Status().String(),
+		OpenedAt:        incident.OpenedAt().UTC().Format(time.RFC3339),
+		DurationSeconds: int64(incident.Duration(now).Seconds()),
+		GeneratedAt:     now.UTC().Format(time.RFC3339),
+		Entries:         make([]TimelineEntryDTO, 0, len(timeline.Entries())),
+	}
+	if incident.IsResolved() {
+		dto.ResolvedAt = incident.ResolvedAt().UTC().Format(time.RFC3339)
+	}
+	if tta, ok := incident.TimeToAcknowledge(); ok {
+		seconds := int64(tta.Seconds())
+		dto.TimeToAcknowledgeSeconds = &seconds
+	}
+	if ttr, ok := incident.TimeToResolve(); ok {
+		seconds := int64(ttr.Seconds())
+		dto.TimeToResolveSeconds = &seconds
+	}
+	for _, entry := range timeline.Entries() {
+		dto.Entries = append(dto.Entries, TimelineEntryDTO{
+			At:       entry.At.UTC().Format(time.RFC3339),
+			Kind:     string(entry.Kind),
+			Status:   entry.Status.String(),
+			Summary:  entry.Summary,
+			Actor:    entry.Actor,
+			Channels: entry.Channels,
+		})
+	}
+	return dto
+}
+
+// TargetRankingReportDTO - Reporte comparativo de targets, del peor al mejor según SortBy
+type TargetRankingReportDTO struct {
+	From    string             `json:"from"`
+	To      string             `json:"to"`
+	SortBy  string             `json:"sort_by"`
+	Targets []TargetRankingDTO `json:"targets"`
+}
+
+// TargetRankingDTO - Fila del reporte (nil = sin datos en la ventana)
+type TargetRankingDTO struct {
+	Rank                int      `json:"rank"`
+	TargetID            string   `json:"target_id"`
+	TargetName          string   `json:"target_name"`
+	URL                 string   `json:"url"`
+	UptimePercent       *float64 `json:"uptime_percent"`
+	Sessions            int64    `json:"sessions"`
+	FailedSessions      int64    `json:"failed_sessions"`
+	AvgResponseTimeMs   *float64 `json:"avg_response_time_ms"`
+	P95ResponseTimeMs   *int     `json:"p95_response_time_ms"`
+	Incidents           int      `json:"incidents"`
+	MTTRSeconds         *int64   `json:"mttr_seconds"`
+	LatencyTrendPercent *float64 `json:"latency_trend_percent"` // Ventana vs promedio histórico; positivo = peor
+}
+
+func ToTargetRankingDTO(row domain.TargetRanking) TargetRankingDTO {
+	dto := TargetRankingDTO{
+		Rank:           row.Rank,
+		TargetID:       row.Target.ID().String(),
+		TargetName:     row.Target.Name(),
+		URL:            row.Target.Url(),
+		Sessions:       row.Latency.Sessions,
+		FailedSessions: row.Latency.Failures,
+		Incidents:      row.Outages.Incidents,
+	}
+	if percent, ok := row.Availability.UptimePercent(); ok {
+		rounded := math.Round(percent*1000) / 1000
+		dto.UptimePercent = &rounded
+	}
+	if row.Latency.HealthySessions() > 0 {
+		avg := math.Round(row.Latency.AvgResponseTimeMs*100) / 100
+		p95 := row.Latency.P95ResponseTimeMs
+		dto.AvgResponseTimeMs = &avg
+		dto.P95ResponseTimeMs = &p95
+	}
+	if mttr, ok := row.Outages.MTTR(); ok {
+		seconds := int64(mttr.Seconds())
+		dto.MTTRSeconds = &seconds
+	}
+	if trend, ok := row.LatencyTrendPercent(); ok {
+		rounded := math.Round(trend*100) / 100
+		dto.LatencyTrendPercent = &rounded
 	}
+	return dto
 }
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"time"
 	"uptrackai/internal/monitoring/domain"
 	userdomain "uptrackai/internal/user/domain"
 )
@@ -23,13 +24,95 @@ type GetTargetMetricsQuery struct {
 	Limit    int
 }
 
+// GetTargetMetricSeriesQuery - To cero = ahora; Resolution vacía = se elige según el rango.
+// Con Step se re-agrupa en buckets de ese ancho y Resolution se ignora.
+type GetTargetMetricSeriesQuery struct {
+	TargetID   domain.TargetId
+	UserID     userdomain.UserId
+	From       time.Time
+	To         time.Time
+	Resolution string // raw | 1m | 1h | 1d
+	Step       time.Duration
+}
+
+// GetTargetHistoryQuery - Sin From: los últimos Limit cambios; con From: todos los del rango (To cero = ahora)
 type GetTargetHistoryQuery struct {
 	TargetID domain.TargetId
 	UserID   userdomain.UserId
 	Limit    int
+	From     time.Time
+	To       time.Time
 }
 
+// GetTargetStatusTimelineQuery - Estados del rango agrupados en tramos de Step (To cero = ahora)
+type GetTargetStatusTimelineQuery struct {
+	TargetID domain.TargetId
+	UserID   userdomain.UserId
+	From     time.Time
+	To       time.Time
+	Step     time.Duration
+}
+
+// GetTargetRankingQuery - Compara los targets visibles para el usuario en [From, To) (To cero = ahora)
+type GetTargetRankingQuery struct {
+	UserID userdomain.UserId
+	Role   string
+	From   time.Time
+	To     time.Time
+	SortBy string // availability | p95 | incidents | mttr | trend (vacío = availability)
+}
+
+type GetSessionPingsQuery struct {
+	TargetID  domain.TargetId
+	SessionID domain.SessionId
+	UserID    userdomain.UserId
+}
+
+// GetTargetStatisticsQuery - From/To opcionales: agregan el uptime de un rango arbitrario
 type GetTargetStatisticsQuery struct {
 	TargetID domain.TargetId
 	UserID   userdomain.UserId
+	From     time.Time
+	To       time.Time // Cero o futuro = ahora
+	// ForecastHorizonDays - Plazo del pronóstico de latencia (0 = domain.DefaultForecastHorizonDays)
+	ForecastHorizonDays int
+}
+
+// ListIncidentsQuery - Filtros opcionales (vacíos = todos los incidentes del usuario)
+type ListIncidentsQuery struct {
+	UserID   userdomain.UserId
+	TargetID domain.TargetId
+	Status   domain.IncidentStatus
+	Since    time.Time
+	Until    time.Time
+	Limit    int
+}
+
+type GetIncidentQuery struct {
+	IncidentID domain.IncidentId
+	UserID     userdomain.UserId
+}
+
+type GetIncidentTimelineQuery struct {
+	IncidentID domain.IncidentId
+	UserID     userdomain.UserId
+}
+
+// ListMaintenanceWindowsQuery - To cero = sin límite (incluye las programadas a futuro)
+type ListMaintenanceWindowsQuery struct {
+	TargetID domain.TargetId
+	UserID   userdomain.UserId
+	From     time.Time
+	To       time.Time
+}
+
+type ListSLOsQuery struct {
+	TargetID domain.TargetId
+	UserID   userdomain.UserId
+}
+
+type GetSLOQuery struct {
+	TargetID domain.TargetId
+	SLOID    domain.SLOId
+	UserID   userdomain.UserId
 }
@@ -0,0 +1,65 @@
+package application
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+)
+
+// rankingCSVHeader - Columnas del CSV (vacío = sin datos en la ventana)
+var rankingCSVHeader = []string{
+	"rank", "target_id", "target_name", "url", "uptime_percent", "sessions", "failed_sessions",
+	"avg_response_time_ms", "p95_response_time_ms", "incidents", "mttr_seconds", "latency_trend_percent",
+}
+
+// RenderTargetRankingCSV arma el reporte comparativo en CSV para planillas
+func RenderTargetRankingCSV(report TargetRankingReportDTO) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.Write(rankingCSVHeader); err != nil {
+		return nil, err
+	}
+	for _, row := range report.Targets {
+		record := []string{
+			strconv.Itoa(row.Rank),
+			row.TargetID,
+			row.TargetName,
+			row.URL,
+			csvFloat(row.UptimePercent),
+			strconv.FormatInt(row.Sessions, 10),
+			strconv.FormatInt(row.FailedSessions, 10),
+			csvFloat(row.AvgResponseTimeMs),
+			csvInt(row.P95ResponseTimeMs),
+			strconv.Itoa(row.Incidents),
+			csvInt64(row.MTTRSeconds),
+			csvFloat(row.LatencyTrendPercent),
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+func csvFloat(value *float64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*value, 'f', -1, 64)
+}
+
+func csvInt(value *int) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.Itoa(*value)
+}
+
+func csvInt64(value *int64) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatInt(*value, 10)
+}
@@ -3,21 +3,51 @@ package application
 import (
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 	"uptrackai/internal/monitoring/domain"
+	userdomain "uptrackai/internal/user/domain"
 )
 
+var ErrProberUnavailable = errors.New("dry-run prober not configured")
+
 // MonitoringApplicationService - Capa de aplicación que orquesta casos de uso
 // NO conoce infraestructura, solo interfaces del dominio
 type SchedulerInterface interface {
 	TriggerImmediateCheck(target *domain.MonitoringTarget)
 }
 
+// TargetProber ejecuta una sesión de sondeo sin persistir nada (dry-run)
+type TargetProber interface {
+	Probe(target *domain.MonitoringTarget) *domain.ProbeReport
+}
+
+// IncidentNotificationReader lee las alertas enviadas por un incidente (historial del módulo notifications)
+type IncidentNotificationReader interface {
+	FindByIncident(incidentId domain.IncidentId) ([]domain.IncidentNotificationRecord, error)
+}
+
+// IncidentActivityPublisher avisa a los canales cuando alguien reconoce o cierra a mano un incidente
+// (las herramientas de guardia lo usan para sincronizar su propio incidente)
+type IncidentActivityPublisher interface {
+	PublishIncidentActivity(incident *domain.Incident, target *domain.MonitoringTarget, activity domain.IncidentEvent)
+}
+
 type MonitoringApplicationService struct {
-	targetRepo  domain.MonitoringTargetRepository
-	metricsRepo domain.MetricsRepository
-	checkRepo   domain.CheckResultRepository
-	statsRepo   domain.TargetStatisticsRepository
-	scheduler   SchedulerInterface // Optional dependency for immediate checks
+	targetRepo    domain.MonitoringTargetRepository
+	metricsRepo   domain.MetricsRepository
+	checkRepo     domain.CheckResultRepository
+	statsRepo     domain.TargetStatisticsRepository
+	pingRepo      domain.PingSampleRepository
+	incidentRepo  domain.IncidentRepository
+	maintenance   domain.MaintenanceWindowRepository
+	sloRepo       domain.SLORepository
+	rollupRepo    domain.MetricRollupRepository
+	sli           *domain.SLIMeter
+	scheduler     SchedulerInterface         // Optional dependency for immediate checks
+	prober        TargetProber               // Optional dependency for dry-run validation
+	notifications IncidentNotificationReader // Optional dependency for incident timelines
+	activity      IncidentActivityPublisher  // Optional dependency for acknowledge/resolve alerts
 }
 
 func NewMonitoringApplicationService(
@@ -25,12 +55,24 @@ func NewMonitoringApplicationService(
 	metricsRepo domain.MetricsRepository,
 	checkRepo domain.CheckResultRepository,
 	statsRepo domain.TargetStatisticsRepository,
+	pingRepo domain.PingSampleRepository,
+	incidentRepo domain.IncidentRepository,
+	maintenance domain.MaintenanceWindowRepository,
+	runRepo domain.MonitorRunRepository,
+	sloRepo domain.SLORepository,
+	rollupRepo domain.MetricRollupRepository,
 ) *MonitoringApplicationService {
 	return &MonitoringApplicationService{
-		targetRepo:  targetRepo,
-		metricsRepo: metricsRepo,
-		checkRepo:   checkRepo,
-		statsRepo:   statsRepo,
+		targetRepo:   targetRepo,
+		metricsRepo:  metricsRepo,
+		checkRepo:    checkRepo,
+		statsRepo:    statsRepo,
+		pingRepo:     pingRepo,
+		incidentRepo: incidentRepo,
+		maintenance:  maintenance,
+		sloRepo:      sloRepo,
+		rollupRepo:   rollupRepo,
+		sli:          domain.NewSLIMeter(checkRepo, metricsRepo, maintenance, runRepo),
 	}
 }
 
@@ -38,6 +80,18 @@ func (s *MonitoringApplicationService) SetScheduler(scheduler SchedulerInterface
 	s.scheduler = scheduler
 }
 
+func (s *MonitoringApplicationService) SetProber(prober TargetProber) {
+	s.prober = prober
+}
+
+func (s *MonitoringApplicationService) SetNotificationHistory(notifications IncidentNotificationReader) {
+	s.notifications = notifications
+}
+
+func (s *MonitoringApplicationService) SetIncidentActivityPublisher(activity IncidentActivityPublisher) {
+	s.activity = activity
+}
+
 // ==================== COMMANDS (Escritura) ====================
 
 // CreateTarget - Crea un nuevo target de monitoreo
@@ -82,6 +136,25 @@ func (s *MonitoringApplicationService) CreateTarget(cmd CreateTargetCommand) (*M
 	return &dto, nil
 }
 
+// TestTarget - Ejecuta una sesión de sondeo con la configuración propuesta sin persistir nada
+// Evita crear/borrar targets de prueba que ensucian check_results y target_statistics
+func (s *MonitoringApplicationService) TestTarget(cmd TestTargetCommand) (*ProbeReportDTO, error) {
+	if s.prober == nil {
+		return nil, ErrProberUnavailable
+	}
+
+	if !cmd.TargetType.IsValid() {
+		return nil, domain.ErrInvalidTargetType
+	}
+
+	// Entidad efímera: sin ID y sin guardar en el repositorio
+	target := domain.NewMinimalMonitoringTarget(cmd.Name, cmd.URL, cmd.TargetType, cmd.UserID)
+
+	report := s.prober.Probe(target)
+	dto := ToProbeReportDTO(report)
+	return &dto, nil
+}
+
 // DeleteTarget - Elimina un target de monitoreo
 func (s *MonitoringApplicationService) DeleteTarget(cmd DeleteTargetCommand) error {
 	// Verificar existencia primero
@@ -158,6 +231,31 @@ func (s *MonitoringApplicationService) UpdateConfiguration(cmd UpdateConfigurati
 		newConfig.DisableRecoveryAlerts()
 	}
 
+	// Umbrales del analizador: se parte de los actuales y se pisan solo los enviados
+	thresholds, err := mergeThresholds(target.Configuration().Thresholds(), cmd)
+	if err != nil {
+		return nil, err
+	}
+	newConfig.UpdateThresholds(thresholds)
+
+	// Pings crudos: también parten de la configuración actual
+	if err := applyRawSamples(newConfig, target.Configuration(), cmd); err != nil {
+		return nil, err
+	}
+
+	// Estados que suman al uptime (nil = conservar los actuales)
+	policy := target.Configuration().AvailabilityPolicy()
+	if cmd.AvailableStatuses != nil {
+		statuses := make([]domain.TargetStatus, 0, len(cmd.AvailableStatuses))
+		for _, status := range cmd.AvailableStatuses {
+			statuses = append(statuses, domain.TargetStatus(strings.ToUpper(strings.TrimSpace(status))))
+		}
+		if policy, err = domain.NewAvailabilityPolicy(statuses); err != nil {
+			return nil, err
+		}
+	}
+	newConfig.UpdateAvailabilityPolicy(policy)
+
 	// Actualizar configuración del target
 	if err := target.UpdateConfiguration(newConfig); err != nil {
 		return nil, fmt.Errorf("failed to update configuration: %w", err)
@@ -173,6 +271,86 @@ func (s *MonitoringApplicationService) UpdateConfiguration(cmd UpdateConfigurati
 	return &dto, nil
 }
 
+// mergeThresholds aplica sobre los umbrales actuales los campos opcionales del comando
+func mergeThresholds(current domain.AnalyzerThresholds, cmd UpdateConfigurationCommand) (domain.AnalyzerThresholds, error) {
+	factor := current.DegradationFactor()
+	minMs := current.MinLatencyThresholdMs()
+	maxMs := current.MaxLatencyThresholdMs()
+	unstableMin := current.UnstableMinPings()
+	unstableMax := current.UnstableMaxPings()
+
+	if cmd.DegradationFactor != nil {
+		factor = *cmd.DegradationFactor
+	}
+	if cmd.MinLatencyThresholdMs != nil {
+		minMs = *cmd.MinLatencyThresholdMs
+	}
+	if cmd.MaxLatencyThresholdMs != nil {
+		maxMs = *cmd.MaxLatencyThresholdMs
+	}
+	if cmd.UnstableMinPings != nil {
+		unstableMin = *cmd.UnstableMinPings
+	}
+	if cmd.UnstableMaxPings != nil {
+		unstableMax = *cmd.UnstableMaxPings
+	}
+
+	thresholds, err := domain.NewAnalyzerThresholds(factor, minMs, maxMs, unstableMin, unstableMax)
+	if err != nil {
+		return thresholds, err
+	}
+
+	baseline := current.BaselineMetric()
+	if cmd.BaselineMetric != nil {
+		baseline = domain.BaselineMetric(*cmd.BaselineMetric)
+	}
+	if thresholds, err = thresholds.WithBaselineMetric(baseline); err != nil {
+		return thresholds, err
+	}
+
+	zScore := current.ZScoreThreshold()
+	if cmd.AnomalyZThreshold != nil {
+		zScore = *cmd.AnomalyZThreshold
+	}
+	if thresholds, err = thresholds.WithZScoreThreshold(zScore); err != nil {
+		return thresholds, err
+	}
+
+	policy := current.ErrorClassPolicy()
+	if cmd.ErrorClassPolicy != nil {
+		overrides := make(map[domain.ErrorClass]domain.TargetStatus, len(cmd.ErrorClassPolicy))
+		for class, status := range cmd.ErrorClassPolicy {
+			overrides[domain.ErrorClass(class)] = domain.TargetStatus(status)
+		}
+		if policy, err = policy.Merge(overrides); err != nil {
+			return thresholds, err
+		}
+	}
+	return thresholds.WithErrorClassPolicy(policy), nil
+}
+
+// applyRawSamples copia el opt-in de pings crudos y aplica los cambios opcionales del comando
+func applyRawSamples(config *domain.CheckConfiguration, current *domain.CheckConfiguration, cmd UpdateConfigurationCommand) error {
+	enabled := current.RawSamplesEnabled()
+	days := current.RawSamplesRetentionDays()
+
+	if cmd.StoreRawSamples != nil {
+		enabled = *cmd.StoreRawSamples
+	}
+	if cmd.RawSamplesRetentionDays != nil {
+		days = *cmd.RawSamplesRetentionDays
+	}
+
+	// Se valida la retención aunque quede deshabilitado: se conserva para cuando se reactive
+	if err := config.EnableRawSamples(days); err != nil {
+		return err
+	}
+	if !enabled {
+		config.DisableRawSamples()
+	}
+	return nil
+}
+
 // ==================== QUERIES (Lectura) ====================
 
 // UpdateTargetName - Actualiza el nombre de un target
@@ -227,11 +405,24 @@ func (s *MonitoringApplicationService) GetAllTargets(query GetAllTargetsQuery) (
 		}
 	}
 
+	// Uptime: los huecos del monitor son los mismos para todos los targets, y el historial
+	// de todos se lee de una vez (es el endpoint más consultado)
+	now := time.Now()
+	ranges := standardAvailabilityRanges(now)
+	var availability map[domain.TargetId]map[string]AvailabilityDTO
+	if selfDown, err := s.selfDowntime(ranges); err == nil {
+		// Si falla el cálculo los targets se listan igual, sin uptime
+		availability, _ = s.targetsAvailability(targets, ranges, selfDown)
+	}
+
 	// Convertir a Summary DTOs con estadísticas
 	dtos := make([]MonitoringTargetSummaryDTO, 0, len(targets))
 	for _, target := range targets {
 		stats := statsMap[string(target.ID())]
 		dto := ToMonitoringTargetSummaryDTO(target, stats)
+		if uptime, ok := availability[target.ID()]; ok {
+			dto.Uptime = ToUptimeSummary(uptime)
+		}
 		dtos = append(dtos, dto)
 	}
 
@@ -284,6 +475,107 @@ func (s *MonitoringApplicationService) GetTargetMetrics(query GetTargetMetricsQu
 	return dtos, nil
 }
 
+// GetTargetMetricSeries - Métricas de un rango con la resolución adecuada:
+// sesiones crudas para rangos cortos y rollups 1m/1h/1d para los largos
+func (s *MonitoringApplicationService) GetTargetMetricSeries(query GetTargetMetricSeriesQuery) (*MetricSeriesDTO, error) {
+	if _, err := s.ownedTarget(query.TargetID, query.UserID); err != nil {
+		return nil, err
+	}
+
+	window, err := queryWindow(query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+	if query.Step > 0 {
+		return s.metricSeriesByStep(query.TargetID, window, query.Step)
+	}
+
+	resolution := domain.SelectMetricResolution(window.Duration())
+	if query.Resolution != "" {
+		if resolution, err = domain.NewMetricResolution(query.Resolution); err != nil {
+			return nil, err
+		}
+		if err := domain.ValidateMetricResolution(resolution, window.Duration()); err != nil {
+			return nil, err
+		}
+	}
+
+	series := MetricSeriesDTO{
+		TargetID:   query.TargetID.String(),
+		Resolution: resolution.String(),
+		From:       window.Start.UTC().Format(time.RFC3339),
+		To:         window.End.UTC().Format(time.RFC3339),
+	}
+
+	if resolution == domain.MetricResolutionRaw {
+		metrics, err := s.metricsRepo.GetByTargetIDBetween(query.TargetID, window.Start, window.End)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch metrics: %w", err)
+		}
+		series.Points = make([]MetricPointDTO, 0, len(metrics))
+		for _, metric := range metrics {
+			series.Points = append(series.Points, ToRawMetricPointDTO(metric))
+		}
+		return &series, nil
+	}
+
+	// El primer bucket incluye el inicio del rango aunque empiece antes
+	rollups, err := s.rollupRepo.ListByTarget(query.TargetID, resolution, resolution.BucketStart(window.Start), window.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metric rollups: %w", err)
+	}
+	series.Points = make([]MetricPointDTO, 0, len(rollups))
+	for _, rollup := range rollups {
+		series.Points = append(series.Points, ToMetricPointDTO(rollup))
+	}
+	return &series, nil
+}
+
+// metricSeriesByStep re-agrupa la serie en buckets de `step` desde la fuente más barata que lo permite
+func (s *MonitoringApplicationService) metricSeriesByStep(targetId domain.TargetId, window domain.TimeRange, step time.Duration) (*MetricSeriesDTO, error) {
+	source, err := domain.SelectStepSource(step, window.Duration())
+	if err != nil {
+		return nil, err
+	}
+	first := domain.StepBucketStart(window.Start, step)
+
+	var buckets []domain.MetricRollup
+	if source == domain.MetricResolutionRaw {
+		metrics, err := s.metricsRepo.GetByTargetIDBetween(targetId, first, window.End)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch metrics: %w", err)
+		}
+		buckets = domain.BucketSessions(targetId, metrics, step)
+	} else {
+		rollups, err := s.rollupRepo.ListByTarget(targetId, source, first, window.End)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch metric rollups: %w", err)
+		}
+		buckets = domain.ResampleRollups(rollups, step)
+	}
+
+	series := MetricSeriesDTO{
+		TargetID:   targetId.String(),
+		Resolution: source.String(),
+		Step:       domain.FormatMetricStep(step),
+		From:       window.Start.UTC().Format(time.RFC3339),
+		To:         window.End.UTC().Format(time.RFC3339),
+		Points:     make([]MetricPointDTO, 0, len(buckets)),
+	}
+	for _, bucket := range buckets {
+		series.Points = append(series.Points, ToMetricPointDTO(bucket))
+	}
+	return &series, nil
+}
+
+// queryWindow arma el rango de una consulta de series (to cero = ahora)
+func queryWindow(from time.Time, to time.Time) (domain.TimeRange, error) {
+	if to.IsZero() {
+		to = time.Now()
+	}
+	return domain.NewTimeRange(from, to)
+}
+
 // GetTargetHistory - Obtiene historial de cambios de estado
 // Retorna DTOs, NO entidades de dominio
 func (s *MonitoringApplicationService) GetTargetHistory(query GetTargetHistoryQuery) ([]CheckResultDTO, error) {
@@ -297,8 +589,17 @@ func (s *MonitoringApplicationService) GetTargetHistory(query GetTargetHistoryQu
 		return nil, fmt.Errorf("unauthorized: user does not own this target")
 	}
 
-	// Obtener historial
-	history, err := s.checkRepo.GetByTargetID(query.TargetID, query.Limit)
+	// Obtener historial: con rango, todos los cambios del rango en orden cronológico
+	var history []*domain.CheckResult
+	if query.From.IsZero() {
+		history, err = s.checkRepo.GetByTargetID(query.TargetID, query.Limit)
+	} else {
+		window, rangeErr := queryWindow(query.From, query.To)
+		if rangeErr != nil {
+			return nil, rangeErr
+		}
+		history, err = s.checkRepo.GetByTargetIDBetween(query.TargetID, window.Start, window.End)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch history: %w", err)
 	}
@@ -312,6 +613,127 @@ func (s *MonitoringApplicationService) GetTargetHistory(query GetTargetHistoryQu
 	return dtos, nil
 }
 
+// GetTargetStatusTimeline - Historial de estados en tramos de Step con el peor estado de cada uno
+func (s *MonitoringApplicationService) GetTargetStatusTimeline(query GetTargetStatusTimelineQuery) (*StatusTimelineDTO, error) {
+	if _, err := s.ownedTarget(query.TargetID, query.UserID); err != nil {
+		return nil, err
+	}
+
+	window, err := queryWindow(query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateMetricStep(query.Step, window.Duration()); err != nil {
+		return nil, err
+	}
+
+	// El primer tramo puede empezar antes del rango: su estado de arranque es el último cambio previo
+	first := domain.StepBucketStart(window.Start, query.Step)
+	initial := domain.TargetStatusUnknown
+	previous, err := s.checkRepo.GetLastBefore(query.TargetID, first)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch history: %w", err)
+	}
+	if previous != nil {
+		initial = previous.Status()
+	}
+	changes, err := s.checkRepo.GetByTargetIDBetween(query.TargetID, first, window.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch history: %w", err)
+	}
+
+	buckets := domain.BucketStatusHistory(initial, changes, window, query.Step)
+	timeline := StatusTimelineDTO{
+		TargetID: query.TargetID.String(),
+		Step:     domain.FormatMetricStep(query.Step),
+		From:     window.Start.UTC().Format(time.RFC3339),
+		To:       window.End.UTC().Format(time.RFC3339),
+		Buckets:  make([]StatusBucketDTO, 0, len(buckets)),
+	}
+	for _, bucket := range buckets {
+		timeline.Buckets = append(timeline.Buckets, ToStatusBucketDTO(bucket))
+	}
+	return &timeline, nil
+}
+
+// GetTargetRanking - Reporte comparativo de los targets del usuario en una ventana.
+// Cada métrica sale de una consulta para todos los targets, no de una por target.
+func (s *MonitoringApplicationService) GetTargetRanking(query GetTargetRankingQuery) (*TargetRankingReportDTO, error) {
+	sortBy, err := domain.NewRankingMetric(query.SortBy)
+	if err != nil {
+		return nil, err
+	}
+	window, err := queryWindow(query.From, query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := s.targetRepo.ListByUserAndRole(query.UserID, query.Role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch targets: %w", err)
+	}
+	ids := make([]domain.TargetId, 0, len(targets))
+	for _, target := range targets {
+		ids = append(ids, target.ID())
+	}
+
+	previous, err := s.checkRepo.GetLastBeforeByTargets(ids, window.Start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch history: %w", err)
+	}
+	changes, err := s.checkRepo.GetByTargetsBetween(ids, window.Start, window.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch history: %w", err)
+	}
+	maintenance, err := s.maintenance.ListByTargets(ids, window.Start, window.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch maintenance windows: %w", err)
+	}
+	latency, err := s.metricsRepo.SummarizeLatency(ids, window.Start, window.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metrics: %w", err)
+	}
+	stats, err := s.statsRepo.GetByTargets(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch statistics: %w", err)
+	}
+	selfDown, err := s.sli.SelfDowntime(window)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]domain.TargetRanking, 0, len(targets))
+	for _, target := range targets {
+		transitions := domain.TransitionsOf(previous[target.ID()], changes[target.ID()])
+		exclusions := domain.AvailabilityExclusions{
+			Maintenance:  domain.MaintenanceRanges(maintenance[target.ID()]),
+			SelfDowntime: selfDown,
+		}
+		row := domain.TargetRanking{
+			Target:       target,
+			Availability: domain.NewStatusHistory(transitions).Availability(window, exclusions, target.Configuration().AvailabilityPolicy()),
+			Latency:      latency[target.ID()],
+			Outages:      domain.SummarizeOutages(transitions, window),
+		}
+		if targetStats := stats[target.ID()]; targetStats != nil {
+			row.BaselineResponseTimeMs = targetStats.AvgResponseTimeMs()
+		}
+		rows = append(rows, row)
+	}
+	domain.RankTargets(rows, sortBy)
+
+	report := TargetRankingReportDTO{
+		From:    window.Start.UTC().Format(time.RFC3339),
+		To:      window.End.UTC().Format(time.RFC3339),
+		SortBy:  sortBy.String(),
+		Targets: make([]TargetRankingDTO, 0, len(rows)),
+	}
+	for _, row := range rows {
+		report.Targets = append(report.Targets, ToTargetRankingDTO(row))
+	}
+	return &report, nil
+}
+
 // GetTargetStatistics - Obtiene estadísticas agregadas
 // Retorna DTO, NO entidad de dominio
 func (s *MonitoringApplicationService) GetTargetStatistics(query GetTargetStatisticsQuery) (*StatisticsDTO, error) {
@@ -331,7 +753,485 @@ func (s *MonitoringApplicationService) GetTargetStatistics(query GetTargetStatis
 		return nil, fmt.Errorf("statistics not found: %w", err)
 	}
 
+	// Uptime por ventana móvil, más el rango pedido (si hay)
+	now := time.Now()
+	ranges := standardAvailabilityRanges(now)
+	if !query.From.IsZero() {
+		to := query.To
+		if to.IsZero() || to.After(now) {
+			to = now
+		}
+		window, err := domain.NewTimeRange(query.From, to)
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, availabilityRange{name: CustomAvailabilityRange, window: window})
+	}
+
+	selfDown, err := s.selfDowntime(ranges)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch monitor runs: %w", err)
+	}
+	availability, err := s.targetAvailability(target, ranges, selfDown)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute availability: %w", err)
+	}
+
+	// Tendencia del p95 diario (rollups 1d) contra el umbral de degradación
+	horizon := query.ForecastHorizonDays
+	if horizon <= 0 {
+		horizon = domain.DefaultForecastHorizonDays
+	}
+	lookback := domain.ForecastRange(now)
+	daily, err := s.rollupRepo.ListByTarget(query.TargetID, domain.MetricResolutionDay, lookback.Start, lookback.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metric rollups: %w", err)
+	}
+
 	// Convertir a DTO
 	dto := ToStatisticsDTO(string(query.TargetID), stats)
+	dto.AvailableStatuses = toStatusStrings(target.Configuration().AvailabilityPolicy().Statuses())
+	dto.Availability = availability
+	if forecast, ok := domain.ForecastTargetLatency(target, stats, daily, horizon, now); ok {
+		dto.Forecast = ToLatencyForecastDTO(forecast, now)
+	}
 	return &dto, nil
 }
+
+// GetSessionPings - Obtiene los pings crudos de una sesión (solo si el target los guardaba)
+func (s *MonitoringApplicationService) GetSessionPings(query GetSessionPingsQuery) ([]PingSampleDTO, error) {
+	// Verificar ownership
+	target, err := s.targetRepo.GetByID(query.TargetID)
+	if err != nil {
+		return nil, fmt.Errorf("target not found: %w", err)
+	}
+
+	if target.UserId() != query.UserID {
+		return nil, fmt.Errorf("unauthorized: user does not own this target")
+	}
+
+	samples, err := s.pingRepo.GetBySession(query.TargetID, query.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch pings: %w", err)
+	}
+	if len(samples) == 0 {
+		return nil, domain.ErrSessionNotFound
+	}
+
+	dtos := make([]PingSampleDTO, 0, len(samples))
+	for _, sample := range samples {
+		dtos = append(dtos, ToPingSampleDTO(sample))
+	}
+
+	return dtos, nil
+}
+
+// ==================== INCIDENTS ====================
+
+// ListIncidents - Incidentes del usuario (más recientes primero) con MTTA/MTTR del conjunto
+func (s *MonitoringApplicationService) ListIncidents(query ListIncidentsQuery) (*IncidentListDTO, error) {
+	incidents, err := s.incidentRepo.List(domain.IncidentFilter{
+		UserId:   query.UserID,
+		TargetId: query.TargetID,
+		Status:   query.Status,
+		Since:    query.Since,
+		Until:    query.Until,
+		Limit:    query.Limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list incidents: %w", err)
+	}
+
+	dto := ToIncidentListDTO(incidents, time.Now())
+	return &dto, nil
+}
+
+// GetIncident - Un incidente con su línea de tiempo
+func (s *MonitoringApplicationService) GetIncident(query GetIncidentQuery) (*IncidentDTO, error) {
+	incident, err := s.ownedIncident(query.IncidentID, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	dto := ToIncidentDTO(incident, time.Now())
+	return &dto, nil
+}
+
+// AcknowledgeIncident - Alguien se hace cargo (fija el MTTA)
+func (s *MonitoringApplicationService) AcknowledgeIncident(cmd AcknowledgeIncidentCommand) (*IncidentDTO, error) {
+	incident, dto, err := s.updateIncident(cmd.IncidentID, cmd.UserID, func(incident *domain.Incident, now time.Time) error {
+		return incident.Acknowledge(cmd.UserID, cmd.Note, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishIncidentActivity(incident)
+	return dto, nil
+}
+
+// CommentIncident - Agrega una nota a la línea de tiempo
+func (s *MonitoringApplicationService) CommentIncident(cmd CommentIncidentCommand) (*IncidentDTO, error) {
+	_, dto, err := s.updateIncident(cmd.IncidentID, cmd.UserID, func(incident *domain.Incident, now time.Time) error {
+		return incident.Comment(cmd.UserID, cmd.Note, now)
+	})
+	return dto, err
+}
+
+// ResolveIncident - Cierre manual (sin esperar a que el target se recupere)
+func (s *MonitoringApplicationService) ResolveIncident(cmd ResolveIncidentCommand) (*IncidentDTO, error) {
+	incident, dto, err := s.updateIncident(cmd.IncidentID, cmd.UserID, func(incident *domain.Incident, now time.Time) error {
+		return incident.Resolve(cmd.UserID, cmd.Note, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishIncidentActivity(incident)
+	return dto, nil
+}
+
+// GetIncidentTimeline - Línea de tiempo del incidente para post-mortems
+// (check_results + primer ping fallido + notificaciones + registro del incidente)
+func (s *MonitoringApplicationService) GetIncidentTimeline(query GetIncidentTimelineQuery) (*IncidentTimelineDTO, error) {
+	incident, err := s.ownedIncident(query.IncidentID, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.targetRepo.GetByID(incident.TargetId())
+	if err != nil {
+		return nil, fmt.Errorf("target not found: %w", err)
+	}
+
+	now := time.Now()
+	until := now
+	if incident.IsResolved() {
+		until = incident.ResolvedAt()
+	}
+	results, err := s.checkRepo.GetByTargetIDBetween(incident.TargetId(), incident.OpenedAt().Add(-domain.TimelineLookback), until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch status changes: %w", err)
+	}
+
+	// El primer ping fallido solo existe si el target guardaba pings crudos (y siguen en retención)
+	var firstFailure *domain.PingSample
+	if confirming := domain.FindConfirmingCheck(incident, results); confirming != nil && confirming.SessionId() != "" {
+		samples, err := s.pingRepo.GetBySession(incident.TargetId(), confirming.SessionId())
+		if err == nil {
+			for _, sample := range samples {
+				if sample.Status() != domain.TargetStatusUp {
+					firstFailure = sample
+					break
+				}
+			}
+		}
+	}
+
+	var notifications []domain.IncidentNotificationRecord
+	if s.notifications != nil {
+		notifications, err = s.notifications.FindByIncident(incident.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch notifications: %w", err)
+		}
+	}
+
+	timeline := domain.BuildIncidentTimeline(incident, results, firstFailure, notifications)
+	dto := ToIncidentTimelineDTO(timeline, target, now)
+	return &dto, nil
+}
+
+func (s *MonitoringApplicationService) updateIncident(
+	id domain.IncidentId,
+	userId userdomain.UserId,
+	apply func(incident *domain.Incident, now time.Time) error,
+) (*domain.Incident, *IncidentDTO, error) {
+	// Si el scheduler guardó el incidente en el medio, se recarga y se vuelve a aplicar
+	for attempt := 1; ; attempt++ {
+		incident, err := s.ownedIncident(id, userId)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		now := time.Now()
+		if err := apply(incident, now); err != nil {
+			return nil, nil, err
+		}
+		err = s.incidentRepo.Save(incident)
+		if errors.Is(err, domain.ErrIncidentConflict) && attempt < domain.IncidentSaveAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to save incident: %w", err)
+		}
+
+		dto := ToIncidentDTO(incident, now)
+		return incident, &dto, nil
+	}
+}
+
+// publishIncidentActivity avisa del último evento del incidente (ya persistido). Si falla
+// la alerta no se revierte el cambio: el incidente es la fuente de verdad.
+func (s *MonitoringApplicationService) publishIncidentActivity(incident *domain.Incident) {
+	if s.activity == nil {
+		return
+	}
+	target, err := s.targetRepo.GetByID(incident.TargetId())
+	if err != nil {
+		return // Target borrado: nadie recibe alertas de él
+	}
+	events := incident.Events()
+	s.activity.PublishIncidentActivity(incident, target, events[len(events)-1])
+}
+
+// ownedIncident obtiene el incidente verificando que el target sea del usuario
+func (s *MonitoringApplicationService) ownedIncident(id domain.IncidentId, userId userdomain.UserId) (*domain.Incident, error) {
+	incident, err := s.incidentRepo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if incident.UserId() != userId {
+		return nil, fmt.Errorf("unauthorized: user does not own this incident")
+	}
+	return incident, nil
+}
+
+// ==================== UPTIME / SLA ====================
+
+// CustomAvailabilityRange - Clave del rango arbitrario pedido en /statistics
+const CustomAvailabilityRange = "range"
+
+// availabilityRange - Ventana con el nombre con el que se expone en el DTO
+type availabilityRange struct {
+	name   string
+	window domain.TimeRange
+}
+
+func standardAvailabilityRanges(now time.Time) []availabilityRange {
+	ranges := make([]availabilityRange, 0, len(domain.StandardAvailabilityWindows)+1)
+	for _, w := range domain.StandardAvailabilityWindows {
+		ranges = append(ranges, availabilityRange{
+			name:   w.Name,
+			window: domain.TimeRange{Start: now.Add(-w.Span), End: now},
+		})
+	}
+	return ranges
+}
+
+func windowsOf(ranges []availabilityRange) []domain.TimeRange {
+	windows := make([]domain.TimeRange, 0, len(ranges))
+	for _, r := range ranges {
+		windows = append(windows, r.window)
+	}
+	return windows
+}
+
+// selfDowntime obtiene los períodos en que el propio monitor no estuvo corriendo
+func (s *MonitoringApplicationService) selfDowntime(ranges []availabilityRange) ([]domain.TimeRange, error) {
+	return s.sli.SelfDowntime(domain.CoveringRange(windowsOf(ranges)))
+}
+
+// targetAvailability expone el reparto de cada ventana con el nombre que lleva en el DTO
+func (s *MonitoringApplicationService) targetAvailability(target *domain.MonitoringTarget, ranges []availabilityRange, selfDown []domain.TimeRange) (map[string]AvailabilityDTO, error) {
+	reports, err := s.sli.Availability(target, windowsOf(ranges), selfDown)
+	if err != nil {
+		return nil, err
+	}
+
+	availability := make(map[string]AvailabilityDTO, len(ranges))
+	for i, r := range ranges {
+		availability[r.name] = ToAvailabilityDTO(reports[i])
+	}
+	return availability, nil
+}
+
+// targetsAvailability es targetAvailability para varios targets, con un número fijo de consultas
+func (s *MonitoringApplicationService) targetsAvailability(targets []*domain.MonitoringTarget, ranges []availabilityRange, selfDown []domain.TimeRange) (map[domain.TargetId]map[string]AvailabilityDTO, error) {
+	reports, err := s.sli.AvailabilityByTargets(targets, windowsOf(ranges), selfDown)
+	if err != nil {
+		return nil, err
+	}
+
+	availability := make(map[domain.TargetId]map[string]AvailabilityDTO, len(reports))
+	for targetId, targetReports := range reports {
+		byName := make(map[string]AvailabilityDTO, len(ranges))
+		for i, r := range ranges {
+			byName[r.name] = ToAvailabilityDTO(targetReports[i])
+		}
+		availability[targetId] = byName
+	}
+	return availability, nil
+}
+
+// CreateMaintenanceWindow - Programa un mantenimiento (se excluye del uptime)
+func (s *MonitoringApplicationService) CreateMaintenanceWindow(cmd CreateMaintenanceWindowCommand) (*MaintenanceWindowDTO, error) {
+	if _, err := s.ownedTarget(cmd.TargetID, cmd.UserID); err != nil {
+		return nil, err
+	}
+
+	window, err := domain.NewMaintenanceWindow(cmd.TargetID, cmd.StartsAt, cmd.EndsAt, cmd.Reason, cmd.UserID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.maintenance.Save(window); err != nil {
+		return nil, fmt.Errorf("failed to save maintenance window: %w", err)
+	}
+
+	dto := ToMaintenanceWindowDTO(window)
+	return &dto, nil
+}
+
+// ListMaintenanceWindows - Ventanas del target que tocan el rango pedido
+func (s *MonitoringApplicationService) ListMaintenanceWindows(query ListMaintenanceWindowsQuery) ([]MaintenanceWindowDTO, error) {
+	if _, err := s.ownedTarget(query.TargetID, query.UserID); err != nil {
+		return nil, err
+	}
+
+	windows, err := s.maintenance.ListByTarget(query.TargetID, query.From, query.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch maintenance windows: %w", err)
+	}
+
+	dtos := make([]MaintenanceWindowDTO, 0, len(windows))
+	for _, w := range windows {
+		dtos = append(dtos, ToMaintenanceWindowDTO(w))
+	}
+	return dtos, nil
+}
+
+// DeleteMaintenanceWindow - Cancela un mantenimiento (el uptime pasado se recalcula sin él)
+func (s *MonitoringApplicationService) DeleteMaintenanceWindow(cmd DeleteMaintenanceWindowCommand) error {
+	if _, err := s.ownedTarget(cmd.TargetID, cmd.UserID); err != nil {
+		return err
+	}
+
+	window, err := s.maintenance.GetByID(cmd.WindowID)
+	if err != nil {
+		return err
+	}
+	if window.TargetId() != cmd.TargetID {
+		return domain.ErrMaintenanceWindowNotFound
+	}
+	return s.maintenance.Delete(cmd.WindowID)
+}
+
+// ==================== SLO ====================
+
+// CreateSLO - Define un objetivo de disponibilidad o latencia sobre el target
+func (s *MonitoringApplicationService) CreateSLO(cmd CreateSLOCommand) (*SLODTO, error) {
+	target, err := s.ownedTarget(cmd.TargetID, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	kind, err := domain.NewSLOKind(cmd.Kind)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	slo, err := domain.NewSLO(cmd.TargetID, cmd.UserID, cmd.Name, kind, cmd.Objective, cmd.WindowDays, cmd.LatencyThresholdMs, now)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.sloRepo.Save(slo); err != nil {
+		return nil, fmt.Errorf("failed to save slo: %w", err)
+	}
+
+	return s.sloStatus(slo, target, now, nil)
+}
+
+// ListSLOs - SLOs del target con su presupuesto de error al momento
+func (s *MonitoringApplicationService) ListSLOs(query ListSLOsQuery) ([]SLODTO, error) {
+	target, err := s.ownedTarget(query.TargetID, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	slos, err := s.sloRepo.ListByTarget(query.TargetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch slos: %w", err)
+	}
+
+	now := time.Now()
+	dtos := make([]SLODTO, 0, len(slos))
+	if len(slos) == 0 {
+		return dtos, nil
+	}
+
+	// Los huecos del monitor son los mismos para todos los SLOs: se leen una vez
+	selfDown, err := s.sli.SelfDowntime(domain.TimeRange{Start: now.Add(-domain.MaxSLOWindowDays * 24 * time.Hour), End: now})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute self downtime: %w", err)
+	}
+	for _, slo := range slos {
+		dto, err := s.sloStatus(slo, target, now, selfDown)
+		if err != nil {
+			return nil, err
+		}
+		dtos = append(dtos, *dto)
+	}
+	return dtos, nil
+}
+
+// GetSLO - Un SLO con su presupuesto de error y tasas de quemado
+func (s *MonitoringApplicationService) GetSLO(query GetSLOQuery) (*SLODTO, error) {
+	target, err := s.ownedTarget(query.TargetID, query.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	slo, err := s.sloRepo.GetByID(query.SLOID)
+	if err != nil {
+		return nil, err
+	}
+	if slo.TargetId() != query.TargetID {
+		return nil, domain.ErrSLONotFound
+	}
+	return s.sloStatus(slo, target, time.Now(), nil)
+}
+
+// DeleteSLO - Elimina el SLO (deja de evaluarse y de alertar)
+func (s *MonitoringApplicationService) DeleteSLO(cmd DeleteSLOCommand) error {
+	if _, err := s.ownedTarget(cmd.TargetID, cmd.UserID); err != nil {
+		return err
+	}
+
+	slo, err := s.sloRepo.GetByID(cmd.SLOID)
+	if err != nil {
+		return err
+	}
+	if slo.TargetId() != cmd.TargetID {
+		return domain.ErrSLONotFound
+	}
+	return s.sloRepo.Delete(cmd.SLOID)
+}
+
+// sloStatus mide el SLO; selfDown nil = se calcula para la ventana de este SLO
+func (s *MonitoringApplicationService) sloStatus(slo *domain.SLO, target *domain.MonitoringTarget, now time.Time, selfDown []domain.TimeRange) (*SLODTO, error) {
+	if selfDown == nil {
+		var err error
+		selfDown, err = s.sli.SelfDowntime(domain.TimeRange{Start: now.Add(-slo.Window()), End: now})
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute self downtime: %w", err)
+		}
+	}
+
+	measurements, err := s.sli.MeasureSLO(slo, target, now, selfDown)
+	if err != nil {
+		return nil, fmt.Errorf("failed to measure slo: %w", err)
+	}
+
+	dto := ToSLODTO(slo, domain.EvaluateSLO(slo, measurements))
+	return &dto, nil
+}
+
+func (s *MonitoringApplicationService) ownedTarget(id domain.TargetId, userId userdomain.UserId) (*domain.MonitoringTarget, error) {
+	target, err := s.targetRepo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("target not found: %w", err)
+	}
+
+	if target.UserId() != userId {
+		return nil, fmt.Errorf("unauthorized: user does not own this target")
+	}
+	return target, nil
+}
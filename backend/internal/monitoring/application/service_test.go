@@ -1,7 +1,10 @@
 package application
 
 import (
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 	"uptrackai/internal/monitoring/domain"
 	userdomain "uptrackai/internal/user/domain"
 )
@@ -94,8 +97,19 @@ func (m *MockTargetRepository) GetDueTargets() ([]*domain.MonitoringTarget, erro
 	return result, nil
 }
 
+func (m *MockTargetRepository) UpdateHostingInfo(id domain.TargetId, info domain.HostingInfo) error {
+	target, exists := m.targets[string(id)]
+	if !exists {
+		return domain.ErrTargetNotFound
+	}
+	target.AssignHostingInfo(info)
+	return nil
+}
+
 // MockStatsRepository - Mock simplificado
-type MockStatsRepository struct{}
+type MockStatsRepository struct {
+	stats map[domain.TargetId]*domain.TargetStatistics
+}
 
 func (m *MockStatsRepository) Save(stats *domain.TargetStatistics) error {
 	return nil
@@ -105,28 +119,377 @@ func (m *MockStatsRepository) Get(targetId domain.TargetId) (*domain.TargetStati
 	return domain.NewTargetStatistics(targetId), nil
 }
 
+func (m *MockStatsRepository) GetByTargets(targetIds []domain.TargetId) (map[domain.TargetId]*domain.TargetStatistics, error) {
+	found := make(map[domain.TargetId]*domain.TargetStatistics)
+	for _, id := range targetIds {
+		if stats, ok := m.stats[id]; ok {
+			found[id] = stats
+		}
+	}
+	return found, nil
+}
+
 // MockMetricsRepository - Mock simplificado
-type MockMetricsRepository struct{}
+type MockMetricsRepository struct {
+	results []*domain.CheckResult
+}
 
 func (m *MockMetricsRepository) Save(result *domain.CheckResult) error {
 	return nil
 }
 
 func (m *MockMetricsRepository) GetByTargetID(targetId domain.TargetId, limit int) ([]*domain.CheckResult, error) {
-	return []*domain.CheckResult{}, nil
+	found := make([]*domain.CheckResult, 0)
+	for _, r := range m.results {
+		if r.MonitoringTargetId() == targetId && (limit <= 0 || len(found) < limit) {
+			found = append(found, r)
+		}
+	}
+	return found, nil
+}
+
+func (m *MockMetricsRepository) GetByTargetIDBetween(targetId domain.TargetId, from time.Time, to time.Time) ([]*domain.CheckResult, error) {
+	found := make([]*domain.CheckResult, 0)
+	for _, r := range m.results {
+		if r.MonitoringTargetId() == targetId && !r.Timestamp().Before(from) && r.Timestamp().Before(to) {
+			found = append(found, r)
+		}
+	}
+	return found, nil
+}
+
+// SummarizeLatency - El p95 del mock es la mayor latencia correcta (alcanza para ordenar)
+func (m *MockMetricsRepository) SummarizeLatency(targetIds []domain.TargetId, from time.Time, to time.Time) (map[domain.TargetId]domain.LatencySummary, error) {
+	summaries := make(map[domain.TargetId]domain.LatencySummary)
+	for _, id := range targetIds {
+		sessions, _ := m.GetByTargetIDBetween(id, from, to)
+		if len(sessions) == 0 {
+			continue
+		}
+		summary := domain.LatencySummary{Sessions: int64(len(sessions))}
+		total := 0
+		for _, session := range sessions {
+			if session.IsFailedSession() {
+				summary.Failures++
+				continue
+			}
+			total += session.ResponseTimeMs()
+			if session.ResponseTimeMs() > summary.P95ResponseTimeMs {
+				summary.P95ResponseTimeMs = session.ResponseTimeMs()
+			}
+		}
+		if healthy := summary.HealthySessions(); healthy > 0 {
+			summary.AvgResponseTimeMs = float64(total) / float64(healthy)
+		}
+		summaries[id] = summary
+	}
+	return summaries, nil
+}
+
+func (m *MockMetricsRepository) CountWithinLatency(targetId domain.TargetId, from time.Time, to time.Time, thresholdMs int) (int64, int64, error) {
+	return 0, 0, nil
 }
 
 // MockCheckRepository - Mock simplificado
-type MockCheckRepository struct{}
+type MockCheckRepository struct {
+	results          []*domain.CheckResult
+	perTargetQueries int // Llamadas a las consultas de un solo target
+}
 
 func (m *MockCheckRepository) Save(result *domain.CheckResult) (*domain.CheckResult, error) {
+	m.results = append(m.results, result)
 	return result, nil
 }
 
+func (m *MockCheckRepository) GetByTargetIDBetween(targetId domain.TargetId, from time.Time, to time.Time) ([]*domain.CheckResult, error) {
+	m.perTargetQueries++
+	return m.between(targetId, from, to), nil
+}
+
+func (m *MockCheckRepository) between(targetId domain.TargetId, from time.Time, to time.Time) []*domain.CheckResult {
+	found := make([]*domain.CheckResult, 0)
+	for _, r := range m.results {
+		if r.MonitoringTargetId() == targetId && !r.Timestamp().Before(from) && !r.Timestamp().After(to) {
+			found = append(found, r)
+		}
+	}
+	return found
+}
+
+func (m *MockCheckRepository) GetLastBefore(targetId domain.TargetId, at time.Time) (*domain.CheckResult, error) {
+	m.perTargetQueries++
+	return m.lastBefore(targetId, at), nil
+}
+
+func (m *MockCheckRepository) lastBefore(targetId domain.TargetId, at time.Time) *domain.CheckResult {
+	var last *domain.CheckResult
+	for _, r := range m.results {
+		if r.MonitoringTargetId() == targetId && r.Timestamp().Before(at) && (last == nil || r.Timestamp().After(last.Timestamp())) {
+			last = r
+		}
+	}
+	return last
+}
+
+func (m *MockCheckRepository) GetByTargetsBetween(targetIds []domain.TargetId, from time.Time, to time.Time) (map[domain.TargetId][]*domain.CheckResult, error) {
+	found := make(map[domain.TargetId][]*domain.CheckResult)
+	for _, id := range targetIds {
+		if results := m.between(id, from, to); len(results) > 0 {
+			found[id] = results
+		}
+	}
+	return found, nil
+}
+
+func (m *MockCheckRepository) GetLastBeforeByTargets(targetIds []domain.TargetId, at time.Time) (map[domain.TargetId]*domain.CheckResult, error) {
+	found := make(map[domain.TargetId]*domain.CheckResult)
+	for _, id := range targetIds {
+		if last := m.lastBefore(id, at); last != nil {
+			found[id] = last
+		}
+	}
+	return found, nil
+}
+
 func (m *MockCheckRepository) GetByTargetID(targetId domain.TargetId, limit int) ([]*domain.CheckResult, error) {
 	return []*domain.CheckResult{}, nil
 }
 
+// MockPingSampleRepository - Mock en memoria de pings crudos
+type MockPingSampleRepository struct {
+	samples []*domain.PingSample
+}
+
+func NewMockPingSampleRepository() *MockPingSampleRepository {
+	return &MockPingSampleRepository{}
+}
+
+func (m *MockPingSampleRepository) SaveBatch(samples []*domain.PingSample) error {
+	m.samples = append(m.samples, samples...)
+	return nil
+}
+
+func (m *MockPingSampleRepository) GetBySession(targetId domain.TargetId, sessionId domain.SessionId) ([]*domain.PingSample, error) {
+	found := make([]*domain.PingSample, 0)
+	for _, s := range m.samples {
+		if s.TargetId() == targetId && s.SessionId() == sessionId {
+			found = append(found, s)
+		}
+	}
+	return found, nil
+}
+
+// MockIncidentRepository - Mock en memoria de incidentes. Guarda copias y verifica la versión
+// como el repositorio real, así cada carga es independiente
+type MockIncidentRepository struct {
+	incidents  map[domain.IncidentId]*domain.Incident
+	nextId     int
+	beforeSave func() // Simula otro proceso guardando justo antes (se ejecuta una vez)
+}
+
+func NewMockIncidentRepository() *MockIncidentRepository {
+	return &MockIncidentRepository{incidents: make(map[domain.IncidentId]*domain.Incident)}
+}
+
+func (m *MockIncidentRepository) Save(incident *domain.Incident) error {
+	if hook := m.beforeSave; hook != nil {
+		m.beforeSave = nil
+		hook()
+	}
+	if incident.ID() == "" {
+		m.nextId++
+		_ = incident.AssignId(domain.IncidentId(fmt.Sprintf("incident-%d", m.nextId)))
+	}
+	if stored, exists := m.incidents[incident.ID()]; exists && stored.Version() != incident.Version() {
+		return domain.ErrIncidentConflict
+	}
+	incident.AssignVersion(incident.Version() + 1)
+	m.incidents[incident.ID()] = cloneIncident(incident)
+	return nil
+}
+
+func (m *MockIncidentRepository) GetByID(id domain.IncidentId) (*domain.Incident, error) {
+	incident, exists := m.incidents[id]
+	if !exists {
+		return nil, domain.ErrIncidentNotFound
+	}
+	return cloneIncident(incident), nil
+}
+
+func (m *MockIncidentRepository) GetActiveByTarget(targetId domain.TargetId) (*domain.Incident, error) {
+	for _, incident := range m.incidents {
+		if incident.TargetId() == targetId && !incident.IsResolved() {
+			return cloneIncident(incident), nil
+		}
+	}
+	return nil, domain.ErrIncidentNotFound
+}
+
+func cloneIncident(i *domain.Incident) *domain.Incident {
+	return domain.NewFullIncident(i.ID(), i.TargetId(), i.UserId(), i.Status(), i.TriggerStatus(), i.LastStatus(),
+		i.OpenedAt(), i.AcknowledgedAt(), i.AcknowledgedBy(), i.ResolvedAt(), i.ResolvedBy(),
+		append([]domain.IncidentEvent(nil), i.Events()...), i.Version())
+}
+
+func (m *MockIncidentRepository) List(filter domain.IncidentFilter) ([]*domain.Incident, error) {
+	result := make([]*domain.Incident, 0)
+	for _, incident := range m.incidents {
+		if filter.UserId != "" && incident.UserId() != filter.UserId {
+			continue
+		}
+		if filter.Status != "" && incident.Status() != filter.Status {
+			continue
+		}
+		result = append(result, cloneIncident(incident))
+	}
+	return result, nil
+}
+
+// MockMaintenanceRepository - Mock en memoria de ventanas de mantenimiento
+type MockMaintenanceRepository struct {
+	windows map[domain.MaintenanceWindowId]*domain.MaintenanceWindow
+	nextId  int
+}
+
+func NewMockMaintenanceRepository() *MockMaintenanceRepository {
+	return &MockMaintenanceRepository{windows: make(map[domain.MaintenanceWindowId]*domain.MaintenanceWindow)}
+}
+
+func (m *MockMaintenanceRepository) Save(window *domain.MaintenanceWindow) error {
+	if window.ID() == "" {
+		m.nextId++
+		_ = window.AssignId(domain.MaintenanceWindowId(fmt.Sprintf("maintenance-%d", m.nextId)))
+	}
+	m.windows[window.ID()] = window
+	return nil
+}
+
+func (m *MockMaintenanceRepository) GetByID(id domain.MaintenanceWindowId) (*domain.MaintenanceWindow, error) {
+	window, exists := m.windows[id]
+	if !exists {
+		return nil, domain.ErrMaintenanceWindowNotFound
+	}
+	return window, nil
+}
+
+func (m *MockMaintenanceRepository) Delete(id domain.MaintenanceWindowId) error {
+	if _, exists := m.windows[id]; !exists {
+		return domain.ErrMaintenanceWindowNotFound
+	}
+	delete(m.windows, id)
+	return nil
+}
+
+func (m *MockMaintenanceRepository) ListByTarget(targetId domain.TargetId, from time.Time, to time.Time) ([]*domain.MaintenanceWindow, error) {
+	found := make([]*domain.MaintenanceWindow, 0)
+	for _, w := range m.windows {
+		if w.TargetId() == targetId && w.EndsAt().After(from) && (to.IsZero() || w.StartsAt().Before(to)) {
+			found = append(found, w)
+		}
+	}
+	return found, nil
+}
+
+func (m *MockMaintenanceRepository) ListByTargets(targetIds []domain.TargetId, from time.Time, to time.Time) (map[domain.TargetId][]*domain.MaintenanceWindow, error) {
+	found := make(map[domain.TargetId][]*domain.MaintenanceWindow)
+	for _, id := range targetIds {
+		if windows, _ := m.ListByTarget(id, from, to); len(windows) > 0 {
+			found[id] = windows
+		}
+	}
+	return found, nil
+}
+
+// MockMonitorRunRepository - Ejecuciones del scheduler fijadas por el test
+type MockMonitorRunRepository struct {
+	runs []*domain.MonitorRun
+}
+
+func (m *MockMonitorRunRepository) Save(run *domain.MonitorRun) error {
+	m.runs = append(m.runs, run)
+	return nil
+}
+
+func (m *MockMonitorRunRepository) ListBetween(from time.Time, to time.Time) ([]*domain.MonitorRun, error) {
+	return m.runs, nil
+}
+
+// MockSLORepository - Mock en memoria de SLOs
+type MockSLORepository struct {
+	slos   map[domain.SLOId]*domain.SLO
+	nextId int
+}
+
+func NewMockSLORepository() *MockSLORepository {
+	return &MockSLORepository{slos: make(map[domain.SLOId]*domain.SLO)}
+}
+
+func (m *MockSLORepository) Save(slo *domain.SLO) error {
+	if slo.ID() == "" {
+		m.nextId++
+		_ = slo.AssignId(domain.SLOId(fmt.Sprintf("slo-%d", m.nextId)))
+	}
+	m.slos[slo.ID()] = slo
+	return nil
+}
+
+func (m *MockSLORepository) GetByID(id domain.SLOId) (*domain.SLO, error) {
+	slo, exists := m.slos[id]
+	if !exists {
+		return nil, domain.ErrSLONotFound
+	}
+	return slo, nil
+}
+
+func (m *MockSLORepository) Delete(id domain.SLOId) error {
+	if _, exists := m.slos[id]; !exists {
+		return domain.ErrSLONotFound
+	}
+	delete(m.slos, id)
+	return nil
+}
+
+func (m *MockSLORepository) ListByTarget(targetId domain.TargetId) ([]*domain.SLO, error) {
+	found := make([]*domain.SLO, 0)
+	for _, slo := range m.slos {
+		if slo.TargetId() == targetId {
+			found = append(found, slo)
+		}
+	}
+	return found, nil
+}
+
+func (m *MockSLORepository) List() ([]*domain.SLO, error) {
+	found := make([]*domain.SLO, 0, len(m.slos))
+	for _, slo := range m.slos {
+		found = append(found, slo)
+	}
+	return found, nil
+}
+
+// MockMetricRollupRepository - Buckets fijados por el test
+type MockMetricRollupRepository struct {
+	rollups []domain.MetricRollup
+}
+
+func (m *MockMetricRollupRepository) Rollup(resolution domain.MetricResolution, from time.Time, to time.Time) error {
+	return nil
+}
+
+func (m *MockMetricRollupRepository) LatestBucket(resolution domain.MetricResolution) (time.Time, error) {
+	return time.Time{}, nil
+}
+
+func (m *MockMetricRollupRepository) ListByTarget(targetId domain.TargetId, resolution domain.MetricResolution, from time.Time, to time.Time) ([]domain.MetricRollup, error) {
+	found := make([]domain.MetricRollup, 0)
+	for _, r := range m.rollups {
+		if r.TargetId == targetId && r.Resolution == resolution && !r.BucketStart.Before(from) && r.BucketStart.Before(to) {
+			found = append(found, r)
+		}
+	}
+	return found, nil
+}
+
 // ==================== TESTS ====================
 
 func TestCreateTarget_Success(t *testing.T) {
@@ -136,6 +499,12 @@ func TestCreateTarget_Success(t *testing.T) {
 		&MockMetricsRepository{},
 		&MockCheckRepository{},
 		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
 	)
 
 	userId, _ := userdomain.NewUserId("user-123")
@@ -174,6 +543,12 @@ func TestGetTargetByID_Unauthorized(t *testing.T) {
 		&MockMetricsRepository{},
 		&MockCheckRepository{},
 		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
 	)
 
 	// Crear target con user1
@@ -218,6 +593,12 @@ func TestGetTargetByID_Authorized(t *testing.T) {
 		&MockMetricsRepository{},
 		&MockCheckRepository{},
 		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
 	)
 
 	// Crear target con user1
@@ -253,3 +634,1217 @@ func TestGetTargetByID_Authorized(t *testing.T) {
 		t.Errorf("Expected target ID %s, got: %s", createdDTO.ID, result.ID)
 	}
 }
+
+// MockProber - Devuelve un reporte fijo sin hacer requests reales
+type MockProber struct {
+	report *domain.ProbeReport
+	probed *domain.MonitoringTarget
+}
+
+func (m *MockProber) Probe(target *domain.MonitoringTarget) *domain.ProbeReport {
+	m.probed = target
+	return m.report
+}
+
+func TestTestTarget_DoesNotPersist(t *testing.T) {
+	// Arrange
+	targetRepo := NewMockTargetRepository()
+	service := NewMonitoringApplicationService(
+		targetRepo,
+		&MockMetricsRepository{},
+		&MockCheckRepository{},
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+	prober := &MockProber{report: &domain.ProbeReport{
+		Status:            domain.TargetStatusDown,
+		HTTPStatusCode:    503,
+		AssertionFailures: []string{"se esperaba HTTP 2xx, se recibió 503"},
+	}}
+	service.SetProber(prober)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	cmd := TestTargetCommand{
+		UserID:     userId,
+		Name:       "Broken",
+		URL:        "https://example.com/503",
+		TargetType: domain.TargetTypeAPI,
+	}
+
+	// Act
+	dto, err := service.TestTarget(cmd)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if dto.Passed {
+		t.Error("Expected probe to fail for a DOWN target")
+	}
+	if dto.Status != "DOWN" || dto.HTTPStatusCode != 503 {
+		t.Errorf("Expected DOWN/503, got %s/%d", dto.Status, dto.HTTPStatusCode)
+	}
+	if len(dto.AssertionFailures) != 1 {
+		t.Errorf("Expected 1 assertion failure, got %d", len(dto.AssertionFailures))
+	}
+	if prober.probed == nil || prober.probed.Url() != cmd.URL {
+		t.Error("Expected prober to receive the proposed target")
+	}
+	if len(targetRepo.targets) != 0 {
+		t.Errorf("Expected nothing persisted, got %d targets", len(targetRepo.targets))
+	}
+}
+
+func TestTestTarget_WithoutProber(t *testing.T) {
+	service := NewMonitoringApplicationService(
+		NewMockTargetRepository(),
+		&MockMetricsRepository{},
+		&MockCheckRepository{},
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	_, err := service.TestTarget(TestTargetCommand{
+		UserID:     userId,
+		Name:       "Google",
+		URL:        "https://google.com",
+		TargetType: domain.TargetTypeWEB,
+	})
+
+	if err != ErrProberUnavailable {
+		t.Errorf("Expected ErrProberUnavailable, got: %v", err)
+	}
+}
+
+func TestUpdateConfiguration_PartialThresholds(t *testing.T) {
+	// Arrange
+	targetRepo := NewMockTargetRepository()
+	service := NewMonitoringApplicationService(
+		targetRepo,
+		&MockMetricsRepository{},
+		&MockCheckRepository{},
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	created, _ := service.CreateTarget(CreateTargetCommand{
+		UserID:     userId,
+		Name:       "API",
+		URL:        "https://example.com",
+		TargetType: domain.TargetTypeAPI,
+	})
+	targetId, _ := domain.NewTargetId(created.ID)
+
+	maxLatency := 1500
+	cmd := UpdateConfigurationCommand{
+		TargetID:              targetId,
+		UserID:                userId,
+		TimeoutSeconds:        10,
+		RetryCount:            3,
+		RetryDelaySeconds:     1,
+		CheckIntervalSeconds:  60,
+		MaxLatencyThresholdMs: &maxLatency,
+	}
+
+	// Act
+	dto, err := service.UpdateConfiguration(cmd)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if dto.Configuration["max_latency_threshold_ms"] != 1500 {
+		t.Errorf("Expected max latency 1500, got %v", dto.Configuration["max_latency_threshold_ms"])
+	}
+	// Los campos omitidos conservan el valor anterior
+	if dto.Configuration["degradation_factor"] != domain.DefaultDegradationFactor {
+		t.Errorf("Expected default factor to be kept, got %v", dto.Configuration["degradation_factor"])
+	}
+}
+
+func TestUpdateConfiguration_InvalidThresholds(t *testing.T) {
+	service := NewMonitoringApplicationService(
+		NewMockTargetRepository(),
+		&MockMetricsRepository{},
+		&MockCheckRepository{},
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	created, _ := service.CreateTarget(CreateTargetCommand{
+		UserID:     userId,
+		Name:       "API",
+		URL:        "https://example.com",
+		TargetType: domain.TargetTypeAPI,
+	})
+	targetId, _ := domain.NewTargetId(created.ID)
+
+	unstableMin := 10
+	_, err := service.UpdateConfiguration(UpdateConfigurationCommand{
+		TargetID:             targetId,
+		UserID:               userId,
+		TimeoutSeconds:       10,
+		RetryCount:           3,
+		RetryDelaySeconds:    1,
+		CheckIntervalSeconds: 60,
+		UnstableMinPings:     &unstableMin, // mayor que el máximo por defecto (9)
+	})
+
+	if err != domain.ErrInvalidUnstableBand {
+		t.Errorf("Expected ErrInvalidUnstableBand, got %v", err)
+	}
+}
+
+func TestUpdateConfiguration_RawSamples(t *testing.T) {
+	service := NewMonitoringApplicationService(
+		NewMockTargetRepository(),
+		&MockMetricsRepository{},
+		&MockCheckRepository{},
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	created, _ := service.CreateTarget(CreateTargetCommand{
+		UserID:     userId,
+		Name:       "API",
+		URL:        "https://example.com",
+		TargetType: domain.TargetTypeAPI,
+	})
+	targetId, _ := domain.NewTargetId(created.ID)
+
+	enabled := true
+	days := 7
+	dto, err := service.UpdateConfiguration(UpdateConfigurationCommand{
+		TargetID:                targetId,
+		UserID:                  userId,
+		TimeoutSeconds:          10,
+		RetryCount:              3,
+		RetryDelaySeconds:       1,
+		CheckIntervalSeconds:    60,
+		StoreRawSamples:         &enabled,
+		RawSamplesRetentionDays: &days,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if dto.Configuration["store_raw_samples"] != true || dto.Configuration["raw_samples_retention_days"] != 7 {
+		t.Errorf("Expected raw samples enabled for 7 days, got %v / %v",
+			dto.Configuration["store_raw_samples"], dto.Configuration["raw_samples_retention_days"])
+	}
+
+	tooLong := domain.MaxRawSamplesRetentionDays + 1
+	_, err = service.UpdateConfiguration(UpdateConfigurationCommand{
+		TargetID:                targetId,
+		UserID:                  userId,
+		TimeoutSeconds:          10,
+		RetryCount:              3,
+		RetryDelaySeconds:       1,
+		CheckIntervalSeconds:    60,
+		RawSamplesRetentionDays: &tooLong,
+	})
+	if err != domain.ErrInvalidRawSamplesRetention {
+		t.Errorf("Expected ErrInvalidRawSamplesRetention, got %v", err)
+	}
+}
+
+func TestGetSessionPings(t *testing.T) {
+	pingRepo := NewMockPingSampleRepository()
+	service := NewMonitoringApplicationService(
+		NewMockTargetRepository(),
+		&MockMetricsRepository{},
+		&MockCheckRepository{},
+		&MockStatsRepository{},
+		pingRepo,
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	created, _ := service.CreateTarget(CreateTargetCommand{
+		UserID:     userId,
+		Name:       "API",
+		URL:        "https://example.com",
+		TargetType: domain.TargetTypeAPI,
+	})
+	targetId, _ := domain.NewTargetId(created.ID)
+	sessionId, _ := domain.NewSessionId("session-1")
+
+	_ = pingRepo.SaveBatch([]*domain.PingSample{
+		domain.NewPingSample(sessionId, 1, domain.NewCheckResult(targetId, 120, true, domain.TargetStatusUp)),
+		domain.NewPingSample(sessionId, 2, domain.NewCheckResult(targetId, 0, false, domain.TargetStatusDown)),
+	})
+
+	// Act
+	pings, err := service.GetSessionPings(GetSessionPingsQuery{TargetID: targetId, SessionID: sessionId, UserID: userId})
+
+	// Assert
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(pings) != 2 || pings[0].Sequence != 1 || pings[1].Status != string(domain.TargetStatusDown) {
+		t.Errorf("Unexpected pings: %+v", pings)
+	}
+
+	// Sesión sin pings guardados
+	other, _ := domain.NewSessionId("session-2")
+	if _, err := service.GetSessionPings(GetSessionPingsQuery{TargetID: targetId, SessionID: other, UserID: userId}); err != domain.ErrSessionNotFound {
+		t.Errorf("Expected ErrSessionNotFound, got %v", err)
+	}
+
+	// Otro usuario
+	intruder, _ := userdomain.NewUserId("user-999")
+	if _, err := service.GetSessionPings(GetSessionPingsQuery{TargetID: targetId, SessionID: sessionId, UserID: intruder}); err == nil {
+		t.Error("Expected authorization error")
+	}
+}
+
+func TestUpdateConfiguration_ErrorClassPolicy(t *testing.T) {
+	service := NewMonitoringApplicationService(
+		NewMockTargetRepository(),
+		&MockMetricsRepository{},
+		&MockCheckRepository{},
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	created, _ := service.CreateTarget(CreateTargetCommand{
+		UserID:     userId,
+		Name:       "API",
+		URL:        "https://example.com",
+		TargetType: domain.TargetTypeAPI,
+	})
+	targetId, _ := domain.NewTargetId(created.ID)
+
+	cmd := UpdateConfigurationCommand{
+		TargetID:             targetId,
+		UserID:               userId,
+		TimeoutSeconds:       10,
+		RetryCount:           3,
+		RetryDelaySeconds:    1,
+		CheckIntervalSeconds: 60,
+		ErrorClassPolicy:     map[string]string{"http_4xx": "UP"},
+	}
+
+	dto, err := service.UpdateConfiguration(cmd)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	policy := dto.Configuration["error_class_policy"].(map[string]string)
+	if policy["http_4xx"] != "UP" || policy["dns"] != "DOWN" {
+		t.Errorf("Expected http_4xx -> UP and dns kept as DOWN, got %v", policy)
+	}
+
+	cmd.ErrorClassPolicy = map[string]string{"tls": "FLAPPING"}
+	if _, err := service.UpdateConfiguration(cmd); err != domain.ErrInvalidErrorClassStatus {
+		t.Errorf("Expected ErrInvalidErrorClassStatus, got %v", err)
+	}
+}
+
+func TestIncidentLifecycle(t *testing.T) {
+	incidentRepo := NewMockIncidentRepository()
+	service := NewMonitoringApplicationService(
+		NewMockTargetRepository(),
+		&MockMetricsRepository{},
+		&MockCheckRepository{},
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		incidentRepo,
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	openedAt := time.Now().Add(-10 * time.Minute)
+	incident := domain.OpenIncident("target-1", userId, domain.TargetStatusDown, openedAt)
+	_ = incidentRepo.Save(incident)
+
+	// Acknowledge
+	dto, err := service.AcknowledgeIncident(AcknowledgeIncidentCommand{IncidentID: incident.ID(), UserID: userId, Note: "on it"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if dto.Status != "ACKNOWLEDGED" || dto.AcknowledgedBy != "user-123" || dto.TimeToAcknowledgeSeconds == nil {
+		t.Errorf("Unexpected acknowledged incident: %+v", dto)
+	}
+	if _, err := service.AcknowledgeIncident(AcknowledgeIncidentCommand{IncidentID: incident.ID(), UserID: userId}); err != domain.ErrIncidentAlreadyAcknowledged {
+		t.Errorf("Expected ErrIncidentAlreadyAcknowledged, got %v", err)
+	}
+
+	// Comentario vacío
+	if _, err := service.CommentIncident(CommentIncidentCommand{IncidentID: incident.ID(), UserID: userId, Note: "  "}); err != domain.ErrIncidentNoteEmpty {
+		t.Errorf("Expected ErrIncidentNoteEmpty, got %v", err)
+	}
+
+	// Cierre manual
+	dto, err = service.ResolveIncident(ResolveIncidentCommand{IncidentID: incident.ID(), UserID: userId, Note: "rolled back"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if dto.Status != "RESOLVED" || dto.TimeToResolveSeconds == nil || len(dto.Events) != 3 {
+		t.Errorf("Unexpected resolved incident: %+v", dto)
+	}
+
+	// Otro usuario no lo ve
+	intruder, _ := userdomain.NewUserId("user-999")
+	if _, err := service.GetIncident(GetIncidentQuery{IncidentID: incident.ID(), UserID: intruder}); err == nil {
+		t.Error("Expected authorization error")
+	}
+
+	list, err := service.ListIncidents(ListIncidentsQuery{UserID: userId})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if list.Total != 1 || list.Open != 0 || list.MTTRSeconds < 599 {
+		t.Errorf("Unexpected incident summary: %+v", list)
+	}
+}
+
+// MockNotificationHistory - Historial fijo de notificaciones de un incidente
+type MockNotificationHistory struct {
+	records []domain.IncidentNotificationRecord
+}
+
+func (m *MockNotificationHistory) FindByIncident(incidentId domain.IncidentId) ([]domain.IncidentNotificationRecord, error) {
+	return m.records, nil
+}
+
+func TestAcknowledgeIncident_RetriesAfterConcurrentSave(t *testing.T) {
+	incidentRepo := NewMockIncidentRepository()
+	service := NewMonitoringApplicationService(
+		NewMockTargetRepository(),
+		&MockMetricsRepository{},
+		&MockCheckRepository{},
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		incidentRepo,
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	incident := domain.OpenIncident("target-api", userId, domain.TargetStatusDown, time.Now().Add(-time.Minute))
+	_ = incidentRepo.Save(incident)
+
+	// El scheduler registra una transición entre la carga y el guardado del reconocimiento
+	incidentRepo.beforeSave = func() {
+		concurrent, _ := incidentRepo.GetActiveByTarget("target-api")
+		_ = concurrent.RecordTransition(domain.TargetStatusDegraded, time.Now())
+		if err := incidentRepo.Save(concurrent); err != nil {
+			t.Fatalf("Expected the scheduler save to win, got %v", err)
+		}
+	}
+
+	if _, err := service.AcknowledgeIncident(AcknowledgeIncidentCommand{IncidentID: incident.ID(), UserID: userId, Note: "on it"}); err != nil {
+		t.Fatalf("Expected the acknowledgement to be retried, got %v", err)
+	}
+
+	stored, _ := incidentRepo.GetByID(incident.ID())
+	if !stored.IsAcknowledged() || stored.LastStatus() != domain.TargetStatusDegraded {
+		t.Errorf("Expected both the acknowledgement and the transition, got status %s last %s", stored.Status(), stored.LastStatus())
+	}
+	if len(stored.Events()) != 3 {
+		t.Errorf("Expected opened, transition and acknowledged events, got %d", len(stored.Events()))
+	}
+
+	// Un guardado sobre una versión vieja no pisa el incidente
+	stale := cloneIncident(stored)
+	_ = stale.Comment(userId, "stale", time.Now())
+	_ = incidentRepo.Save(stored)
+	if err := incidentRepo.Save(stale); err != domain.ErrIncidentConflict {
+		t.Errorf("Expected ErrIncidentConflict for a stale save, got %v", err)
+	}
+}
+
+func TestGetIncidentTimeline(t *testing.T) {
+	targetRepo := NewMockTargetRepository()
+	checkRepo := &MockCheckRepository{}
+	incidentRepo := NewMockIncidentRepository()
+	service := NewMonitoringApplicationService(
+		targetRepo,
+		&MockMetricsRepository{},
+		checkRepo,
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		incidentRepo,
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	created, _ := service.CreateTarget(CreateTargetCommand{
+		UserID:     userId,
+		Name:       "Checkout | API",
+		URL:        "https://example.com",
+		TargetType: domain.TargetTypeAPI,
+	})
+	targetId, _ := domain.NewTargetId(created.ID)
+
+	openedAt := time.Now().Add(-30 * time.Minute)
+	_, _ = checkRepo.Save(domain.NewFullCheckResult("cr-1", targetId, openedAt.Add(-time.Second), 0, false, domain.TargetStatusDown, "connection refused"))
+	_, _ = checkRepo.Save(domain.NewFullCheckResult("cr-2", targetId, openedAt.Add(5*time.Minute), 150, true, domain.TargetStatusUp, ""))
+
+	incident := domain.OpenIncident(targetId, userId, domain.TargetStatusDown, openedAt)
+	_ = incident.ResolveOnRecovery(domain.TargetStatusUp, openedAt.Add(5*time.Minute+time.Second))
+	_ = incidentRepo.Save(incident)
+
+	service.SetNotificationHistory(&MockNotificationHistory{records: []domain.IncidentNotificationRecord{
+		{At: openedAt.Add(time.Second), Title: "Status Change: Checkout | API", Severity: "CRITICAL", Channels: []string{"TELEGRAM", "SLACK"}},
+	}})
+
+	timeline, err := service.GetIncidentTimeline(GetIncidentTimelineQuery{IncidentID: incident.ID(), UserID: userId})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	kinds := make([]string, 0, len(timeline.Entries))
+	for _, entry := range timeline.Entries {
+		kinds = append(kinds, entry.Kind)
+	}
+	if strings.Join(kinds, ",") != "CONFIRMED,INCIDENT_OPENED,NOTIFICATION,RECOVERED,RESOLVED" {
+		t.Errorf("Unexpected timeline: %v", kinds)
+	}
+	if timeline.TimeToResolveSeconds == nil || *timeline.TimeToResolveSeconds != 301 {
+		t.Errorf("Expected TTR of 301s, got %v", timeline.TimeToResolveSeconds)
+	}
+
+	markdown := RenderTimelineMarkdown(*timeline)
+	if !strings.Contains(markdown, "# Incident "+incident.ID().String()+" — Checkout \\| API") {
+		t.Errorf("Expected escaped title in markdown, got:\n%s", markdown)
+	}
+	if !strings.Contains(markdown, "→ TELEGRAM, SLACK") {
+		t.Errorf("Expected notification channels in markdown, got:\n%s", markdown)
+	}
+
+	intruder, _ := userdomain.NewUserId("user-999")
+	if _, err := service.GetIncidentTimeline(GetIncidentTimelineQuery{IncidentID: incident.ID(), UserID: intruder}); err == nil {
+		t.Error("Expected authorization error")
+	}
+}
+
+func TestTargetAvailability(t *testing.T) {
+	checkRepo := &MockCheckRepository{}
+	service := NewMonitoringApplicationService(
+		NewMockTargetRepository(),
+		&MockMetricsRepository{},
+		checkRepo,
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	created, _ := service.CreateTarget(CreateTargetCommand{
+		UserID:     userId,
+		Name:       "API",
+		URL:        "https://example.com",
+		TargetType: domain.TargetTypeAPI,
+	})
+	targetId, _ := domain.NewTargetId(created.ID)
+
+	// DOWN 1h y luego UP: la primera mitad la cubre un mantenimiento
+	now := time.Now()
+	_, _ = checkRepo.Save(domain.NewFullCheckResult("cr-1", targetId, now.Add(-2*time.Hour), 0, false, domain.TargetStatusDown, "connection refused"))
+	_, _ = checkRepo.Save(domain.NewFullCheckResult("cr-2", targetId, now.Add(-time.Hour), 120, true, domain.TargetStatusUp, ""))
+	window, err := service.CreateMaintenanceWindow(CreateMaintenanceWindowCommand{
+		TargetID: targetId,
+		UserID:   userId,
+		StartsAt: now.Add(-2 * time.Hour),
+		EndsAt:   now.Add(-90 * time.Minute),
+		Reason:   "DB upgrade",
+	})
+	if err != nil {
+		t.Fatalf("Expected maintenance window, got %v", err)
+	}
+
+	stats, err := service.GetTargetStatistics(GetTargetStatisticsQuery{
+		TargetID: targetId,
+		UserID:   userId,
+		From:     now.Add(-2 * time.Hour),
+		To:       now.Add(-30 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("Expected statistics, got %v", err)
+	}
+	day := stats.Availability["24h"]
+	if day.UptimePercent == nil || *day.UptimePercent != 66.667 {
+		t.Errorf("Expected 66.667%% over 24h, got %v", day.UptimePercent)
+	}
+	if day.MaintenanceSeconds != 1800 || day.DowntimeSeconds != 1800 {
+		t.Errorf("Expected 1800s maintenance and downtime, got %d / %d", day.MaintenanceSeconds, day.DowntimeSeconds)
+	}
+	custom := stats.Availability[CustomAvailabilityRange]
+	if custom.UptimePercent == nil || *custom.UptimePercent != 50 {
+		t.Errorf("Expected 50%% over the custom range, got %v", custom.UptimePercent)
+	}
+
+	// El mantenimiento solo lo borra el dueño; sin él, la hora DOWN cuenta completa
+	intruder, _ := userdomain.NewUserId("user-999")
+	windowId := domain.MaintenanceWindowId(window.ID)
+	if err := service.DeleteMaintenanceWindow(DeleteMaintenanceWindowCommand{TargetID: targetId, WindowID: windowId, UserID: intruder}); err == nil {
+		t.Error("Expected authorization error")
+	}
+	if err := service.DeleteMaintenanceWindow(DeleteMaintenanceWindowCommand{TargetID: targetId, WindowID: windowId, UserID: userId}); err != nil {
+		t.Fatalf("Expected window deleted, got %v", err)
+	}
+
+	targets, _ := service.GetAllTargets(GetAllTargetsQuery{UserID: userId, Role: "USER"})
+	if len(targets) != 1 || targets[0].Uptime["24h"] == nil || *targets[0].Uptime["24h"] != 50 {
+		t.Errorf("Expected 50%% uptime in the target list, got %v", targets)
+	}
+}
+
+func TestGetAllTargets_UptimeWithBatchedQueries(t *testing.T) {
+	targetRepo := NewMockTargetRepository()
+	checkRepo := &MockCheckRepository{}
+	service := NewMonitoringApplicationService(
+		targetRepo,
+		&MockMetricsRepository{},
+		checkRepo,
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	now := time.Now()
+	// El mock no asigna IDs: se fijan a mano para tener varios targets distintos
+	for _, name := range []string{"api", "web", "db"} {
+		target := domain.NewMinimalMonitoringTarget(name, "https://"+name+".example.com", domain.TargetTypeAPI, userId)
+		id := domain.TargetId("target-" + name)
+		if err := target.AssignId(id); err != nil {
+			t.Fatalf("Failed to assign target ID: %v", err)
+		}
+		targetRepo.Save(target)
+		_, _ = checkRepo.Save(domain.NewFullCheckResult(domain.CheckResultId("cr-"+name), id, now.Add(-time.Hour), 100, true, domain.TargetStatusUp, ""))
+	}
+
+	targets, err := service.GetAllTargets(GetAllTargetsQuery{UserID: userId, Role: "USER"})
+	if err != nil || len(targets) != 3 {
+		t.Fatalf("Expected 3 targets, got %d (%v)", len(targets), err)
+	}
+	for _, target := range targets {
+		if target.Uptime["24h"] == nil || *target.Uptime["24h"] != 100 {
+			t.Errorf("Expected 100%% uptime for %s, got %v", target.Name, target.Uptime)
+		}
+	}
+	// El historial se lee con las consultas por lote, no una vez por target
+	if checkRepo.perTargetQueries != 0 {
+		t.Errorf("Expected no per-target history queries, got %d", checkRepo.perTargetQueries)
+	}
+}
+
+func TestGetTargetStatistics_LatencyForecast(t *testing.T) {
+	rollupRepo := &MockMetricRollupRepository{}
+	service := NewMonitoringApplicationService(
+		NewMockTargetRepository(),
+		&MockMetricsRepository{},
+		&MockCheckRepository{},
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		rollupRepo,
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	created, _ := service.CreateTarget(CreateTargetCommand{
+		UserID:     userId,
+		Name:       "API",
+		URL:        "https://example.com",
+		TargetType: domain.TargetTypeAPI,
+	})
+	targetId, _ := domain.NewTargetId(created.ID)
+
+	// Sin historial no hay pronóstico
+	stats, err := service.GetTargetStatistics(GetTargetStatisticsQuery{TargetID: targetId, UserID: userId})
+	if err != nil {
+		t.Fatalf("Expected statistics, got %v", err)
+	}
+	if stats.Forecast != nil {
+		t.Errorf("Expected no forecast without daily rollups, got %+v", stats.Forecast)
+	}
+
+	// Umbral absoluto de 500ms y p95 diario subiendo 10ms/día
+	maxLatency := 500
+	if _, err := service.UpdateConfiguration(UpdateConfigurationCommand{
+		TargetID:              targetId,
+		UserID:                userId,
+		TimeoutSeconds:        10,
+		RetryCount:            3,
+		RetryDelaySeconds:     1,
+		CheckIntervalSeconds:  60,
+		MaxLatencyThresholdMs: &maxLatency,
+	}); err != nil {
+		t.Fatalf("Expected configuration updated, got %v", err)
+	}
+	today := domain.MetricResolutionDay.BucketStart(time.Now())
+	for i := 0; i < 14; i++ {
+		rollupRepo.rollups = append(rollupRepo.rollups, domain.MetricRollup{
+			TargetId:          targetId,
+			Resolution:        domain.MetricResolutionDay,
+			BucketStart:       today.AddDate(0, 0, i-13),
+			Count:             1440,
+			P95ResponseTimeMs: 300 + 10*i,
+		})
+	}
+
+	stats, err = service.GetTargetStatistics(GetTargetStatisticsQuery{TargetID: targetId, UserID: userId, ForecastHorizonDays: 30})
+	if err != nil {
+		t.Fatalf("Expected statistics, got %v", err)
+	}
+	forecast := stats.Forecast
+	if forecast == nil || forecast.SampleDays != 14 || forecast.ThresholdMs == nil || *forecast.ThresholdMs != 500 {
+		t.Fatalf("Expected a forecast against the 500ms rule, got %+v", forecast)
+	}
+	if forecast.SlopeMsPerDay != 10 || !forecast.AtRisk || forecast.DaysToThreshold == nil || forecast.ProjectedCrossingAt == "" {
+		t.Errorf("Expected a rising trend crossing within 30 days, got %+v", forecast)
+	}
+}
+
+func TestUpdateConfiguration_AvailableStatuses(t *testing.T) {
+	service := NewMonitoringApplicationService(
+		NewMockTargetRepository(),
+		&MockMetricsRepository{},
+		&MockCheckRepository{},
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	created, _ := service.CreateTarget(CreateTargetCommand{
+		UserID:     userId,
+		Name:       "API",
+		URL:        "https://example.com",
+		TargetType: domain.TargetTypeAPI,
+	})
+	targetId, _ := domain.NewTargetId(created.ID)
+
+	cmd := UpdateConfigurationCommand{
+		TargetID:             targetId,
+		UserID:               userId,
+		TimeoutSeconds:       10,
+		RetryCount:           3,
+		RetryDelaySeconds:    1,
+		CheckIntervalSeconds: 60,
+		AvailableStatuses:    []string{"degraded"},
+	}
+	if _, err := service.UpdateConfiguration(cmd); err != domain.ErrAvailabilityRequiresUp {
+		t.Errorf("Expected ErrAvailabilityRequiresUp, got %v", err)
+	}
+
+	cmd.AvailableStatuses = []string{"up", "degraded"}
+	dto, err := service.UpdateConfiguration(cmd)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := dto.Configuration["available_statuses"].([]string); strings.Join(got, ",") != "UP,DEGRADED" {
+		t.Errorf("Expected UP,DEGRADED, got %v", got)
+	}
+
+	// Sin el campo se conserva la política
+	cmd.AvailableStatuses = nil
+	dto, _ = service.UpdateConfiguration(cmd)
+	if got := dto.Configuration["available_statuses"].([]string); len(got) != 2 {
+		t.Errorf("Expected the policy to be kept, got %v", got)
+	}
+}
+
+func TestSLOLifecycle(t *testing.T) {
+	checkRepo := &MockCheckRepository{}
+	service := NewMonitoringApplicationService(
+		NewMockTargetRepository(),
+		&MockMetricsRepository{},
+		checkRepo,
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	created, _ := service.CreateTarget(CreateTargetCommand{
+		UserID:     userId,
+		Name:       "API",
+		URL:        "https://example.com",
+		TargetType: domain.TargetTypeAPI,
+	})
+	targetId, _ := domain.NewTargetId(created.ID)
+
+	// UP durante 90 minutos y caído la última media hora
+	now := time.Now()
+	_, _ = checkRepo.Save(domain.NewFullCheckResult("cr-1", targetId, now.Add(-2*time.Hour), 120, true, domain.TargetStatusUp, ""))
+	_, _ = checkRepo.Save(domain.NewFullCheckResult("cr-2", targetId, now.Add(-30*time.Minute), 0, false, domain.TargetStatusDown, "connection refused"))
+
+	if _, err := service.CreateSLO(CreateSLOCommand{TargetID: targetId, UserID: userId, Kind: "LATENCY", Objective: 95, WindowDays: 30}); err != domain.ErrInvalidSLOLatencyThreshold {
+		t.Errorf("Expected ErrInvalidSLOLatencyThreshold, got %v", err)
+	}
+	intruder, _ := userdomain.NewUserId("user-999")
+	if _, err := service.CreateSLO(CreateSLOCommand{TargetID: targetId, UserID: intruder, Kind: "AVAILABILITY", Objective: 99, WindowDays: 1}); err == nil {
+		t.Error("Expected authorization error")
+	}
+
+	slo, err := service.CreateSLO(CreateSLOCommand{
+		TargetID:   targetId,
+		UserID:     userId,
+		Name:       "API availability",
+		Kind:       "availability",
+		Objective:  99,
+		WindowDays: 1,
+	})
+	if err != nil {
+		t.Fatalf("Expected SLO created, got %v", err)
+	}
+	if slo.Kind != "AVAILABILITY" || slo.SLI == nil || *slo.SLI != 75 {
+		t.Errorf("Expected AVAILABILITY SLO at 75%% SLI, got %+v", slo)
+	}
+	// 30 min caídos de 2h medidas con 1% de presupuesto: consumido 25 veces
+	if slo.ErrorBudgetRemaining == nil || *slo.ErrorBudgetRemaining != -2400 {
+		t.Errorf("Expected -2400%% budget remaining, got %v", slo.ErrorBudgetRemaining)
+	}
+	if slo.AlertLevel != "FAST" {
+		t.Errorf("Expected FAST burn, got %s", slo.AlertLevel)
+	}
+
+	slos, err := service.ListSLOs(ListSLOsQuery{TargetID: targetId, UserID: userId})
+	if err != nil || len(slos) != 1 {
+		t.Fatalf("Expected 1 SLO, got %v (%v)", slos, err)
+	}
+
+	sloId := domain.SLOId(slo.ID)
+	if err := service.DeleteSLO(DeleteSLOCommand{TargetID: targetId, SLOID: sloId, UserID: userId}); err != nil {
+		t.Fatalf("Expected SLO deleted, got %v", err)
+	}
+	if _, err := service.GetSLO(GetSLOQuery{TargetID: targetId, SLOID: sloId, UserID: userId}); err != domain.ErrSLONotFound {
+		t.Errorf("Expected ErrSLONotFound, got %v", err)
+	}
+}
+
+func TestGetTargetMetricSeries(t *testing.T) {
+	metricsRepo := &MockMetricsRepository{}
+	rollupRepo := &MockMetricRollupRepository{}
+	service := NewMonitoringApplicationService(
+		NewMockTargetRepository(),
+		metricsRepo,
+		&MockCheckRepository{},
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		rollupRepo,
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	created, _ := service.CreateTarget(CreateTargetCommand{
+		UserID:     userId,
+		Name:       "API",
+		URL:        "https://example.com",
+		TargetType: domain.TargetTypeAPI,
+	})
+	targetId, _ := domain.NewTargetId(created.ID)
+
+	now := time.Now()
+	healthy := domain.NewFullCheckResult("m-1", targetId, now.Add(-20*time.Minute), 120, true, domain.TargetStatusUp, "")
+	failed := domain.NewFullCheckResult("m-2", targetId, now.Add(-10*time.Minute), 0, false, domain.TargetStatusDown, "")
+	failed.Classify(domain.ErrorClassConnectTimeout)
+	metricsRepo.results = []*domain.CheckResult{healthy, failed}
+
+	// Rango corto: sesiones crudas
+	series, err := service.GetTargetMetricSeries(GetTargetMetricSeriesQuery{TargetID: targetId, UserID: userId, From: now.Add(-time.Hour)})
+	if err != nil {
+		t.Fatalf("Expected raw series, got %v", err)
+	}
+	if series.Resolution != "raw" || len(series.Points) != 2 {
+		t.Fatalf("Expected 2 raw points, got %s / %d", series.Resolution, len(series.Points))
+	}
+	if series.Points[0].P95ResponseTimeMs != 120 || series.Points[1].FailureCount != 1 || series.Points[1].ErrorClass == "" {
+		t.Errorf("Unexpected raw points: %+v", series.Points)
+	}
+
+	// 7 días: buckets de 1h (el primero puede empezar antes del rango)
+	from := now.Add(-7 * 24 * time.Hour)
+	rollupRepo.rollups = []domain.MetricRollup{
+		{TargetId: targetId, Resolution: domain.MetricResolutionHour, BucketStart: from.Truncate(time.Hour), Count: 60, FailureCount: 2, P95ResponseTimeMs: 300},
+		{TargetId: targetId, Resolution: domain.MetricResolutionMinute, BucketStart: from, Count: 1},
+		{TargetId: targetId, Resolution: domain.MetricResolutionHour, BucketStart: from.Add(-2 * time.Hour), Count: 60},
+	}
+	series, err = service.GetTargetMetricSeries(GetTargetMetricSeriesQuery{TargetID: targetId, UserID: userId, From: from, To: now})
+	if err != nil {
+		t.Fatalf("Expected hourly series, got %v", err)
+	}
+	if series.Resolution != "1h" || len(series.Points) != 1 || series.Points[0].Count != 60 || series.Points[0].FailureCount != 2 {
+		t.Errorf("Expected the single 1h bucket, got %s / %+v", series.Resolution, series.Points)
+	}
+
+	if _, err := service.GetTargetMetricSeries(GetTargetMetricSeriesQuery{TargetID: targetId, UserID: userId, From: from, Resolution: "1m"}); err != domain.ErrMetricResolutionTooFine {
+		t.Errorf("Expected ErrMetricResolutionTooFine, got %v", err)
+	}
+	if _, err := service.GetTargetMetricSeries(GetTargetMetricSeriesQuery{TargetID: targetId, UserID: userId, From: now, To: from}); err != domain.ErrInvalidTimeRange {
+		t.Errorf("Expected ErrInvalidTimeRange, got %v", err)
+	}
+}
+
+func TestGetTargetMetrics_ReturnsSessionState(t *testing.T) {
+	metricsRepo := &MockMetricsRepository{}
+	service := NewMonitoringApplicationService(
+		NewMockTargetRepository(),
+		metricsRepo,
+		&MockCheckRepository{},
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	created, _ := service.CreateTarget(CreateTargetCommand{
+		UserID:     userId,
+		Name:       "API",
+		URL:        "https://example.com",
+		TargetType: domain.TargetTypeAPI,
+	})
+	targetId, _ := domain.NewTargetId(created.ID)
+
+	down := domain.NewFullCheckResult("m-1", targetId, time.Now(), 0, false, domain.TargetStatusDown, "")
+	down.AttachSessionCounts(&domain.SessionCounts{MaxResponseTimeMs: 5000, SuccessCount: 1, FailureCount: 4})
+	legacy := domain.NewFullCheckResult("m-2", targetId, time.Now(), 90, true, domain.TargetStatusUnknown, "")
+	metricsRepo.results = []*domain.CheckResult{down, legacy}
+
+	metrics, err := service.GetTargetMetrics(GetTargetMetricsQuery{TargetID: targetId, UserID: userId, Limit: 10})
+	if err != nil {
+		t.Fatalf("Expected metrics, got %v", err)
+	}
+	if len(metrics) != 2 {
+		t.Fatalf("Expected 2 metrics, got %d", len(metrics))
+	}
+	got := metrics[0]
+	if got.Status != "DOWN" || got.Reachable {
+		t.Errorf("Expected an unreachable DOWN session, got %s / %v", got.Status, got.Reachable)
+	}
+	if got.MaxResponseTimeMs == nil || *got.MaxResponseTimeMs != 5000 || *got.SuccessCount != 1 || *got.FailureCount != 4 {
+		t.Errorf("Expected session counts to be returned, got %+v", got)
+	}
+	if metrics[1].Status != "UNKNOWN" || metrics[1].SuccessCount != nil {
+		t.Errorf("Expected legacy metric without counts, got %+v", metrics[1])
+	}
+}
+
+func TestGetTargetMetricSeries_Step(t *testing.T) {
+	metricsRepo := &MockMetricsRepository{}
+	rollupRepo := &MockMetricRollupRepository{}
+	service := NewMonitoringApplicationService(
+		NewMockTargetRepository(),
+		metricsRepo,
+		&MockCheckRepository{},
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		rollupRepo,
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	created, _ := service.CreateTarget(CreateTargetCommand{
+		UserID:     userId,
+		Name:       "API",
+		URL:        "https://example.com",
+		TargetType: domain.TargetTypeAPI,
+	})
+	targetId, _ := domain.NewTargetId(created.ID)
+
+	// Rango corto: se re-agrupan las sesiones crudas
+	to := time.Now().UTC().Truncate(time.Hour)
+	from := to.Add(-2 * time.Hour)
+	metricsRepo.results = []*domain.CheckResult{
+		domain.NewFullCheckResult("m-1", targetId, from.Add(5*time.Minute), 100, true, domain.TargetStatusUp, ""),
+		domain.NewFullCheckResult("m-2", targetId, from.Add(10*time.Minute), 0, false, domain.TargetStatusDown, ""),
+		domain.NewFullCheckResult("m-3", targetId, from.Add(70*time.Minute), 200, true, domain.TargetStatusUp, ""),
+	}
+	series, err := service.GetTargetMetricSeries(GetTargetMetricSeriesQuery{TargetID: targetId, UserID: userId, From: from, To: to, Step: time.Hour})
+	if err != nil {
+		t.Fatalf("Expected stepped series, got %v", err)
+	}
+	if series.Resolution != "raw" || series.Step != "1h" || len(series.Points) != 2 {
+		t.Fatalf("Expected 2 hourly points from raw sessions, got %s / %s / %d", series.Resolution, series.Step, len(series.Points))
+	}
+	if series.Points[0].Count != 2 || series.Points[0].FailureCount != 1 || series.Points[0].AvgResponseTimeMs != 100 {
+		t.Errorf("Unexpected first point: %+v", series.Points[0])
+	}
+
+	// Rango largo: se re-agrupan los rollups horarios
+	from = to.Add(-7 * 24 * time.Hour)
+	rollupRepo.rollups = []domain.MetricRollup{
+		{TargetId: targetId, Resolution: domain.MetricResolutionHour, BucketStart: from, Count: 60, FailureCount: 0, AvgResponseTimeMs: 100, P95ResponseTimeMs: 150},
+		{TargetId: targetId, Resolution: domain.MetricResolutionHour, BucketStart: from.Add(time.Hour), Count: 60, FailureCount: 0, AvgResponseTimeMs: 300, P95ResponseTimeMs: 500},
+	}
+	series, err = service.GetTargetMetricSeries(GetTargetMetricSeriesQuery{TargetID: targetId, UserID: userId, From: from, To: to, Step: 6 * time.Hour})
+	if err != nil {
+		t.Fatalf("Expected stepped series, got %v", err)
+	}
+	if series.Resolution != "1h" || len(series.Points) != 1 || series.Points[0].Count != 120 || series.Points[0].AvgResponseTimeMs != 200 {
+		t.Errorf("Expected one 6h point from hourly rollups, got %s / %+v", series.Resolution, series.Points)
+	}
+
+	if _, err := service.GetTargetMetricSeries(GetTargetMetricSeriesQuery{TargetID: targetId, UserID: userId, From: from, To: to, Step: time.Minute}); err != domain.ErrMetricStepTooFine {
+		t.Errorf("Expected ErrMetricStepTooFine, got %v", err)
+	}
+}
+
+func TestGetTargetHistory_RangeAndTimeline(t *testing.T) {
+	checkRepo := &MockCheckRepository{}
+	service := NewMonitoringApplicationService(
+		NewMockTargetRepository(),
+		&MockMetricsRepository{},
+		checkRepo,
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	created, _ := service.CreateTarget(CreateTargetCommand{
+		UserID:     userId,
+		Name:       "API",
+		URL:        "https://example.com",
+		TargetType: domain.TargetTypeAPI,
+	})
+	targetId, _ := domain.NewTargetId(created.ID)
+
+	// Martes 14:00-16:00
+	from := time.Date(2024, 3, 5, 14, 0, 0, 0, time.UTC)
+	to := from.Add(2 * time.Hour)
+	checkRepo.results = []*domain.CheckResult{
+		domain.NewFullCheckResult("c-1", targetId, from.Add(-3*time.Hour), 100, true, domain.TargetStatusUp, ""),
+		domain.NewFullCheckResult("c-2", targetId, from.Add(30*time.Minute), 0, false, domain.TargetStatusDown, ""),
+		domain.NewFullCheckResult("c-3", targetId, from.Add(40*time.Minute), 120, true, domain.TargetStatusUp, ""),
+		domain.NewFullCheckResult("c-4", targetId, to.Add(time.Hour), 0, false, domain.TargetStatusDown, ""),
+	}
+
+	history, err := service.GetTargetHistory(GetTargetHistoryQuery{TargetID: targetId, UserID: userId, From: from, To: to})
+	if err != nil {
+		t.Fatalf("Expected ranged history, got %v", err)
+	}
+	if len(history) != 2 || history[0].Status != "DOWN" || history[1].Status != "UP" {
+		t.Errorf("Expected the two changes inside the range, got %+v", history)
+	}
+
+	timeline, err := service.GetTargetStatusTimeline(GetTargetStatusTimelineQuery{TargetID: targetId, UserID: userId, From: from, To: to, Step: time.Hour})
+	if err != nil {
+		t.Fatalf("Expected timeline, got %v", err)
+	}
+	if timeline.Step != "1h" || len(timeline.Buckets) != 2 {
+		t.Fatalf("Expected 2 hourly buckets, got %s / %d", timeline.Step, len(timeline.Buckets))
+	}
+	if timeline.Buckets[0].Status != "DOWN" || timeline.Buckets[0].Changes != 2 || timeline.Buckets[1].Status != "UP" {
+		t.Errorf("Unexpected timeline: %+v", timeline.Buckets)
+	}
+
+	if _, err := service.GetTargetStatusTimeline(GetTargetStatusTimelineQuery{TargetID: targetId, UserID: userId, From: from, To: to, Step: time.Second}); err != domain.ErrInvalidMetricStep {
+		t.Errorf("Expected ErrInvalidMetricStep, got %v", err)
+	}
+}
+
+func TestGetTargetRanking(t *testing.T) {
+	targetRepo := NewMockTargetRepository()
+	checkRepo := &MockCheckRepository{}
+	metricsRepo := &MockMetricsRepository{}
+	statsRepo := &MockStatsRepository{}
+	service := NewMonitoringApplicationService(
+		targetRepo,
+		metricsRepo,
+		checkRepo,
+		statsRepo,
+		NewMockPingSampleRepository(),
+		NewMockIncidentRepository(),
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	// El mock no asigna IDs: se fijan a mano para tener dos targets distintos
+	newTarget := func(name string) domain.TargetId {
+		target := domain.NewMinimalMonitoringTarget(name, "https://"+name+".example.com", domain.TargetTypeAPI, userId)
+		id := domain.TargetId("target-" + name)
+		if err := target.AssignId(id); err != nil {
+			t.Fatalf("Failed to assign target ID: %v", err)
+		}
+		targetRepo.Save(target)
+		return id
+	}
+	steadyId := newTarget("steady")
+	flakyId := newTarget("flaky")
+
+	to := time.Now()
+	from := to.Add(-24 * time.Hour)
+	checkRepo.results = []*domain.CheckResult{
+		domain.NewFullCheckResult("c-1", steadyId, from.Add(-time.Hour), 100, true, domain.TargetStatusUp, ""),
+		domain.NewFullCheckResult("c-2", flakyId, from.Add(-time.Hour), 100, true, domain.TargetStatusUp, ""),
+		domain.NewFullCheckResult("c-3", flakyId, from.Add(6*time.Hour), 0, false, domain.TargetStatusDown, ""),
+		domain.NewFullCheckResult("c-4", flakyId, from.Add(12*time.Hour), 100, true, domain.TargetStatusUp, ""),
+	}
+	metricsRepo.results = []*domain.CheckResult{
+		domain.NewFullCheckResult("m-1", steadyId, from.Add(time.Hour), 100, true, domain.TargetStatusUp, ""),
+		domain.NewFullCheckResult("m-2", flakyId, from.Add(time.Hour), 300, true, domain.TargetStatusUp, ""),
+		domain.NewFullCheckResult("m-3", flakyId, from.Add(7*time.Hour), 0, false, domain.TargetStatusDown, ""),
+	}
+	statsRepo.stats = map[domain.TargetId]*domain.TargetStatistics{
+		flakyId: domain.NewFullTargetStatistics(flakyId, 200, 1000, nil, domain.EWMAStats{}, domain.FlapHistory{}),
+	}
+
+	report, err := service.GetTargetRanking(GetTargetRankingQuery{UserID: userId, Role: "USER", From: from, To: to})
+	if err != nil {
+		t.Fatalf("Expected ranking, got %v", err)
+	}
+	if report.SortBy != "availability" || len(report.Targets) != 2 {
+		t.Fatalf("Expected 2 targets ranked by availability, got %s / %d", report.SortBy, len(report.Targets))
+	}
+	worst := report.Targets[0]
+	if worst.TargetName != "flaky" || worst.Rank != 1 || worst.UptimePercent == nil || *worst.UptimePercent != 75 {
+		t.Errorf("Expected flaky first with 75%% uptime, got %+v", worst)
+	}
+	if worst.Incidents != 1 || worst.MTTRSeconds == nil || *worst.MTTRSeconds != int64((6*time.Hour).Seconds()) {
+		t.Errorf("Expected one 6h outage, got %+v", worst)
+	}
+	if worst.FailedSessions != 1 || worst.P95ResponseTimeMs == nil || *worst.P95ResponseTimeMs != 300 || worst.LatencyTrendPercent == nil || *worst.LatencyTrendPercent != 50 {
+		t.Errorf("Expected latency from metrics and trend vs statistics, got %+v", worst)
+	}
+	if report.Targets[1].LatencyTrendPercent != nil || report.Targets[1].MTTRSeconds != nil {
+		t.Errorf("Expected no trend or MTTR for steady, got %+v", report.Targets[1])
+	}
+
+	csv, err := RenderTargetRankingCSV(*report)
+	if err != nil {
+		t.Fatalf("Expected CSV, got %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(csv)), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "rank,target_id,target_name") || !strings.Contains(lines[1], ",flaky,") {
+		t.Errorf("Unexpected CSV:\n%s", csv)
+	}
+
+	if _, err := service.GetTargetRanking(GetTargetRankingQuery{UserID: userId, From: from, SortBy: "uptime"}); err != domain.ErrInvalidRankingMetric {
+		t.Errorf("Expected ErrInvalidRankingMetric, got %v", err)
+	}
+}
+
+// recordingActivityPublisher guarda la actividad publicada de los incidentes
+type recordingActivityPublisher struct {
+	published []domain.IncidentEventType
+}
+
+func (p *recordingActivityPublisher) PublishIncidentActivity(incident *domain.Incident, target *domain.MonitoringTarget, activity domain.IncidentEvent) {
+	p.published = append(p.published, activity.Type())
+}
+
+func TestIncidentActivity_PublishedAfterAcknowledgeAndResolve(t *testing.T) {
+	targetRepo := NewMockTargetRepository()
+	incidentRepo := NewMockIncidentRepository()
+	service := NewMonitoringApplicationService(
+		targetRepo,
+		&MockMetricsRepository{},
+		&MockCheckRepository{},
+		&MockStatsRepository{},
+		NewMockPingSampleRepository(),
+		incidentRepo,
+		NewMockMaintenanceRepository(),
+		&MockMonitorRunRepository{},
+		NewMockSLORepository(),
+		&MockMetricRollupRepository{},
+	)
+	publisher := &recordingActivityPublisher{}
+	service.SetIncidentActivityPublisher(publisher)
+
+	userId, _ := userdomain.NewUserId("user-123")
+	target := domain.NewMinimalMonitoringTarget("api", "https://api.example.com", domain.TargetTypeAPI, userId)
+	_ = target.AssignId("target-api")
+	targetRepo.Save(target)
+	incident := domain.OpenIncident("target-api", userId, domain.TargetStatusDown, time.Now().Add(-time.Minute))
+	_ = incidentRepo.Save(incident)
+
+	if _, err := service.AcknowledgeIncident(AcknowledgeIncidentCommand{IncidentID: incident.ID(), UserID: userId}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Los comentarios y los cambios rechazados no avisan
+	_, _ = service.CommentIncident(CommentIncidentCommand{IncidentID: incident.ID(), UserID: userId, Note: "checking"})
+	_, _ = service.AcknowledgeIncident(AcknowledgeIncidentCommand{IncidentID: incident.ID(), UserID: userId})
+	if _, err := service.ResolveIncident(ResolveIncidentCommand{IncidentID: incident.ID(), UserID: userId}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []domain.IncidentEventType{domain.IncidentEventAcknowledged, domain.IncidentEventResolved}
+	if len(publisher.published) != len(expected) || publisher.published[0] != expected[0] || publisher.published[1] != expected[1] {
+		t.Errorf("Expected %v published, got %v", expected, publisher.published)
+	}
+}
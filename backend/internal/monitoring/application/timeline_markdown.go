@@ -0,0 +1,59 @@
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// RenderTimelineMarkdown arma el borrador de post-mortem de un incidente
+func RenderTimelineMarkdown(timeline IncidentTimelineDTO) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# Incident %s — %s\n\n", timeline.IncidentID, markdownCell(timeline.TargetName))
+
+	b.WriteString("| | |\n|---|---|\n")
+	fmt.Fprintf(&b, "| Target | %s (%s) |\n", markdownCell(timeline.TargetName), markdownCell(timeline.TargetURL))
+	fmt.Fprintf(&b, "| Status | %s |\n", timeline.Status)
+	fmt.Fprintf(&b, "| Opened | %s |\n", timeline.OpenedAt)
+	if timeline.ResolvedAt != "" {
+		fmt.Fprintf(&b, "| Resolved | %s |\n", timeline.ResolvedAt)
+	}
+	fmt.Fprintf(&b, "| Duration | %s |\n", formatSeconds(timeline.DurationSeconds))
+	if timeline.TimeToAcknowledgeSeconds != nil {
+		fmt.Fprintf(&b, "| Time to acknowledge | %s |\n", formatSeconds(*timeline.TimeToAcknowledgeSeconds))
+	}
+	if timeline.TimeToResolveSeconds != nil {
+		fmt.Fprintf(&b, "| Time to resolve | %s |\n", formatSeconds(*timeline.TimeToResolveSeconds))
+	}
+
+	b.WriteString("\n## Timeline (UTC)\n\n")
+	b.WriteString("| Time | Event | Status | Details |\n|---|---|---|---|\n")
+	for _, entry := range timeline.Entries {
+		details := entry.Summary
+		if entry.Actor != "" {
+			details = fmt.Sprintf("%s (by %s)", details, entry.Actor)
+		}
+		if entry.Kind == "NOTIFICATION" {
+			if len(entry.Channels) > 0 {
+				details = fmt.Sprintf("%s → %s", details, strings.Join(entry.Channels, ", "))
+			} else {
+				details = details + " → history only"
+			}
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", entry.At, entry.Kind, entry.Status, markdownCell(details))
+	}
+
+	fmt.Fprintf(&b, "\n_Generated %s_\n", timeline.GeneratedAt)
+	return b.String()
+}
+
+// markdownCell evita que mensajes de error con "|" o saltos de línea rompan la tabla
+func markdownCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.Join(strings.Fields(value), " ")
+}
+
+func formatSeconds(seconds int64) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
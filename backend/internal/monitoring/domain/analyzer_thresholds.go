@@ -0,0 +1,174 @@
+package domain
+
+import "math"
+
+// Valores por defecto de los umbrales del analizador (comportamiento histórico)
+const (
+	DefaultDegradationFactor     = 3.0 // DEGRADED si el promedio supera 3x el histórico
+	DefaultMinLatencyThresholdMs = 200 // Por debajo de esto no se considera degradación relativa
+	DefaultMaxLatencyThresholdMs = 0   // 0 = regla absoluta deshabilitada
+	DefaultUnstableMinPings      = 5   // Banda UNSTABLE: pings necesarios para estabilizarse
+	DefaultUnstableMaxPings      = 9
+	maxPingsPerSession           = 12 // Debe coincidir con HealthChecker
+	minZScoreThreshold           = 1.0
+	maxZScoreThreshold           = 10.0
+)
+
+// BaselineMetric - Línea base contra la que la regla relativa mide la degradación
+type BaselineMetric string
+
+const (
+	BaselineMetricMean BaselineMetric = "MEAN" // Promedio ponderado (comportamiento histórico)
+	BaselineMetricP95  BaselineMetric = "P95"  // Percentil 95 de los últimos 7 días: un pico aislado no la mueve
+)
+
+func (b BaselineMetric) IsValid() bool {
+	return b == BaselineMetricMean || b == BaselineMetricP95
+}
+
+// Value Object: AnalyzerThresholds
+// Umbrales que usa el ResultAnalyzer para decidir DEGRADED / UNSTABLE por target.
+type AnalyzerThresholds struct {
+	degradationFactor     float64
+	minLatencyThresholdMs int
+	maxLatencyThresholdMs int
+	unstableMinPings      int
+	unstableMaxPings      int
+	baselineMetric        BaselineMetric
+	zScoreThreshold       float64          // Sensibilidad del detector de anomalías (menor = más sensible)
+	errorClassPolicy      ErrorClassPolicy // Estado que confirma cada clase de fallo
+}
+
+func NewDefaultAnalyzerThresholds() AnalyzerThresholds {
+	return AnalyzerThresholds{
+		degradationFactor:     DefaultDegradationFactor,
+		minLatencyThresholdMs: DefaultMinLatencyThresholdMs,
+		maxLatencyThresholdMs: DefaultMaxLatencyThresholdMs,
+		unstableMinPings:      DefaultUnstableMinPings,
+		unstableMaxPings:      DefaultUnstableMaxPings,
+		baselineMetric:        BaselineMetricMean,
+		zScoreThreshold:       DefaultZScoreThreshold,
+	}
+}
+
+// NewAnalyzerThresholds valida y crea los umbrales
+// maxLatencyThresholdMs = 0 deshabilita la regla absoluta "más lento que X ms es DEGRADED"
+func NewAnalyzerThresholds(degradationFactor float64, minLatencyThresholdMs int, maxLatencyThresholdMs int, unstableMinPings int, unstableMaxPings int) (AnalyzerThresholds, error) {
+	if degradationFactor < 1 {
+		return AnalyzerThresholds{}, ErrInvalidDegradationFactor
+	}
+	if minLatencyThresholdMs < 0 || maxLatencyThresholdMs < 0 {
+		return AnalyzerThresholds{}, ErrInvalidLatencyThreshold
+	}
+	// Se necesitan al menos 3 pings para confirmar estado, y nunca más de los que hace una sesión
+	if unstableMinPings < 3 || unstableMaxPings > maxPingsPerSession || unstableMinPings > unstableMaxPings {
+		return AnalyzerThresholds{}, ErrInvalidUnstableBand
+	}
+
+	return AnalyzerThresholds{
+		degradationFactor:     degradationFactor,
+		minLatencyThresholdMs: minLatencyThresholdMs,
+		maxLatencyThresholdMs: maxLatencyThresholdMs,
+		unstableMinPings:      unstableMinPings,
+		unstableMaxPings:      unstableMaxPings,
+		baselineMetric:        BaselineMetricMean,
+		zScoreThreshold:       DefaultZScoreThreshold,
+	}, nil
+}
+
+// WithBaselineMetric devuelve una copia de los umbrales usando otra línea base
+func (t AnalyzerThresholds) WithBaselineMetric(metric BaselineMetric) (AnalyzerThresholds, error) {
+	if !metric.IsValid() {
+		return t, ErrInvalidBaselineMetric
+	}
+	t.baselineMetric = metric
+	return t, nil
+}
+
+// WithZScoreThreshold devuelve una copia con otra sensibilidad para el detector de anomalías
+func (t AnalyzerThresholds) WithZScoreThreshold(threshold float64) (AnalyzerThresholds, error) {
+	if threshold < minZScoreThreshold || threshold > maxZScoreThreshold {
+		return t, ErrInvalidZScoreThreshold
+	}
+	t.zScoreThreshold = threshold
+	return t, nil
+}
+
+// WithErrorClassPolicy devuelve una copia con otra política por clase de error (ya validada)
+func (t AnalyzerThresholds) WithErrorClassPolicy(policy ErrorClassPolicy) AnalyzerThresholds {
+	t.errorClassPolicy = policy
+	return t
+}
+
+// Getters
+func (t AnalyzerThresholds) ErrorClassPolicy() ErrorClassPolicy {
+	return t.errorClassPolicy
+}
+
+func (t AnalyzerThresholds) DegradationFactor() float64 {
+	return t.degradationFactor
+}
+
+func (t AnalyzerThresholds) MinLatencyThresholdMs() int {
+	return t.minLatencyThresholdMs
+}
+
+func (t AnalyzerThresholds) MaxLatencyThresholdMs() int {
+	return t.maxLatencyThresholdMs
+}
+
+func (t AnalyzerThresholds) UnstableMinPings() int {
+	return t.unstableMinPings
+}
+
+func (t AnalyzerThresholds) UnstableMaxPings() int {
+	return t.unstableMaxPings
+}
+
+func (t AnalyzerThresholds) BaselineMetric() BaselineMetric {
+	return t.baselineMetric
+}
+
+func (t AnalyzerThresholds) ZScoreThreshold() float64 {
+	return t.zScoreThreshold
+}
+
+// IsRelativelyDegraded aplica la regla relativa: supera factor x línea base Y el piso de latencia
+func (t AnalyzerThresholds) IsRelativelyDegraded(currentMs int, baselineMs int) bool {
+	if baselineMs <= 0 {
+		return false
+	}
+	return float64(currentMs) >= float64(baselineMs)*t.degradationFactor && currentMs > t.minLatencyThresholdMs
+}
+
+// DegradationThresholdMs devuelve la latencia a partir de la cual el analizador marca DEGRADED:
+// la regla absoluta si está activa, la relativa (factor x línea base, nunca bajo el piso) si hay
+// línea base, y la menor de las dos si aplican ambas. false si no hay umbral contra el cual proyectar.
+func (t AnalyzerThresholds) DegradationThresholdMs(baselineMs int) (int, bool) {
+	threshold := 0
+	if baselineMs > 0 {
+		threshold = int(math.Ceil(float64(baselineMs) * t.degradationFactor))
+		if threshold < t.minLatencyThresholdMs {
+			threshold = t.minLatencyThresholdMs
+		}
+	}
+	if t.maxLatencyThresholdMs > 0 && (threshold == 0 || t.maxLatencyThresholdMs < threshold) {
+		threshold = t.maxLatencyThresholdMs
+	}
+	return threshold, threshold > 0
+}
+
+// IsAbsolutelyDegraded aplica la regla absoluta (detecta deriva gradual que la relativa no ve)
+func (t AnalyzerThresholds) IsAbsolutelyDegraded(currentMs int) bool {
+	return t.maxLatencyThresholdMs > 0 && currentMs > t.maxLatencyThresholdMs
+}
+
+// IsAnomalous aplica el detector estadístico: z-score sobre el umbral Y el piso de latencia
+func (t AnalyzerThresholds) IsAnomalous(currentMs int, zScore float64) bool {
+	return zScore >= t.zScoreThreshold && currentMs > t.minLatencyThresholdMs
+}
+
+// IsUnstable indica si la cantidad de pings para estabilizarse cae en la banda UNSTABLE
+func (t AnalyzerThresholds) IsUnstable(pings int) bool {
+	return pings >= t.unstableMinPings && pings <= t.unstableMaxPings
+}
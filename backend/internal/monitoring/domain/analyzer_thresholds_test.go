@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestNewDefaultAnalyzerThresholds(t *testing.T) {
+	thresholds := NewDefaultAnalyzerThresholds()
+
+	if thresholds.DegradationFactor() != 3.0 {
+		t.Errorf("Expected default factor 3.0, got %f", thresholds.DegradationFactor())
+	}
+	if thresholds.MinLatencyThresholdMs() != 200 {
+		t.Errorf("Expected default latency floor 200, got %d", thresholds.MinLatencyThresholdMs())
+	}
+	if thresholds.MaxLatencyThresholdMs() != 0 {
+		t.Errorf("Expected absolute rule disabled by default, got %d", thresholds.MaxLatencyThresholdMs())
+	}
+	if thresholds.UnstableMinPings() != 5 || thresholds.UnstableMaxPings() != 9 {
+		t.Errorf("Expected unstable band 5-9, got %d-%d", thresholds.UnstableMinPings(), thresholds.UnstableMaxPings())
+	}
+}
+
+func TestNewAnalyzerThresholds_Invalid(t *testing.T) {
+	if _, err := NewAnalyzerThresholds(0.5, 200, 0, 5, 9); err != ErrInvalidDegradationFactor {
+		t.Errorf("Expected ErrInvalidDegradationFactor, got %v", err)
+	}
+	if _, err := NewAnalyzerThresholds(3, -1, 0, 5, 9); err != ErrInvalidLatencyThreshold {
+		t.Errorf("Expected ErrInvalidLatencyThreshold, got %v", err)
+	}
+	if _, err := NewAnalyzerThresholds(3, 200, 0, 2, 9); err != ErrInvalidUnstableBand {
+		t.Errorf("Expected ErrInvalidUnstableBand for min < 3, got %v", err)
+	}
+	if _, err := NewAnalyzerThresholds(3, 200, 0, 8, 6); err != ErrInvalidUnstableBand {
+		t.Errorf("Expected ErrInvalidUnstableBand for min > max, got %v", err)
+	}
+}
+
+func TestAnalyzerThresholds_RelativeDegradation(t *testing.T) {
+	thresholds, err := NewAnalyzerThresholds(2, 100, 0, 5, 9)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !thresholds.IsRelativelyDegraded(300, 150) {
+		t.Error("Expected 300ms vs 150ms baseline to be degraded with factor 2")
+	}
+	if thresholds.IsRelativelyDegraded(80, 20) {
+		t.Error("Expected latency below the floor not to be degraded")
+	}
+	if thresholds.IsRelativelyDegraded(500, 0) {
+		t.Error("Expected no degradation without baseline")
+	}
+}
+
+func TestAnalyzerThresholds_AbsoluteDegradation(t *testing.T) {
+	disabled := NewDefaultAnalyzerThresholds()
+	if disabled.IsAbsolutelyDegraded(10000) {
+		t.Error("Expected absolute rule to be disabled by default")
+	}
+
+	thresholds, _ := NewAnalyzerThresholds(3, 200, 1000, 5, 9)
+	if !thresholds.IsAbsolutelyDegraded(1200) {
+		t.Error("Expected 1200ms to exceed the 1000ms absolute limit")
+	}
+	if thresholds.IsAbsolutelyDegraded(900) {
+		t.Error("Expected 900ms to be within the absolute limit")
+	}
+}
+
+func TestAnalyzerThresholds_IsUnstable(t *testing.T) {
+	thresholds, _ := NewAnalyzerThresholds(3, 200, 0, 4, 6)
+
+	if thresholds.IsUnstable(3) {
+		t.Error("Expected 3 pings (fast stabilization) not to be unstable")
+	}
+	if !thresholds.IsUnstable(4) || !thresholds.IsUnstable(6) {
+		t.Error("Expected band bounds to be inclusive")
+	}
+	if thresholds.IsUnstable(7) {
+		t.Error("Expected 7 pings to be outside the band")
+	}
+}
+
+func TestCheckConfiguration_UpdateThresholds(t *testing.T) {
+	config := NewDefaultCheckConfiguration()
+	thresholds, _ := NewAnalyzerThresholds(4, 300, 2000, 6, 10)
+
+	config.UpdateThresholds(thresholds)
+
+	if config.Thresholds().DegradationFactor() != 4 {
+		t.Errorf("Expected factor 4, got %f", config.Thresholds().DegradationFactor())
+	}
+	if config.Thresholds().MaxLatencyThresholdMs() != 2000 {
+		t.Errorf("Expected absolute limit 2000, got %d", config.Thresholds().MaxLatencyThresholdMs())
+	}
+}
+
+func TestAnalyzerThresholds_WithBaselineMetric(t *testing.T) {
+	thresholds := NewDefaultAnalyzerThresholds()
+	if thresholds.BaselineMetric() != BaselineMetricMean {
+		t.Errorf("Expected MEAN baseline by default, got %s", thresholds.BaselineMetric())
+	}
+
+	p95, err := thresholds.WithBaselineMetric(BaselineMetricP95)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if p95.BaselineMetric() != BaselineMetricP95 {
+		t.Errorf("Expected P95 baseline, got %s", p95.BaselineMetric())
+	}
+	if thresholds.BaselineMetric() != BaselineMetricMean {
+		t.Error("Expected original thresholds to be unchanged")
+	}
+
+	if _, err := thresholds.WithBaselineMetric("MEDIAN"); err != ErrInvalidBaselineMetric {
+		t.Errorf("Expected ErrInvalidBaselineMetric, got %v", err)
+	}
+}
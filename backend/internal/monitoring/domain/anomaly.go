@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	EWMAAlpha              = 0.1 // Peso de la muestra nueva (~ últimas 20 sesiones dominan la media)
+	MinEWMASamples         = 20  // Warm-up: antes de esto se usa la regla del factor
+	DefaultZScoreThreshold = 3.0 // Sensibilidad por defecto: 3 desviaciones estándar
+	minRelativeStdDev      = 0.05
+)
+
+// Reglas que pueden marcar un target como DEGRADED
+const (
+	DegradationRuleZScore   = "ZSCORE"   // Anomalía estadística contra la media EWMA (o estacional)
+	DegradationRuleFactor   = "FACTOR"   // N veces la línea base (mientras el detector aprende)
+	DegradationRuleAbsolute = "ABSOLUTE" // Supera el máximo absoluto configurado
+)
+
+// MetricResponseTime es la métrica que evalúa hoy el detector
+const MetricResponseTime = "response_time_ms"
+
+// Value Object: EWMAStats
+// Media y varianza exponencialmente ponderadas del tiempo de respuesta de un target.
+// A diferencia del promedio de 7 días, reacciona rápido y permite medir "qué tan raro" es un valor (z-score).
+type EWMAStats struct {
+	mean     float64
+	variance float64
+	samples  int
+}
+
+func NewEWMAStats(mean float64, variance float64, samples int) EWMAStats {
+	return EWMAStats{mean: mean, variance: variance, samples: samples}
+}
+
+// Getters
+func (e EWMAStats) Mean() float64 {
+	return e.mean
+}
+
+func (e EWMAStats) Variance() float64 {
+	return e.variance
+}
+
+func (e EWMAStats) Samples() int {
+	return e.samples
+}
+
+// IsWarm indica si ya hay suficientes muestras para confiar en el z-score
+func (e EWMAStats) IsWarm() bool {
+	return e.samples >= MinEWMASamples
+}
+
+// StdDev devuelve la desviación estándar con un piso relativo a la media:
+// un servicio muy estable (varianza ~0) no debe convertir cualquier jitter en una anomalía
+func (e EWMAStats) StdDev() float64 {
+	return math.Max(math.Sqrt(e.variance), math.Max(e.mean*minRelativeStdDev, 1))
+}
+
+// ZScore mide cuántas desviaciones estándar se aleja `observed` de `baseline`
+func (e EWMAStats) ZScore(observed float64, baseline float64) float64 {
+	return (observed - baseline) / e.StdDev()
+}
+
+// Update incorpora una muestra. Si el detector ya está caliente, la muestra se recorta a
+// mean + clipZ*std: una anomalía no infla la varianza, pero un cambio de nivel sostenido sí se aprende.
+func (e EWMAStats) Update(sample float64, clipZ float64) EWMAStats {
+	if e.samples == 0 {
+		return EWMAStats{mean: sample, variance: 0, samples: 1}
+	}
+
+	if e.IsWarm() && clipZ > 0 {
+		sample = math.Min(sample, e.mean+clipZ*e.StdDev())
+	}
+
+	diff := sample - e.mean
+	increment := EWMAAlpha * diff
+	return EWMAStats{
+		mean:     e.mean + increment,
+		variance: (1 - EWMAAlpha) * (e.variance + diff*increment),
+		samples:  e.samples + 1,
+	}
+}
+
+// Value Object: DegradationReason
+// Explicación legible por máquina de por qué una sesión se marcó DEGRADED.
+type DegradationReason struct {
+	Rule     string  // ZSCORE | FACTOR | ABSOLUTE
+	Metric   string  // Métrica evaluada (response_time_ms)
+	Observed float64 // Valor de la sesión
+	Baseline float64 // Valor contra el que se comparó
+	Score    float64 // z-score (ZSCORE) o ratio observado/base (FACTOR, ABSOLUTE)
+}
+
+func (r DegradationReason) String() string {
+	return fmt.Sprintf("%s %.0f vs baseline %.0f (%s score %.2f)", r.Metric, r.Observed, r.Baseline, r.Rule, r.Score)
+}
+
+// Metadata aplana la razón para AlertEvent.Metadata
+func (r DegradationReason) Metadata() map[string]string {
+	return map[string]string{
+		"reason_rule":     r.Rule,
+		"reason_metric":   r.Metric,
+		"reason_observed": fmt.Sprintf("%.0f", r.Observed),
+		"reason_baseline": fmt.Sprintf("%.0f", r.Baseline),
+		"reason_score":    fmt.Sprintf("%.2f", r.Score),
+	}
+}
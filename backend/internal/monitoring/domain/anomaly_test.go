@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"math"
+	"testing"
+)
+
+func warmEWMA(samples ...float64) EWMAStats {
+	ewma := EWMAStats{}
+	for i := 0; i < MinEWMASamples; i++ {
+		ewma = ewma.Update(samples[i%len(samples)], DefaultZScoreThreshold)
+	}
+	return ewma
+}
+
+func TestEWMAStats_WarmUp(t *testing.T) {
+	ewma := EWMAStats{}
+	if ewma.IsWarm() {
+		t.Error("Expected empty EWMA not to be warm")
+	}
+
+	ewma = ewma.Update(200, DefaultZScoreThreshold)
+	if ewma.Mean() != 200 || ewma.Variance() != 0 {
+		t.Errorf("Expected first sample to seed mean 200 / variance 0, got %f / %f", ewma.Mean(), ewma.Variance())
+	}
+
+	ewma = warmEWMA(190, 210)
+	if !ewma.IsWarm() {
+		t.Errorf("Expected EWMA to be warm after %d samples", MinEWMASamples)
+	}
+	if math.Abs(ewma.Mean()-200) > 10 {
+		t.Errorf("Expected mean close to 200, got %f", ewma.Mean())
+	}
+}
+
+func TestEWMAStats_ZScore(t *testing.T) {
+	ewma := warmEWMA(190, 210)
+
+	if z := ewma.ZScore(205, ewma.Mean()); z >= DefaultZScoreThreshold {
+		t.Errorf("Expected normal jitter to have low z-score, got %f", z)
+	}
+	if z := ewma.ZScore(900, ewma.Mean()); z < DefaultZScoreThreshold {
+		t.Errorf("Expected 900ms to be anomalous, got z=%f", z)
+	}
+}
+
+func TestEWMAStats_StdDevFloor(t *testing.T) {
+	// Servicio perfectamente estable: varianza 0
+	ewma := NewEWMAStats(100, 0, MinEWMASamples)
+
+	if ewma.StdDev() != 5 {
+		t.Errorf("Expected std dev floor of 5%% of mean (5), got %f", ewma.StdDev())
+	}
+	if z := ewma.ZScore(104, 100); z >= 1 {
+		t.Errorf("Expected 4ms jitter not to explode the z-score, got %f", z)
+	}
+}
+
+func TestEWMAStats_ClipsAnomalies(t *testing.T) {
+	ewma := warmEWMA(190, 210)
+	before := ewma.Mean()
+
+	after := ewma.Update(10000, DefaultZScoreThreshold)
+
+	maxShift := EWMAAlpha * DefaultZScoreThreshold * ewma.StdDev()
+	if after.Mean()-before > maxShift+0.001 {
+		t.Errorf("Expected clipped update (shift <= %f), got %f", maxShift, after.Mean()-before)
+	}
+}
+
+func TestAnalyzerThresholds_IsAnomalous(t *testing.T) {
+	thresholds, err := NewDefaultAnalyzerThresholds().WithZScoreThreshold(2.5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !thresholds.IsAnomalous(500, 2.6) {
+		t.Error("Expected z=2.6 to be anomalous with sensitivity 2.5")
+	}
+	if thresholds.IsAnomalous(500, 2.4) {
+		t.Error("Expected z=2.4 not to be anomalous with sensitivity 2.5")
+	}
+	if thresholds.IsAnomalous(50, 8) {
+		t.Error("Expected latency below the floor never to be anomalous")
+	}
+
+	if _, err := thresholds.WithZScoreThreshold(0.5); err != ErrInvalidZScoreThreshold {
+		t.Errorf("Expected ErrInvalidZScoreThreshold, got %v", err)
+	}
+}
+
+func TestDegradationReason_Metadata(t *testing.T) {
+	reason := DegradationReason{
+		Rule:     DegradationRuleZScore,
+		Metric:   MetricResponseTime,
+		Observed: 950,
+		Baseline: 310,
+		Score:    4.271,
+	}
+
+	metadata := reason.Metadata()
+	expected := map[string]string{
+		"reason_rule":     "ZSCORE",
+		"reason_metric":   "response_time_ms",
+		"reason_observed": "950",
+		"reason_baseline": "310",
+		"reason_score":    "4.27",
+	}
+	for key, want := range expected {
+		if metadata[key] != want {
+			t.Errorf("Expected %s=%s, got %s", key, want, metadata[key])
+		}
+	}
+}
+
+func TestCheckResult_DegradationReason(t *testing.T) {
+	targetId, _ := NewTargetId("test-target-123")
+	result := NewCheckResult(targetId, 950, true, TargetStatusDegraded)
+
+	if result.DegradationReason() != nil {
+		t.Error("Expected no reason by default")
+	}
+
+	result.AttachDegradationReason(&DegradationReason{Rule: DegradationRuleFactor, Metric: MetricResponseTime})
+	if result.DegradationReason() == nil || result.DegradationReason().Rule != DegradationRuleFactor {
+		t.Error("Expected attached reason to be returned")
+	}
+}
@@ -0,0 +1,225 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// AvailabilityWindow - Ventana móvil de uptime (termina "ahora")
+type AvailabilityWindow struct {
+	Name string
+	Span time.Duration
+}
+
+// StandardAvailabilityWindows - Ventanas que se exponen en estadísticas y en la lista de targets
+var StandardAvailabilityWindows = []AvailabilityWindow{
+	{Name: "24h", Span: 24 * time.Hour},
+	{Name: "7d", Span: 7 * 24 * time.Hour},
+	{Name: "30d", Span: 30 * 24 * time.Hour},
+	{Name: "90d", Span: 90 * 24 * time.Hour},
+}
+
+// TimeRange - Intervalo semiabierto [Start, End)
+type TimeRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+func NewTimeRange(start time.Time, end time.Time) (TimeRange, error) {
+	if !end.After(start) {
+		return TimeRange{}, ErrInvalidTimeRange
+	}
+	return TimeRange{Start: start, End: end}, nil
+}
+
+func (r TimeRange) Duration() time.Duration {
+	if !r.End.After(r.Start) {
+		return 0
+	}
+	return r.End.Sub(r.Start)
+}
+
+func (r TimeRange) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+func (r TimeRange) Overlaps(other TimeRange) bool {
+	return r.Start.Before(other.End) && other.Start.Before(r.End)
+}
+
+// Value Object: AvailabilityPolicy
+// Qué estados cuentan como "disponible". UP siempre cuenta; UNKNOWN nunca (es falta de datos)
+type AvailabilityPolicy struct {
+	statuses map[TargetStatus]bool
+}
+
+// availabilityStatusOrder - Orden estable para exponer la política
+var availabilityStatusOrder = []TargetStatus{
+	TargetStatusUp,
+	TargetStatusDegraded,
+	TargetStatusUnstable,
+	TargetStatusFlapping,
+	TargetStatusDown,
+}
+
+func NewDefaultAvailabilityPolicy() AvailabilityPolicy {
+	return AvailabilityPolicy{statuses: map[TargetStatus]bool{TargetStatusUp: true}}
+}
+
+// NewAvailabilityPolicy valida la lista: debe incluir UP y no puede incluir UNKNOWN
+func NewAvailabilityPolicy(statuses []TargetStatus) (AvailabilityPolicy, error) {
+	set := make(map[TargetStatus]bool, len(statuses))
+	for _, status := range statuses {
+		if !status.IsValid() || status == TargetStatusUnknown {
+			return AvailabilityPolicy{}, ErrInvalidAvailabilityStatus
+		}
+		set[status] = true
+	}
+	if !set[TargetStatusUp] {
+		return AvailabilityPolicy{}, ErrAvailabilityRequiresUp
+	}
+	return AvailabilityPolicy{statuses: set}, nil
+}
+
+func (p AvailabilityPolicy) IsAvailable(status TargetStatus) bool {
+	if p.statuses == nil {
+		return status == TargetStatusUp
+	}
+	return p.statuses[status]
+}
+
+func (p AvailabilityPolicy) Statuses() []TargetStatus {
+	statuses := make([]TargetStatus, 0, len(availabilityStatusOrder))
+	for _, status := range availabilityStatusOrder {
+		if p.IsAvailable(status) {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// StatusTransition - Momento en que el target pasó a un estado (un check_result)
+type StatusTransition struct {
+	At     time.Time
+	Status TargetStatus
+}
+
+// AvailabilityExclusions - Períodos que no cuentan ni a favor ni en contra del SLA
+type AvailabilityExclusions struct {
+	Maintenance  []TimeRange
+	SelfDowntime []TimeRange // El propio monitor estaba caído: el estado guardado no es confiable
+}
+
+// AvailabilityReport - Reparto del tiempo de una ventana
+type AvailabilityReport struct {
+	Window       TimeRange
+	Available    time.Duration
+	Unavailable  time.Duration
+	Maintenance  time.Duration
+	SelfDowntime time.Duration
+	Unknown      time.Duration // Sin datos: antes del primer cambio de estado o estado UNKNOWN
+}
+
+// Measured es el tiempo que efectivamente entra en el cálculo del porcentaje
+func (r AvailabilityReport) Measured() time.Duration {
+	return r.Available + r.Unavailable
+}
+
+// UptimePercent devuelve false si en la ventana no hubo tiempo medido
+func (r AvailabilityReport) UptimePercent() (float64, bool) {
+	measured := r.Measured()
+	if measured <= 0 {
+		return 0, false
+	}
+	return float64(r.Available) / float64(measured) * 100, true
+}
+
+// StatusHistory - Estado de un target en el tiempo, reconstruido desde sus cambios de estado.
+// Antes de la primera transición el estado es desconocido.
+type StatusHistory struct {
+	transitions []StatusTransition
+}
+
+func NewStatusHistory(transitions []StatusTransition) StatusHistory {
+	sorted := make([]StatusTransition, len(transitions))
+	copy(sorted, transitions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].At.Before(sorted[j].At)
+	})
+	return StatusHistory{transitions: sorted}
+}
+
+// StatusAt devuelve el estado vigente en t (UNKNOWN si todavía no había datos)
+func (h StatusHistory) StatusAt(t time.Time) TargetStatus {
+	idx := sort.Search(len(h.transitions), func(i int) bool {
+		return h.transitions[i].At.After(t)
+	})
+	if idx == 0 {
+		return TargetStatusUnknown
+	}
+	return h.transitions[idx-1].Status
+}
+
+// Availability reparte la ventana entre disponible, caído, excluido y sin datos.
+// Mantenimiento tiene prioridad sobre la caída del monitor si se superponen.
+func (h StatusHistory) Availability(window TimeRange, exclusions AvailabilityExclusions, policy AvailabilityPolicy) AvailabilityReport {
+	report := AvailabilityReport{Window: window}
+	if window.Duration() == 0 {
+		return report
+	}
+
+	// Puntos de corte: dentro de cada tramo nada cambia
+	cuts := []time.Time{window.Start, window.End}
+	for _, transition := range h.transitions {
+		if window.Contains(transition.At) {
+			cuts = append(cuts, transition.At)
+		}
+	}
+	for _, ranges := range [][]TimeRange{exclusions.Maintenance, exclusions.SelfDowntime} {
+		for _, r := range ranges {
+			if window.Contains(r.Start) {
+				cuts = append(cuts, r.Start)
+			}
+			if window.Contains(r.End) {
+				cuts = append(cuts, r.End)
+			}
+		}
+	}
+	sort.Slice(cuts, func(i, j int) bool { return cuts[i].Before(cuts[j]) })
+
+	for i := 0; i+1 < len(cuts); i++ {
+		start, end := cuts[i], cuts[i+1]
+		span := end.Sub(start)
+		if span <= 0 {
+			continue
+		}
+
+		switch {
+		case anyContains(exclusions.Maintenance, start):
+			report.Maintenance += span
+		case anyContains(exclusions.SelfDowntime, start):
+			report.SelfDowntime += span
+		default:
+			status := h.StatusAt(start)
+			switch {
+			case status == TargetStatusUnknown:
+				report.Unknown += span
+			case policy.IsAvailable(status):
+				report.Available += span
+			default:
+				report.Unavailable += span
+			}
+		}
+	}
+
+	return report
+}
+
+func anyContains(ranges []TimeRange, t time.Time) bool {
+	for _, r := range ranges {
+		if r.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
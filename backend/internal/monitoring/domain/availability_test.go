@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAvailabilityPolicy_Validation(t *testing.T) {
+	if _, err := NewAvailabilityPolicy([]TargetStatus{TargetStatusDegraded}); !errors.Is(err, ErrAvailabilityRequiresUp) {
+		t.Errorf("Expected ErrAvailabilityRequiresUp, got %v", err)
+	}
+	if _, err := NewAvailabilityPolicy([]TargetStatus{TargetStatusUp, TargetStatusUnknown}); !errors.Is(err, ErrInvalidAvailabilityStatus) {
+		t.Errorf("Expected ErrInvalidAvailabilityStatus for UNKNOWN, got %v", err)
+	}
+
+	policy, err := NewAvailabilityPolicy([]TargetStatus{TargetStatusDegraded, TargetStatusUp})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !policy.IsAvailable(TargetStatusDegraded) || policy.IsAvailable(TargetStatusDown) {
+		t.Error("Expected DEGRADED available and DOWN unavailable")
+	}
+	statuses := policy.Statuses()
+	if len(statuses) != 2 || statuses[0] != TargetStatusUp || statuses[1] != TargetStatusDegraded {
+		t.Errorf("Expected [UP DEGRADED], got %v", statuses)
+	}
+
+	// El valor cero se comporta como el default
+	if !(AvailabilityPolicy{}).IsAvailable(TargetStatusUp) || (AvailabilityPolicy{}).IsAvailable(TargetStatusDegraded) {
+		t.Error("Expected zero policy to count only UP")
+	}
+}
+
+func TestStatusHistory_Availability(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := TimeRange{Start: start, End: start.Add(10 * time.Hour)}
+
+	// UP desde antes de la ventana, DOWN 1h, DEGRADED 2h, UP
+	history := NewStatusHistory([]StatusTransition{
+		{At: start.Add(5 * time.Hour), Status: TargetStatusDegraded},
+		{At: start.Add(-time.Hour), Status: TargetStatusUp},
+		{At: start.Add(4 * time.Hour), Status: TargetStatusDown},
+		{At: start.Add(7 * time.Hour), Status: TargetStatusUp},
+	})
+
+	report := history.Availability(window, AvailabilityExclusions{}, NewDefaultAvailabilityPolicy())
+	if report.Available != 7*time.Hour || report.Unavailable != 3*time.Hour {
+		t.Errorf("Expected 7h available / 3h unavailable, got %s / %s", report.Available, report.Unavailable)
+	}
+	if percent, ok := report.UptimePercent(); !ok || percent != 70 {
+		t.Errorf("Expected 70%%, got %v (ok=%v)", percent, ok)
+	}
+
+	// Con DEGRADED como disponible solo cuenta la hora DOWN
+	lenient, _ := NewAvailabilityPolicy([]TargetStatus{TargetStatusUp, TargetStatusDegraded})
+	report = history.Availability(window, AvailabilityExclusions{}, lenient)
+	if report.Unavailable != time.Hour {
+		t.Errorf("Expected 1h unavailable, got %s", report.Unavailable)
+	}
+}
+
+func TestStatusHistory_AvailabilityExclusions(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := TimeRange{Start: start, End: start.Add(10 * time.Hour)}
+	history := NewStatusHistory([]StatusTransition{
+		{At: start.Add(2 * time.Hour), Status: TargetStatusUp},
+		{At: start.Add(4 * time.Hour), Status: TargetStatusDown},
+		{At: start.Add(8 * time.Hour), Status: TargetStatusUp},
+	})
+
+	exclusions := AvailabilityExclusions{
+		// La caída 4h-8h: 4h-6h en mantenimiento, 5h-7h el monitor estaba caído (se superponen)
+		Maintenance:  []TimeRange{{Start: start.Add(4 * time.Hour), End: start.Add(6 * time.Hour)}},
+		SelfDowntime: []TimeRange{{Start: start.Add(5 * time.Hour), End: start.Add(7 * time.Hour)}},
+	}
+	report := history.Availability(window, exclusions, NewDefaultAvailabilityPolicy())
+
+	if report.Unknown != 2*time.Hour {
+		t.Errorf("Expected 2h unknown before the first transition, got %s", report.Unknown)
+	}
+	if report.Maintenance != 2*time.Hour {
+		t.Errorf("Expected 2h maintenance, got %s", report.Maintenance)
+	}
+	if report.SelfDowntime != time.Hour {
+		t.Errorf("Expected 1h self downtime (maintenance wins the overlap), got %s", report.SelfDowntime)
+	}
+	if report.Unavailable != time.Hour || report.Available != 4*time.Hour {
+		t.Errorf("Expected 4h available / 1h unavailable, got %s / %s", report.Available, report.Unavailable)
+	}
+	if percent, _ := report.UptimePercent(); percent != 80 {
+		t.Errorf("Expected 80%%, got %v", percent)
+	}
+
+	// Ventana sin datos: no hay porcentaje
+	empty := NewStatusHistory(nil).Availability(window, AvailabilityExclusions{}, NewDefaultAvailabilityPolicy())
+	if _, ok := empty.UptimePercent(); ok || empty.Unknown != 10*time.Hour {
+		t.Errorf("Expected no uptime and 10h unknown, got %s unknown", empty.Unknown)
+	}
+}
+
+func TestNewMaintenanceWindow(t *testing.T) {
+	now := time.Now()
+	if _, err := NewMaintenanceWindow("target-1", now, now, "", "user-1", now); !errors.Is(err, ErrInvalidMaintenanceWindow) {
+		t.Errorf("Expected ErrInvalidMaintenanceWindow for an empty window, got %v", err)
+	}
+
+	window, err := NewMaintenanceWindow("target-1", now, now.Add(time.Hour), "  upgrade ", "user-1", now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if window.Reason() != "upgrade" {
+		t.Errorf("Expected trimmed reason, got %q", window.Reason())
+	}
+	if !window.IsActiveAt(now) || window.IsActiveAt(now.Add(time.Hour)) {
+		t.Error("Expected the window to be half-open [start, end)")
+	}
+}
@@ -9,40 +9,59 @@ type CheckConfiguration struct {
 	checkIntervalSeconds int // Frecuencia de chequeo en segundos
 	alertOnFailure       bool
 	alertOnRecovery      bool
+	thresholds           AnalyzerThresholds // Umbrales del ResultAnalyzer
+	// Muestras crudas (opt-in): cada ping de la sesión, para depurar UNSTABLE/FLAPPING
+	rawSamplesEnabled       bool
+	rawSamplesRetentionDays int
+	availabilityPolicy      AvailabilityPolicy // Estados que cuentan como disponibles para el uptime
 }
 
+const (
+	DefaultRawSamplesRetentionDays = 3
+	MaxRawSamplesRetentionDays     = 14 // Retención corta: es data de depuración, no histórico
+)
+
 // NewCheckConfiguration crea una nueva instancia de CheckConfiguration
 func NewCheckConfiguration(timeoutSeconds int, retryCount int, retryDelaySeconds int, checkIntervalSeconds int) *CheckConfiguration {
 	return &CheckConfiguration{
-		timeoutSeconds:       timeoutSeconds,
-		retryCount:           retryCount,
-		retryDelaySeconds:    retryDelaySeconds,
-		checkIntervalSeconds: checkIntervalSeconds,
-		alertOnFailure:       true,
-		alertOnRecovery:      true,
+		timeoutSeconds:          timeoutSeconds,
+		retryCount:              retryCount,
+		retryDelaySeconds:       retryDelaySeconds,
+		checkIntervalSeconds:    checkIntervalSeconds,
+		alertOnFailure:          true,
+		alertOnRecovery:         true,
+		thresholds:              NewDefaultAnalyzerThresholds(),
+		rawSamplesRetentionDays: DefaultRawSamplesRetentionDays,
+		availabilityPolicy:      NewDefaultAvailabilityPolicy(),
 	}
 }
 
 func NewDefaultCheckConfiguration() *CheckConfiguration {
 	return &CheckConfiguration{
-		timeoutSeconds:       10,
-		retryCount:           3,
-		retryDelaySeconds:    1,
-		checkIntervalSeconds: 300, // 5 minutos por defecto
-		alertOnFailure:       true,
-		alertOnRecovery:      true,
+		timeoutSeconds:          10,
+		retryCount:              3,
+		retryDelaySeconds:       1,
+		checkIntervalSeconds:    300, // 5 minutos por defecto
+		alertOnFailure:          true,
+		alertOnRecovery:         true,
+		thresholds:              NewDefaultAnalyzerThresholds(),
+		rawSamplesRetentionDays: DefaultRawSamplesRetentionDays,
+		availabilityPolicy:      NewDefaultAvailabilityPolicy(),
 	}
 }
 
 func NewFullCheckConfiguration(id ConfigId, timeoutSeconds int, retryCount int, retryDelaySeconds int, checkIntervalSeconds int, alertOnFailure bool, alertOnRecovery bool) *CheckConfiguration {
 	return &CheckConfiguration{
-		configId:             id,
-		timeoutSeconds:       timeoutSeconds,
-		retryCount:           retryCount,
-		retryDelaySeconds:    retryDelaySeconds,
-		checkIntervalSeconds: checkIntervalSeconds,
-		alertOnFailure:       alertOnFailure,
-		alertOnRecovery:      alertOnRecovery,
+		configId:                id,
+		timeoutSeconds:          timeoutSeconds,
+		retryCount:              retryCount,
+		retryDelaySeconds:       retryDelaySeconds,
+		checkIntervalSeconds:    checkIntervalSeconds,
+		alertOnFailure:          alertOnFailure,
+		alertOnRecovery:         alertOnRecovery,
+		thresholds:              NewDefaultAnalyzerThresholds(),
+		rawSamplesRetentionDays: DefaultRawSamplesRetentionDays,
+		availabilityPolicy:      NewDefaultAvailabilityPolicy(),
 	}
 }
 
@@ -75,6 +94,10 @@ func (c *CheckConfiguration) AlertOnRecovery() bool {
 	return c.alertOnRecovery
 }
 
+func (c *CheckConfiguration) Thresholds() AnalyzerThresholds {
+	return c.thresholds
+}
+
 // Business methods
 func (c *CheckConfiguration) UpdateInterval(seconds int) error {
 	if seconds <= 0 {
@@ -96,6 +119,43 @@ func (c *CheckConfiguration) UpdateRetryPolicy(retries int, delaySeconds int) er
 	return nil
 }
 
+func (c *CheckConfiguration) RawSamplesEnabled() bool {
+	return c.rawSamplesEnabled
+}
+
+func (c *CheckConfiguration) RawSamplesRetentionDays() int {
+	return c.rawSamplesRetentionDays
+}
+
+// EnableRawSamples activa el guardado de cada ping de la sesión con su propia retención
+func (c *CheckConfiguration) EnableRawSamples(retentionDays int) error {
+	if retentionDays < 1 || retentionDays > MaxRawSamplesRetentionDays {
+		return ErrInvalidRawSamplesRetention
+	}
+	c.rawSamplesEnabled = true
+	c.rawSamplesRetentionDays = retentionDays
+	return nil
+}
+
+// DisableRawSamples deja de guardar pings (los existentes se purgan al vencer su retención)
+func (c *CheckConfiguration) DisableRawSamples() {
+	c.rawSamplesEnabled = false
+}
+
+// UpdateThresholds reemplaza los umbrales del analizador (ya validados por NewAnalyzerThresholds)
+func (c *CheckConfiguration) UpdateThresholds(thresholds AnalyzerThresholds) {
+	c.thresholds = thresholds
+}
+
+func (c *CheckConfiguration) AvailabilityPolicy() AvailabilityPolicy {
+	return c.availabilityPolicy
+}
+
+// UpdateAvailabilityPolicy cambia qué estados suman al uptime (ya validada por NewAvailabilityPolicy)
+func (c *CheckConfiguration) UpdateAvailabilityPolicy(policy AvailabilityPolicy) {
+	c.availabilityPolicy = policy
+}
+
 func (c *CheckConfiguration) IsValid() bool {
 	return c.timeoutSeconds > 0 && c.retryCount >= 0 && c.retryDelaySeconds >= 0
 }
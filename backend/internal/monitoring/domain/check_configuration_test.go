@@ -130,3 +130,35 @@ func TestCheckConfiguration_IsValid_InvalidTimeout(t *testing.T) {
 		t.Error("Expected config to be invalid with timeout 0")
 	}
 }
+
+func TestCheckConfiguration_RawSamples(t *testing.T) {
+	config := NewDefaultCheckConfiguration()
+
+	// Opt-in: deshabilitado por defecto
+	if config.RawSamplesEnabled() {
+		t.Error("Expected raw samples disabled by default")
+	}
+	if config.RawSamplesRetentionDays() != DefaultRawSamplesRetentionDays {
+		t.Errorf("Expected default retention %d, got %d", DefaultRawSamplesRetentionDays, config.RawSamplesRetentionDays())
+	}
+
+	if err := config.EnableRawSamples(MaxRawSamplesRetentionDays + 1); err != ErrInvalidRawSamplesRetention {
+		t.Errorf("Expected ErrInvalidRawSamplesRetention, got %v", err)
+	}
+	if err := config.EnableRawSamples(0); err != ErrInvalidRawSamplesRetention {
+		t.Errorf("Expected ErrInvalidRawSamplesRetention for 0 days, got %v", err)
+	}
+
+	if err := config.EnableRawSamples(7); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !config.RawSamplesEnabled() || config.RawSamplesRetentionDays() != 7 {
+		t.Errorf("Expected raw samples enabled for 7 days, got %v/%d", config.RawSamplesEnabled(), config.RawSamplesRetentionDays())
+	}
+
+	// Deshabilitar conserva la retención para cuando se reactive
+	config.DisableRawSamples()
+	if config.RawSamplesEnabled() || config.RawSamplesRetentionDays() != 7 {
+		t.Errorf("Expected disabled with retention kept, got %v/%d", config.RawSamplesEnabled(), config.RawSamplesRetentionDays())
+	}
+}
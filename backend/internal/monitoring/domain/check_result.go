@@ -13,6 +13,21 @@ type CheckResult struct {
 	reachable          bool
 	status             TargetStatus
 	errorMessage       string
+	degradationReason  *DegradationReason // Solo en sesiones DEGRADED: por qué se disparó
+	sessionId          SessionId          // Sesión que produjo este resultado (vacío en datos antiguos)
+	errorClass         ErrorClass         // Por qué falló (vacío si fue exitoso o en datos antiguos)
+	sessionCounts      *SessionCounts     // Solo en puntos de métricas: resumen de los pings de la sesión
+}
+
+// SessionCounts - Resumen de los pings de una sesión que acompaña a cada punto de la serie
+type SessionCounts struct {
+	MaxResponseTimeMs int // Pico de la sesión (incluye pings fallidos: el tiempo es real)
+	SuccessCount      int // UP y DEGRADED
+	FailureCount      int
+}
+
+func (s SessionCounts) Total() int {
+	return s.SuccessCount + s.FailureCount
 }
 
 func NewCheckResult(targetId TargetId, responseTimeMs int, reachable bool, status TargetStatus) *CheckResult {
@@ -75,6 +90,47 @@ func (c *CheckResult) ErrorMessage() string {
 	return c.errorMessage
 }
 
+func (c *CheckResult) SessionId() SessionId {
+	return c.sessionId
+}
+
+// AssignSession vincula el resultado con la sesión que lo produjo
+func (c *CheckResult) AssignSession(sessionId SessionId) {
+	c.sessionId = sessionId
+}
+
+func (c *CheckResult) DegradationReason() *DegradationReason {
+	return c.degradationReason
+}
+
+// AttachDegradationReason adjunta la explicación de un DEGRADED
+func (c *CheckResult) AttachDegradationReason(reason *DegradationReason) {
+	c.degradationReason = reason
+}
+
+func (c *CheckResult) ErrorClass() ErrorClass {
+	return c.errorClass
+}
+
+// Classify registra la clase del fallo (el mensaje libre se conserva para depurar)
+func (c *CheckResult) Classify(class ErrorClass) {
+	c.errorClass = class
+}
+
+func (c *CheckResult) SessionCounts() *SessionCounts {
+	return c.sessionCounts
+}
+
+// AttachSessionCounts adjunta el resumen de la sesión (nil en métricas antiguas)
+func (c *CheckResult) AttachSessionCounts(counts *SessionCounts) {
+	c.sessionCounts = counts
+}
+
+// IsFailedSession - Regla de las series: DOWN, sin tiempo de respuesta o con clase de error
+func (c *CheckResult) IsFailedSession() bool {
+	return c.status == TargetStatusDown || c.responseTimeMs <= 0 || c.errorClass != ""
+}
+
 func (c *CheckResult) IsHealthy() bool {
 	return c.reachable && c.status == TargetStatusUp
 }
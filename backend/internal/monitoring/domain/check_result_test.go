@@ -2,6 +2,7 @@ package domain
 
 import (
 	"testing"
+	"time"
 )
 
 func TestNewCheckResult_Success(t *testing.T) {
@@ -75,3 +76,44 @@ func TestCheckResult_IsHealthy_StatusDown(t *testing.T) {
 		t.Error("Expected result to be unhealthy when status is DOWN")
 	}
 }
+
+func TestNewPingSample_FromCheckResult(t *testing.T) {
+	targetId := TargetId("target-123")
+	sessionId, err := NewSessionId("session-abc")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	result := NewFullCheckResult("", targetId, time.Now(), 0, false, TargetStatusDown, "connection refused")
+	result.AssignSession(sessionId)
+
+	sample := NewPingSample(sessionId, 3, result)
+
+	if sample.SessionId() != sessionId || sample.TargetId() != targetId {
+		t.Errorf("Expected session/target to be copied, got %s/%s", sample.SessionId(), sample.TargetId())
+	}
+	if sample.Sequence() != 3 || sample.Status() != TargetStatusDown || sample.ErrorMessage() != "connection refused" {
+		t.Errorf("Unexpected sample: seq=%d status=%s error=%q", sample.Sequence(), sample.Status(), sample.ErrorMessage())
+	}
+	if result.SessionId() != sessionId {
+		t.Errorf("Expected check result to carry session %s, got %s", sessionId, result.SessionId())
+	}
+
+	if _, err := NewSessionId(""); err != ErrSessionIdEmpty {
+		t.Errorf("Expected ErrSessionIdEmpty, got %v", err)
+	}
+}
+
+func TestCheckResult_AttachSessionCounts(t *testing.T) {
+	result := NewCheckResult(TargetId("target-123"), 0, false, TargetStatusDown)
+	if result.SessionCounts() != nil {
+		t.Fatal("Expected no session counts by default")
+	}
+
+	result.AttachSessionCounts(&SessionCounts{MaxResponseTimeMs: 3000, SuccessCount: 2, FailureCount: 3})
+
+	counts := result.SessionCounts()
+	if counts == nil || counts.MaxResponseTimeMs != 3000 || counts.Total() != 5 {
+		t.Errorf("Expected attached counts with 5 pings, got %+v", counts)
+	}
+}
@@ -0,0 +1,143 @@
+package domain
+
+// ErrorClass - Clasificación estable de por qué falló un ping.
+// El texto de error (err.Error()) cambia entre versiones de Go y no sirve para alertas ni estadísticas.
+type ErrorClass string
+
+const (
+	ErrorClassNone           ErrorClass = ""                // Ping exitoso o error no reconocido
+	ErrorClassDNS            ErrorClass = "dns"             // El nombre no resuelve
+	ErrorClassConnectRefused ErrorClass = "connect_refused" // El host respondió, pero nada escucha en el puerto
+	ErrorClassConnectTimeout ErrorClass = "connect_timeout" // No se llegó a establecer la conexión a tiempo
+	ErrorClassTLS            ErrorClass = "tls"             // Handshake o certificado inválido (expirado, hostname, CA)
+	ErrorClassReadTimeout    ErrorClass = "read_timeout"    // Conectó, pero la respuesta no llegó a tiempo
+	ErrorClassHTTP5xx        ErrorClass = "http_5xx"
+	ErrorClassHTTP4xx        ErrorClass = "http_4xx"
+	ErrorClassAssertion      ErrorClass = "assertion" // Respondió, pero no lo esperado (ej: 3xx sin seguir)
+)
+
+// AllErrorClasses lista las clases válidas (en orden estable, para DTOs y validación)
+var AllErrorClasses = []ErrorClass{
+	ErrorClassDNS,
+	ErrorClassConnectRefused,
+	ErrorClassConnectTimeout,
+	ErrorClassTLS,
+	ErrorClassReadTimeout,
+	ErrorClassHTTP5xx,
+	ErrorClassHTTP4xx,
+	ErrorClassAssertion,
+}
+
+func NewErrorClass(value string) (ErrorClass, error) {
+	class := ErrorClass(value)
+	if !class.IsValid() {
+		return ErrorClassNone, ErrInvalidErrorClass
+	}
+	return class, nil
+}
+
+func (c ErrorClass) IsValid() bool {
+	for _, known := range AllErrorClasses {
+		if c == known {
+			return true
+		}
+	}
+	return false
+}
+
+func (c ErrorClass) String() string {
+	return string(c)
+}
+
+// ClassifyHTTPStatus clasifica una respuesta HTTP que no es 2xx (None si es 2xx)
+func ClassifyHTTPStatus(statusCode int) ErrorClass {
+	switch {
+	case statusCode >= 200 && statusCode < 300:
+		return ErrorClassNone
+	case statusCode >= 500:
+		return ErrorClassHTTP5xx
+	case statusCode >= 400:
+		return ErrorClassHTTP4xx
+	default:
+		return ErrorClassAssertion
+	}
+}
+
+// defaultErrorClassStatus - Estado al que lleva cada clase (comportamiento histórico del HealthChecker):
+// 4xx y aserciones son DEGRADED (el servidor está vivo), todo lo demás es DOWN.
+var defaultErrorClassStatus = map[ErrorClass]TargetStatus{
+	ErrorClassDNS:            TargetStatusDown,
+	ErrorClassConnectRefused: TargetStatusDown,
+	ErrorClassConnectTimeout: TargetStatusDown,
+	ErrorClassTLS:            TargetStatusDown,
+	ErrorClassReadTimeout:    TargetStatusDown,
+	ErrorClassHTTP5xx:        TargetStatusDown,
+	ErrorClassHTTP4xx:        TargetStatusDegraded,
+	ErrorClassAssertion:      TargetStatusDegraded,
+}
+
+// Value Object: ErrorClassPolicy
+// Qué estado confirma el analizador según la clase del fallo. Por target se pueden pisar
+// clases puntuales, ej: un endpoint que responde 401 a propósito (http_4xx -> UP)
+// o un read_timeout que solo debe marcar DEGRADED.
+type ErrorClassPolicy struct {
+	overrides map[ErrorClass]TargetStatus
+}
+
+func NewDefaultErrorClassPolicy() ErrorClassPolicy {
+	return ErrorClassPolicy{}
+}
+
+// NewErrorClassPolicy valida las reglas: clases conocidas y solo UP, DEGRADED o DOWN como destino
+func NewErrorClassPolicy(overrides map[ErrorClass]TargetStatus) (ErrorClassPolicy, error) {
+	copied := make(map[ErrorClass]TargetStatus, len(overrides))
+	for class, status := range overrides {
+		if !class.IsValid() {
+			return ErrorClassPolicy{}, ErrInvalidErrorClass
+		}
+		if status != TargetStatusUp && status != TargetStatusDegraded && status != TargetStatusDown {
+			return ErrorClassPolicy{}, ErrInvalidErrorClassStatus
+		}
+		if defaultErrorClassStatus[class] == status {
+			continue // Igual al default: no hace falta guardarlo
+		}
+		copied[class] = status
+	}
+	return ErrorClassPolicy{overrides: copied}, nil
+}
+
+// StatusFor devuelve el estado que corresponde a la clase (y si la política lo define)
+func (p ErrorClassPolicy) StatusFor(class ErrorClass) (TargetStatus, bool) {
+	if status, ok := p.overrides[class]; ok {
+		return status, true
+	}
+	status, ok := defaultErrorClassStatus[class]
+	return status, ok
+}
+
+// Overrides devuelve solo las reglas que difieren del default (lo que se persiste)
+func (p ErrorClassPolicy) Overrides() map[ErrorClass]TargetStatus {
+	copied := make(map[ErrorClass]TargetStatus, len(p.overrides))
+	for class, status := range p.overrides {
+		copied[class] = status
+	}
+	return copied
+}
+
+// Effective devuelve la tabla completa clase -> estado (para mostrarla en la configuración)
+func (p ErrorClassPolicy) Effective() map[ErrorClass]TargetStatus {
+	effective := make(map[ErrorClass]TargetStatus, len(defaultErrorClassStatus))
+	for _, class := range AllErrorClasses {
+		effective[class], _ = p.StatusFor(class)
+	}
+	return effective
+}
+
+// Merge aplica nuevas reglas sobre las actuales (las clases no mencionadas se conservan)
+func (p ErrorClassPolicy) Merge(overrides map[ErrorClass]TargetStatus) (ErrorClassPolicy, error) {
+	merged := p.Overrides()
+	for class, status := range overrides {
+		merged[class] = status
+	}
+	return NewErrorClassPolicy(merged)
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"testing"
+)
+
+func TestClassifyHTTPStatus(t *testing.T) {
+	cases := map[int]ErrorClass{
+		200: ErrorClassNone,
+		204: ErrorClassNone,
+		301: ErrorClassAssertion,
+		404: ErrorClassHTTP4xx,
+		429: ErrorClassHTTP4xx,
+		500: ErrorClassHTTP5xx,
+		503: ErrorClassHTTP5xx,
+	}
+
+	for code, expected := range cases {
+		if got := ClassifyHTTPStatus(code); got != expected {
+			t.Errorf("HTTP %d: expected %q, got %q", code, expected, got)
+		}
+	}
+}
+
+func TestNewErrorClass(t *testing.T) {
+	if class, err := NewErrorClass("read_timeout"); err != nil || class != ErrorClassReadTimeout {
+		t.Errorf("Expected read_timeout, got %q (%v)", class, err)
+	}
+	if _, err := NewErrorClass("timeout"); err != ErrInvalidErrorClass {
+		t.Errorf("Expected ErrInvalidErrorClass, got %v", err)
+	}
+}
+
+func TestErrorClassPolicy_Defaults(t *testing.T) {
+	policy := NewDefaultErrorClassPolicy()
+
+	if status, _ := policy.StatusFor(ErrorClassDNS); status != TargetStatusDown {
+		t.Errorf("Expected dns -> DOWN, got %s", status)
+	}
+	if status, _ := policy.StatusFor(ErrorClassHTTP4xx); status != TargetStatusDegraded {
+		t.Errorf("Expected http_4xx -> DEGRADED, got %s", status)
+	}
+	if _, ok := policy.StatusFor(ErrorClassNone); ok {
+		t.Error("Expected no rule for an unclassified failure")
+	}
+	if len(policy.Effective()) != len(AllErrorClasses) {
+		t.Errorf("Expected effective policy to cover every class, got %d", len(policy.Effective()))
+	}
+}
+
+func TestErrorClassPolicy_Overrides(t *testing.T) {
+	policy, err := NewErrorClassPolicy(map[ErrorClass]TargetStatus{
+		ErrorClassHTTP4xx:     TargetStatusUp,
+		ErrorClassReadTimeout: TargetStatusDegraded,
+		ErrorClassDNS:         TargetStatusDown, // Igual al default: no se guarda
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if status, _ := policy.StatusFor(ErrorClassHTTP4xx); status != TargetStatusUp {
+		t.Errorf("Expected http_4xx -> UP, got %s", status)
+	}
+	if len(policy.Overrides()) != 2 {
+		t.Errorf("Expected only 2 overrides to be kept, got %v", policy.Overrides())
+	}
+
+	// Merge conserva las reglas no mencionadas
+	merged, err := policy.Merge(map[ErrorClass]TargetStatus{ErrorClassHTTP4xx: TargetStatusDegraded})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if status, _ := merged.StatusFor(ErrorClassReadTimeout); status != TargetStatusDegraded {
+		t.Errorf("Expected read_timeout rule to be kept, got %s", status)
+	}
+	if len(merged.Overrides()) != 1 {
+		t.Errorf("Expected http_4xx back to default, got %v", merged.Overrides())
+	}
+}
+
+func TestErrorClassPolicy_Invalid(t *testing.T) {
+	if _, err := NewErrorClassPolicy(map[ErrorClass]TargetStatus{"timeout": TargetStatusDown}); err != ErrInvalidErrorClass {
+		t.Errorf("Expected ErrInvalidErrorClass, got %v", err)
+	}
+	if _, err := NewErrorClassPolicy(map[ErrorClass]TargetStatus{ErrorClassTLS: TargetStatusFlapping}); err != ErrInvalidErrorClassStatus {
+		t.Errorf("Expected ErrInvalidErrorClassStatus, got %v", err)
+	}
+}
@@ -17,6 +17,58 @@ var (
 var (
 	ErrCheckResultNotFound = errors.New("resultado de check no encontrado")
 	ErrCheckResultIdEmpty  = errors.New("check result id no puede estar vacío")
+	ErrSessionIdEmpty      = errors.New("session id no puede estar vacío")
+	ErrSessionNotFound     = errors.New("no hay pings guardados para esta sesión")
+	ErrInvalidErrorClass   = errors.New("clase de error inválida")
+)
+
+// Domain Errors - Incident
+var (
+	ErrIncidentNotFound            = errors.New("incidente no encontrado")
+	ErrIncidentIdEmpty             = errors.New("incident id no puede estar vacío")
+	ErrIncidentAlreadyHasId        = errors.New("el ID del incidente ya ha sido establecido")
+	ErrInvalidIncidentStatus       = errors.New("estado de incidente inválido (OPEN, ACKNOWLEDGED o RESOLVED)")
+	ErrIncidentResolved            = errors.New("el incidente ya está resuelto")
+	ErrIncidentAlreadyAcknowledged = errors.New("el incidente ya fue reconocido")
+	ErrIncidentNoteEmpty           = errors.New("la nota del incidente no puede estar vacía")
+	ErrIncidentConflict            = errors.New("el incidente cambió mientras se actualizaba, reintentá")
+)
+
+// Domain Errors - Availability
+var (
+	ErrInvalidTimeRange              = errors.New("rango inválido: el fin debe ser posterior al inicio")
+	ErrInvalidAvailabilityStatus     = errors.New("estado disponible inválido (UP, DEGRADED, UNSTABLE, FLAPPING o DOWN)")
+	ErrAvailabilityRequiresUp        = errors.New("la política de disponibilidad debe incluir UP")
+	ErrMaintenanceWindowNotFound     = errors.New("ventana de mantenimiento no encontrada")
+	ErrMaintenanceWindowIdEmpty      = errors.New("maintenance window id no puede estar vacío")
+	ErrMaintenanceWindowAlreadyHasId = errors.New("el ID de la ventana de mantenimiento ya ha sido establecido")
+	ErrInvalidMaintenanceWindow      = errors.New("ventana de mantenimiento inválida: el fin debe ser posterior al inicio")
+)
+
+// Domain Errors - SLO
+var (
+	ErrSLONotFound                = errors.New("SLO no encontrado")
+	ErrSLOIdEmpty                 = errors.New("slo id no puede estar vacío")
+	ErrSLOAlreadyHasId            = errors.New("el ID del SLO ya ha sido establecido")
+	ErrInvalidSLOKind             = errors.New("tipo de SLO inválido (AVAILABILITY o LATENCY)")
+	ErrInvalidSLOObjective        = errors.New("objetivo del SLO inválido (porcentaje mayor a 0 y menor a 100)")
+	ErrInvalidSLOWindow           = errors.New("ventana del SLO inválida (entre 1 y 90 días)")
+	ErrInvalidSLOLatencyThreshold = errors.New("un SLO de latencia necesita un umbral en ms mayor a 0")
+)
+
+// Domain Errors - Metrics rollups
+var (
+	ErrInvalidMetricResolution = errors.New("resolución de métricas inválida (raw, 1m, 1h o 1d)")
+	ErrMetricResolutionTooFine = errors.New("la resolución pedida genera demasiados puntos para el rango")
+	ErrInvalidTimeBound        = errors.New("fecha inválida: usar RFC3339, now o relativo (ej: -24h, -7d)")
+	ErrInvalidMetricStep       = errors.New("paso inválido: duración de al menos 1m (ej: 5m, 1h, 1d)")
+	ErrMetricStepTooFine       = errors.New("el paso pedido genera demasiados puntos para el rango")
+	ErrMetricStepUnaligned     = errors.New("para rangos de más de 6h el paso debe ser múltiplo de 1m")
+)
+
+// Domain Errors - Reports
+var (
+	ErrInvalidRankingMetric = errors.New("criterio de ranking inválido (availability, p95, incidents, mttr o trend)")
 )
 
 // Domain Errors - CheckConfiguration
@@ -27,4 +79,16 @@ var (
 	ErrInvalidRetryCount = errors.New("número de reintentos no puede ser negativo")
 	ErrInvalidRetryDelay = errors.New("delay de reintentos no puede ser negativo")
 	ErrInvalidTimeout    = errors.New("timeout debe ser mayor a 0")
+
+	ErrInvalidRawSamplesRetention = errors.New("retención de muestras crudas debe estar entre 1 y 14 días")
+)
+
+// Domain Errors - AnalyzerThresholds
+var (
+	ErrInvalidDegradationFactor = errors.New("factor de degradación debe ser mayor o igual a 1")
+	ErrInvalidLatencyThreshold  = errors.New("umbral de latencia no puede ser negativo")
+	ErrInvalidUnstableBand      = errors.New("banda UNSTABLE inválida (mínimo 3, máximo 12 pings, min <= max)")
+	ErrInvalidBaselineMetric    = errors.New("línea base inválida (MEAN o P95)")
+	ErrInvalidZScoreThreshold   = errors.New("sensibilidad de anomalías inválida (z-score entre 1 y 10)")
+	ErrInvalidErrorClassStatus  = errors.New("una clase de error solo puede llevar a UP, DEGRADED o DOWN")
 )
@@ -0,0 +1,91 @@
+package domain
+
+const (
+	FlapWindowSessions     = 21   // Sesiones consideradas (20 posibles cambios, como Nagios)
+	FlapMinSessions        = 11   // No se evalúa con menos historia: 2 sesiones distintas no son "flapping"
+	FlapHighThresholdPct   = 50.0 // % de cambios para ENTRAR en flapping
+	FlapLowThresholdPct    = 25.0 // % de cambios para SALIR (histéresis: evita entrar/salir en cada ciclo)
+	flapOldestChangeWeight = 0.8  // Los cambios recientes pesan más (0.8 el más viejo -> 1.2 el más nuevo)
+	flapNewestChangeWeight = 1.2
+)
+
+// FlapTransition indica si la última sesión hizo entrar o salir del estado flapping
+type FlapTransition int
+
+const (
+	FlapTransitionNone FlapTransition = iota
+	FlapTransitionStarted
+	FlapTransitionEnded
+)
+
+// Value Object: FlapHistory
+// Detección de flapping entre sesiones (estilo Nagios). El FLAPPING de una sesión solo detecta
+// "no hubo 3 pings iguales en 12"; un target que alterna UP/DOWN en cada intervalo nunca lo dispara.
+// Aquí se mide el porcentaje ponderado de cambios de estado en las últimas N sesiones.
+type FlapHistory struct {
+	recentStates []TargetStatus // De la más vieja a la más nueva
+	isFlapping   bool
+}
+
+func NewFlapHistory(recentStates []TargetStatus, isFlapping bool) FlapHistory {
+	states := recentStates
+	if len(states) > FlapWindowSessions {
+		states = states[len(states)-FlapWindowSessions:]
+	}
+	copied := make([]TargetStatus, len(states))
+	copy(copied, states)
+
+	return FlapHistory{recentStates: copied, isFlapping: isFlapping}
+}
+
+// Getters
+func (f FlapHistory) RecentStates() []TargetStatus {
+	return f.recentStates
+}
+
+func (f FlapHistory) IsFlapping() bool {
+	return f.isFlapping
+}
+
+// StateChangePercent calcula el % ponderado de cambios de estado en la ventana
+func (f FlapHistory) StateChangePercent() float64 {
+	possibleChanges := len(f.recentStates) - 1
+	if possibleChanges < 1 {
+		return 0
+	}
+
+	weighted := 0.0
+	for i := 1; i < len(f.recentStates); i++ {
+		if f.recentStates[i] == f.recentStates[i-1] {
+			continue
+		}
+		position := 0.0
+		if possibleChanges > 1 {
+			position = float64(i-1) / float64(possibleChanges-1)
+		}
+		weighted += flapOldestChangeWeight + position*(flapNewestChangeWeight-flapOldestChangeWeight)
+	}
+
+	return weighted / float64(possibleChanges) * 100
+}
+
+// Record agrega el estado de una sesión y aplica la histéresis
+func (f FlapHistory) Record(status TargetStatus) (FlapHistory, FlapTransition) {
+	states := append(append(make([]TargetStatus, 0, len(f.recentStates)+1), f.recentStates...), status)
+	next := NewFlapHistory(states, f.isFlapping)
+
+	if len(next.recentStates) < FlapMinSessions {
+		return next, FlapTransitionNone
+	}
+
+	percent := next.StateChangePercent()
+	switch {
+	case !next.isFlapping && percent >= FlapHighThresholdPct:
+		next.isFlapping = true
+		return next, FlapTransitionStarted
+	case next.isFlapping && percent < FlapLowThresholdPct:
+		next.isFlapping = false
+		return next, FlapTransitionEnded
+	}
+	return next, FlapTransitionNone
+}
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"testing"
+)
+
+func recordAll(history FlapHistory, states ...TargetStatus) (FlapHistory, []FlapTransition) {
+	transitions := make([]FlapTransition, 0, len(states))
+	for _, s := range states {
+		var tr FlapTransition
+		history, tr = history.Record(s)
+		transitions = append(transitions, tr)
+	}
+	return history, transitions
+}
+
+func alternating(n int) []TargetStatus {
+	states := make([]TargetStatus, n)
+	for i := range states {
+		if i%2 == 0 {
+			states[i] = TargetStatusUp
+		} else {
+			states[i] = TargetStatusDown
+		}
+	}
+	return states
+}
+
+func repeat(status TargetStatus, n int) []TargetStatus {
+	states := make([]TargetStatus, n)
+	for i := range states {
+		states[i] = status
+	}
+	return states
+}
+
+func TestFlapHistory_StableTargetNeverFlaps(t *testing.T) {
+	history, transitions := recordAll(FlapHistory{}, repeat(TargetStatusUp, 30)...)
+
+	if history.IsFlapping() {
+		t.Error("Expected stable target not to flap")
+	}
+	if history.StateChangePercent() != 0 {
+		t.Errorf("Expected 0%% state change, got %f", history.StateChangePercent())
+	}
+	for _, tr := range transitions {
+		if tr != FlapTransitionNone {
+			t.Fatal("Expected no flap transitions for a stable target")
+		}
+	}
+	if len(history.RecentStates()) != FlapWindowSessions {
+		t.Errorf("Expected window capped at %d, got %d", FlapWindowSessions, len(history.RecentStates()))
+	}
+}
+
+func TestFlapHistory_AlternatingTargetStartsOnce(t *testing.T) {
+	_, transitions := recordAll(FlapHistory{}, alternating(30)...)
+
+	started := 0
+	for i, tr := range transitions {
+		if tr == FlapTransitionStarted {
+			started++
+			if i+1 < FlapMinSessions {
+				t.Errorf("Expected no evaluation before %d sessions, started at %d", FlapMinSessions, i+1)
+			}
+		}
+		if tr == FlapTransitionEnded {
+			t.Error("Expected flapping not to end while still alternating")
+		}
+	}
+	if started != 1 {
+		t.Errorf("Expected exactly one flapping-start, got %d", started)
+	}
+}
+
+func TestFlapHistory_Hysteresis(t *testing.T) {
+	history, _ := recordAll(FlapHistory{}, alternating(FlapWindowSessions)...)
+	if !history.IsFlapping() {
+		t.Fatal("Expected alternating target to be flapping")
+	}
+
+	// Al estabilizarse, el % baja gradualmente: no debe salir apenas cae bajo el umbral alto
+	ended := 0
+	for i := 0; i < FlapWindowSessions; i++ {
+		var tr FlapTransition
+		history, tr = history.Record(TargetStatusUp)
+
+		percent := history.StateChangePercent()
+		if tr == FlapTransitionEnded {
+			ended++
+			if percent >= FlapLowThresholdPct {
+				t.Errorf("Expected to end only below %.0f%%, ended at %.1f%%", FlapLowThresholdPct, percent)
+			}
+		} else if history.IsFlapping() && percent < FlapLowThresholdPct {
+			t.Errorf("Expected flapping to end at %.1f%%", percent)
+		}
+	}
+
+	if ended != 1 {
+		t.Errorf("Expected exactly one flapping-end, got %d", ended)
+	}
+	if history.IsFlapping() {
+		t.Error("Expected target to stop flapping once stable")
+	}
+}
+
+func TestFlapHistory_RecentChangesWeighMore(t *testing.T) {
+	old := NewFlapHistory(append([]TargetStatus{TargetStatusDown}, repeat(TargetStatusUp, 20)...), false)
+	recent := NewFlapHistory(append(repeat(TargetStatusUp, 20), TargetStatusDown), false)
+
+	if recent.StateChangePercent() <= old.StateChangePercent() {
+		t.Errorf("Expected recent change (%.2f%%) to weigh more than old change (%.2f%%)",
+			recent.StateChangePercent(), old.StateChangePercent())
+	}
+}
+
+func TestTargetStatistics_RecordSessionState(t *testing.T) {
+	targetId, _ := NewTargetId("test-target-123")
+	stats := NewTargetStatistics(targetId)
+
+	var last FlapTransition
+	for _, s := range alternating(FlapWindowSessions) {
+		if tr := stats.RecordSessionState(s); tr != FlapTransitionNone {
+			last = tr
+		}
+	}
+
+	if last != FlapTransitionStarted || !stats.IsFlapping() {
+		t.Error("Expected statistics to report flapping after alternating sessions")
+	}
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+)
+
+// HostingRefreshInterval - Cada cuánto se vuelve a resolver DNS/proveedor de un target
+const HostingRefreshInterval = 24 * time.Hour
+
+// ProviderRecord - Resultado de buscar una IP en la base offline de proveedores/ASN
+type ProviderRecord struct {
+	ASN      int
+	Provider string
+}
+
+// ProviderDatabase - Base offline IP -> proveedor/ASN (sin WHOIS en línea)
+type ProviderDatabase interface {
+	Lookup(ip string) (ProviderRecord, bool)
+}
+
+// Value Object: HostingInfo
+// Dónde está alojado un target: sus IPs resueltas y el proveedor/ASN de la primera IP conocida.
+type HostingInfo struct {
+	ips        []string
+	provider   string
+	asn        int
+	resolvedAt time.Time
+}
+
+func NewHostingInfo(ips []string, provider string, asn int, resolvedAt time.Time) HostingInfo {
+	copied := make([]string, len(ips))
+	copy(copied, ips)
+	return HostingInfo{ips: copied, provider: provider, asn: asn, resolvedAt: resolvedAt}
+}
+
+// Getters
+func (h HostingInfo) IPs() []string {
+	return h.ips
+}
+
+func (h HostingInfo) Provider() string {
+	return h.provider
+}
+
+func (h HostingInfo) ASN() int {
+	return h.asn
+}
+
+func (h HostingInfo) ResolvedAt() time.Time {
+	return h.resolvedAt
+}
+
+func (h HostingInfo) IsResolved() bool {
+	return !h.resolvedAt.IsZero()
+}
+
+// NeedsRefresh indica si nunca se resolvió o si la resolución ya es vieja
+func (h HostingInfo) NeedsRefresh(now time.Time) bool {
+	return !h.IsResolved() || now.Sub(h.resolvedAt) >= HostingRefreshInterval
+}
+
+// Causes devuelve las posibles causas comunes, de la más específica (IP) a la más general (proveedor)
+func (h HostingInfo) Causes() []InfrastructureCause {
+	causes := make([]InfrastructureCause, 0, len(h.ips)+2)
+	for _, ip := range h.ips {
+		causes = append(causes, InfrastructureCause{Kind: CauseKindIP, Value: ip})
+	}
+	if h.asn > 0 {
+		causes = append(causes, InfrastructureCause{Kind: CauseKindASN, Value: strconv.Itoa(h.asn)})
+	}
+	if h.provider != "" {
+		causes = append(causes, InfrastructureCause{Kind: CauseKindProvider, Value: h.provider})
+	}
+	return causes
+}
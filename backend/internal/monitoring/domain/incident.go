@@ -0,0 +1,347 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	userdomain "uptrackai/internal/user/domain"
+)
+
+// Value Object: IncidentId
+type IncidentId string
+
+func NewIncidentId(value string) (IncidentId, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", ErrIncidentIdEmpty
+	}
+	return IncidentId(value), nil
+}
+
+func (i IncidentId) String() string {
+	return string(i)
+}
+
+// Enum: IncidentStatus
+type IncidentStatus string
+
+const (
+	IncidentStatusOpen         IncidentStatus = "OPEN"
+	IncidentStatusAcknowledged IncidentStatus = "ACKNOWLEDGED"
+	IncidentStatusResolved     IncidentStatus = "RESOLVED"
+)
+
+func NewIncidentStatus(value string) (IncidentStatus, error) {
+	status := IncidentStatus(strings.ToUpper(strings.TrimSpace(value)))
+	switch status {
+	case IncidentStatusOpen, IncidentStatusAcknowledged, IncidentStatusResolved:
+		return status, nil
+	}
+	return "", ErrInvalidIncidentStatus
+}
+
+func (s IncidentStatus) String() string {
+	return string(s)
+}
+
+// Enum: IncidentEventType
+type IncidentEventType string
+
+const (
+	IncidentEventOpened        IncidentEventType = "OPENED"
+	IncidentEventStatusChanged IncidentEventType = "STATUS_CHANGED"
+	IncidentEventAcknowledged  IncidentEventType = "ACKNOWLEDGED"
+	IncidentEventCommented     IncidentEventType = "COMMENTED"
+	IncidentEventResolved      IncidentEventType = "RESOLVED"
+)
+
+// Value Object: IncidentEvent
+// Una entrada de la línea de tiempo del incidente. Sin autor = la generó el scheduler.
+type IncidentEvent struct {
+	eventType IncidentEventType
+	at        time.Time
+	status    TargetStatus // Estado del target en ese momento
+	author    userdomain.UserId
+	note      string
+}
+
+func NewIncidentEvent(eventType IncidentEventType, at time.Time, status TargetStatus, author userdomain.UserId, note string) IncidentEvent {
+	return IncidentEvent{eventType: eventType, at: at, status: status, author: author, note: note}
+}
+
+// Getters
+func (e IncidentEvent) Type() IncidentEventType {
+	return e.eventType
+}
+
+func (e IncidentEvent) At() time.Time {
+	return e.at
+}
+
+func (e IncidentEvent) Status() TargetStatus {
+	return e.status
+}
+
+func (e IncidentEvent) Author() userdomain.UserId {
+	return e.author
+}
+
+func (e IncidentEvent) Note() string {
+	return e.note
+}
+
+// IncidentSaveAttempts - Veces que se recarga y reaplica un cambio cuando otro proceso guardó
+// el mismo incidente en el medio (el scheduler y la API lo modifican a la vez)
+const IncidentSaveAttempts = 3
+
+// Aggregate: Incident
+// Una caída que alguien atiende: se abre al pasar a severidad CRITICAL, acumula las
+// transiciones siguientes y se cierra sola cuando el target vuelve a UP (o a mano).
+type Incident struct {
+	id             IncidentId
+	targetId       TargetId
+	userId         userdomain.UserId // Dueño del target
+	status         IncidentStatus
+	triggerStatus  TargetStatus // Estado que lo abrió
+	lastStatus     TargetStatus // Último estado conocido del target
+	openedAt       time.Time
+	acknowledgedAt time.Time
+	acknowledgedBy userdomain.UserId
+	resolvedAt     time.Time
+	resolvedBy     userdomain.UserId // Vacío = auto-resuelto por recuperación
+	events         []IncidentEvent
+	version        int // Versión persistida (0 = nunca guardado): control de concurrencia optimista
+}
+
+// OpenIncident abre un incidente por la transición del target a `status`
+func OpenIncident(targetId TargetId, userId userdomain.UserId, status TargetStatus, at time.Time) *Incident {
+	return &Incident{
+		targetId:      targetId,
+		userId:        userId,
+		status:        IncidentStatusOpen,
+		triggerStatus: status,
+		lastStatus:    status,
+		openedAt:      at,
+		events:        []IncidentEvent{NewIncidentEvent(IncidentEventOpened, at, status, "", "")},
+	}
+}
+
+func NewFullIncident(id IncidentId, targetId TargetId, userId userdomain.UserId, status IncidentStatus,
+	triggerStatus TargetStatus, lastStatus TargetStatus, openedAt time.Time,
+	acknowledgedAt time.Time, acknowledgedBy userdomain.UserId,
+	resolvedAt time.Time, resolvedBy userdomain.UserId, events []IncidentEvent, version int) *Incident {
+	return &Incident{
+		id:             id,
+		targetId:       targetId,
+		userId:         userId,
+		status:         status,
+		triggerStatus:  triggerStatus,
+		lastStatus:     lastStatus,
+		openedAt:       openedAt,
+		acknowledgedAt: acknowledgedAt,
+		acknowledgedBy: acknowledgedBy,
+		resolvedAt:     resolvedAt,
+		resolvedBy:     resolvedBy,
+		events:         events,
+		version:        version,
+	}
+}
+
+// Getters
+func (i *Incident) ID() IncidentId {
+	return i.id
+}
+
+func (i *Incident) TargetId() TargetId {
+	return i.targetId
+}
+
+func (i *Incident) UserId() userdomain.UserId {
+	return i.userId
+}
+
+func (i *Incident) Status() IncidentStatus {
+	return i.status
+}
+
+func (i *Incident) TriggerStatus() TargetStatus {
+	return i.triggerStatus
+}
+
+func (i *Incident) LastStatus() TargetStatus {
+	return i.lastStatus
+}
+
+func (i *Incident) OpenedAt() time.Time {
+	return i.openedAt
+}
+
+func (i *Incident) AcknowledgedAt() time.Time {
+	return i.acknowledgedAt
+}
+
+func (i *Incident) AcknowledgedBy() userdomain.UserId {
+	return i.acknowledgedBy
+}
+
+func (i *Incident) ResolvedAt() time.Time {
+	return i.resolvedAt
+}
+
+func (i *Incident) ResolvedBy() userdomain.UserId {
+	return i.resolvedBy
+}
+
+func (i *Incident) Events() []IncidentEvent {
+	return i.events
+}
+
+func (i *Incident) Version() int {
+	return i.version
+}
+
+func (i *Incident) IsResolved() bool {
+	return i.status == IncidentStatusResolved
+}
+
+func (i *Incident) IsAcknowledged() bool {
+	return !i.acknowledgedAt.IsZero()
+}
+
+// AssignId lo usa el repositorio al persistir un incidente nuevo
+func (i *Incident) AssignId(id IncidentId) error {
+	if i.id != "" {
+		return ErrIncidentAlreadyHasId
+	}
+	i.id = id
+	return nil
+}
+
+// AssignVersion lo usa el repositorio tras guardar: la próxima escritura parte de esta versión
+func (i *Incident) AssignVersion(version int) {
+	i.version = version
+}
+
+// RecordTransition agrega un cambio de estado del target mientras el incidente sigue abierto
+func (i *Incident) RecordTransition(status TargetStatus, at time.Time) error {
+	if i.IsResolved() {
+		return ErrIncidentResolved
+	}
+	i.lastStatus = status
+	i.events = append(i.events, NewIncidentEvent(IncidentEventStatusChanged, at, status, "", ""))
+	return nil
+}
+
+// Acknowledge registra quién se hizo cargo (solo la primera vez cuenta para el MTTA)
+func (i *Incident) Acknowledge(by userdomain.UserId, note string, at time.Time) error {
+	if i.IsResolved() {
+		return ErrIncidentResolved
+	}
+	if i.IsAcknowledged() {
+		return ErrIncidentAlreadyAcknowledged
+	}
+	i.status = IncidentStatusAcknowledged
+	i.acknowledgedAt = at
+	i.acknowledgedBy = by
+	i.events = append(i.events, NewIncidentEvent(IncidentEventAcknowledged, at, i.lastStatus, by, strings.TrimSpace(note)))
+	return nil
+}
+
+// Comment agrega una nota; se permite también en incidentes resueltos (post-mortem)
+func (i *Incident) Comment(by userdomain.UserId, note string, at time.Time) error {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return ErrIncidentNoteEmpty
+	}
+	i.events = append(i.events, NewIncidentEvent(IncidentEventCommented, at, i.lastStatus, by, note))
+	return nil
+}
+
+// Resolve cierra el incidente a mano
+func (i *Incident) Resolve(by userdomain.UserId, note string, at time.Time) error {
+	if i.IsResolved() {
+		return ErrIncidentResolved
+	}
+	i.status = IncidentStatusResolved
+	i.resolvedAt = at
+	i.resolvedBy = by
+	i.events = append(i.events, NewIncidentEvent(IncidentEventResolved, at, i.lastStatus, by, strings.TrimSpace(note)))
+	return nil
+}
+
+// ResolveOnRecovery cierra el incidente porque el target volvió a `status` (UP)
+func (i *Incident) ResolveOnRecovery(status TargetStatus, at time.Time) error {
+	if err := i.RecordTransition(status, at); err != nil {
+		return err
+	}
+	return i.Resolve("", "", at)
+}
+
+// Duration - Cuánto duró (o lleva abierto) el incidente
+func (i *Incident) Duration(now time.Time) time.Duration {
+	if i.IsResolved() {
+		return i.resolvedAt.Sub(i.openedAt)
+	}
+	return now.Sub(i.openedAt)
+}
+
+// TimeToAcknowledge - Tiempo hasta que alguien se hizo cargo (false si nadie lo hizo)
+func (i *Incident) TimeToAcknowledge() (time.Duration, bool) {
+	if !i.IsAcknowledged() {
+		return 0, false
+	}
+	return i.acknowledgedAt.Sub(i.openedAt), true
+}
+
+// TimeToResolve - Tiempo hasta la resolución (false si sigue abierto)
+func (i *Incident) TimeToResolve() (time.Duration, bool) {
+	if !i.IsResolved() {
+		return 0, false
+	}
+	return i.resolvedAt.Sub(i.openedAt), true
+}
+
+// IncidentFilter - Criterios para listar incidentes (campos vacíos = sin filtro)
+type IncidentFilter struct {
+	UserId   userdomain.UserId
+	TargetId TargetId
+	Status   IncidentStatus
+	Since    time.Time // openedAt >= Since
+	Until    time.Time // openedAt < Until
+	Limit    int
+}
+
+// IncidentMetrics - MTTA/MTTR sobre un conjunto de incidentes
+type IncidentMetrics struct {
+	Total        int
+	Open         int // OPEN o ACKNOWLEDGED
+	Acknowledged int // Incidentes con ack (base del MTTA)
+	Resolved     int // Base del MTTR
+	MTTA         time.Duration
+	MTTR         time.Duration
+}
+
+func SummarizeIncidents(incidents []*Incident) IncidentMetrics {
+	metrics := IncidentMetrics{Total: len(incidents)}
+	var ackTotal, resolveTotal time.Duration
+
+	for _, incident := range incidents {
+		if !incident.IsResolved() {
+			metrics.Open++
+		}
+		if tta, ok := incident.TimeToAcknowledge(); ok {
+			metrics.Acknowledged++
+			ackTotal += tta
+		}
+		if ttr, ok := incident.TimeToResolve(); ok {
+			metrics.Resolved++
+			resolveTotal += ttr
+		}
+	}
+
+	if metrics.Acknowledged > 0 {
+		metrics.MTTA = ackTotal / time.Duration(metrics.Acknowledged)
+	}
+	if metrics.Resolved > 0 {
+		metrics.MTTR = resolveTotal / time.Duration(metrics.Resolved)
+	}
+	return metrics
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIncident_AutoResolvesOnRecovery(t *testing.T) {
+	openedAt := time.Now()
+	incident := OpenIncident("target-1", "user-1", TargetStatusDown, openedAt)
+
+	if incident.Status() != IncidentStatusOpen || incident.TriggerStatus() != TargetStatusDown {
+		t.Fatalf("Expected OPEN incident triggered by DOWN, got %s/%s", incident.Status(), incident.TriggerStatus())
+	}
+
+	if err := incident.RecordTransition(TargetStatusDegraded, openedAt.Add(2*time.Minute)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if err := incident.ResolveOnRecovery(TargetStatusUp, openedAt.Add(5*time.Minute)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !incident.IsResolved() || incident.ResolvedBy() != "" {
+		t.Errorf("Expected auto-resolved incident, got %s by %q", incident.Status(), incident.ResolvedBy())
+	}
+	if ttr, ok := incident.TimeToResolve(); !ok || ttr != 5*time.Minute {
+		t.Errorf("Expected TTR 5m, got %v (%v)", ttr, ok)
+	}
+	if _, ok := incident.TimeToAcknowledge(); ok {
+		t.Error("Expected no TTA without acknowledge")
+	}
+
+	// OPENED, DEGRADED, UP, RESOLVED
+	if len(incident.Events()) != 4 {
+		t.Errorf("Expected 4 timeline events, got %d", len(incident.Events()))
+	}
+	if err := incident.RecordTransition(TargetStatusDown, openedAt.Add(6*time.Minute)); err != ErrIncidentResolved {
+		t.Errorf("Expected ErrIncidentResolved, got %v", err)
+	}
+}
+
+func TestIncident_AcknowledgeAndComment(t *testing.T) {
+	openedAt := time.Now()
+	incident := OpenIncident("target-1", "user-1", TargetStatusDown, openedAt)
+
+	if err := incident.Acknowledge("user-1", " checking ", openedAt.Add(90*time.Second)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if incident.Status() != IncidentStatusAcknowledged || incident.AcknowledgedBy() != "user-1" {
+		t.Errorf("Expected ACKNOWLEDGED by user-1, got %s by %s", incident.Status(), incident.AcknowledgedBy())
+	}
+	if tta, _ := incident.TimeToAcknowledge(); tta != 90*time.Second {
+		t.Errorf("Expected TTA 90s, got %v", tta)
+	}
+	if err := incident.Acknowledge("user-2", "", openedAt.Add(time.Hour)); err != ErrIncidentAlreadyAcknowledged {
+		t.Errorf("Expected ErrIncidentAlreadyAcknowledged, got %v", err)
+	}
+
+	if err := incident.Comment("user-1", "   ", openedAt); err != ErrIncidentNoteEmpty {
+		t.Errorf("Expected ErrIncidentNoteEmpty, got %v", err)
+	}
+
+	if err := incident.Resolve("user-1", "fixed", openedAt.Add(10*time.Minute)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Post-mortem: se puede comentar un incidente cerrado
+	if err := incident.Comment("user-1", "root cause: expired cert", openedAt.Add(time.Hour)); err != nil {
+		t.Errorf("Expected comment on resolved incident, got %v", err)
+	}
+
+	last := incident.Events()[len(incident.Events())-1]
+	if last.Type() != IncidentEventCommented || last.Note() != "root cause: expired cert" {
+		t.Errorf("Unexpected last event: %s %q", last.Type(), last.Note())
+	}
+}
+
+func TestSummarizeIncidents(t *testing.T) {
+	base := time.Now()
+
+	acked := OpenIncident("t1", "u1", TargetStatusDown, base)
+	_ = acked.Acknowledge("u1", "", base.Add(2*time.Minute))
+	_ = acked.Resolve("u1", "", base.Add(10*time.Minute))
+
+	auto := OpenIncident("t2", "u1", TargetStatusDown, base)
+	_ = auto.ResolveOnRecovery(TargetStatusUp, base.Add(20*time.Minute))
+
+	open := OpenIncident("t3", "u1", TargetStatusDown, base)
+	_ = open.Acknowledge("u1", "", base.Add(4*time.Minute))
+
+	metrics := SummarizeIncidents([]*Incident{acked, auto, open})
+
+	if metrics.Total != 3 || metrics.Open != 1 {
+		t.Errorf("Expected 3 total / 1 open, got %d/%d", metrics.Total, metrics.Open)
+	}
+	if metrics.MTTA != 3*time.Minute {
+		t.Errorf("Expected MTTA 3m, got %v", metrics.MTTA)
+	}
+	if metrics.MTTR != 15*time.Minute {
+		t.Errorf("Expected MTTR 15m, got %v", metrics.MTTR)
+	}
+}
+
+func TestNewIncidentStatus(t *testing.T) {
+	if status, err := NewIncidentStatus("acknowledged"); err != nil || status != IncidentStatusAcknowledged {
+		t.Errorf("Expected ACKNOWLEDGED, got %s (%v)", status, err)
+	}
+	if _, err := NewIncidentStatus("CLOSED"); err != ErrInvalidIncidentStatus {
+		t.Errorf("Expected ErrInvalidIncidentStatus, got %v", err)
+	}
+}
@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// TimelineLookback - Cuánto antes de la apertura se buscan los check_results que la confirmaron
+const TimelineLookback = 15 * time.Minute
+
+// Enum: TimelineEntryKind
+type TimelineEntryKind string
+
+const (
+	TimelineFirstFailure   TimelineEntryKind = "FIRST_FAILURE" // Primer ping fallido (solo con pings crudos)
+	TimelineConfirmed      TimelineEntryKind = "CONFIRMED"     // Sesión que confirmó el cambio de estado
+	TimelineIncidentOpened TimelineEntryKind = "INCIDENT_OPENED"
+	TimelineStatusChange   TimelineEntryKind = "STATUS_CHANGE"
+	TimelineNotification   TimelineEntryKind = "NOTIFICATION"
+	TimelineAcknowledged   TimelineEntryKind = "ACKNOWLEDGED"
+	TimelineComment        TimelineEntryKind = "COMMENT"
+	TimelineRecovered      TimelineEntryKind = "RECOVERED"
+	TimelineResolved       TimelineEntryKind = "RESOLVED"
+)
+
+// IncidentNotificationRecord - Una alerta del historial de notificaciones ligada al incidente
+type IncidentNotificationRecord struct {
+	At       time.Time
+	Title    string
+	Severity string
+	Channels []string // Vacío = solo quedó en el historial (GUI)
+}
+
+// TimelineEntry - Un hecho de la línea de tiempo
+type TimelineEntry struct {
+	At       time.Time
+	Kind     TimelineEntryKind
+	Status   TargetStatus
+	Summary  string
+	Actor    string // Usuario (ack, comentarios, cierre manual); vacío = sistema
+	Channels []string
+}
+
+// IncidentTimeline - Línea de tiempo ordenada de un incidente, lista para un post-mortem
+type IncidentTimeline struct {
+	incident *Incident
+	entries  []TimelineEntry
+}
+
+func (t *IncidentTimeline) Incident() *Incident {
+	return t.incident
+}
+
+func (t *IncidentTimeline) Entries() []TimelineEntry {
+	return t.entries
+}
+
+// FindConfirmingCheck devuelve el cambio de estado que abrió el incidente: el último
+// check_result no-UP registrado hasta la apertura (nil si ya no existe)
+func FindConfirmingCheck(incident *Incident, results []*CheckResult) *CheckResult {
+	var confirming *CheckResult
+	for _, result := range results {
+		if result.Timestamp().After(incident.OpenedAt()) || result.Status() == TargetStatusUp {
+			continue
+		}
+		if confirming == nil || result.Timestamp().After(confirming.Timestamp()) {
+			confirming = result
+		}
+	}
+	return confirming
+}
+
+// BuildIncidentTimeline junta check_results, el primer ping fallido (si se guardó),
+// el historial de notificaciones y el registro del incidente en una sola línea de tiempo.
+// Los cambios de estado salen de check_results (traen latencia, clase y motivo); si ya
+// no están, se usan las transiciones que guardó el propio incidente.
+func BuildIncidentTimeline(incident *Incident, results []*CheckResult, firstFailure *PingSample, notifications []IncidentNotificationRecord) *IncidentTimeline {
+	entries := make([]TimelineEntry, 0, len(results)+len(incident.Events())+len(notifications)+1)
+
+	if firstFailure != nil {
+		summary := fmt.Sprintf("Ping #%d failed", firstFailure.Sequence())
+		if firstFailure.ErrorClass() != ErrorClassNone {
+			summary = fmt.Sprintf("%s (%s)", summary, firstFailure.ErrorClass())
+		}
+		if firstFailure.ErrorMessage() != "" {
+			summary = fmt.Sprintf("%s: %s", summary, firstFailure.ErrorMessage())
+		}
+		entries = append(entries, TimelineEntry{
+			At:      firstFailure.Timestamp(),
+			Kind:    TimelineFirstFailure,
+			Status:  firstFailure.Status(),
+			Summary: summary,
+		})
+	}
+
+	confirming := FindConfirmingCheck(incident, results)
+	if confirming != nil {
+		entries = append(entries, TimelineEntry{
+			At:      confirming.Timestamp(),
+			Kind:    TimelineConfirmed,
+			Status:  confirming.Status(),
+			Summary: "Confirmed " + describeCheckResult(confirming),
+		})
+	}
+
+	// Cambios de estado posteriores a la confirmación
+	fromChecks := 0
+	for _, result := range results {
+		if confirming != nil && !result.Timestamp().After(confirming.Timestamp()) {
+			continue
+		}
+		if confirming == nil && result.Timestamp().Before(incident.OpenedAt()) {
+			continue
+		}
+		if incident.IsResolved() && result.Timestamp().After(incident.ResolvedAt()) {
+			continue
+		}
+		entries = append(entries, statusChangeEntry(result.Timestamp(), result.Status(), describeCheckResult(result)))
+		fromChecks++
+	}
+
+	for _, event := range incident.Events() {
+		switch event.Type() {
+		case IncidentEventOpened:
+			entries = append(entries, TimelineEntry{
+				At:      event.At(),
+				Kind:    TimelineIncidentOpened,
+				Status:  event.Status(),
+				Summary: "Incident opened",
+			})
+		case IncidentEventStatusChanged:
+			if fromChecks == 0 {
+				entries = append(entries, statusChangeEntry(event.At(), event.Status(), event.Status().String()))
+			}
+		case IncidentEventAcknowledged:
+			entries = append(entries, userEntry(event, TimelineAcknowledged, "Acknowledged"))
+		case IncidentEventCommented:
+			entries = append(entries, userEntry(event, TimelineComment, "Comment"))
+		case IncidentEventResolved:
+			summary := "Auto-resolved: target recovered"
+			if event.Author() != "" {
+				summary = "Closed manually"
+			}
+			entries = append(entries, userEntry(event, TimelineResolved, summary))
+		}
+	}
+
+	for _, notification := range notifications {
+		summary := fmt.Sprintf("%s [%s]", notification.Title, notification.Severity)
+		entries = append(entries, TimelineEntry{
+			At:       notification.At,
+			Kind:     TimelineNotification,
+			Summary:  summary,
+			Channels: notification.Channels,
+		})
+	}
+
+	// Orden estable: a igual hora se conserva el orden lógico de arriba
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].At.Before(entries[j].At)
+	})
+
+	return &IncidentTimeline{incident: incident, entries: entries}
+}
+
+func statusChangeEntry(at time.Time, status TargetStatus, summary string) TimelineEntry {
+	kind := TimelineStatusChange
+	if status == TargetStatusUp {
+		kind = TimelineRecovered
+	}
+	return TimelineEntry{At: at, Kind: kind, Status: status, Summary: summary}
+}
+
+func userEntry(event IncidentEvent, kind TimelineEntryKind, summary string) TimelineEntry {
+	if event.Note() != "" {
+		summary = fmt.Sprintf("%s: %s", summary, event.Note())
+	}
+	return TimelineEntry{
+		At:      event.At(),
+		Kind:    kind,
+		Status:  event.Status(),
+		Summary: summary,
+		Actor:   event.Author().String(),
+	}
+}
+
+// describeCheckResult resume un cambio de estado: "DOWN (connect_timeout): dial tcp ... — 10000ms"
+func describeCheckResult(result *CheckResult) string {
+	summary := result.Status().String()
+	if result.ErrorClass() != ErrorClassNone {
+		summary = fmt.Sprintf("%s (%s)", summary, result.ErrorClass())
+	}
+	if result.DegradationReason() != nil {
+		summary = fmt.Sprintf("%s: %s", summary, result.DegradationReason())
+	} else if result.ErrorMessage() != "" {
+		summary = fmt.Sprintf("%s: %s", summary, result.ErrorMessage())
+	}
+	return fmt.Sprintf("%s — %dms", summary, result.ResponseTimeMs())
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildIncidentTimeline(t *testing.T) {
+	base := time.Date(2026, 3, 2, 14, 0, 0, 0, time.UTC)
+
+	confirmed := NewFullCheckResult("cr-1", "target-1", base, 10000, false, TargetStatusDown, "dial tcp: i/o timeout")
+	confirmed.Classify(ErrorClassConnectTimeout)
+	degraded := NewFullCheckResult("cr-2", "target-1", base.Add(4*time.Minute), 900, true, TargetStatusDegraded, "")
+	recovered := NewFullCheckResult("cr-3", "target-1", base.Add(9*time.Minute), 120, true, TargetStatusUp, "")
+	earlier := NewFullCheckResult("cr-0", "target-1", base.Add(-10*time.Minute), 100, true, TargetStatusUp, "")
+
+	incident := OpenIncident("target-1", "user-1", TargetStatusDown, base.Add(time.Second))
+	_ = incident.RecordTransition(TargetStatusDegraded, base.Add(4*time.Minute))
+	_ = incident.Acknowledge("user-1", "looking", base.Add(2*time.Minute))
+	_ = incident.ResolveOnRecovery(TargetStatusUp, base.Add(9*time.Minute+time.Second))
+
+	firstPing := NewFullPingSample("session-1", "target-1", 1, base.Add(-20*time.Second), 10000, TargetStatusDown, "dial tcp: i/o timeout", ErrorClassConnectTimeout)
+	notifications := []IncidentNotificationRecord{
+		{At: base.Add(2 * time.Second), Title: "Status Change: API", Severity: "CRITICAL", Channels: []string{"TELEGRAM"}},
+	}
+
+	timeline := BuildIncidentTimeline(incident, []*CheckResult{earlier, confirmed, degraded, recovered}, firstPing, notifications)
+
+	expected := []TimelineEntryKind{
+		TimelineFirstFailure,
+		TimelineConfirmed,
+		TimelineIncidentOpened,
+		TimelineNotification,
+		TimelineAcknowledged,
+		TimelineStatusChange, // De check_results: la transición del incidente no se duplica
+		TimelineRecovered,
+		TimelineResolved,
+	}
+	entries := timeline.Entries()
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d entries, got %d: %+v", len(expected), len(entries), entries)
+	}
+	for i, kind := range expected {
+		if entries[i].Kind != kind {
+			t.Errorf("Entry %d: expected %s, got %s", i, kind, entries[i].Kind)
+		}
+	}
+
+	if entries[1].Summary != "Confirmed DOWN (connect_timeout): dial tcp: i/o timeout — 10000ms" {
+		t.Errorf("Unexpected confirmation summary: %q", entries[1].Summary)
+	}
+	if entries[4].Actor != "user-1" || entries[4].Summary != "Acknowledged: looking" {
+		t.Errorf("Unexpected acknowledge entry: %+v", entries[4])
+	}
+	if entries[7].Summary != "Auto-resolved: target recovered" {
+		t.Errorf("Unexpected resolution summary: %q", entries[7].Summary)
+	}
+}
+
+func TestBuildIncidentTimeline_WithoutCheckResults(t *testing.T) {
+	base := time.Now()
+	incident := OpenIncident("target-1", "user-1", TargetStatusDown, base)
+	_ = incident.RecordTransition(TargetStatusDegraded, base.Add(time.Minute))
+
+	// check_results purgados: las transiciones salen del propio incidente
+	timeline := BuildIncidentTimeline(incident, nil, nil, nil)
+
+	entries := timeline.Entries()
+	if len(entries) != 2 || entries[1].Kind != TimelineStatusChange || entries[1].Status != TargetStatusDegraded {
+		t.Errorf("Expected opened + status change from the incident record, got %+v", entries)
+	}
+}
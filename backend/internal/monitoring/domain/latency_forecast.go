@@ -0,0 +1,109 @@
+package domain
+
+import "time"
+
+const (
+	// ForecastLookbackDays - Días de p95 diario sobre los que se ajusta la tendencia
+	ForecastLookbackDays = 28
+	// MinForecastDays - Con menos días la recta es ruido
+	MinForecastDays = 7
+	// DefaultForecastHorizonDays - Se avisa si el cruce se proyecta dentro de este plazo
+	DefaultForecastHorizonDays = 14
+)
+
+// LatencyForecast - Recta ajustada por mínimos cuadrados sobre el p95 diario de un target
+type LatencyForecast struct {
+	Samples       int     // Días con latencia usados en el ajuste
+	SlopeMsPerDay float64 // Positivo = la latencia crece
+	CurrentMs     float64 // Valor de la recta hoy
+	ThresholdMs   int
+	HorizonDays   int
+	// DaysToThreshold es cuánto falta para que la recta cruce el umbral (0 si ya lo cruzó).
+	// nil si no crece o el umbral no se alcanza nunca.
+	DaysToThreshold *float64
+}
+
+// WillCrossWithinHorizon indica si hay que avisar: el cruce se proyecta dentro del horizonte
+func (f LatencyForecast) WillCrossWithinHorizon() bool {
+	return f.DaysToThreshold != nil && *f.DaysToThreshold <= float64(f.HorizonDays)
+}
+
+// ForecastLatency ajusta una regresión lineal sobre los buckets diarios (ordenados) y proyecta
+// cuándo el p95 cruzaría el umbral. Solo cuentan los días con sesiones correctas.
+// false si no hay suficientes días para ajustar.
+func ForecastLatency(daily []MetricRollup, thresholdMs int, horizonDays int, now time.Time) (LatencyForecast, bool) {
+	xs := make([]float64, 0, len(daily))
+	ys := make([]float64, 0, len(daily))
+	for _, bucket := range daily {
+		if bucket.Count-bucket.FailureCount <= 0 || bucket.P95ResponseTimeMs <= 0 {
+			continue
+		}
+		xs = append(xs, bucket.BucketStart.Sub(now).Hours()/24)
+		ys = append(ys, float64(bucket.P95ResponseTimeMs))
+	}
+	if len(xs) < MinForecastDays {
+		return LatencyForecast{}, false
+	}
+
+	slope, intercept := linearRegression(xs, ys)
+	forecast := LatencyForecast{
+		Samples:       len(xs),
+		SlopeMsPerDay: slope,
+		CurrentMs:     intercept, // x = 0 es `now`
+		ThresholdMs:   thresholdMs,
+		HorizonDays:   horizonDays,
+	}
+	if thresholdMs <= 0 {
+		return forecast, true
+	}
+
+	switch {
+	case forecast.CurrentMs >= float64(thresholdMs):
+		days := 0.0
+		forecast.DaysToThreshold = &days
+	case slope > 0:
+		days := (float64(thresholdMs) - forecast.CurrentMs) / slope
+		forecast.DaysToThreshold = &days
+	}
+	return forecast, true
+}
+
+// ForecastRange es el tramo de días completos (más el de hoy) sobre el que se ajusta la tendencia
+func ForecastRange(now time.Time) TimeRange {
+	start := MetricResolutionDay.BucketStart(now.AddDate(0, 0, -ForecastLookbackDays))
+	return TimeRange{Start: start, End: now}
+}
+
+// ForecastTargetLatency proyecta el p95 diario contra el umbral de degradación vigente del target
+// (stats puede ser nil: sin línea base solo aplica la regla absoluta)
+func ForecastTargetLatency(target *MonitoringTarget, stats *TargetStatistics, daily []MetricRollup, horizonDays int, now time.Time) (LatencyForecast, bool) {
+	thresholds := target.Configuration().Thresholds()
+	baseline := 0
+	if stats != nil {
+		baseline = stats.BaselineResponseTimeMs(thresholds.BaselineMetric())
+	}
+	threshold, _ := thresholds.DegradationThresholdMs(baseline)
+	return ForecastLatency(daily, threshold, horizonDays, now)
+}
+
+// linearRegression devuelve pendiente y ordenada al origen por mínimos cuadrados
+func linearRegression(xs []float64, ys []float64) (float64, float64) {
+	n := float64(len(xs))
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/n, sumY/n
+
+	var covariance, variance float64
+	for i := range xs {
+		covariance += (xs[i] - meanX) * (ys[i] - meanY)
+		variance += (xs[i] - meanX) * (xs[i] - meanX)
+	}
+	if variance == 0 {
+		return 0, meanY
+	}
+	slope := covariance / variance
+	return slope, meanY - slope*meanX
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// dailyRollups arma un bucket 1d por día hasta hoy con p95 = start + slope*i
+func dailyRollups(now time.Time, days int, startMs int, slopeMs int) []MetricRollup {
+	today := MetricResolutionDay.BucketStart(now)
+	rollups := make([]MetricRollup, 0, days)
+	for i := 0; i < days; i++ {
+		rollups = append(rollups, MetricRollup{
+			Resolution:        MetricResolutionDay,
+			BucketStart:       today.AddDate(0, 0, i-days+1),
+			Count:             1440,
+			P95ResponseTimeMs: startMs + slopeMs*i,
+		})
+	}
+	return rollups
+}
+
+func TestForecastLatency_ProjectsCrossing(t *testing.T) {
+	now := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	// 14 días subiendo 10ms/día hasta 330ms hoy
+	daily := dailyRollups(now, 14, 200, 10)
+
+	forecast, ok := ForecastLatency(daily, 400, 14, now)
+
+	if !ok || forecast.Samples != 14 {
+		t.Fatalf("Expected a forecast over 14 days, got %+v (%v)", forecast, ok)
+	}
+	if math.Abs(forecast.SlopeMsPerDay-10) > 1e-9 || math.Abs(forecast.CurrentMs-330) > 1e-9 {
+		t.Errorf("Expected slope 10 and current 330, got %+v", forecast)
+	}
+	if forecast.DaysToThreshold == nil || math.Abs(*forecast.DaysToThreshold-7) > 1e-9 {
+		t.Fatalf("Expected the threshold crossed in 7 days, got %v", forecast.DaysToThreshold)
+	}
+	if !forecast.WillCrossWithinHorizon() {
+		t.Error("Expected the crossing to fall within the horizon")
+	}
+
+	forecast.HorizonDays = 5
+	if forecast.WillCrossWithinHorizon() {
+		t.Error("Expected no alert when the crossing is beyond the horizon")
+	}
+}
+
+func TestForecastLatency_FlatOrAlreadyOver(t *testing.T) {
+	now := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	flat, ok := ForecastLatency(dailyRollups(now, 10, 300, 0), 400, 14, now)
+	if !ok || flat.DaysToThreshold != nil || flat.WillCrossWithinHorizon() {
+		t.Errorf("Expected a flat trend never to cross, got %+v", flat)
+	}
+
+	over, ok := ForecastLatency(dailyRollups(now, 10, 500, -5), 400, 14, now)
+	if !ok || over.DaysToThreshold == nil || *over.DaysToThreshold != 0 || !over.WillCrossWithinHorizon() {
+		t.Errorf("Expected a trend already above the threshold to be at risk now, got %+v", over)
+	}
+}
+
+func TestForecastLatency_NotEnoughDays(t *testing.T) {
+	now := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	daily := dailyRollups(now, 9, 200, 10)
+	// Días sin sesiones correctas no cuentan
+	for i := 0; i < 3; i++ {
+		daily[i].FailureCount = daily[i].Count
+	}
+
+	if _, ok := ForecastLatency(daily, 400, 14, now); ok {
+		t.Error("Expected no forecast with fewer than 7 usable days")
+	}
+}
+
+func TestAnalyzerThresholds_DegradationThresholdMs(t *testing.T) {
+	relative, _ := NewAnalyzerThresholds(3, 200, 0, 5, 9)
+	if threshold, ok := relative.DegradationThresholdMs(150); !ok || threshold != 450 {
+		t.Errorf("Expected 3x baseline = 450, got %d (%v)", threshold, ok)
+	}
+	if threshold, _ := relative.DegradationThresholdMs(50); threshold != 200 {
+		t.Errorf("Expected the latency floor 200, got %d", threshold)
+	}
+	if _, ok := relative.DegradationThresholdMs(0); ok {
+		t.Error("Expected no threshold without baseline nor absolute rule")
+	}
+
+	both, _ := NewAnalyzerThresholds(3, 200, 400, 5, 9)
+	if threshold, _ := both.DegradationThresholdMs(150); threshold != 400 {
+		t.Errorf("Expected the lower absolute rule 400, got %d", threshold)
+	}
+	if threshold, ok := both.DegradationThresholdMs(0); !ok || threshold != 400 {
+		t.Errorf("Expected the absolute rule without baseline, got %d (%v)", threshold, ok)
+	}
+}
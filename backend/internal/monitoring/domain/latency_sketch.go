@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	LatencySketchWindowDays = 7    // Misma ventana que el promedio histórico
+	latencySketchGamma      = 1.02 // Error relativo ~1% por bucket (estilo DDSketch)
+	secondsPerDay           = 86400
+)
+
+// LatencySketchDay es el histograma de un día UTC.
+// Exportado solo para que infraestructura pueda serializarlo; el dominio opera con LatencySketch.
+type LatencySketchDay struct {
+	Day     int64       // Días desde epoch (UTC)
+	Buckets map[int]int // índice de bucket logarítmico -> cantidad de muestras
+}
+
+// Value Object: LatencySketch
+// Histograma logarítmico por día que permite estimar percentiles de latencia en streaming
+// sobre los últimos 7 días sin guardar las muestras crudas. Al rotar el día, el más viejo se descarta.
+type LatencySketch struct {
+	days []LatencySketchDay // Ordenados de más viejo a más nuevo
+}
+
+func NewLatencySketch() *LatencySketch {
+	return &LatencySketch{days: make([]LatencySketchDay, 0, LatencySketchWindowDays)}
+}
+
+// NewLatencySketchFromDays reconstruye el sketch desde persistencia
+func NewLatencySketchFromDays(days []LatencySketchDay) *LatencySketch {
+	sorted := make([]LatencySketchDay, len(days))
+	copy(sorted, days)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Day < sorted[j].Day })
+
+	return &LatencySketch{days: sorted}
+}
+
+// Days devuelve los histogramas diarios (para persistencia)
+func (s *LatencySketch) Days() []LatencySketchDay {
+	return s.days
+}
+
+// Record agrega una muestra de latencia en el día de `at` y descarta los días fuera de la ventana
+func (s *LatencySketch) Record(responseTimeMs int, at time.Time) {
+	if responseTimeMs <= 0 {
+		return
+	}
+
+	day := at.UTC().Unix() / secondsPerDay
+	s.evictBefore(day - LatencySketchWindowDays + 1)
+
+	if n := len(s.days); n == 0 || s.days[n-1].Day != day {
+		s.days = append(s.days, LatencySketchDay{Day: day, Buckets: make(map[int]int)})
+	}
+	s.days[len(s.days)-1].Buckets[bucketIndex(responseTimeMs)]++
+}
+
+// Count devuelve la cantidad de muestras en la ventana
+func (s *LatencySketch) Count() int {
+	total := 0
+	for _, d := range s.days {
+		for _, c := range d.Buckets {
+			total += c
+		}
+	}
+	return total
+}
+
+// Quantile estima el percentil q (0..1) en ms. Devuelve 0 si no hay muestras.
+func (s *LatencySketch) Quantile(q float64) int {
+	merged := make(map[int]int)
+	total := 0
+	for _, d := range s.days {
+		for idx, c := range d.Buckets {
+			merged[idx] += c
+			total += c
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+
+	indexes := make([]int, 0, len(merged))
+	for idx := range merged {
+		indexes = append(indexes, idx)
+	}
+	sort.Ints(indexes)
+
+	// Rango (1-based) de la muestra buscada
+	rank := int(math.Ceil(q * float64(total)))
+	if rank < 1 {
+		rank = 1
+	}
+
+	seen := 0
+	for _, idx := range indexes {
+		seen += merged[idx]
+		if seen >= rank {
+			return bucketValue(idx)
+		}
+	}
+	return bucketValue(indexes[len(indexes)-1])
+}
+
+func (s *LatencySketch) evictBefore(minDay int64) {
+	keep := 0
+	for keep < len(s.days) && s.days[keep].Day < minDay {
+		keep++
+	}
+	s.days = s.days[keep:]
+}
+
+// bucketIndex: el bucket i cubre (gamma^(i-1), gamma^i]
+func bucketIndex(ms int) int {
+	return int(math.Ceil(math.Log(float64(ms)) / math.Log(latencySketchGamma)))
+}
+
+// bucketValue devuelve el punto medio del bucket (minimiza el error relativo)
+func bucketValue(idx int) int {
+	upper := math.Pow(latencySketchGamma, float64(idx))
+	return int(math.Round(2 * upper / (latencySketchGamma + 1)))
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// withinRelativeError valida la garantía del sketch (~1% de error relativo por bucket)
+func withinRelativeError(got, want int) bool {
+	diff := float64(got - want)
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= float64(want)*0.02+1
+}
+
+func TestLatencySketch_Quantiles(t *testing.T) {
+	sketch := NewLatencySketch()
+	now := time.Now()
+
+	// 1..1000 ms: p50 ~ 500, p95 ~ 950, p99 ~ 990
+	for ms := 1; ms <= 1000; ms++ {
+		sketch.Record(ms, now)
+	}
+
+	if sketch.Count() != 1000 {
+		t.Fatalf("Expected 1000 samples, got %d", sketch.Count())
+	}
+
+	cases := map[float64]int{0.50: 500, 0.90: 900, 0.95: 950, 0.99: 990}
+	for q, want := range cases {
+		if got := sketch.Quantile(q); !withinRelativeError(got, want) {
+			t.Errorf("Quantile(%.2f): expected ~%d, got %d", q, want, got)
+		}
+	}
+}
+
+func TestLatencySketch_EmptyAndInvalidSamples(t *testing.T) {
+	sketch := NewLatencySketch()
+	sketch.Record(0, time.Now())
+	sketch.Record(-5, time.Now())
+
+	if sketch.Count() != 0 {
+		t.Errorf("Expected non-positive samples to be ignored, got %d", sketch.Count())
+	}
+	if sketch.Quantile(0.95) != 0 {
+		t.Errorf("Expected 0 for empty sketch, got %d", sketch.Quantile(0.95))
+	}
+}
+
+func TestLatencySketch_EvictsDaysOutsideWindow(t *testing.T) {
+	sketch := NewLatencySketch()
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Un pico de 5s hace 7 días no debe seguir pesando en el p99
+	sketch.Record(5000, start)
+	for day := 1; day <= LatencySketchWindowDays; day++ {
+		sketch.Record(100, start.AddDate(0, 0, day))
+	}
+
+	if len(sketch.Days()) != LatencySketchWindowDays {
+		t.Errorf("Expected %d days in window, got %d", LatencySketchWindowDays, len(sketch.Days()))
+	}
+	if got := sketch.Quantile(0.99); !withinRelativeError(got, 100) {
+		t.Errorf("Expected old spike to be evicted (p99 ~100), got %d", got)
+	}
+}
+
+func TestLatencySketch_RestoreFromDays(t *testing.T) {
+	original := NewLatencySketch()
+	now := time.Now()
+	for ms := 100; ms < 200; ms++ {
+		original.Record(ms, now)
+	}
+
+	restored := NewLatencySketchFromDays(original.Days())
+
+	if restored.Count() != original.Count() {
+		t.Errorf("Expected %d samples, got %d", original.Count(), restored.Count())
+	}
+	if restored.Quantile(0.95) != original.Quantile(0.95) {
+		t.Errorf("Expected same p95, got %d vs %d", restored.Quantile(0.95), original.Quantile(0.95))
+	}
+}
+
+func TestTargetStatistics_BaselineResponseTime(t *testing.T) {
+	targetId, _ := NewTargetId("test-target-123")
+	stats := NewFullTargetStatistics(targetId, 100, 50, nil, EWMAStats{}, FlapHistory{})
+
+	// Sin muestras suficientes, P95 cae en el promedio
+	if got := stats.BaselineResponseTimeMs(BaselineMetricP95); got != 100 {
+		t.Errorf("Expected fallback to mean (100), got %d", got)
+	}
+
+	samples := make([]int, 0, MinPercentileSamples)
+	for i := 0; i < MinPercentileSamples; i++ {
+		samples = append(samples, 300)
+	}
+	stats.RecordLatencies(samples, time.Now())
+
+	if got := stats.BaselineResponseTimeMs(BaselineMetricP95); !withinRelativeError(got, 300) {
+		t.Errorf("Expected p95 baseline ~300, got %d", got)
+	}
+	if got := stats.BaselineResponseTimeMs(BaselineMetricMean); got != 100 {
+		t.Errorf("Expected mean baseline 100, got %d", got)
+	}
+}
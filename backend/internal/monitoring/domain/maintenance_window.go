@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	userdomain "uptrackai/internal/user/domain"
+)
+
+// Value Object: MaintenanceWindowId
+type MaintenanceWindowId string
+
+func NewMaintenanceWindowId(value string) (MaintenanceWindowId, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", ErrMaintenanceWindowIdEmpty
+	}
+	return MaintenanceWindowId(value), nil
+}
+
+func (m MaintenanceWindowId) String() string {
+	return string(m)
+}
+
+// Entity: MaintenanceWindow
+// Período planificado en el que el target puede caerse sin afectar su uptime
+type MaintenanceWindow struct {
+	id        MaintenanceWindowId
+	targetId  TargetId
+	startsAt  time.Time
+	endsAt    time.Time
+	reason    string
+	createdBy userdomain.UserId
+	createdAt time.Time
+}
+
+func NewMaintenanceWindow(targetId TargetId, startsAt time.Time, endsAt time.Time, reason string, createdBy userdomain.UserId, now time.Time) (*MaintenanceWindow, error) {
+	if !endsAt.After(startsAt) {
+		return nil, ErrInvalidMaintenanceWindow
+	}
+	return &MaintenanceWindow{
+		targetId:  targetId,
+		startsAt:  startsAt,
+		endsAt:    endsAt,
+		reason:    strings.TrimSpace(reason),
+		createdBy: createdBy,
+		createdAt: now,
+	}, nil
+}
+
+// NewFullMaintenanceWindow reconstruye una ventana persistida
+func NewFullMaintenanceWindow(id MaintenanceWindowId, targetId TargetId, startsAt time.Time, endsAt time.Time, reason string, createdBy userdomain.UserId, createdAt time.Time) *MaintenanceWindow {
+	return &MaintenanceWindow{
+		id:        id,
+		targetId:  targetId,
+		startsAt:  startsAt,
+		endsAt:    endsAt,
+		reason:    reason,
+		createdBy: createdBy,
+		createdAt: createdAt,
+	}
+}
+
+func (m *MaintenanceWindow) AssignId(id MaintenanceWindowId) error {
+	if m.id != "" {
+		return ErrMaintenanceWindowAlreadyHasId
+	}
+	m.id = id
+	return nil
+}
+
+// Getters
+func (m *MaintenanceWindow) ID() MaintenanceWindowId {
+	return m.id
+}
+
+func (m *MaintenanceWindow) TargetId() TargetId {
+	return m.targetId
+}
+
+func (m *MaintenanceWindow) StartsAt() time.Time {
+	return m.startsAt
+}
+
+func (m *MaintenanceWindow) EndsAt() time.Time {
+	return m.endsAt
+}
+
+func (m *MaintenanceWindow) Reason() string {
+	return m.reason
+}
+
+func (m *MaintenanceWindow) CreatedBy() userdomain.UserId {
+	return m.createdBy
+}
+
+func (m *MaintenanceWindow) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+func (m *MaintenanceWindow) Range() TimeRange {
+	return TimeRange{Start: m.startsAt, End: m.endsAt}
+}
+
+func (m *MaintenanceWindow) IsActiveAt(t time.Time) bool {
+	return m.Range().Contains(t)
+}
+
+// MaintenanceRanges extrae los intervalos para el cálculo de disponibilidad
+func MaintenanceRanges(windows []*MaintenanceWindow) []TimeRange {
+	ranges := make([]TimeRange, 0, len(windows))
+	for _, w := range windows {
+		ranges = append(ranges, w.Range())
+	}
+	return ranges
+}
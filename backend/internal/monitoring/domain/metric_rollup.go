@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Enum: MetricResolution - Granularidad de una serie de métricas
+type MetricResolution string
+
+const (
+	MetricResolutionRaw    MetricResolution = "raw" // Una fila por sesión (tabla metrics)
+	MetricResolutionMinute MetricResolution = "1m"
+	MetricResolutionHour   MetricResolution = "1h"
+	MetricResolutionDay    MetricResolution = "1d"
+)
+
+const (
+	// MaxMetricPoints - Tope de buckets por consulta: suficiente para un gráfico, barato de servir
+	MaxMetricPoints = 1500
+	// RawMetricsMaxSpan - Hasta este rango se sirven las sesiones crudas
+	RawMetricsMaxSpan = 6 * time.Hour
+)
+
+// RollupResolutions - Resoluciones agregadas, de la más fina a la más gruesa
+var RollupResolutions = []MetricResolution{MetricResolutionMinute, MetricResolutionHour, MetricResolutionDay}
+
+func NewMetricResolution(value string) (MetricResolution, error) {
+	resolution := MetricResolution(strings.ToLower(strings.TrimSpace(value)))
+	switch resolution {
+	case MetricResolutionRaw, MetricResolutionMinute, MetricResolutionHour, MetricResolutionDay:
+		return resolution, nil
+	}
+	return "", ErrInvalidMetricResolution
+}
+
+func (r MetricResolution) String() string {
+	return string(r)
+}
+
+// BucketSize es el ancho de cada bucket (0 para raw)
+func (r MetricResolution) BucketSize() time.Duration {
+	switch r {
+	case MetricResolutionMinute:
+		return time.Minute
+	case MetricResolutionHour:
+		return time.Hour
+	case MetricResolutionDay:
+		return 24 * time.Hour
+	}
+	return 0
+}
+
+// BucketStart alinea `t` al inicio de su bucket (los días empiezan a medianoche UTC)
+func (r MetricResolution) BucketStart(t time.Time) time.Time {
+	size := r.BucketSize()
+	if size == 0 {
+		return t
+	}
+	return t.UTC().Truncate(size)
+}
+
+// RefreshInterval - Cada resolución se recalcula cada 1/24 de su bucket (el día, cada hora)
+func (r MetricResolution) RefreshInterval() time.Duration {
+	return r.BucketSize() / 24
+}
+
+// SelectMetricResolution elige la resolución más fina que entra en MaxMetricPoints para el rango
+func SelectMetricResolution(span time.Duration) MetricResolution {
+	if span <= RawMetricsMaxSpan {
+		return MetricResolutionRaw
+	}
+	for _, resolution := range RollupResolutions {
+		if span/resolution.BucketSize() <= MaxMetricPoints {
+			return resolution
+		}
+	}
+	return MetricResolutionDay
+}
+
+// ValidateMetricResolution rechaza resoluciones pedidas a mano que desbordarían el tope de puntos
+func ValidateMetricResolution(resolution MetricResolution, span time.Duration) error {
+	if resolution == MetricResolutionRaw {
+		if span > RawMetricsMaxSpan {
+			return ErrMetricResolutionTooFine
+		}
+		return nil
+	}
+	if resolution == MetricResolutionDay {
+		return nil
+	}
+	if span/resolution.BucketSize() > MaxMetricPoints {
+		return ErrMetricResolutionTooFine
+	}
+	return nil
+}
+
+// MetricRollup - Agregado de las sesiones de un target en un bucket.
+// Latencias solo sobre sesiones correctas; una sesión fallida suma a FailureCount.
+type MetricRollup struct {
+	TargetId          TargetId
+	Resolution        MetricResolution
+	BucketStart       time.Time
+	Count             int64
+	FailureCount      int64
+	MinResponseTimeMs int
+	MaxResponseTimeMs int
+	AvgResponseTimeMs float64
+	P95ResponseTimeMs int
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSelectMetricResolution(t *testing.T) {
+	cases := []struct {
+		span time.Duration
+		want MetricResolution
+	}{
+		{time.Hour, MetricResolutionRaw},
+		{RawMetricsMaxSpan, MetricResolutionRaw},
+		{24 * time.Hour, MetricResolutionMinute},
+		{7 * 24 * time.Hour, MetricResolutionHour},
+		{60 * 24 * time.Hour, MetricResolutionHour},
+		{90 * 24 * time.Hour, MetricResolutionDay},
+		{10 * 365 * 24 * time.Hour, MetricResolutionDay},
+	}
+	for _, tc := range cases {
+		if got := SelectMetricResolution(tc.span); got != tc.want {
+			t.Errorf("span %s: expected %s, got %s", tc.span, tc.want, got)
+		}
+	}
+}
+
+func TestValidateMetricResolution(t *testing.T) {
+	if err := ValidateMetricResolution(MetricResolutionRaw, 24*time.Hour); err != ErrMetricResolutionTooFine {
+		t.Errorf("Expected raw over 24h to be rejected, got %v", err)
+	}
+	if err := ValidateMetricResolution(MetricResolutionMinute, 30*24*time.Hour); err != ErrMetricResolutionTooFine {
+		t.Errorf("Expected 1m over 30d to be rejected, got %v", err)
+	}
+	if err := ValidateMetricResolution(MetricResolutionHour, 30*24*time.Hour); err != nil {
+		t.Errorf("Expected 1h over 30d to be valid, got %v", err)
+	}
+	if _, err := NewMetricResolution("5m"); err != ErrInvalidMetricResolution {
+		t.Errorf("Expected ErrInvalidMetricResolution, got %v", err)
+	}
+}
+
+func TestMetricResolution_BucketStart(t *testing.T) {
+	at := time.Date(2024, 3, 10, 17, 42, 31, 0, time.FixedZone("ART", -3*3600))
+
+	if got := MetricResolutionHour.BucketStart(at); !got.Equal(time.Date(2024, 3, 10, 20, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected 20:00 UTC, got %s", got)
+	}
+	// Los días se cortan en UTC, no en la zona del timestamp
+	if got := MetricResolutionDay.BucketStart(at); !got.Equal(time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected midnight UTC, got %s", got)
+	}
+	if MetricResolutionDay.RefreshInterval() != time.Hour {
+		t.Errorf("Expected the daily rollup to refresh hourly, got %s", MetricResolutionDay.RefreshInterval())
+	}
+}
@@ -0,0 +1,210 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MinMetricStep - Paso mínimo de una serie re-agrupada (coincide con el rollup más fino)
+const MinMetricStep = time.Minute
+
+const stepDay = 24 * time.Hour
+
+// ParseTimeBound interpreta un extremo de rango: RFC3339, "now" o relativo a ahora ("-24h", "-30m", "-7d", "-2w").
+// Vacío devuelve el tiempo cero para que cada caso de uso aplique su default.
+func ParseTimeBound(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if strings.EqualFold(value, "now") {
+		return now, nil
+	}
+	if strings.HasPrefix(value, "-") {
+		offset, err := parseSpan(value[1:])
+		if err != nil || offset <= 0 {
+			return time.Time{}, ErrInvalidTimeBound
+		}
+		return now.Add(-offset), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, ErrInvalidTimeBound
+	}
+	return t, nil
+}
+
+// ParseMetricStep interpreta el ancho de bucket pedido por el cliente ("5m", "1h", "1d"); vacío = sin re-agrupar
+func ParseMetricStep(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	step, err := parseSpan(value)
+	if err != nil || step < MinMetricStep {
+		return 0, ErrInvalidMetricStep
+	}
+	return step, nil
+}
+
+// FormatMetricStep es el inverso de ParseMetricStep en la unidad más gruesa exacta
+func FormatMetricStep(step time.Duration) string {
+	switch {
+	case step <= 0:
+		return ""
+	case step%stepDay == 0:
+		return strconv.FormatInt(int64(step/stepDay), 10) + "d"
+	case step%time.Hour == 0:
+		return strconv.FormatInt(int64(step/time.Hour), 10) + "h"
+	case step%time.Minute == 0:
+		return strconv.FormatInt(int64(step/time.Minute), 10) + "m"
+	}
+	return strconv.FormatInt(int64(step/time.Second), 10) + "s"
+}
+
+// parseSpan acepta las unidades de time.ParseDuration más días (d) y semanas (w) enteros
+func parseSpan(value string) (time.Duration, error) {
+	units := map[byte]time.Duration{'d': stepDay, 'w': 7 * stepDay}
+	if n := len(value); n > 1 {
+		if unit, ok := units[value[n-1]]; ok {
+			count, err := strconv.Atoi(value[:n-1])
+			if err != nil || count <= 0 {
+				return 0, ErrInvalidMetricStep
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	return time.ParseDuration(value)
+}
+
+// StepBucketStart alinea `t` al inicio de su bucket de ancho `step` (UTC, igual que los rollups)
+func StepBucketStart(t time.Time, step time.Duration) time.Time {
+	return t.UTC().Truncate(step)
+}
+
+// ValidateMetricStep rechaza pasos que desbordarían el tope de puntos para el rango
+func ValidateMetricStep(step time.Duration, span time.Duration) error {
+	if step < MinMetricStep {
+		return ErrInvalidMetricStep
+	}
+	if span/step > MaxMetricPoints {
+		return ErrMetricStepTooFine
+	}
+	return nil
+}
+
+// SelectStepSource elige de qué se re-agrupa un paso: sesiones crudas si el rango lo permite
+// (p95 exacto), si no el rollup más grueso que divide al paso.
+func SelectStepSource(step time.Duration, span time.Duration) (MetricResolution, error) {
+	if err := ValidateMetricStep(step, span); err != nil {
+		return "", err
+	}
+	if span <= RawMetricsMaxSpan {
+		return MetricResolutionRaw, nil
+	}
+	for i := len(RollupResolutions) - 1; i >= 0; i-- {
+		if step%RollupResolutions[i].BucketSize() == 0 {
+			return RollupResolutions[i], nil
+		}
+	}
+	return "", ErrMetricStepUnaligned
+}
+
+// BucketSessions agrupa sesiones crudas (ordenadas) en buckets de `step` con la misma regla que los rollups
+func BucketSessions(targetId TargetId, sessions []*CheckResult, step time.Duration) []MetricRollup {
+	buckets := make([]MetricRollup, 0)
+	var latencies []int
+	flush := func() {
+		if len(buckets) > 0 {
+			fillLatencyStats(&buckets[len(buckets)-1], latencies)
+		}
+		latencies = latencies[:0]
+	}
+
+	for _, session := range sessions {
+		start := StepBucketStart(session.Timestamp(), step)
+		if len(buckets) == 0 || !buckets[len(buckets)-1].BucketStart.Equal(start) {
+			flush()
+			buckets = append(buckets, MetricRollup{TargetId: targetId, Resolution: MetricResolutionRaw, BucketStart: start})
+		}
+		bucket := &buckets[len(buckets)-1]
+		bucket.Count++
+		if session.IsFailedSession() {
+			bucket.FailureCount++
+			continue
+		}
+		latencies = append(latencies, session.ResponseTimeMs())
+	}
+	flush()
+	return buckets
+}
+
+// ResampleRollups junta buckets de una resolución en buckets de `step` (múltiplo de ella).
+// Sin las sesiones el p95 exacto no se puede recalcular: se informa el mayor p95 de los
+// buckets que lo componen, que es una cota superior.
+func ResampleRollups(rollups []MetricRollup, step time.Duration) []MetricRollup {
+	buckets := make([]MetricRollup, 0)
+	var latencySum float64
+	flush := func() {
+		if len(buckets) > 0 {
+			last := &buckets[len(buckets)-1]
+			if healthy := last.Count - last.FailureCount; healthy > 0 {
+				last.AvgResponseTimeMs = latencySum / float64(healthy)
+			}
+		}
+		latencySum = 0
+	}
+
+	for _, rollup := range rollups {
+		start := StepBucketStart(rollup.BucketStart, step)
+		if len(buckets) == 0 || !buckets[len(buckets)-1].BucketStart.Equal(start) {
+			flush()
+			buckets = append(buckets, MetricRollup{TargetId: rollup.TargetId, Resolution: rollup.Resolution, BucketStart: start})
+		}
+		bucket := &buckets[len(buckets)-1]
+		healthy := rollup.Count - rollup.FailureCount
+		bucket.Count += rollup.Count
+		bucket.FailureCount += rollup.FailureCount
+		if healthy <= 0 {
+			continue
+		}
+		if bucket.MinResponseTimeMs == 0 || rollup.MinResponseTimeMs < bucket.MinResponseTimeMs {
+			bucket.MinResponseTimeMs = rollup.MinResponseTimeMs
+		}
+		if rollup.MaxResponseTimeMs > bucket.MaxResponseTimeMs {
+			bucket.MaxResponseTimeMs = rollup.MaxResponseTimeMs
+		}
+		if rollup.P95ResponseTimeMs > bucket.P95ResponseTimeMs {
+			bucket.P95ResponseTimeMs = rollup.P95ResponseTimeMs
+		}
+		latencySum += rollup.AvgResponseTimeMs * float64(healthy)
+	}
+	flush()
+	return buckets
+}
+
+// fillLatencyStats calcula min/max/avg/p95 (p95 interpolado, como percentile_cont en SQL)
+func fillLatencyStats(bucket *MetricRollup, latencies []int) {
+	if len(latencies) == 0 {
+		return
+	}
+	sorted := append([]int(nil), latencies...)
+	sort.Ints(sorted)
+
+	sum := 0
+	for _, latency := range sorted {
+		sum += latency
+	}
+	bucket.MinResponseTimeMs = sorted[0]
+	bucket.MaxResponseTimeMs = sorted[len(sorted)-1]
+	bucket.AvgResponseTimeMs = float64(sum) / float64(len(sorted))
+
+	position := 0.95 * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := int(math.Ceil(position))
+	fraction := position - float64(lower)
+	bucket.P95ResponseTimeMs = int(math.Round(float64(sorted[lower]) + fraction*float64(sorted[upper]-sorted[lower])))
+}
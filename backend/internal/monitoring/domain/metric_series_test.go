@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeBound(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]time.Time{
+		"":                     {},
+		"now":                  now,
+		"-24h":                 now.Add(-24 * time.Hour),
+		"-90m":                 now.Add(-90 * time.Minute),
+		"-7d":                  now.Add(-7 * 24 * time.Hour),
+		"-2w":                  now.Add(-14 * 24 * time.Hour),
+		"2024-03-05T14:00:00Z": time.Date(2024, 3, 5, 14, 0, 0, 0, time.UTC),
+	}
+	for value, expected := range cases {
+		got, err := ParseTimeBound(value, now)
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", value, err)
+			continue
+		}
+		if !got.Equal(expected) {
+			t.Errorf("%q: expected %v, got %v", value, expected, got)
+		}
+	}
+
+	for _, value := range []string{"yesterday", "-0h", "-d", "+1h", "2024-03-05"} {
+		if _, err := ParseTimeBound(value, now); err != ErrInvalidTimeBound {
+			t.Errorf("%q: expected ErrInvalidTimeBound, got %v", value, err)
+		}
+	}
+}
+
+func TestParseMetricStep(t *testing.T) {
+	step, err := ParseMetricStep("1d")
+	if err != nil || step != 24*time.Hour {
+		t.Errorf("Expected 1d, got %v (%v)", step, err)
+	}
+	if FormatMetricStep(step) != "1d" || FormatMetricStep(90*time.Minute) != "90m" || FormatMetricStep(6*time.Hour) != "6h" {
+		t.Error("Expected steps to be formatted in their coarsest exact unit")
+	}
+	if step, err := ParseMetricStep(""); err != nil || step != 0 {
+		t.Errorf("Expected empty step to mean no bucketing, got %v (%v)", step, err)
+	}
+	for _, value := range []string{"30s", "abc", "0d"} {
+		if _, err := ParseMetricStep(value); err != ErrInvalidMetricStep {
+			t.Errorf("%q: expected ErrInvalidMetricStep, got %v", value, err)
+		}
+	}
+}
+
+func TestSelectStepSource(t *testing.T) {
+	cases := []struct {
+		step     time.Duration
+		span     time.Duration
+		expected MetricResolution
+		err      error
+	}{
+		{5 * time.Minute, 2 * time.Hour, MetricResolutionRaw, nil},
+		{90 * time.Minute, 7 * 24 * time.Hour, MetricResolutionMinute, nil},
+		{6 * time.Hour, 30 * 24 * time.Hour, MetricResolutionHour, nil},
+		{48 * time.Hour, 365 * 24 * time.Hour, MetricResolutionDay, nil},
+		{time.Minute, 7 * 24 * time.Hour, "", ErrMetricStepTooFine},
+		{90 * time.Second, 24 * time.Hour, "", ErrMetricStepUnaligned},
+		{30 * time.Second, time.Hour, "", ErrInvalidMetricStep},
+	}
+	for _, c := range cases {
+		got, err := SelectStepSource(c.step, c.span)
+		if got != c.expected || err != c.err {
+			t.Errorf("step %v span %v: expected %s/%v, got %s/%v", c.step, c.span, c.expected, c.err, got, err)
+		}
+	}
+}
+
+func TestBucketSessions(t *testing.T) {
+	base := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	targetId := TargetId("target-123")
+	down := NewFullCheckResult("m-3", targetId, base.Add(3*time.Minute), 0, false, TargetStatusDown, "")
+	sessions := []*CheckResult{
+		NewFullCheckResult("m-1", targetId, base.Add(time.Minute), 100, true, TargetStatusUp, ""),
+		NewFullCheckResult("m-2", targetId, base.Add(2*time.Minute), 300, true, TargetStatusUp, ""),
+		down,
+		NewFullCheckResult("m-4", targetId, base.Add(6*time.Minute), 200, true, TargetStatusUp, ""),
+	}
+
+	buckets := BucketSessions(targetId, sessions, 5*time.Minute)
+
+	if len(buckets) != 2 {
+		t.Fatalf("Expected 2 buckets, got %d", len(buckets))
+	}
+	first := buckets[0]
+	if !first.BucketStart.Equal(base) || first.Count != 3 || first.FailureCount != 1 {
+		t.Errorf("Unexpected first bucket: %+v", first)
+	}
+	if first.MinResponseTimeMs != 100 || first.MaxResponseTimeMs != 300 || first.AvgResponseTimeMs != 200 || first.P95ResponseTimeMs != 290 {
+		t.Errorf("Expected latency stats over healthy sessions only, got %+v", first)
+	}
+	if buckets[1].Count != 1 || buckets[1].P95ResponseTimeMs != 200 {
+		t.Errorf("Unexpected second bucket: %+v", buckets[1])
+	}
+}
+
+func TestResampleRollups(t *testing.T) {
+	base := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	rollups := []MetricRollup{
+		{Resolution: MetricResolutionHour, BucketStart: base, Count: 60, FailureCount: 0, MinResponseTimeMs: 80, MaxResponseTimeMs: 400, AvgResponseTimeMs: 100, P95ResponseTimeMs: 200},
+		{Resolution: MetricResolutionHour, BucketStart: base.Add(time.Hour), Count: 60, FailureCount: 30, MinResponseTimeMs: 50, MaxResponseTimeMs: 900, AvgResponseTimeMs: 400, P95ResponseTimeMs: 700},
+		{Resolution: MetricResolutionHour, BucketStart: base.Add(2 * time.Hour), Count: 60, FailureCount: 60},
+	}
+
+	buckets := ResampleRollups(rollups, 2*time.Hour)
+
+	if len(buckets) != 2 {
+		t.Fatalf("Expected 2 buckets, got %d", len(buckets))
+	}
+	merged := buckets[0]
+	if merged.Count != 120 || merged.FailureCount != 30 || merged.MinResponseTimeMs != 50 || merged.MaxResponseTimeMs != 900 {
+		t.Errorf("Unexpected merged bucket: %+v", merged)
+	}
+	// (60*100 + 30*400) / 90 sesiones correctas
+	if merged.AvgResponseTimeMs != 200 || merged.P95ResponseTimeMs != 700 {
+		t.Errorf("Expected weighted avg 200 and p95 upper bound 700, got %+v", merged)
+	}
+	if buckets[1].FailureCount != 60 || buckets[1].AvgResponseTimeMs != 0 {
+		t.Errorf("Expected an all-failed bucket without latency, got %+v", buckets[1])
+	}
+}
@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	// MonitorHeartbeatInterval - Cada cuánto el scheduler deja constancia de que sigue vivo
+	MonitorHeartbeatInterval = time.Minute
+	// SelfDowntimeTolerance - Huecos menores (reinicios, un tick perdido) no se consideran caída
+	SelfDowntimeTolerance = 3 * MonitorHeartbeatInterval
+)
+
+// Value Object: MonitorRunId
+type MonitorRunId string
+
+func (m MonitorRunId) String() string {
+	return string(m)
+}
+
+// Entity: MonitorRun
+// Una ejecución del scheduler, desde que arrancó hasta su último heartbeat.
+// Los huecos entre ejecuciones son los períodos en que UpTrackAI no estuvo monitoreando.
+type MonitorRun struct {
+	id         MonitorRunId
+	startedAt  time.Time
+	lastSeenAt time.Time
+}
+
+func StartMonitorRun(id MonitorRunId, at time.Time) *MonitorRun {
+	return &MonitorRun{id: id, startedAt: at, lastSeenAt: at}
+}
+
+func NewFullMonitorRun(id MonitorRunId, startedAt time.Time, lastSeenAt time.Time) *MonitorRun {
+	return &MonitorRun{id: id, startedAt: startedAt, lastSeenAt: lastSeenAt}
+}
+
+func (m *MonitorRun) ID() MonitorRunId {
+	return m.id
+}
+
+func (m *MonitorRun) StartedAt() time.Time {
+	return m.startedAt
+}
+
+func (m *MonitorRun) LastSeenAt() time.Time {
+	return m.lastSeenAt
+}
+
+// Beat registra un heartbeat (nunca retrocede)
+func (m *MonitorRun) Beat(at time.Time) {
+	if at.After(m.lastSeenAt) {
+		m.lastSeenAt = at
+	}
+}
+
+// SelfDowntime devuelve los huecos entre ejecuciones que superan la tolerancia, recortados
+// a la ventana. Soporta ejecuciones superpuestas (varias réplicas); antes de la primera
+// ejecución conocida no hay datos, así que no se considera caída.
+func SelfDowntime(runs []*MonitorRun, window TimeRange, tolerance time.Duration) []TimeRange {
+	if len(runs) == 0 {
+		return []TimeRange{}
+	}
+
+	sorted := make([]*MonitorRun, len(runs))
+	copy(sorted, runs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].startedAt.Before(sorted[j].startedAt)
+	})
+
+	gaps := make([]TimeRange, 0)
+	addGap := func(start time.Time, end time.Time) {
+		if end.Sub(start) <= tolerance {
+			return
+		}
+		gap := TimeRange{Start: start, End: end}
+		if !gap.Overlaps(window) {
+			return
+		}
+		if gap.Start.Before(window.Start) {
+			gap.Start = window.Start
+		}
+		if gap.End.After(window.End) {
+			gap.End = window.End
+		}
+		gaps = append(gaps, gap)
+	}
+
+	coveredUntil := sorted[0].lastSeenAt
+	for _, run := range sorted[1:] {
+		if run.startedAt.After(coveredUntil) {
+			addGap(coveredUntil, run.startedAt)
+		}
+		if run.lastSeenAt.After(coveredUntil) {
+			coveredUntil = run.lastSeenAt
+		}
+	}
+	// Sin heartbeat reciente: el monitor sigue caído
+	addGap(coveredUntil, window.End)
+
+	return gaps
+}
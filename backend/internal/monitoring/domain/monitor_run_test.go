@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSelfDowntime(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := TimeRange{Start: start, End: start.Add(10 * time.Hour)}
+
+	runs := []*MonitorRun{
+		NewFullMonitorRun("run-3", start.Add(6*time.Hour), start.Add(10*time.Hour)),
+		// Empezó antes de la ventana y se cayó a la 1h
+		NewFullMonitorRun("run-1", start.Add(-2*time.Hour), start.Add(time.Hour)),
+		// Reinicio rápido: el hueco queda dentro de la tolerancia
+		NewFullMonitorRun("run-2", start.Add(time.Hour+time.Minute), start.Add(3*time.Hour)),
+		// Réplica superpuesta que cubre parte del hueco
+		NewFullMonitorRun("run-2b", start.Add(2*time.Hour), start.Add(4*time.Hour)),
+	}
+
+	gaps := SelfDowntime(runs, window, SelfDowntimeTolerance)
+	if len(gaps) != 1 {
+		t.Fatalf("Expected 1 gap, got %d: %v", len(gaps), gaps)
+	}
+	if !gaps[0].Start.Equal(start.Add(4*time.Hour)) || !gaps[0].End.Equal(start.Add(6*time.Hour)) {
+		t.Errorf("Expected gap 4h-6h, got %s - %s", gaps[0].Start, gaps[0].End)
+	}
+}
+
+func TestSelfDowntime_StillDown(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := TimeRange{Start: start, End: start.Add(10 * time.Hour)}
+
+	// Sin heartbeat desde la 8h: hueco hasta el fin de la ventana
+	runs := []*MonitorRun{NewFullMonitorRun("run-1", start.Add(-time.Hour), start.Add(8*time.Hour))}
+	gaps := SelfDowntime(runs, window, SelfDowntimeTolerance)
+	if len(gaps) != 1 || gaps[0].Duration() != 2*time.Hour {
+		t.Errorf("Expected a trailing 2h gap, got %v", gaps)
+	}
+
+	// Sin ejecuciones conocidas no se asume caída
+	if gaps := SelfDowntime(nil, window, SelfDowntimeTolerance); len(gaps) != 0 {
+		t.Errorf("Expected no gaps without runs, got %v", gaps)
+	}
+}
+
+func TestMonitorRun_Beat(t *testing.T) {
+	start := time.Now()
+	run := StartMonitorRun("run-1", start)
+	run.Beat(start.Add(time.Minute))
+	run.Beat(start) // Un reloj atrasado no retrocede el heartbeat
+
+	if !run.LastSeenAt().Equal(start.Add(time.Minute)) {
+		t.Errorf("Expected last seen at +1m, got %s", run.LastSeenAt())
+	}
+}
@@ -19,6 +19,7 @@ type MonitoringTarget struct {
 	lastResponseTime int
 	targetType       TargetType
 	configuration    *CheckConfiguration // Relación "Define" con CheckConfiguration
+	hostingInfo      HostingInfo         // IPs y proveedor/ASN (lo completa un job aparte)
 }
 
 func NewMinimalMonitoringTarget(name string, url string, targetType TargetType, userId domain.UserId) *MonitoringTarget {
@@ -108,6 +109,15 @@ func (m *MonitoringTarget) NextCheckAt() time.Time {
 	return m.lastCheckedAt.Add(time.Duration(interval) * time.Second)
 }
 
+func (m *MonitoringTarget) HostingInfo() HostingInfo {
+	return m.hostingInfo
+}
+
+// AssignHostingInfo registra dónde está alojado el target (IPs, proveedor, ASN)
+func (m *MonitoringTarget) AssignHostingInfo(info HostingInfo) {
+	m.hostingInfo = info
+}
+
 func (m *MonitoringTarget) Configuration() *CheckConfiguration {
 	return m.configuration
 }
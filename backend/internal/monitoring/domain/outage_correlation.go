@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+const (
+	CorrelationWindow    = 5 * time.Minute // Caídas más separadas que esto no se consideran la misma
+	MinCorrelatedTargets = 3               // Targets distintos caídos que comparten algo para sospechar infraestructura
+	// Cuánto se retiene la alerta de una caída aislada esperando otras con la misma causa:
+	// cubre los targets revisados en el mismo ciclo sin demorar demasiado una caída real
+	CorrelationHold = 90 * time.Second
+)
+
+// CauseKind - Qué comparten los targets caídos
+type CauseKind string
+
+const (
+	CauseKindIP       CauseKind = "ip"
+	CauseKindASN      CauseKind = "asn"
+	CauseKindProvider CauseKind = "provider"
+)
+
+// InfrastructureCause - Causa común sospechada, ej: "provider:Cloudflare" o "ip:104.16.1.1"
+type InfrastructureCause struct {
+	Kind  CauseKind
+	Value string
+}
+
+func (c InfrastructureCause) String() string {
+	return string(c.Kind) + ":" + c.Value
+}
+
+// CorrelatedFailure - Una caída (transición a DOWN) de un target
+type CorrelatedFailure struct {
+	TargetId   TargetId
+	TargetName string
+	UserId     string
+	At         time.Time
+}
+
+// CorrelationResult - Qué hacer con una caída recién registrada
+type CorrelationResult struct {
+	Cause *InfrastructureCause // nil = caída aislada, se alerta normalmente
+	// Usuarios que todavía no recibieron la alerta del incidente, con sus targets afectados
+	PendingUsers map[string][]string
+	Affected     int // Targets (de todos los usuarios) caídos por esta causa
+}
+
+// ResolvedIncident - Incidente de infraestructura cerrado: se recuperó el último de sus targets
+type ResolvedIncident struct {
+	Cause         InfrastructureCause
+	NotifiedUsers []string // Usuarios que recibieron la alerta del incidente (ordenados)
+}
+
+type trackedFailure struct {
+	failure CorrelatedFailure
+	causes  []InfrastructureCause
+}
+
+// InfrastructureIncident - Varias caídas que comparten IP, ASN o proveedor
+type InfrastructureIncident struct {
+	cause         InfrastructureCause
+	startedAt     time.Time
+	targets       map[TargetId]CorrelatedFailure
+	notifiedUsers map[string]bool
+}
+
+func (i *InfrastructureIncident) Cause() InfrastructureCause {
+	return i.cause
+}
+
+func (i *InfrastructureIncident) StartedAt() time.Time {
+	return i.startedAt
+}
+
+// OutageCorrelator agrupa las caídas recientes por causa común.
+// No es thread-safe: quien lo usa desde varios workers debe serializar el acceso.
+type OutageCorrelator struct {
+	window     time.Duration
+	minTargets int
+	failures   map[TargetId]trackedFailure
+	incidents  map[InfrastructureCause]*InfrastructureIncident
+}
+
+func NewOutageCorrelator(window time.Duration, minTargets int) *OutageCorrelator {
+	return &OutageCorrelator{
+		window:     window,
+		minTargets: minTargets,
+		failures:   make(map[TargetId]trackedFailure),
+		incidents:  make(map[InfrastructureCause]*InfrastructureIncident),
+	}
+}
+
+// RecordFailure registra una caída y devuelve si pertenece a un incidente de infraestructura
+func (c *OutageCorrelator) RecordFailure(failure CorrelatedFailure, hosting HostingInfo) CorrelationResult {
+	c.prune(failure.At)
+
+	causes := hosting.Causes()
+	c.failures[failure.TargetId] = trackedFailure{failure: failure, causes: causes}
+
+	// 1. ¿Ya hay un incidente abierto para alguna de sus causas? Se suma a ese.
+	for _, cause := range causes {
+		if incident, ok := c.incidents[cause]; ok {
+			incident.targets[failure.TargetId] = failure
+			return c.resultFor(incident)
+		}
+	}
+
+	// 2. ¿Alcanza el mínimo de targets distintos con la misma causa? (la más específica gana)
+	for _, cause := range causes {
+		matching := c.failuresWith(cause)
+		if len(matching) < c.minTargets {
+			continue
+		}
+
+		incident := &InfrastructureIncident{
+			cause:         cause,
+			startedAt:     failure.At,
+			targets:       make(map[TargetId]CorrelatedFailure, len(matching)),
+			notifiedUsers: make(map[string]bool),
+		}
+		for _, f := range matching {
+			incident.targets[f.TargetId] = f
+		}
+		c.incidents[cause] = incident
+		return c.resultFor(incident)
+	}
+
+	return CorrelationResult{}
+}
+
+// RecordRecovery saca al target de las caídas recientes. Un incidente sin targets se cierra
+// y se devuelve, para avisar a quienes recibieron su alerta.
+func (c *OutageCorrelator) RecordRecovery(targetId TargetId) []ResolvedIncident {
+	delete(c.failures, targetId)
+
+	resolved := make([]ResolvedIncident, 0)
+	for cause, incident := range c.incidents {
+		if _, ok := incident.targets[targetId]; !ok {
+			continue
+		}
+		delete(incident.targets, targetId)
+		if len(incident.targets) > 0 {
+			continue
+		}
+
+		delete(c.incidents, cause)
+		users := make([]string, 0, len(incident.notifiedUsers))
+		for userId := range incident.notifiedUsers {
+			users = append(users, userId)
+		}
+		sort.Strings(users)
+		resolved = append(resolved, ResolvedIncident{Cause: cause, NotifiedUsers: users})
+	}
+	return resolved
+}
+
+// resultFor marca como notificados a los usuarios que aún no conocían el incidente
+func (c *OutageCorrelator) resultFor(incident *InfrastructureIncident) CorrelationResult {
+	cause := incident.cause
+	pending := make(map[string][]string)
+	for _, f := range incident.targets {
+		if incident.notifiedUsers[f.UserId] {
+			continue
+		}
+		pending[f.UserId] = append(pending[f.UserId], f.TargetName)
+	}
+	for userId, names := range pending {
+		sort.Strings(names)
+		incident.notifiedUsers[userId] = true
+	}
+
+	return CorrelationResult{Cause: &cause, PendingUsers: pending, Affected: len(incident.targets)}
+}
+
+func (c *OutageCorrelator) failuresWith(cause InfrastructureCause) []CorrelatedFailure {
+	matching := make([]CorrelatedFailure, 0)
+	for _, tracked := range c.failures {
+		for _, candidate := range tracked.causes {
+			if candidate == cause {
+				matching = append(matching, tracked.failure)
+				break
+			}
+		}
+	}
+	return matching
+}
+
+// prune olvida las caídas fuera de la ventana (los incidentes abiertos siguen hasta que se recuperan)
+func (c *OutageCorrelator) prune(now time.Time) {
+	for id, tracked := range c.failures {
+		if now.Sub(tracked.failure.At) > c.window {
+			delete(c.failures, id)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHostingInfo_Causes(t *testing.T) {
+	info := NewHostingInfo([]string{"104.16.1.1"}, "Cloudflare", 13335, time.Now())
+
+	causes := info.Causes()
+	expected := []string{"ip:104.16.1.1", "asn:13335", "provider:Cloudflare"}
+	if len(causes) != len(expected) {
+		t.Fatalf("Expected %d causes, got %d", len(expected), len(causes))
+	}
+	for i, cause := range causes {
+		if cause.String() != expected[i] {
+			t.Errorf("Cause %d: expected %s, got %s", i, expected[i], cause.String())
+		}
+	}
+
+	// Sin proveedor conocido solo queda la IP
+	if got := len(NewHostingInfo([]string{"10.0.0.1"}, "", 0, time.Now()).Causes()); got != 1 {
+		t.Errorf("Expected only the IP cause, got %d", got)
+	}
+}
+
+func TestHostingInfo_NeedsRefresh(t *testing.T) {
+	now := time.Now()
+
+	if !(HostingInfo{}).NeedsRefresh(now) {
+		t.Error("Expected unresolved hosting to need refresh")
+	}
+	if NewHostingInfo(nil, "", 0, now.Add(-time.Hour)).NeedsRefresh(now) {
+		t.Error("Expected recent resolution to be fresh")
+	}
+	if !NewHostingInfo(nil, "", 0, now.Add(-HostingRefreshInterval)).NeedsRefresh(now) {
+		t.Error("Expected old resolution to need refresh")
+	}
+}
+
+func cloudflareFailure(id, user string, at time.Time) (CorrelatedFailure, HostingInfo) {
+	failure := CorrelatedFailure{TargetId: TargetId(id), TargetName: "site-" + id, UserId: user, At: at}
+	// IPs distintas: la causa común es el proveedor
+	return failure, NewHostingInfo([]string{"104.16.0." + id}, "Cloudflare", 13335, at)
+}
+
+func TestOutageCorrelator_OpensIncidentAtThreshold(t *testing.T) {
+	correlator := NewOutageCorrelator(CorrelationWindow, 3)
+	now := time.Now()
+
+	for i, id := range []string{"1", "2"} {
+		result := correlator.RecordFailure(cloudflareFailure(id, "user-a", now.Add(time.Duration(i)*time.Second)))
+		if result.Cause != nil {
+			t.Fatalf("Expected isolated failure %s, got cause %s", id, result.Cause)
+		}
+	}
+
+	result := correlator.RecordFailure(cloudflareFailure("3", "user-b", now.Add(2*time.Second)))
+	if result.Cause == nil {
+		t.Fatal("Expected an infrastructure incident on the third failure")
+	}
+	// ASN es más específico que proveedor y lo comparten los tres
+	if result.Cause.String() != "asn:13335" {
+		t.Errorf("Expected cause asn:13335, got %s", result.Cause)
+	}
+	if result.Affected != 3 {
+		t.Errorf("Expected 3 affected targets, got %d", result.Affected)
+	}
+	if len(result.PendingUsers["user-a"]) != 2 || len(result.PendingUsers["user-b"]) != 1 {
+		t.Errorf("Expected both users pending with their targets, got %v", result.PendingUsers)
+	}
+}
+
+func TestOutageCorrelator_NotifiesEachUserOnce(t *testing.T) {
+	correlator := NewOutageCorrelator(CorrelationWindow, 3)
+	now := time.Now()
+
+	for _, id := range []string{"1", "2", "3"} {
+		correlator.RecordFailure(cloudflareFailure(id, "user-a", now))
+	}
+
+	// Otro target del mismo usuario se suma al incidente sin re-notificar
+	result := correlator.RecordFailure(cloudflareFailure("4", "user-a", now.Add(time.Second)))
+	if result.Cause == nil {
+		t.Fatal("Expected the failure to join the open incident")
+	}
+	if len(result.PendingUsers) != 0 {
+		t.Errorf("Expected no pending users, got %v", result.PendingUsers)
+	}
+	if result.Affected != 4 {
+		t.Errorf("Expected 4 affected targets, got %d", result.Affected)
+	}
+
+	// Un usuario nuevo sí recibe el aviso
+	result = correlator.RecordFailure(cloudflareFailure("5", "user-b", now.Add(2*time.Second)))
+	if names := result.PendingUsers["user-b"]; len(names) != 1 || names[0] != "site-5" {
+		t.Errorf("Expected user-b pending with site-5, got %v", result.PendingUsers)
+	}
+}
+
+func TestOutageCorrelator_IgnoresFailuresOutsideWindow(t *testing.T) {
+	correlator := NewOutageCorrelator(CorrelationWindow, 3)
+	now := time.Now()
+
+	correlator.RecordFailure(cloudflareFailure("1", "user-a", now.Add(-2*CorrelationWindow)))
+	correlator.RecordFailure(cloudflareFailure("2", "user-a", now))
+
+	if result := correlator.RecordFailure(cloudflareFailure("3", "user-a", now)); result.Cause != nil {
+		t.Errorf("Expected no incident with a stale failure, got %s", result.Cause)
+	}
+}
+
+func TestOutageCorrelator_RecoveryClosesIncident(t *testing.T) {
+	correlator := NewOutageCorrelator(CorrelationWindow, 3)
+	now := time.Now()
+
+	for _, id := range []string{"1", "2", "3"} {
+		correlator.RecordFailure(cloudflareFailure(id, "user-a", now))
+	}
+	for _, id := range []string{"1", "2"} {
+		if resolved := correlator.RecordRecovery(TargetId(id)); len(resolved) != 0 {
+			t.Fatalf("Expected the incident open while targets are down, got %+v", resolved)
+		}
+	}
+	resolved := correlator.RecordRecovery(TargetId("3"))
+	if len(resolved) != 1 || resolved[0].Cause.String() != "asn:13335" || len(resolved[0].NotifiedUsers) != 1 || resolved[0].NotifiedUsers[0] != "user-a" {
+		t.Errorf("Expected the incident resolved for user-a with the last recovery, got %+v", resolved)
+	}
+
+	// Sin incidente abierto ni caídas recientes, una nueva caída es aislada
+	if result := correlator.RecordFailure(cloudflareFailure("4", "user-a", now.Add(time.Minute))); result.Cause != nil {
+		t.Errorf("Expected isolated failure after recovery, got %s", result.Cause)
+	}
+}
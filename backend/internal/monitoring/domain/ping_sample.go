@@ -0,0 +1,77 @@
+package domain
+
+import "time"
+
+// Entity: PingSample
+// Un ping individual de una sesión. Solo se guarda si el target lo habilitó (opt-in)
+// y vive poco: sirve para depurar por qué una sesión salió UNSTABLE o FLAPPING.
+type PingSample struct {
+	sessionId      SessionId
+	targetId       TargetId
+	sequence       int // Orden del ping dentro de la sesión (1..12)
+	timestamp      time.Time
+	responseTimeMs int
+	status         TargetStatus
+	errorMessage   string
+	errorClass     ErrorClass
+}
+
+// NewPingSample toma el resultado crudo de un ping de la sesión
+func NewPingSample(sessionId SessionId, sequence int, result *CheckResult) *PingSample {
+	return &PingSample{
+		sessionId:      sessionId,
+		targetId:       result.MonitoringTargetId(),
+		sequence:       sequence,
+		timestamp:      result.Timestamp(),
+		responseTimeMs: result.ResponseTimeMs(),
+		status:         result.Status(),
+		errorMessage:   result.ErrorMessage(),
+		errorClass:     result.ErrorClass(),
+	}
+}
+
+func NewFullPingSample(sessionId SessionId, targetId TargetId, sequence int, timestamp time.Time, responseTimeMs int, status TargetStatus, errorMessage string, errorClass ErrorClass) *PingSample {
+	return &PingSample{
+		sessionId:      sessionId,
+		targetId:       targetId,
+		sequence:       sequence,
+		timestamp:      timestamp,
+		responseTimeMs: responseTimeMs,
+		status:         status,
+		errorMessage:   errorMessage,
+		errorClass:     errorClass,
+	}
+}
+
+// Getters
+func (p *PingSample) SessionId() SessionId {
+	return p.sessionId
+}
+
+func (p *PingSample) TargetId() TargetId {
+	return p.targetId
+}
+
+func (p *PingSample) Sequence() int {
+	return p.sequence
+}
+
+func (p *PingSample) Timestamp() time.Time {
+	return p.timestamp
+}
+
+func (p *PingSample) ResponseTimeMs() int {
+	return p.responseTimeMs
+}
+
+func (p *PingSample) Status() TargetStatus {
+	return p.status
+}
+
+func (p *PingSample) ErrorMessage() string {
+	return p.errorMessage
+}
+
+func (p *PingSample) ErrorClass() ErrorClass {
+	return p.errorClass
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// TLSInfo - Value object con los datos del certificado presentado por el target
+type TLSInfo struct {
+	Version       string
+	Issuer        string
+	Subject       string
+	NotAfter      time.Time
+	DaysRemaining int
+}
+
+// ProbeReport - Resultado de una sesión de sondeo en seco (dry-run).
+// No se persiste: sirve para validar un target antes de crearlo.
+type ProbeReport struct {
+	Status            TargetStatus
+	Reachable         bool
+	AvgResponseTimeMs int
+	MaxResponseTimeMs int
+	PingCount         int
+	Stable            bool
+	HTTPStatusCode    int
+	TLS               *TLSInfo
+	AssertionFailures []string
+	ErrorMessage      string
+	ErrorClass        ErrorClass
+}
+
+// Passed indica si la sesión terminó en UP sin fallos de aserción
+func (r *ProbeReport) Passed() bool {
+	return r.Status == TargetStatusUp && len(r.AssertionFailures) == 0
+}
@@ -1,6 +1,9 @@
 package domain
 
-import userdomain "uptrackai/internal/user/domain"
+import (
+	"time"
+	userdomain "uptrackai/internal/user/domain"
+)
 
 // Repository interface
 type MonitoringTargetRepository interface {
@@ -13,16 +16,41 @@ type MonitoringTargetRepository interface {
 	GetDueTargets() ([]*MonitoringTarget, error)
 	Delete(id TargetId) error
 	ToggleActive(id TargetId, isActive bool) error
+	// UpdateHostingInfo solo toca las columnas de hosting (no pisa estado ni configuración)
+	UpdateHostingInfo(id TargetId, info HostingInfo) error
 }
 
 type CheckResultRepository interface {
 	Save(result *CheckResult) (*CheckResult, error)
 	GetByTargetID(targetId TargetId, limit int) ([]*CheckResult, error)
+	// GetByTargetIDBetween devuelve los cambios de estado en [from, to], del más viejo al más nuevo
+	GetByTargetIDBetween(targetId TargetId, from time.Time, to time.Time) ([]*CheckResult, error)
+	// GetLastBefore devuelve el último cambio de estado anterior a `at` (nil si no hay)
+	GetLastBefore(targetId TargetId, at time.Time) (*CheckResult, error)
+	// GetByTargetsBetween es GetByTargetIDBetween para varios targets en una sola consulta
+	GetByTargetsBetween(targetIds []TargetId, from time.Time, to time.Time) (map[TargetId][]*CheckResult, error)
+	// GetLastBeforeByTargets es GetLastBefore para varios targets (los que no tienen historial no aparecen)
+	GetLastBeforeByTargets(targetIds []TargetId, at time.Time) (map[TargetId]*CheckResult, error)
 }
 
 type MetricsRepository interface {
 	Save(result *CheckResult) error
 	GetByTargetID(targetId TargetId, limit int) ([]*CheckResult, error)
+	// CountWithinLatency cuenta las sesiones de [from, to) y cuántas fueron correctas y bajo el umbral
+	CountWithinLatency(targetId TargetId, from time.Time, to time.Time, thresholdMs int) (good int64, total int64, err error)
+	// GetByTargetIDBetween devuelve las sesiones de [from, to), de la más vieja a la más nueva
+	GetByTargetIDBetween(targetId TargetId, from time.Time, to time.Time) ([]*CheckResult, error)
+	// SummarizeLatency agrega las sesiones de [from, to) de cada target (los que no tienen sesiones no aparecen)
+	SummarizeLatency(targetIds []TargetId, from time.Time, to time.Time) (map[TargetId]LatencySummary, error)
+}
+
+type MetricRollupRepository interface {
+	// Rollup recalcula (upsert) los buckets de todos los targets que empiezan en [from, to)
+	Rollup(resolution MetricResolution, from time.Time, to time.Time) error
+	// LatestBucket devuelve el inicio del último bucket calculado (cero si aún no hay)
+	LatestBucket(resolution MetricResolution) (time.Time, error)
+	// ListByTarget devuelve los buckets de [from, to), del más viejo al más nuevo
+	ListByTarget(targetId TargetId, resolution MetricResolution, from time.Time, to time.Time) ([]MetricRollup, error)
 }
 
 type CheckConfigurationRepository interface {
@@ -32,4 +60,54 @@ type CheckConfigurationRepository interface {
 type TargetStatisticsRepository interface {
 	Get(targetId TargetId) (*TargetStatistics, error)
 	Save(stats *TargetStatistics) error
+	// GetByTargets devuelve las estadísticas existentes (no crea las que faltan)
+	GetByTargets(targetIds []TargetId) (map[TargetId]*TargetStatistics, error)
+}
+
+type SeasonalBaselineRepository interface {
+	GetByTargetID(targetId TargetId) (*SeasonalBaseline, error)
+	// RebuildFromMetrics recalcula todos los buckets a partir de las métricas desde `since`
+	RebuildFromMetrics(since time.Time) error
+}
+
+type PingSampleRepository interface {
+	SaveBatch(samples []*PingSample) error
+	GetBySession(targetId TargetId, sessionId SessionId) ([]*PingSample, error)
+}
+
+type IncidentRepository interface {
+	// Save crea o actualiza el incidente con su línea de tiempo (asigna ID si es nuevo)
+	Save(incident *Incident) error
+	GetByID(id IncidentId) (*Incident, error)
+	// GetActiveByTarget devuelve el incidente sin resolver del target o ErrIncidentNotFound
+	GetActiveByTarget(targetId TargetId) (*Incident, error)
+	List(filter IncidentFilter) ([]*Incident, error)
+}
+
+type MaintenanceWindowRepository interface {
+	// Save crea o actualiza la ventana (asigna ID si es nueva)
+	Save(window *MaintenanceWindow) error
+	GetByID(id MaintenanceWindowId) (*MaintenanceWindow, error)
+	Delete(id MaintenanceWindowId) error
+	// ListByTarget devuelve las ventanas que se superponen con [from, to); `to` cero = sin límite
+	ListByTarget(targetId TargetId, from time.Time, to time.Time) ([]*MaintenanceWindow, error)
+	// ListByTargets es ListByTarget para varios targets en una sola consulta
+	ListByTargets(targetIds []TargetId, from time.Time, to time.Time) (map[TargetId][]*MaintenanceWindow, error)
+}
+
+type MonitorRunRepository interface {
+	// Save crea o actualiza la ejecución (heartbeat)
+	Save(run *MonitorRun) error
+	// ListBetween devuelve las ejecuciones que tocan [from, to] más la última anterior a `from`
+	ListBetween(from time.Time, to time.Time) ([]*MonitorRun, error)
+}
+
+type SLORepository interface {
+	// Save crea o actualiza el SLO (asigna ID si es nuevo)
+	Save(slo *SLO) error
+	GetByID(id SLOId) (*SLO, error)
+	Delete(id SLOId) error
+	ListByTarget(targetId TargetId) ([]*SLO, error)
+	// List devuelve todos los SLOs (evaluación periódica)
+	List() ([]*SLO, error)
 }
@@ -0,0 +1,110 @@
+package domain
+
+import "time"
+
+const (
+	HoursPerWeek        = 168
+	SeasonalWindowWeeks = 4 // Semanas de métricas usadas para construir los buckets
+	MinSeasonalWeeks    = 2 // La misma hora debe haberse visto en al menos 2 semanas distintas
+	MinSeasonalSamples  = 6 // ...y con un mínimo de sesiones para que el promedio sea confiable
+)
+
+// HourOfWeek devuelve el bucket (0..167) de un instante, en UTC. 0 = domingo 00:00-00:59
+func HourOfWeek(at time.Time) int {
+	utc := at.UTC()
+	return int(utc.Weekday())*24 + utc.Hour()
+}
+
+// SeasonalBucket - Línea base de latencia para una hora concreta de la semana
+type SeasonalBucket struct {
+	hourOfWeek        int
+	avgResponseTimeMs int
+	p95ResponseTimeMs int
+	sampleCount       int
+	weekCount         int
+}
+
+func NewSeasonalBucket(hourOfWeek int, avgResponseTimeMs int, p95ResponseTimeMs int, sampleCount int, weekCount int) SeasonalBucket {
+	return SeasonalBucket{
+		hourOfWeek:        hourOfWeek,
+		avgResponseTimeMs: avgResponseTimeMs,
+		p95ResponseTimeMs: p95ResponseTimeMs,
+		sampleCount:       sampleCount,
+		weekCount:         weekCount,
+	}
+}
+
+// Getters
+func (b SeasonalBucket) HourOfWeek() int {
+	return b.hourOfWeek
+}
+
+func (b SeasonalBucket) AvgResponseTimeMs() int {
+	return b.avgResponseTimeMs
+}
+
+func (b SeasonalBucket) P95ResponseTimeMs() int {
+	return b.p95ResponseTimeMs
+}
+
+func (b SeasonalBucket) SampleCount() int {
+	return b.sampleCount
+}
+
+func (b SeasonalBucket) WeekCount() int {
+	return b.weekCount
+}
+
+// IsReady indica si el bucket ya aprendió lo suficiente para reemplazar a la línea base global
+func (b SeasonalBucket) IsReady() bool {
+	return b.weekCount >= MinSeasonalWeeks && b.sampleCount >= MinSeasonalSamples
+}
+
+// SeasonalBaseline - Líneas base por hora de la semana de un target.
+// Los servicios son legítimamente más lentos el lunes a las 9am que el domingo a las 3am:
+// comparar contra el bucket de la misma hora evita falsos DEGRADED en los picos.
+type SeasonalBaseline struct {
+	targetId TargetId
+	buckets  map[int]SeasonalBucket
+}
+
+func NewSeasonalBaseline(targetId TargetId, buckets []SeasonalBucket) *SeasonalBaseline {
+	byHour := make(map[int]SeasonalBucket, len(buckets))
+	for _, b := range buckets {
+		byHour[b.hourOfWeek] = b
+	}
+	return &SeasonalBaseline{targetId: targetId, buckets: byHour}
+}
+
+func (s *SeasonalBaseline) TargetId() TargetId {
+	return s.targetId
+}
+
+// ReadyBuckets cuenta cuántas horas de la semana ya tienen línea base propia
+func (s *SeasonalBaseline) ReadyBuckets() int {
+	ready := 0
+	for _, b := range s.buckets {
+		if b.IsReady() {
+			ready++
+		}
+	}
+	return ready
+}
+
+// BaselineAt devuelve la línea base estacional para el instante dado.
+// ok = false mientras el bucket sigue aprendiendo: el llamador debe usar la línea base global.
+func (s *SeasonalBaseline) BaselineAt(at time.Time, metric BaselineMetric) (baselineMs int, ok bool) {
+	if s == nil {
+		return 0, false
+	}
+
+	bucket, exists := s.buckets[HourOfWeek(at)]
+	if !exists || !bucket.IsReady() {
+		return 0, false
+	}
+
+	if metric == BaselineMetricP95 {
+		return bucket.p95ResponseTimeMs, bucket.p95ResponseTimeMs > 0
+	}
+	return bucket.avgResponseTimeMs, bucket.avgResponseTimeMs > 0
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHourOfWeek(t *testing.T) {
+	sunday3am := time.Date(2025, 1, 5, 3, 30, 0, 0, time.UTC)
+	monday9am := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	saturdayLast := time.Date(2025, 1, 11, 23, 59, 0, 0, time.UTC)
+
+	if got := HourOfWeek(sunday3am); got != 3 {
+		t.Errorf("Expected Sunday 03:30 -> 3, got %d", got)
+	}
+	if got := HourOfWeek(monday9am); got != 33 {
+		t.Errorf("Expected Monday 09:00 -> 33, got %d", got)
+	}
+	if got := HourOfWeek(saturdayLast); got != HoursPerWeek-1 {
+		t.Errorf("Expected Saturday 23:59 -> %d, got %d", HoursPerWeek-1, got)
+	}
+
+	// Se normaliza a UTC
+	bogota := time.FixedZone("COT", -5*3600)
+	if got := HourOfWeek(time.Date(2025, 1, 6, 4, 0, 0, 0, bogota)); got != 33 {
+		t.Errorf("Expected Monday 04:00 COT -> 33 (09:00 UTC), got %d", got)
+	}
+}
+
+func TestSeasonalBaseline_FallsBackWhileLearning(t *testing.T) {
+	targetId, _ := NewTargetId("test-target-123")
+	monday9am := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+	learning := NewSeasonalBaseline(targetId, []SeasonalBucket{
+		NewSeasonalBucket(HourOfWeek(monday9am), 800, 1200, 12, 1), // una sola semana observada
+	})
+
+	if _, ok := learning.BaselineAt(monday9am, BaselineMetricMean); ok {
+		t.Error("Expected bucket seen in a single week not to be used yet")
+	}
+	if learning.ReadyBuckets() != 0 {
+		t.Errorf("Expected 0 ready buckets, got %d", learning.ReadyBuckets())
+	}
+
+	var missing *SeasonalBaseline
+	if _, ok := missing.BaselineAt(monday9am, BaselineMetricMean); ok {
+		t.Error("Expected nil baseline to fall back")
+	}
+}
+
+func TestSeasonalBaseline_UsesMatchingBucket(t *testing.T) {
+	targetId, _ := NewTargetId("test-target-123")
+	monday9am := time.Date(2025, 1, 6, 9, 15, 0, 0, time.UTC)
+	sunday3am := time.Date(2025, 1, 5, 3, 0, 0, 0, time.UTC)
+
+	baseline := NewSeasonalBaseline(targetId, []SeasonalBucket{
+		NewSeasonalBucket(HourOfWeek(monday9am), 800, 1200, 24, 3),
+		NewSeasonalBucket(HourOfWeek(sunday3am), 150, 210, 24, 3),
+	})
+
+	if got, ok := baseline.BaselineAt(monday9am, BaselineMetricMean); !ok || got != 800 {
+		t.Errorf("Expected Monday 9am mean 800, got %d (ok=%v)", got, ok)
+	}
+	if got, ok := baseline.BaselineAt(monday9am, BaselineMetricP95); !ok || got != 1200 {
+		t.Errorf("Expected Monday 9am p95 1200, got %d (ok=%v)", got, ok)
+	}
+	if got, ok := baseline.BaselineAt(sunday3am, BaselineMetricMean); !ok || got != 150 {
+		t.Errorf("Expected Sunday 3am mean 150, got %d (ok=%v)", got, ok)
+	}
+	if _, ok := baseline.BaselineAt(sunday3am.Add(2*time.Hour), BaselineMetricMean); ok {
+		t.Error("Expected hour without bucket to fall back")
+	}
+}
@@ -1,3 +1,151 @@
 package domain
 
+import "time"
+
 // Domain Services
+
+// SLIMeter mide disponibilidad y latencia de un target leyendo sus cambios de estado,
+// métricas, mantenimientos y las ejecuciones del monitor. Lo comparten la API y el scheduler.
+type SLIMeter struct {
+	checkRepo   CheckResultRepository
+	metricsRepo MetricsRepository
+	maintenance MaintenanceWindowRepository
+	runRepo     MonitorRunRepository
+}
+
+func NewSLIMeter(checkRepo CheckResultRepository, metricsRepo MetricsRepository, maintenance MaintenanceWindowRepository, runRepo MonitorRunRepository) *SLIMeter {
+	return &SLIMeter{
+		checkRepo:   checkRepo,
+		metricsRepo: metricsRepo,
+		maintenance: maintenance,
+		runRepo:     runRepo,
+	}
+}
+
+// SelfDowntime devuelve los huecos del monitor en el rango (iguales para todos los targets)
+func (m *SLIMeter) SelfDowntime(span TimeRange) ([]TimeRange, error) {
+	runs, err := m.runRepo.ListBetween(span.Start, span.End)
+	if err != nil {
+		return nil, err
+	}
+	return SelfDowntime(runs, span, SelfDowntimeTolerance), nil
+}
+
+// Availability reparte cada ventana leyendo el historial del target una sola vez
+func (m *SLIMeter) Availability(target *MonitoringTarget, windows []TimeRange, selfDown []TimeRange) ([]AvailabilityReport, error) {
+	span := CoveringRange(windows)
+
+	// El estado vigente al inicio lo da el último cambio anterior al rango
+	previous, err := m.checkRepo.GetLastBefore(target.ID(), span.Start)
+	if err != nil {
+		return nil, err
+	}
+	results, err := m.checkRepo.GetByTargetIDBetween(target.ID(), span.Start, span.End)
+	if err != nil {
+		return nil, err
+	}
+	transitions := TransitionsOf(previous, results)
+
+	maintenance, err := m.maintenance.ListByTarget(target.ID(), span.Start, span.End)
+	if err != nil {
+		return nil, err
+	}
+	return availabilityReports(target, transitions, maintenance, windows, selfDown), nil
+}
+
+// AvailabilityByTargets es Availability para varios targets: el historial y los mantenimientos
+// de todos se leen con una consulta cada uno, sin importar cuántos targets sean
+func (m *SLIMeter) AvailabilityByTargets(targets []*MonitoringTarget, windows []TimeRange, selfDown []TimeRange) (map[TargetId][]AvailabilityReport, error) {
+	reports := make(map[TargetId][]AvailabilityReport, len(targets))
+	if len(targets) == 0 {
+		return reports, nil
+	}
+
+	span := CoveringRange(windows)
+	ids := make([]TargetId, 0, len(targets))
+	for _, target := range targets {
+		ids = append(ids, target.ID())
+	}
+	previous, err := m.checkRepo.GetLastBeforeByTargets(ids, span.Start)
+	if err != nil {
+		return nil, err
+	}
+	results, err := m.checkRepo.GetByTargetsBetween(ids, span.Start, span.End)
+	if err != nil {
+		return nil, err
+	}
+	maintenance, err := m.maintenance.ListByTargets(ids, span.Start, span.End)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, target := range targets {
+		transitions := TransitionsOf(previous[target.ID()], results[target.ID()])
+		reports[target.ID()] = availabilityReports(target, transitions, maintenance[target.ID()], windows, selfDown)
+	}
+	return reports, nil
+}
+
+// availabilityReports reparte cada ventana con el historial ya leído del target
+func availabilityReports(target *MonitoringTarget, transitions []StatusTransition, maintenance []*MaintenanceWindow, windows []TimeRange, selfDown []TimeRange) []AvailabilityReport {
+	history := NewStatusHistory(transitions)
+	exclusions := AvailabilityExclusions{
+		Maintenance:  MaintenanceRanges(maintenance),
+		SelfDowntime: selfDown,
+	}
+	policy := target.Configuration().AvailabilityPolicy()
+
+	reports := make([]AvailabilityReport, 0, len(windows))
+	for _, window := range windows {
+		reports = append(reports, history.Availability(window, exclusions, policy))
+	}
+	return reports
+}
+
+// MeasureSLO devuelve el SLI de cada ventana de evaluación del SLO, terminando en `now`
+func (m *SLIMeter) MeasureSLO(slo *SLO, target *MonitoringTarget, now time.Time, selfDown []TimeRange) (map[time.Duration]SLIMeasurement, error) {
+	durations := slo.EvaluationWindows()
+	measurements := make(map[time.Duration]SLIMeasurement, len(durations))
+
+	switch slo.Kind() {
+	case SLOKindAvailability:
+		windows := make([]TimeRange, 0, len(durations))
+		for _, d := range durations {
+			windows = append(windows, TimeRange{Start: now.Add(-d), End: now})
+		}
+		reports, err := m.Availability(target, windows, selfDown)
+		if err != nil {
+			return nil, err
+		}
+		for i, report := range reports {
+			measurements[durations[i]] = SLIMeasurement{
+				Good:  report.Available.Seconds(),
+				Total: report.Measured().Seconds(),
+			}
+		}
+	case SLOKindLatency:
+		for _, d := range durations {
+			good, total, err := m.metricsRepo.CountWithinLatency(target.ID(), now.Add(-d), now, slo.LatencyThresholdMs())
+			if err != nil {
+				return nil, err
+			}
+			measurements[d] = SLIMeasurement{Good: float64(good), Total: float64(total)}
+		}
+	}
+
+	return measurements, nil
+}
+
+// CoveringRange es el rango mínimo que contiene todas las ventanas
+func CoveringRange(windows []TimeRange) TimeRange {
+	span := windows[0]
+	for _, w := range windows[1:] {
+		if w.Start.Before(span.Start) {
+			span.Start = w.Start
+		}
+		if w.End.After(span.End) {
+			span.End = w.End
+		}
+	}
+	return span
+}
@@ -0,0 +1,300 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	userdomain "uptrackai/internal/user/domain"
+)
+
+const (
+	MaxSLOWindowDays = 90 // Mismo horizonte que el uptime: más atrás no hay garantía de datos
+)
+
+// Value Object: SLOId
+type SLOId string
+
+func NewSLOId(value string) (SLOId, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", ErrSLOIdEmpty
+	}
+	return SLOId(value), nil
+}
+
+func (s SLOId) String() string {
+	return string(s)
+}
+
+// Enum: SLOKind
+type SLOKind string
+
+const (
+	SLOKindAvailability SLOKind = "AVAILABILITY" // % del tiempo medido en un estado disponible
+	SLOKindLatency      SLOKind = "LATENCY"      // % de sesiones correctas bajo el umbral de latencia
+)
+
+func NewSLOKind(value string) (SLOKind, error) {
+	kind := SLOKind(strings.ToUpper(strings.TrimSpace(value)))
+	switch kind {
+	case SLOKindAvailability, SLOKindLatency:
+		return kind, nil
+	}
+	return "", ErrInvalidSLOKind
+}
+
+func (k SLOKind) String() string {
+	return string(k)
+}
+
+// Enum: BurnAlertLevel
+type BurnAlertLevel string
+
+const (
+	BurnAlertNone BurnAlertLevel = "NONE"
+	BurnAlertSlow BurnAlertLevel = "SLOW" // Consumo sostenido: se agota el presupuesto en días
+	BurnAlertFast BurnAlertLevel = "FAST" // Consumo agudo: se agota el presupuesto en horas
+)
+
+func (l BurnAlertLevel) String() string {
+	return string(l)
+}
+
+// BurnRateRule - Alerta multi-ventana: dispara si ambas ventanas queman por encima del umbral.
+// La ventana corta hace que la alerta se apague rápido cuando el problema se corta.
+type BurnRateRule struct {
+	Level       BurnAlertLevel
+	LongWindow  time.Duration
+	ShortWindow time.Duration
+	BudgetSpent float64 // Fracción del presupuesto total consumida en LongWindow
+}
+
+// BurnRateRules - Ordenadas de mayor a menor gravedad
+var BurnRateRules = []BurnRateRule{
+	{Level: BurnAlertFast, LongWindow: time.Hour, ShortWindow: 5 * time.Minute, BudgetSpent: 0.02},
+	{Level: BurnAlertSlow, LongWindow: 6 * time.Hour, ShortWindow: 30 * time.Minute, BudgetSpent: 0.05},
+}
+
+// Threshold es la tasa de quemado equivalente para la ventana del SLO (14.4 y 6 con 30 días)
+func (r BurnRateRule) Threshold(sloWindow time.Duration) float64 {
+	return r.BudgetSpent * sloWindow.Hours() / r.LongWindow.Hours()
+}
+
+// SLIMeasurement - Eventos buenos sobre totales (segundos disponibles o sesiones bajo el umbral)
+type SLIMeasurement struct {
+	Good  float64
+	Total float64
+}
+
+func (m SLIMeasurement) HasData() bool {
+	return m.Total > 0
+}
+
+// ErrorRate devuelve 0 sin datos: una ventana vacía no quema presupuesto
+func (m SLIMeasurement) ErrorRate() float64 {
+	if !m.HasData() {
+		return 0
+	}
+	return (m.Total - m.Good) / m.Total
+}
+
+// Entity: SLO
+type SLO struct {
+	id                 SLOId
+	targetId           TargetId
+	userId             userdomain.UserId
+	name               string
+	kind               SLOKind
+	objective          float64 // Porcentaje, ej: 99.9
+	windowDays         int
+	latencyThresholdMs int // Solo LATENCY
+	alertLevel         BurnAlertLevel
+	alertChangedAt     time.Time
+	createdAt          time.Time
+}
+
+func NewSLO(targetId TargetId, userId userdomain.UserId, name string, kind SLOKind, objective float64, windowDays int, latencyThresholdMs int, now time.Time) (*SLO, error) {
+	if objective <= 0 || objective >= 100 {
+		return nil, ErrInvalidSLOObjective
+	}
+	if windowDays < 1 || windowDays > MaxSLOWindowDays {
+		return nil, ErrInvalidSLOWindow
+	}
+	switch kind {
+	case SLOKindLatency:
+		if latencyThresholdMs <= 0 {
+			return nil, ErrInvalidSLOLatencyThreshold
+		}
+	case SLOKindAvailability:
+		latencyThresholdMs = 0
+	default:
+		return nil, ErrInvalidSLOKind
+	}
+
+	return &SLO{
+		targetId:           targetId,
+		userId:             userId,
+		name:               strings.TrimSpace(name),
+		kind:               kind,
+		objective:          objective,
+		windowDays:         windowDays,
+		latencyThresholdMs: latencyThresholdMs,
+		alertLevel:         BurnAlertNone,
+		createdAt:          now,
+	}, nil
+}
+
+// NewFullSLO reconstruye un SLO persistido
+func NewFullSLO(id SLOId, targetId TargetId, userId userdomain.UserId, name string, kind SLOKind, objective float64, windowDays int, latencyThresholdMs int, alertLevel BurnAlertLevel, alertChangedAt time.Time, createdAt time.Time) *SLO {
+	if alertLevel == "" {
+		alertLevel = BurnAlertNone
+	}
+	return &SLO{
+		id:                 id,
+		targetId:           targetId,
+		userId:             userId,
+		name:               name,
+		kind:               kind,
+		objective:          objective,
+		windowDays:         windowDays,
+		latencyThresholdMs: latencyThresholdMs,
+		alertLevel:         alertLevel,
+		alertChangedAt:     alertChangedAt,
+		createdAt:          createdAt,
+	}
+}
+
+func (s *SLO) AssignId(id SLOId) error {
+	if s.id != "" {
+		return ErrSLOAlreadyHasId
+	}
+	s.id = id
+	return nil
+}
+
+// Getters
+func (s *SLO) ID() SLOId {
+	return s.id
+}
+
+func (s *SLO) TargetId() TargetId {
+	return s.targetId
+}
+
+func (s *SLO) UserId() userdomain.UserId {
+	return s.userId
+}
+
+func (s *SLO) Name() string {
+	return s.name
+}
+
+func (s *SLO) Kind() SLOKind {
+	return s.kind
+}
+
+func (s *SLO) Objective() float64 {
+	return s.objective
+}
+
+func (s *SLO) WindowDays() int {
+	return s.windowDays
+}
+
+func (s *SLO) LatencyThresholdMs() int {
+	return s.latencyThresholdMs
+}
+
+func (s *SLO) AlertLevel() BurnAlertLevel {
+	return s.alertLevel
+}
+
+func (s *SLO) AlertChangedAt() time.Time {
+	return s.alertChangedAt
+}
+
+func (s *SLO) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s *SLO) Window() time.Duration {
+	return time.Duration(s.windowDays) * 24 * time.Hour
+}
+
+// ErrorBudget es la fracción de eventos que puede fallar (0.001 para 99.9%)
+func (s *SLO) ErrorBudget() float64 {
+	return 1 - s.objective/100
+}
+
+// BurnRate: 1 = el presupuesto se agota justo al final de la ventana
+func (s *SLO) BurnRate(m SLIMeasurement) float64 {
+	return m.ErrorRate() / s.ErrorBudget()
+}
+
+// EvaluationWindows son las ventanas a medir: la del SLO y las de cada regla de quemado
+func (s *SLO) EvaluationWindows() []time.Duration {
+	windows := []time.Duration{s.Window()}
+	for _, rule := range BurnRateRules {
+		windows = append(windows, rule.LongWindow, rule.ShortWindow)
+	}
+	return windows
+}
+
+// UpdateAlertLevel registra el nivel de alerta evaluado; devuelve true si cambió
+func (s *SLO) UpdateAlertLevel(level BurnAlertLevel, at time.Time) bool {
+	if level == s.alertLevel {
+		return false
+	}
+	s.alertLevel = level
+	s.alertChangedAt = at
+	return true
+}
+
+// BurnRateReading - Tasa de quemado de una ventana
+type BurnRateReading struct {
+	Window  time.Duration
+	Rate    float64
+	HasData bool
+}
+
+// SLOStatus - Estado del SLO en un instante
+type SLOStatus struct {
+	SLI             float64 // Porcentaje de eventos buenos en la ventana del SLO
+	HasData         bool
+	BudgetRemaining float64 // Fracción del presupuesto sin consumir (negativa = SLO incumplido)
+	BurnRates       []BurnRateReading
+	AlertLevel      BurnAlertLevel
+}
+
+// EvaluateSLO calcula presupuesto restante, tasas de quemado y nivel de alerta.
+// `measurements` trae una medición por cada ventana de EvaluationWindows.
+func EvaluateSLO(slo *SLO, measurements map[time.Duration]SLIMeasurement) SLOStatus {
+	status := SLOStatus{BudgetRemaining: 1, AlertLevel: BurnAlertNone}
+
+	full := measurements[slo.Window()]
+	if full.HasData() {
+		status.HasData = true
+		status.SLI = full.Good / full.Total * 100
+		status.BudgetRemaining = 1 - slo.BurnRate(full)
+	}
+
+	seen := make(map[time.Duration]bool)
+	for _, rule := range BurnRateRules {
+		for _, window := range []time.Duration{rule.LongWindow, rule.ShortWindow} {
+			if seen[window] {
+				continue
+			}
+			seen[window] = true
+			m := measurements[window]
+			status.BurnRates = append(status.BurnRates, BurnRateReading{Window: window, Rate: slo.BurnRate(m), HasData: m.HasData()})
+		}
+	}
+
+	for _, rule := range BurnRateRules {
+		threshold := rule.Threshold(slo.Window())
+		if slo.BurnRate(measurements[rule.LongWindow]) > threshold && slo.BurnRate(measurements[rule.ShortWindow]) > threshold {
+			status.AlertLevel = rule.Level
+			break
+		}
+	}
+
+	return status
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestNewSLO_Validation(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name      string
+		kind      SLOKind
+		objective float64
+		window    int
+		latency   int
+		want      error
+	}{
+		{"objective 100", SLOKindAvailability, 100, 30, 0, ErrInvalidSLOObjective},
+		{"objective 0", SLOKindAvailability, 0, 30, 0, ErrInvalidSLOObjective},
+		{"window too long", SLOKindAvailability, 99.9, MaxSLOWindowDays + 1, 0, ErrInvalidSLOWindow},
+		{"latency without threshold", SLOKindLatency, 95, 30, 0, ErrInvalidSLOLatencyThreshold},
+		{"unknown kind", SLOKind("ERRORS"), 99, 30, 0, ErrInvalidSLOKind},
+		{"valid latency", SLOKindLatency, 95, 30, 500, nil},
+	}
+	for _, tc := range cases {
+		_, err := NewSLO("target-1", "user-1", "slo", tc.kind, tc.objective, tc.window, tc.latency, now)
+		if err != tc.want {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+
+	// El umbral de latencia no aplica a disponibilidad
+	slo, _ := NewSLO("target-1", "user-1", "slo", SLOKindAvailability, 99.9, 30, 500, now)
+	if slo.LatencyThresholdMs() != 0 || slo.AlertLevel() != BurnAlertNone {
+		t.Errorf("Expected no latency threshold and NONE level, got %d / %s", slo.LatencyThresholdMs(), slo.AlertLevel())
+	}
+}
+
+func TestBurnRateRule_Threshold(t *testing.T) {
+	window := 30 * 24 * time.Hour
+	if got := BurnRateRules[0].Threshold(window); math.Abs(got-14.4) > 1e-9 {
+		t.Errorf("Expected fast threshold 14.4, got %f", got)
+	}
+	if got := BurnRateRules[1].Threshold(window); math.Abs(got-6) > 1e-9 {
+		t.Errorf("Expected slow threshold 6, got %f", got)
+	}
+}
+
+func TestEvaluateSLO(t *testing.T) {
+	slo, _ := NewSLO("target-1", "user-1", "", SLOKindAvailability, 99.9, 30, 0, time.Now())
+	healthy := SLIMeasurement{Good: 1000, Total: 1000}
+	burning := func(rate float64) SLIMeasurement {
+		// rate veces el presupuesto (0.1%) en errores
+		return SLIMeasurement{Good: 1000 - rate, Total: 1000}
+	}
+
+	cases := []struct {
+		name         string
+		measurements map[time.Duration]SLIMeasurement
+		want         BurnAlertLevel
+	}{
+		{"healthy", map[time.Duration]SLIMeasurement{
+			slo.Window(): healthy, time.Hour: healthy, 5 * time.Minute: healthy, 6 * time.Hour: healthy, 30 * time.Minute: healthy,
+		}, BurnAlertNone},
+		{"fast burn", map[time.Duration]SLIMeasurement{
+			slo.Window(): burning(1), time.Hour: burning(20), 5 * time.Minute: burning(20), 6 * time.Hour: burning(4), 30 * time.Minute: burning(20),
+		}, BurnAlertFast},
+		// Ya no quema en la ventana corta: la alerta rápida se apaga
+		{"fast burn recovered", map[time.Duration]SLIMeasurement{
+			slo.Window(): burning(1), time.Hour: burning(20), 5 * time.Minute: healthy, 6 * time.Hour: burning(4), 30 * time.Minute: healthy,
+		}, BurnAlertNone},
+		{"slow burn", map[time.Duration]SLIMeasurement{
+			slo.Window(): burning(1), time.Hour: burning(8), 5 * time.Minute: burning(8), 6 * time.Hour: burning(8), 30 * time.Minute: burning(8),
+		}, BurnAlertSlow},
+	}
+	for _, tc := range cases {
+		status := EvaluateSLO(slo, tc.measurements)
+		if status.AlertLevel != tc.want {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.want, status.AlertLevel)
+		}
+	}
+
+	status := EvaluateSLO(slo, map[time.Duration]SLIMeasurement{slo.Window(): burning(0.5)})
+	if !status.HasData || math.Abs(status.BudgetRemaining-0.5) > 1e-9 {
+		t.Errorf("Expected half the budget remaining, got %f", status.BudgetRemaining)
+	}
+	if len(status.BurnRates) != 4 {
+		t.Errorf("Expected 4 burn rate windows, got %d", len(status.BurnRates))
+	}
+	if empty := EvaluateSLO(slo, nil); empty.HasData || empty.BudgetRemaining != 1 {
+		t.Errorf("Expected a full budget without data, got %+v", empty)
+	}
+}
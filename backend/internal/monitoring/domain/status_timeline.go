@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+// StatusBucket - Tramo de la línea de tiempo de estados de un target
+type StatusBucket struct {
+	Start   time.Time
+	Status  TargetStatus // El peor estado vigente en algún momento del tramo
+	Changes int          // Cambios de estado registrados dentro del tramo
+}
+
+// statusWeight ordena los estados de mejor a peor para elegir el representante de un tramo
+func statusWeight(status TargetStatus) int {
+	switch status {
+	case TargetStatusDown:
+		return 5
+	case TargetStatusFlapping:
+		return 4
+	case TargetStatusUnstable:
+		return 3
+	case TargetStatusDegraded:
+		return 2
+	case TargetStatusUp:
+		return 1
+	}
+	return 0
+}
+
+// BucketStatusHistory reparte los cambios de estado (ordenados) en tramos de `step` dentro de la ventana.
+// `initial` es el estado vigente al comenzar el primer tramo (UNKNOWN si no hay registro previo).
+func BucketStatusHistory(initial TargetStatus, changes []*CheckResult, window TimeRange, step time.Duration) []StatusBucket {
+	buckets := make([]StatusBucket, 0)
+	current := initial
+	next := 0
+
+	// Los cambios anteriores al primer tramo solo definen el estado de arranque
+	first := StepBucketStart(window.Start, step)
+	for next < len(changes) && changes[next].Timestamp().Before(first) {
+		current = changes[next].Status()
+		next++
+	}
+
+	for start := first; start.Before(window.End); start = start.Add(step) {
+		end := start.Add(step)
+		bucket := StatusBucket{Start: start, Status: current}
+		for next < len(changes) && changes[next].Timestamp().Before(end) {
+			current = changes[next].Status()
+			if statusWeight(current) > statusWeight(bucket.Status) {
+				bucket.Status = current
+			}
+			bucket.Changes++
+			next++
+		}
+		buckets = append(buckets, bucket)
+	}
+	return buckets
+}
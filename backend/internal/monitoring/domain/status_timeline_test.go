@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBucketStatusHistory(t *testing.T) {
+	base := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	targetId := TargetId("target-123")
+	changes := []*CheckResult{
+		NewFullCheckResult("c-1", targetId, base.Add(10*time.Minute), 0, false, TargetStatusDown, ""),
+		NewFullCheckResult("c-2", targetId, base.Add(20*time.Minute), 150, true, TargetStatusUp, ""),
+		NewFullCheckResult("c-3", targetId, base.Add(150*time.Minute), 900, true, TargetStatusDegraded, ""),
+	}
+	window := TimeRange{Start: base.Add(5 * time.Minute), End: base.Add(3 * time.Hour)}
+
+	buckets := BucketStatusHistory(TargetStatusUp, changes, window, time.Hour)
+
+	if len(buckets) != 3 {
+		t.Fatalf("Expected 3 hourly buckets, got %d", len(buckets))
+	}
+	if !buckets[0].Start.Equal(base) || buckets[0].Status != TargetStatusDown || buckets[0].Changes != 2 {
+		t.Errorf("Expected the short outage to mark the first hour DOWN, got %+v", buckets[0])
+	}
+	if buckets[1].Status != TargetStatusUp || buckets[1].Changes != 0 {
+		t.Errorf("Expected a quiet UP hour, got %+v", buckets[1])
+	}
+	if buckets[2].Status != TargetStatusDegraded || buckets[2].Changes != 1 {
+		t.Errorf("Expected the last hour DEGRADED, got %+v", buckets[2])
+	}
+}
+
+func TestBucketStatusHistory_UnknownStart(t *testing.T) {
+	base := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	window := TimeRange{Start: base, End: base.Add(30 * time.Minute)}
+
+	buckets := BucketStatusHistory(TargetStatusUnknown, nil, window, 15*time.Minute)
+
+	if len(buckets) != 2 || buckets[0].Status != TargetStatusUnknown || buckets[1].Status != TargetStatusUnknown {
+		t.Errorf("Expected UNKNOWN buckets without history, got %+v", buckets)
+	}
+}
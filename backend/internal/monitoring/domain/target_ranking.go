@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Enum: RankingMetric - Criterio con el que se ordena el reporte comparativo de targets
+type RankingMetric string
+
+const (
+	RankByAvailability RankingMetric = "availability"
+	RankByP95          RankingMetric = "p95"
+	RankByIncidents    RankingMetric = "incidents"
+	RankByMTTR         RankingMetric = "mttr"
+	RankByLatencyTrend RankingMetric = "trend"
+)
+
+// NewRankingMetric interpreta el criterio pedido (vacío = availability)
+func NewRankingMetric(value string) (RankingMetric, error) {
+	metric := RankingMetric(strings.ToLower(strings.TrimSpace(value)))
+	switch metric {
+	case "":
+		return RankByAvailability, nil
+	case RankByAvailability, RankByP95, RankByIncidents, RankByMTTR, RankByLatencyTrend:
+		return metric, nil
+	}
+	return "", ErrInvalidRankingMetric
+}
+
+func (m RankingMetric) String() string {
+	return string(m)
+}
+
+// LatencySummary - Latencia de un target en un rango, agregada en SQL sobre la tabla metrics.
+// Avg y p95 solo cuentan sesiones correctas (misma regla que los rollups).
+type LatencySummary struct {
+	Sessions          int64
+	Failures          int64
+	AvgResponseTimeMs float64
+	P95ResponseTimeMs int
+}
+
+func (l LatencySummary) HealthySessions() int64 {
+	return l.Sessions - l.Failures
+}
+
+// OutageSummary - Caídas (entradas a DOWN) que empezaron en una ventana y cuánto tardaron en recuperarse
+type OutageSummary struct {
+	Incidents     int
+	Resolved      int
+	TotalRecovery time.Duration
+}
+
+// MTTR es el tiempo medio de recuperación de las caídas resueltas dentro de la ventana
+func (o OutageSummary) MTTR() (time.Duration, bool) {
+	if o.Resolved == 0 {
+		return 0, false
+	}
+	return o.TotalRecovery / time.Duration(o.Resolved), true
+}
+
+// TransitionsOf arma la secuencia de estados a partir del último cambio previo (puede ser nil) y los del rango
+func TransitionsOf(previous *CheckResult, results []*CheckResult) []StatusTransition {
+	transitions := make([]StatusTransition, 0, len(results)+1)
+	if previous != nil {
+		transitions = append(transitions, StatusTransition{At: previous.Timestamp(), Status: previous.Status()})
+	}
+	for _, result := range results {
+		transitions = append(transitions, StatusTransition{At: result.Timestamp(), Status: result.Status()})
+	}
+	return transitions
+}
+
+// SummarizeOutages recorre los cambios de estado (ordenados) y mide las caídas que empezaron en la ventana.
+// Una caída que sigue abierta al final de la ventana cuenta como incidente pero no entra en el MTTR.
+func SummarizeOutages(transitions []StatusTransition, window TimeRange) OutageSummary {
+	var summary OutageSummary
+	var downSince *time.Time
+	previous := TargetStatusUnknown
+
+	for _, transition := range transitions {
+		wentDown := transition.Status == TargetStatusDown && previous != TargetStatusDown
+		recovered := transition.Status != TargetStatusDown && previous == TargetStatusDown
+		previous = transition.Status
+
+		switch {
+		case wentDown && window.Contains(transition.At):
+			at := transition.At
+			downSince = &at
+			summary.Incidents++
+		case recovered && downSince != nil:
+			summary.Resolved++
+			summary.TotalRecovery += transition.At.Sub(*downSince)
+			downSince = nil
+		}
+	}
+	return summary
+}
+
+// TargetRanking - Fila del reporte comparativo de un target en la ventana
+type TargetRanking struct {
+	Rank         int
+	Target       *MonitoringTarget
+	Availability AvailabilityReport
+	Latency      LatencySummary
+	Outages      OutageSummary
+	// BaselineResponseTimeMs es el promedio histórico de target_statistics (0 = sin historial)
+	BaselineResponseTimeMs int
+}
+
+// LatencyTrendPercent compara la latencia media de la ventana con el promedio histórico del target
+// (positivo = empeoró)
+func (r TargetRanking) LatencyTrendPercent() (float64, bool) {
+	if r.BaselineResponseTimeMs <= 0 || r.Latency.HealthySessions() <= 0 {
+		return 0, false
+	}
+	baseline := float64(r.BaselineResponseTimeMs)
+	return (r.Latency.AvgResponseTimeMs - baseline) / baseline * 100, true
+}
+
+// badness devuelve el valor por el que se ordena: mayor = peor. false si el target no tiene el dato.
+func (r TargetRanking) badness(metric RankingMetric) (float64, bool) {
+	switch metric {
+	case RankByP95:
+		return float64(r.Latency.P95ResponseTimeMs), r.Latency.HealthySessions() > 0
+	case RankByIncidents:
+		return float64(r.Outages.Incidents), true
+	case RankByMTTR:
+		mttr, ok := r.Outages.MTTR()
+		return float64(mttr), ok
+	case RankByLatencyTrend:
+		return r.LatencyTrendPercent()
+	}
+	uptime, ok := r.Availability.UptimePercent()
+	return -uptime, ok
+}
+
+// RankTargets ordena del peor al mejor según el criterio y asigna la posición.
+// Los targets sin dato para el criterio van al final; los empates se resuelven por nombre.
+func RankTargets(rows []TargetRanking, metric RankingMetric) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, aOk := rows[i].badness(metric)
+		b, bOk := rows[j].badness(metric)
+		if aOk != bOk {
+			return aOk
+		}
+		if aOk && a != b {
+			return a > b
+		}
+		return rows[i].Target.Name() < rows[j].Target.Name()
+	})
+	for i := range rows {
+		rows[i].Rank = i + 1
+	}
+}
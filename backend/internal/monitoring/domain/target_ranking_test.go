@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSummarizeOutages(t *testing.T) {
+	base := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	window := TimeRange{Start: base, End: base.Add(24 * time.Hour)}
+	transitions := []StatusTransition{
+		{At: base.Add(-time.Hour), Status: TargetStatusDown}, // Caída previa: no cuenta
+		{At: base.Add(10 * time.Minute), Status: TargetStatusUp},
+		{At: base.Add(2 * time.Hour), Status: TargetStatusDown},
+		{At: base.Add(2*time.Hour + 10*time.Minute), Status: TargetStatusDegraded},
+		{At: base.Add(5 * time.Hour), Status: TargetStatusDown},
+		{At: base.Add(5*time.Hour + 30*time.Minute), Status: TargetStatusUp},
+		{At: base.Add(23 * time.Hour), Status: TargetStatusDown}, // Sigue abierta
+	}
+
+	summary := SummarizeOutages(transitions, window)
+
+	if summary.Incidents != 3 || summary.Resolved != 2 {
+		t.Fatalf("Expected 3 incidents with 2 resolved, got %+v", summary)
+	}
+	mttr, ok := summary.MTTR()
+	if !ok || mttr != 20*time.Minute {
+		t.Errorf("Expected MTTR of 20m, got %v (%v)", mttr, ok)
+	}
+	if _, ok := (OutageSummary{}).MTTR(); ok {
+		t.Error("Expected no MTTR without resolved outages")
+	}
+}
+
+func TestRankTargets(t *testing.T) {
+	window := TimeRange{Start: time.Now().Add(-time.Hour), End: time.Now()}
+	report := func(available time.Duration) AvailabilityReport {
+		return AvailabilityReport{Window: window, Available: available, Unavailable: time.Hour - available}
+	}
+	steady := TargetRanking{
+		Target:                 NewMonitoringTarget("steady", "https://a.example.com", TargetTypeAPI),
+		Availability:           report(time.Hour),
+		Latency:                LatencySummary{Sessions: 60, AvgResponseTimeMs: 100, P95ResponseTimeMs: 150},
+		BaselineResponseTimeMs: 100,
+	}
+	flaky := TargetRanking{
+		Target:                 NewMonitoringTarget("flaky", "https://b.example.com", TargetTypeAPI),
+		Availability:           report(30 * time.Minute),
+		Latency:                LatencySummary{Sessions: 60, Failures: 30, AvgResponseTimeMs: 300, P95ResponseTimeMs: 900},
+		Outages:                OutageSummary{Incidents: 2, Resolved: 2, TotalRecovery: 30 * time.Minute},
+		BaselineResponseTimeMs: 200,
+	}
+	fresh := TargetRanking{Target: NewMonitoringTarget("fresh", "https://c.example.com", TargetTypeAPI)}
+
+	rows := []TargetRanking{steady, fresh, flaky}
+	RankTargets(rows, RankByAvailability)
+	if rows[0].Target.Name() != "flaky" || rows[1].Target.Name() != "steady" || rows[2].Target.Name() != "fresh" {
+		t.Errorf("Expected worst availability first and no data last, got %s, %s, %s", rows[0].Target.Name(), rows[1].Target.Name(), rows[2].Target.Name())
+	}
+	if rows[0].Rank != 1 || rows[2].Rank != 3 {
+		t.Errorf("Expected ranks to follow the order, got %d and %d", rows[0].Rank, rows[2].Rank)
+	}
+
+	RankTargets(rows, RankByLatencyTrend)
+	if trend, _ := rows[0].LatencyTrendPercent(); rows[0].Target.Name() != "flaky" || trend != 50 {
+		t.Errorf("Expected flaky first with a +50%% trend, got %s (%v)", rows[0].Target.Name(), trend)
+	}
+
+	RankTargets(rows, RankByIncidents)
+	if rows[0].Target.Name() != "flaky" || rows[1].Target.Name() != "fresh" {
+		t.Errorf("Expected flaky first and ties on zero incidents sorted by name, got %s, %s", rows[0].Target.Name(), rows[1].Target.Name())
+	}
+}
+
+func TestNewRankingMetric(t *testing.T) {
+	if metric, err := NewRankingMetric(""); err != nil || metric != RankByAvailability {
+		t.Errorf("Expected availability by default, got %s (%v)", metric, err)
+	}
+	if metric, err := NewRankingMetric("P95"); err != nil || metric != RankByP95 {
+		t.Errorf("Expected p95, got %s (%v)", metric, err)
+	}
+	if _, err := NewRankingMetric("uptime"); err != ErrInvalidRankingMetric {
+		t.Errorf("Expected ErrInvalidRankingMetric, got %v", err)
+	}
+}
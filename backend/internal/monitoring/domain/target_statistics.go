@@ -1,10 +1,18 @@
 package domain
 
+import "time"
+
+// MinPercentileSamples - Muestras mínimas en la ventana para confiar en los percentiles como línea base
+const MinPercentileSamples = 30
+
 // TargetStatistics - Value object para estadísticas computadas
 type TargetStatistics struct {
 	targetId          TargetId
 	avgResponseTimeMs int
-	totalChecksCount  int // Total de checks realizados (Monotónico)
+	totalChecksCount  int            // Total de checks realizados (Monotónico)
+	latencySketch     *LatencySketch // Percentiles de los últimos 7 días
+	ewma              EWMAStats      // Media/varianza para el detector de anomalías
+	flapHistory       FlapHistory    // Estados de las últimas sesiones (flapping entre sesiones)
 }
 
 func NewTargetStatistics(targetId TargetId) *TargetStatistics {
@@ -12,14 +20,21 @@ func NewTargetStatistics(targetId TargetId) *TargetStatistics {
 		targetId:          targetId,
 		avgResponseTimeMs: 0,
 		totalChecksCount:  0,
+		latencySketch:     NewLatencySketch(),
 	}
 }
 
-func NewFullTargetStatistics(targetId TargetId, avgResponseTimeMs int, totalChecksCount int) *TargetStatistics {
+func NewFullTargetStatistics(targetId TargetId, avgResponseTimeMs int, totalChecksCount int, latencySketch *LatencySketch, ewma EWMAStats, flapHistory FlapHistory) *TargetStatistics {
+	if latencySketch == nil {
+		latencySketch = NewLatencySketch()
+	}
 	return &TargetStatistics{
 		targetId:          targetId,
 		avgResponseTimeMs: avgResponseTimeMs,
 		totalChecksCount:  totalChecksCount,
+		latencySketch:     latencySketch,
+		ewma:              ewma,
+		flapHistory:       flapHistory,
 	}
 }
 
@@ -36,6 +51,75 @@ func (s *TargetStatistics) TotalChecksCount() int {
 	return s.totalChecksCount
 }
 
+func (s *TargetStatistics) LatencySketch() *LatencySketch {
+	return s.latencySketch
+}
+
+func (s *TargetStatistics) EWMA() EWMAStats {
+	return s.ewma
+}
+
+func (s *TargetStatistics) FlapHistory() FlapHistory {
+	return s.flapHistory
+}
+
+func (s *TargetStatistics) IsFlapping() bool {
+	return s.flapHistory.IsFlapping()
+}
+
+func (s *TargetStatistics) P50ResponseTimeMs() int {
+	return s.latencySketch.Quantile(0.50)
+}
+
+func (s *TargetStatistics) P90ResponseTimeMs() int {
+	return s.latencySketch.Quantile(0.90)
+}
+
+func (s *TargetStatistics) P95ResponseTimeMs() int {
+	return s.latencySketch.Quantile(0.95)
+}
+
+func (s *TargetStatistics) P99ResponseTimeMs() int {
+	return s.latencySketch.Quantile(0.99)
+}
+
+// PercentileSampleCount devuelve las muestras que respaldan los percentiles
+func (s *TargetStatistics) PercentileSampleCount() int {
+	return s.latencySketch.Count()
+}
+
+// RecordLatencies alimenta el sketch con los tiempos de respuesta de los pings sanos de una sesión
+func (s *TargetStatistics) RecordLatencies(responseTimesMs []int, at time.Time) {
+	for _, ms := range responseTimesMs {
+		s.latencySketch.Record(ms, at)
+	}
+}
+
+// RecordEWMASample alimenta el detector de anomalías con el promedio de una sesión.
+// clipZ es la sensibilidad del target: las muestras más anómalas que eso se recortan.
+func (s *TargetStatistics) RecordEWMASample(responseTimeMs int, clipZ float64) {
+	if responseTimeMs <= 0 {
+		return
+	}
+	s.ewma = s.ewma.Update(float64(responseTimeMs), clipZ)
+}
+
+// RecordSessionState registra el estado final de una sesión y avisa si entró o salió de flapping
+func (s *TargetStatistics) RecordSessionState(status TargetStatus) FlapTransition {
+	var transition FlapTransition
+	s.flapHistory, transition = s.flapHistory.Record(status)
+	return transition
+}
+
+// BaselineResponseTimeMs devuelve la línea base contra la que se mide la degradación.
+// Con P95 se usa el percentil solo si hay suficientes muestras; mientras tanto, el promedio.
+func (s *TargetStatistics) BaselineResponseTimeMs(metric BaselineMetric) int {
+	if metric == BaselineMetricP95 && s.latencySketch.Count() >= MinPercentileSamples {
+		return s.P95ResponseTimeMs()
+	}
+	return s.avgResponseTimeMs
+}
+
 // UpdateState actualiza el estado de las estadísticas con valores ya calculados
 // La matemática se delega a StatisticsCalculator
 func (s *TargetStatistics) UpdateState(newAvgResponseTime int, maxChecks int) {
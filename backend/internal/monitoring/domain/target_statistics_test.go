@@ -27,7 +27,7 @@ func TestNewFullTargetStatistics_Success(t *testing.T) {
 	avgTime := 150
 	totalChecks := 100
 
-	stats := NewFullTargetStatistics(targetId, avgTime, totalChecks)
+	stats := NewFullTargetStatistics(targetId, avgTime, totalChecks, nil, EWMAStats{}, FlapHistory{})
 
 	if stats.AvgResponseTimeMs() != avgTime {
 		t.Errorf("Expected avg time %d, got %d", avgTime, stats.AvgResponseTimeMs())
@@ -118,7 +118,7 @@ func TestUpdateWithNewChecks_Accumulation_MultipleUpdates(t *testing.T) {
 func TestUpdateWithNewChecks_StableEMA(t *testing.T) {
 	targetId := TargetId("target-123")
 	// Crear stats en fase estable (≥1080 checks)
-	stats := NewFullTargetStatistics(targetId, 150, 1080)
+	stats := NewFullTargetStatistics(targetId, 150, 1080, nil, EWMAStats{}, FlapHistory{})
 
 	// Actualizar con nuevo promedio de 200ms
 	// EMA: nuevo = 150*0.997 + 200*0.003 = 149.55 + 0.6 = 150.15 ≈ 150
@@ -141,7 +141,7 @@ func TestUpdateWithNewChecks_StableEMA(t *testing.T) {
 
 func TestUpdateWithNewChecks_StableEMA_SignificantChange(t *testing.T) {
 	targetId := TargetId("target-123")
-	stats := NewFullTargetStatistics(targetId, 100, 1080)
+	stats := NewFullTargetStatistics(targetId, 100, 1080, nil, EWMAStats{}, FlapHistory{})
 
 	// Actualizar con valor muy diferente (500ms)
 	// EMA: 100*0.997 + 500*0.003 = 99.7 + 1.5 = 101.2 ≈ 101
@@ -160,7 +160,7 @@ func TestUpdateWithNewChecks_StableEMA_SignificantChange(t *testing.T) {
 // TEST DE TRANSICIÓN: Accumulation → Stable
 func TestUpdateWithNewChecks_TransitionToStable(t *testing.T) {
 	targetId := TargetId("target-123")
-	stats := NewFullTargetStatistics(targetId, 150, 1075) // Cerca del límite
+	stats := NewFullTargetStatistics(targetId, 150, 1075, nil, EWMAStats{}, FlapHistory{}) // Cerca del límite
 
 	// Agregar 6 checks → pasa de 1075 a 1081 (entra en fase estable)
 	newAvg := CalculateNewAverage(stats.AvgResponseTimeMs(), stats.TotalChecksCount(), 200, 6)
@@ -177,7 +177,7 @@ func TestUpdateWithNewChecks_TransitionToStable(t *testing.T) {
 // TEST EDGE CASE: Actualizar con 0 checks (no debería cambiar nada)
 func TestUpdateWithNewChecks_ZeroChecks(t *testing.T) {
 	targetId := TargetId("target-123")
-	stats := NewFullTargetStatistics(targetId, 150, 100)
+	stats := NewFullTargetStatistics(targetId, 150, 100, nil, EWMAStats{}, FlapHistory{})
 
 	originalAvg := stats.AvgResponseTimeMs()
 	originalTotal := stats.TotalChecksCount()
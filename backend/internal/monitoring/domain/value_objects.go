@@ -34,6 +34,21 @@ func (c CheckResultId) String() string {
 	return string(c)
 }
 
+// Value Object: SessionId
+// Identifica una sesión de "ping hasta estabilidad" (hasta 12 pings)
+type SessionId string
+
+func NewSessionId(value string) (SessionId, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", ErrSessionIdEmpty
+	}
+	return SessionId(value), nil
+}
+
+func (s SessionId) String() string {
+	return string(s)
+}
+
 // Value Object: ConfigId
 type ConfigId string
 
@@ -0,0 +1,65 @@
+package infrastructure
+
+import (
+	"fmt"
+	"uptrackai/internal/monitoring/domain"
+	notificationdomain "uptrackai/internal/notifications/domain"
+)
+
+// AlertDispatcher encola alertas para el módulo notifications (lo implementa el dispatcher del scheduler)
+type AlertDispatcher interface {
+	Dispatch(event notificationdomain.AlertEvent)
+}
+
+// IncidentActivityNotifier convierte el reconocimiento o cierre manual de un incidente en una alerta.
+// Lleva el target_id y la acción para que las herramientas de guardia actualicen su incidente.
+type IncidentActivityNotifier struct {
+	dispatcher     AlertDispatcher
+	severityMapper *notificationdomain.SeverityMapper
+}
+
+func NewIncidentActivityNotifier(dispatcher AlertDispatcher) *IncidentActivityNotifier {
+	return &IncidentActivityNotifier{
+		dispatcher:     dispatcher,
+		severityMapper: notificationdomain.NewSeverityMapper(),
+	}
+}
+
+func (n *IncidentActivityNotifier) PublishIncidentActivity(incident *domain.Incident, target *domain.MonitoringTarget, activity domain.IncidentEvent) {
+	var action, title, message string
+	var severity notificationdomain.AlertSeverity
+	switch activity.Type() {
+	case domain.IncidentEventAcknowledged:
+		action = notificationdomain.IncidentActionAcknowledged
+		title = "Incident Acknowledged: " + target.Name()
+		message = fmt.Sprintf("The incident on %s was acknowledged", target.Name())
+		severity = notificationdomain.SeverityInfo
+	case domain.IncidentEventResolved:
+		action = notificationdomain.IncidentActionResolved
+		title = "Incident Resolved: " + target.Name()
+		message = fmt.Sprintf("The incident on %s was resolved manually while the target is %s", target.Name(), incident.LastStatus())
+		severity = notificationdomain.SeverityOk
+	default:
+		return // Los comentarios quedan en la línea de tiempo
+	}
+	if note := activity.Note(); note != "" {
+		message = fmt.Sprintf("%s: %s", message, note)
+	}
+
+	event := notificationdomain.NewAlertEvent(
+		incident.UserId().String(),
+		title,
+		message,
+		severity,
+		n.severityMapper.Map(string(incident.LastStatus())),
+		"Target: "+target.Name(),
+		notificationdomain.AlertTypeMonitoring,
+		map[string]string{
+			notificationdomain.MetadataTargetID:       target.ID().String(),
+			notificationdomain.MetadataURL:            target.Url(),
+			notificationdomain.MetadataIncidentID:     incident.ID().String(),
+			notificationdomain.MetadataIncidentAction: action,
+		},
+	)
+	n.dispatcher.Dispatch(*event)
+}
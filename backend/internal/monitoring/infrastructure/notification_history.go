@@ -0,0 +1,33 @@
+package infrastructure
+
+import (
+	"uptrackai/internal/monitoring/domain"
+	notificationApp "uptrackai/internal/notifications/application"
+)
+
+// NotificationHistoryReader expone el historial del módulo notifications a las líneas de tiempo de incidentes
+type NotificationHistoryReader struct {
+	service *notificationApp.NotificationService
+}
+
+func NewNotificationHistoryReader(service *notificationApp.NotificationService) *NotificationHistoryReader {
+	return &NotificationHistoryReader{service: service}
+}
+
+func (r *NotificationHistoryReader) FindByIncident(incidentId domain.IncidentId) ([]domain.IncidentNotificationRecord, error) {
+	notifications, err := r.service.FindByIncident(incidentId.String())
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]domain.IncidentNotificationRecord, 0, len(notifications))
+	for _, n := range notifications {
+		records = append(records, domain.IncidentNotificationRecord{
+			At:       n.CreatedAt(),
+			Title:    n.Title(),
+			Severity: n.Severity().String(),
+			Channels: n.DeliveredChannels(),
+		})
+	}
+	return records, nil
+}
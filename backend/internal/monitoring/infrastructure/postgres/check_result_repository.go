@@ -1,6 +1,9 @@
 package postgres
 
 import (
+	"encoding/json"
+	"errors"
+	"time"
 	"uptrackai/internal/monitoring/domain"
 
 	"github.com/google/uuid"
@@ -48,6 +51,93 @@ func (r *PostgresCheckResultRepository) GetByTargetID(targetId domain.TargetId,
 	return results, nil
 }
 
+// GetByTargetIDBetween obtiene los cambios de estado de un rango (ej: la vida de un incidente)
+func (r *PostgresCheckResultRepository) GetByTargetIDBetween(targetId domain.TargetId, from time.Time, to time.Time) ([]*domain.CheckResult, error) {
+	var entities []CheckResultEntity
+	targetUUID := uuid.MustParse(string(targetId))
+
+	err := r.db.Where("monitoring_target_id = ? AND timestamp BETWEEN ? AND ?", targetUUID, from, to).
+		Order("timestamp ASC").
+		Find(&entities).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*domain.CheckResult, 0, len(entities))
+	for _, e := range entities {
+		result, _ := r.toDomain(&e)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// GetLastBefore obtiene el estado vigente al inicio de un rango (nil si el target no tenía historial)
+func (r *PostgresCheckResultRepository) GetLastBefore(targetId domain.TargetId, at time.Time) (*domain.CheckResult, error) {
+	var entity CheckResultEntity
+	targetUUID := uuid.MustParse(string(targetId))
+
+	err := r.db.Where("monitoring_target_id = ? AND timestamp < ?", targetUUID, at).
+		Order("timestamp DESC").
+		First(&entity).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.toDomain(&entity)
+}
+
+// GetByTargetsBetween obtiene los cambios de estado de varios targets en una sola consulta
+func (r *PostgresCheckResultRepository) GetByTargetsBetween(targetIds []domain.TargetId, from time.Time, to time.Time) (map[domain.TargetId][]*domain.CheckResult, error) {
+	results := make(map[domain.TargetId][]*domain.CheckResult, len(targetIds))
+	if len(targetIds) == 0 {
+		return results, nil
+	}
+
+	var entities []CheckResultEntity
+	err := r.db.Where("monitoring_target_id IN ? AND timestamp BETWEEN ? AND ?", targetUUIDs(targetIds), from, to).
+		Order("monitoring_target_id, timestamp ASC").
+		Find(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entities {
+		result, _ := r.toDomain(&entities[i])
+		results[result.MonitoringTargetId()] = append(results[result.MonitoringTargetId()], result)
+	}
+	return results, nil
+}
+
+// GetLastBeforeByTargets obtiene el estado vigente de cada target al inicio de un rango
+func (r *PostgresCheckResultRepository) GetLastBeforeByTargets(targetIds []domain.TargetId, at time.Time) (map[domain.TargetId]*domain.CheckResult, error) {
+	results := make(map[domain.TargetId]*domain.CheckResult, len(targetIds))
+	if len(targetIds) == 0 {
+		return results, nil
+	}
+
+	var entities []CheckResultEntity
+	err := r.db.Raw(`
+		SELECT DISTINCT ON (monitoring_target_id) *
+		FROM check_results
+		WHERE monitoring_target_id IN ? AND timestamp < ?
+		ORDER BY monitoring_target_id, timestamp DESC`, targetUUIDs(targetIds), at).
+		Scan(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entities {
+		result, _ := r.toDomain(&entities[i])
+		results[result.MonitoringTargetId()] = result
+	}
+	return results, nil
+}
+
 // --- MAPPERS ---
 
 func (r *PostgresCheckResultRepository) toEntity(result *domain.CheckResult) *CheckResultEntity {
@@ -67,6 +157,9 @@ func (r *PostgresCheckResultRepository) toEntity(result *domain.CheckResult) *Ch
 		Status:             string(result.Status()),
 		AvgResponseTimeMs:  result.ResponseTimeMs(),
 		ErrorMessage:       result.ErrorMessage(),
+		DegradationReason:  encodeDegradationReason(result.DegradationReason()),
+		SessionID:          encodeSessionId(result.SessionId()),
+		ErrorClass:         result.ErrorClass().String(),
 	}
 }
 
@@ -81,7 +174,7 @@ func (r *PostgresCheckResultRepository) toDomain(entity *CheckResultEntity) (*do
 		return nil, err
 	}
 
-	return domain.NewFullCheckResult(
+	result := domain.NewFullCheckResult(
 		checkResultId,
 		targetId,
 		entity.Timestamp,
@@ -89,5 +182,73 @@ func (r *PostgresCheckResultRepository) toDomain(entity *CheckResultEntity) (*do
 		entity.ErrorMessage == "",
 		domain.TargetStatus(entity.Status),
 		entity.ErrorMessage,
-	), nil
+	)
+	result.AttachDegradationReason(decodeDegradationReason(entity.DegradationReason))
+	result.AssignSession(decodeSessionId(entity.SessionID))
+	result.Classify(domain.ErrorClass(entity.ErrorClass))
+
+	return result, nil
+}
+
+// targetUUIDs convierte ids de dominio para consultas con IN
+func targetUUIDs(targetIds []domain.TargetId) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(targetIds))
+	for _, targetId := range targetIds {
+		ids = append(ids, uuid.MustParse(targetId.String()))
+	}
+	return ids
+}
+
+// encodeSessionId devuelve nil para resultados sin sesión (o con un id que no es UUID)
+func encodeSessionId(sessionId domain.SessionId) *uuid.UUID {
+	if sessionId == "" {
+		return nil
+	}
+	parsed, err := uuid.Parse(sessionId.String())
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
+
+func decodeSessionId(raw *uuid.UUID) domain.SessionId {
+	if raw == nil {
+		return ""
+	}
+	return domain.SessionId(raw.String())
+}
+
+// degradationReasonJSON - Formato persistido de domain.DegradationReason
+type degradationReasonJSON struct {
+	Rule     string  `json:"rule"`
+	Metric   string  `json:"metric"`
+	Observed float64 `json:"observed"`
+	Baseline float64 `json:"baseline"`
+	Score    float64 `json:"score"`
+}
+
+func encodeDegradationReason(reason *domain.DegradationReason) *string {
+	if reason == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(degradationReasonJSON(*reason))
+	if err != nil {
+		return nil
+	}
+	encoded := string(raw)
+	return &encoded
+}
+
+func decodeDegradationReason(raw *string) *domain.DegradationReason {
+	if raw == nil || *raw == "" {
+		return nil
+	}
+
+	var decoded degradationReasonJSON
+	if err := json.Unmarshal([]byte(*raw), &decoded); err != nil {
+		return nil
+	}
+	reason := domain.DegradationReason(decoded)
+	return &reason
 }
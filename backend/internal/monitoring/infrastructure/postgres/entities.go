@@ -20,29 +20,59 @@ type MonitoringTargetEntity struct {
 	TimeoutSeconds       int       `gorm:"default:10"`
 	RetryCount           int       `gorm:"default:3"`
 	RetryDelaySeconds    int       `gorm:"default:1"`
-	LastCheckedAt        time.Time `gorm:"default:null"`
-	NextCheckAt          time.Time `gorm:"index;default:null"` // Optimización: Para polling eficiente
-	CreatedAt            time.Time `gorm:"autoCreateTime"`
-	UpdatedAt            time.Time `gorm:"autoUpdateTime"`
+	// Umbrales del analizador (ver domain.AnalyzerThresholds)
+	DegradationFactor     float64 `gorm:"default:3"`
+	MinLatencyThresholdMs int     // Sin default: GORM lo escribiría en vez de un 0 (el 200 lo pone el dominio)
+	MaxLatencyThresholdMs int     `gorm:"default:0"` // 0 = regla absoluta deshabilitada
+	UnstableMinPings      int     `gorm:"default:5"`
+	UnstableMaxPings      int     `gorm:"default:9"`
+	BaselineMetric        string  `gorm:"type:varchar(10);default:'MEAN'"`
+	AnomalyZThreshold     float64 `gorm:"default:3"`  // Sensibilidad del detector de anomalías
+	ErrorClassPolicy      *string `gorm:"type:jsonb"` // Solo las clases que difieren del default, ej: {"http_4xx":"UP"}
+	// Muestras crudas (opt-in)
+	RawSamplesEnabled       bool `gorm:"default:false"`
+	RawSamplesRetentionDays int  `gorm:"default:3"`
+	// Uptime: estados que cuentan como disponibles (separados por coma)
+	AvailableStatuses string `gorm:"type:varchar(100);default:'UP'"`
+	// Hosting (resuelto offline: DNS + base de proveedores/ASN)
+	HostingIPs        string     `gorm:"type:text"` // Separadas por coma
+	HostingProvider   string     `gorm:"type:varchar(100);index"`
+	HostingASN        int        `gorm:"index"`
+	HostingResolvedAt *time.Time `gorm:"default:null"`
+	LastCheckedAt     time.Time  `gorm:"default:null"`
+	NextCheckAt       time.Time  `gorm:"index;default:null"` // Optimización: Para polling eficiente
+	CreatedAt         time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt         time.Time  `gorm:"autoUpdateTime"`
 }
 
 // CheckResultEntity - Tabla SQL para alertas (solo cambios de estado)
 type CheckResultEntity struct {
-	ID                 uuid.UUID `gorm:"type:uuid;primaryKey"`
-	MonitoringTargetID uuid.UUID `gorm:"type:uuid;not null;index:idx_target_timestamp"`
-	Timestamp          time.Time `gorm:"not null;index:idx_target_timestamp"`
-	Status             string    `gorm:"type:varchar(50);not null"`
-	AvgResponseTimeMs  int       `gorm:"not null"`
-	ErrorMessage       string    `gorm:"type:text"`
-	CreatedAt          time.Time `gorm:"autoCreateTime"`
+	ID                 uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	MonitoringTargetID uuid.UUID  `gorm:"type:uuid;not null;index:idx_target_timestamp"`
+	Timestamp          time.Time  `gorm:"not null;index:idx_target_timestamp"`
+	Status             string     `gorm:"type:varchar(50);not null"`
+	AvgResponseTimeMs  int        `gorm:"not null"`
+	ErrorMessage       string     `gorm:"type:text"`
+	DegradationReason  *string    `gorm:"type:jsonb"` // Por qué se marcó DEGRADED (null en otros estados)
+	SessionID          *uuid.UUID `gorm:"type:uuid"`  // Sesión que produjo el cambio (ver ping_samples)
+	ErrorClass         string     `gorm:"type:varchar(30);index"`
+	CreatedAt          time.Time  `gorm:"autoCreateTime"`
 }
 
-// MetricEntity - Tabla NoSQL simulada para seguimiento continuo (solo checks correctos)
+// MetricEntity - Tabla NoSQL simulada para seguimiento continuo (un punto por sesión, incluidas las caídas)
 type MetricEntity struct {
-	MonitoringTargetID uuid.UUID `gorm:"type:uuid;not null;index:idx_metric_target_time"`
-	Timestamp          time.Time `gorm:"not null;index:idx_metric_target_time"`
-	ResponseTimeMs     int       `gorm:"not null"`
-	CreatedAt          time.Time `gorm:"autoCreateTime"`
+	MonitoringTargetID uuid.UUID  `gorm:"type:uuid;not null;index:idx_metric_target_time"`
+	Timestamp          time.Time  `gorm:"not null;index:idx_metric_target_time"`
+	ResponseTimeMs     int        `gorm:"not null"` // Promedio de los pings UP (0 si no hubo)
+	SessionID          *uuid.UUID `gorm:"type:uuid"`
+	ErrorClass         string     `gorm:"type:varchar(30)"`
+	// Estado de la sesión: vacío/nil en filas anteriores a que se guardara
+	Status            string `gorm:"type:varchar(50)"`
+	Reachable         *bool
+	MaxResponseTimeMs *int
+	SuccessCount      *int
+	FailureCount      *int
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
 }
 
 func (MonitoringTargetEntity) TableName() string {
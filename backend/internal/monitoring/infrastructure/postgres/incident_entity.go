@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IncidentEntity - Caídas atendibles (se abren en CRITICAL, se cierran al recuperarse o a mano)
+type IncidentEntity struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TargetID       uuid.UUID  `gorm:"type:uuid;not null;index:idx_incident_target_status"`
+	UserID         uuid.UUID  `gorm:"type:uuid;not null;index:idx_incident_user_opened"`
+	Status         string     `gorm:"type:varchar(20);not null;index:idx_incident_target_status"`
+	TriggerStatus  string     `gorm:"type:varchar(50);not null"`
+	LastStatus     string     `gorm:"type:varchar(50);not null"`
+	OpenedAt       time.Time  `gorm:"not null;index:idx_incident_user_opened"`
+	AcknowledgedAt *time.Time `gorm:"default:null"`
+	AcknowledgedBy *uuid.UUID `gorm:"type:uuid"`
+	ResolvedAt     *time.Time `gorm:"default:null"`
+	ResolvedBy     *uuid.UUID `gorm:"type:uuid"`          // null en auto-resolución
+	Version        int        `gorm:"not null;default:1"` // Concurrencia optimista; 1 para las filas previas a la columna
+	CreatedAt      time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime"`
+}
+
+// IncidentEventEntity - Línea de tiempo del incidente (solo se agregan filas)
+type IncidentEventEntity struct {
+	IncidentID uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Sequence   int        `gorm:"primaryKey;autoIncrement:false"`
+	Type       string     `gorm:"type:varchar(20);not null"`
+	At         time.Time  `gorm:"not null"`
+	Status     string     `gorm:"type:varchar(50)"`
+	AuthorID   *uuid.UUID `gorm:"type:uuid"` // null = evento del scheduler
+	Note       string     `gorm:"type:text"`
+}
+
+func (IncidentEntity) TableName() string {
+	return "incidents"
+}
+
+func (IncidentEventEntity) TableName() string {
+	return "incident_events"
+}
@@ -0,0 +1,247 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+	"uptrackai/internal/monitoring/domain"
+	userdomain "uptrackai/internal/user/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresIncidentRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresIncidentRepository(db *gorm.DB) *PostgresIncidentRepository {
+	return &PostgresIncidentRepository{db: db}
+}
+
+// Save guarda el incidente y agrega los eventos nuevos de la línea de tiempo. El scheduler y la
+// API (reconocer, comentar, resolver) cargan y guardan el mismo incidente: la escritura solo
+// procede si la versión sigue siendo la que se leyó, si no devuelve ErrIncidentConflict.
+func (r *PostgresIncidentRepository) Save(incident *domain.Incident) error {
+	if incident.ID() == "" {
+		if err := incident.AssignId(domain.IncidentId(uuid.Must(uuid.NewV7()).String())); err != nil {
+			return err
+		}
+	}
+
+	entity := r.toEntity(incident)
+	entity.Version = incident.Version() + 1
+	events := make([]IncidentEventEntity, 0, len(incident.Events()))
+	for i, event := range incident.Events() {
+		events = append(events, IncidentEventEntity{
+			IncidentID: entity.ID,
+			Sequence:   i + 1,
+			Type:       string(event.Type()),
+			At:         event.At(),
+			Status:     event.Status().String(),
+			AuthorID:   encodeUserId(event.Author()),
+			Note:       event.Note(),
+		})
+	}
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if incident.Version() == 0 {
+			if err := tx.Create(entity).Error; err != nil {
+				return err
+			}
+		} else {
+			result := tx.Model(&IncidentEntity{}).
+				Where("id = ? AND version = ?", entity.ID, incident.Version()).
+				Select("*").Omit("id", "created_at").
+				Updates(entity)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return domain.ErrIncidentConflict
+			}
+		}
+		// Con la versión verificada nadie más agregó eventos: los ya guardados son los mismos
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&events).Error
+	})
+	if err != nil {
+		return err
+	}
+	incident.AssignVersion(entity.Version)
+	return nil
+}
+
+func (r *PostgresIncidentRepository) GetByID(id domain.IncidentId) (*domain.Incident, error) {
+	incidentUUID, err := uuid.Parse(id.String())
+	if err != nil {
+		return nil, domain.ErrIncidentNotFound
+	}
+
+	var entity IncidentEntity
+	if err := r.db.First(&entity, "id = ?", incidentUUID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrIncidentNotFound
+		}
+		return nil, err
+	}
+
+	incidents, err := r.withEvents([]IncidentEntity{entity})
+	if err != nil {
+		return nil, err
+	}
+	return incidents[0], nil
+}
+
+func (r *PostgresIncidentRepository) GetActiveByTarget(targetId domain.TargetId) (*domain.Incident, error) {
+	var entity IncidentEntity
+	err := r.db.Where("target_id = ? AND status <> ?", uuid.MustParse(targetId.String()), string(domain.IncidentStatusResolved)).
+		Order("opened_at DESC").
+		First(&entity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrIncidentNotFound
+		}
+		return nil, err
+	}
+
+	incidents, err := r.withEvents([]IncidentEntity{entity})
+	if err != nil {
+		return nil, err
+	}
+	return incidents[0], nil
+}
+
+// List devuelve los incidentes más recientes primero
+func (r *PostgresIncidentRepository) List(filter domain.IncidentFilter) ([]*domain.Incident, error) {
+	query := r.db.Model(&IncidentEntity{})
+	if filter.UserId != "" {
+		query = query.Where("user_id = ?", uuid.MustParse(filter.UserId.String()))
+	}
+	if filter.TargetId != "" {
+		targetUUID, err := uuid.Parse(filter.TargetId.String())
+		if err != nil {
+			return []*domain.Incident{}, nil
+		}
+		query = query.Where("target_id = ?", targetUUID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("opened_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("opened_at < ?", filter.Until)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var entities []IncidentEntity
+	if err := query.Order("opened_at DESC").Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	return r.withEvents(entities)
+}
+
+// withEvents carga las líneas de tiempo de todos los incidentes en una sola consulta
+func (r *PostgresIncidentRepository) withEvents(entities []IncidentEntity) ([]*domain.Incident, error) {
+	if len(entities) == 0 {
+		return []*domain.Incident{}, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(entities))
+	for _, e := range entities {
+		ids = append(ids, e.ID)
+	}
+
+	var eventEntities []IncidentEventEntity
+	if err := r.db.Where("incident_id IN ?", ids).Order("incident_id, sequence ASC").Find(&eventEntities).Error; err != nil {
+		return nil, err
+	}
+
+	eventsByIncident := make(map[uuid.UUID][]domain.IncidentEvent, len(entities))
+	for _, e := range eventEntities {
+		eventsByIncident[e.IncidentID] = append(eventsByIncident[e.IncidentID], domain.NewIncidentEvent(
+			domain.IncidentEventType(e.Type),
+			e.At,
+			domain.TargetStatus(e.Status),
+			decodeUserId(e.AuthorID),
+			e.Note,
+		))
+	}
+
+	incidents := make([]*domain.Incident, 0, len(entities))
+	for i := range entities {
+		incidents = append(incidents, r.toDomain(&entities[i], eventsByIncident[entities[i].ID]))
+	}
+	return incidents, nil
+}
+
+// --- MAPPERS ---
+
+func (r *PostgresIncidentRepository) toEntity(incident *domain.Incident) *IncidentEntity {
+	return &IncidentEntity{
+		ID:             uuid.MustParse(incident.ID().String()),
+		TargetID:       uuid.MustParse(incident.TargetId().String()),
+		UserID:         uuid.MustParse(incident.UserId().String()),
+		Status:         incident.Status().String(),
+		TriggerStatus:  incident.TriggerStatus().String(),
+		LastStatus:     incident.LastStatus().String(),
+		OpenedAt:       incident.OpenedAt(),
+		AcknowledgedAt: encodeOptionalTime(incident.AcknowledgedAt()),
+		AcknowledgedBy: encodeUserId(incident.AcknowledgedBy()),
+		ResolvedAt:     encodeOptionalTime(incident.ResolvedAt()),
+		ResolvedBy:     encodeUserId(incident.ResolvedBy()),
+	}
+}
+
+func (r *PostgresIncidentRepository) toDomain(entity *IncidentEntity, events []domain.IncidentEvent) *domain.Incident {
+	return domain.NewFullIncident(
+		domain.IncidentId(entity.ID.String()),
+		domain.TargetId(entity.TargetID.String()),
+		userdomain.UserId(entity.UserID.String()),
+		domain.IncidentStatus(entity.Status),
+		domain.TargetStatus(entity.TriggerStatus),
+		domain.TargetStatus(entity.LastStatus),
+		entity.OpenedAt,
+		decodeOptionalTime(entity.AcknowledgedAt),
+		decodeUserId(entity.AcknowledgedBy),
+		decodeOptionalTime(entity.ResolvedAt),
+		decodeUserId(entity.ResolvedBy),
+		events,
+		entity.Version,
+	)
+}
+
+func encodeUserId(id userdomain.UserId) *uuid.UUID {
+	if id == "" {
+		return nil
+	}
+	parsed, err := uuid.Parse(id.String())
+	if err != nil {
+		return nil
+	}
+	return &parsed
+}
+
+func decodeUserId(id *uuid.UUID) userdomain.UserId {
+	if id == nil {
+		return ""
+	}
+	return userdomain.UserId(id.String())
+}
+
+func encodeOptionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func decodeOptionalTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
+}
@@ -0,0 +1,22 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaintenanceWindowEntity - Ventanas de mantenimiento planificadas (se excluyen del uptime)
+type MaintenanceWindowEntity struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TargetID  uuid.UUID  `gorm:"type:uuid;not null;index:idx_maintenance_target_range"`
+	StartsAt  time.Time  `gorm:"not null;index:idx_maintenance_target_range"`
+	EndsAt    time.Time  `gorm:"not null"`
+	Reason    string     `gorm:"type:text"`
+	CreatedBy *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt time.Time  `gorm:"autoCreateTime"`
+}
+
+func (MaintenanceWindowEntity) TableName() string {
+	return "maintenance_windows"
+}
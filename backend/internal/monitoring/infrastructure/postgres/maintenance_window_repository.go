@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+	"uptrackai/internal/monitoring/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresMaintenanceWindowRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresMaintenanceWindowRepository(db *gorm.DB) *PostgresMaintenanceWindowRepository {
+	return &PostgresMaintenanceWindowRepository{db: db}
+}
+
+func (r *PostgresMaintenanceWindowRepository) Save(window *domain.MaintenanceWindow) error {
+	if window.ID() == "" {
+		if err := window.AssignId(domain.MaintenanceWindowId(uuid.Must(uuid.NewV7()).String())); err != nil {
+			return err
+		}
+	}
+	return r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(r.toEntity(window)).Error
+}
+
+func (r *PostgresMaintenanceWindowRepository) GetByID(id domain.MaintenanceWindowId) (*domain.MaintenanceWindow, error) {
+	windowUUID, err := uuid.Parse(id.String())
+	if err != nil {
+		return nil, domain.ErrMaintenanceWindowNotFound
+	}
+
+	var entity MaintenanceWindowEntity
+	if err := r.db.First(&entity, "id = ?", windowUUID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrMaintenanceWindowNotFound
+		}
+		return nil, err
+	}
+	return r.toDomain(&entity), nil
+}
+
+func (r *PostgresMaintenanceWindowRepository) Delete(id domain.MaintenanceWindowId) error {
+	windowUUID, err := uuid.Parse(id.String())
+	if err != nil {
+		return domain.ErrMaintenanceWindowNotFound
+	}
+
+	result := r.db.Delete(&MaintenanceWindowEntity{}, "id = ?", windowUUID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrMaintenanceWindowNotFound
+	}
+	return nil
+}
+
+// ListByTarget devuelve las ventanas superpuestas con el rango, en orden cronológico
+func (r *PostgresMaintenanceWindowRepository) ListByTarget(targetId domain.TargetId, from time.Time, to time.Time) ([]*domain.MaintenanceWindow, error) {
+	query := r.db.Where("target_id = ? AND ends_at > ?", uuid.MustParse(targetId.String()), from)
+	if !to.IsZero() {
+		query = query.Where("starts_at < ?", to)
+	}
+
+	var entities []MaintenanceWindowEntity
+	if err := query.Order("starts_at ASC").Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	windows := make([]*domain.MaintenanceWindow, 0, len(entities))
+	for i := range entities {
+		windows = append(windows, r.toDomain(&entities[i]))
+	}
+	return windows, nil
+}
+
+// ListByTargets obtiene las ventanas de varios targets que se superponen con [from, to)
+func (r *PostgresMaintenanceWindowRepository) ListByTargets(targetIds []domain.TargetId, from time.Time, to time.Time) (map[domain.TargetId][]*domain.MaintenanceWindow, error) {
+	windows := make(map[domain.TargetId][]*domain.MaintenanceWindow, len(targetIds))
+	if len(targetIds) == 0 {
+		return windows, nil
+	}
+
+	query := r.db.Where("target_id IN ? AND ends_at > ?", targetUUIDs(targetIds), from)
+	if !to.IsZero() {
+		query = query.Where("starts_at < ?", to)
+	}
+
+	var entities []MaintenanceWindowEntity
+	if err := query.Order("starts_at ASC").Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range entities {
+		window := r.toDomain(&entities[i])
+		windows[window.TargetId()] = append(windows[window.TargetId()], window)
+	}
+	return windows, nil
+}
+
+// --- MAPPERS ---
+
+func (r *PostgresMaintenanceWindowRepository) toEntity(window *domain.MaintenanceWindow) *MaintenanceWindowEntity {
+	return &MaintenanceWindowEntity{
+		ID:        uuid.MustParse(window.ID().String()),
+		TargetID:  uuid.MustParse(window.TargetId().String()),
+		StartsAt:  window.StartsAt(),
+		EndsAt:    window.EndsAt(),
+		Reason:    window.Reason(),
+		CreatedBy: encodeUserId(window.CreatedBy()),
+		CreatedAt: window.CreatedAt(),
+	}
+}
+
+func (r *PostgresMaintenanceWindowRepository) toDomain(entity *MaintenanceWindowEntity) *domain.MaintenanceWindow {
+	return domain.NewFullMaintenanceWindow(
+		domain.MaintenanceWindowId(entity.ID.String()),
+		domain.TargetId(entity.TargetID.String()),
+		entity.StartsAt,
+		entity.EndsAt,
+		entity.Reason,
+		decodeUserId(entity.CreatedBy),
+		entity.CreatedAt,
+	)
+}
@@ -0,0 +1,25 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MetricRollupEntity - Agregados de metrics por bucket (1m, 1h, 1d)
+type MetricRollupEntity struct {
+	MonitoringTargetID uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Resolution         string    `gorm:"type:varchar(5);primaryKey"`
+	BucketStart        time.Time `gorm:"primaryKey"`
+	Count              int64     `gorm:"not null"`
+	FailureCount       int64     `gorm:"not null;default:0"`
+	MinResponseTimeMs  int       `gorm:"not null;default:0"`
+	MaxResponseTimeMs  int       `gorm:"not null;default:0"`
+	AvgResponseTimeMs  float64   `gorm:"not null;default:0"`
+	P95ResponseTimeMs  int       `gorm:"not null;default:0"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
+}
+
+func (MetricRollupEntity) TableName() string {
+	return "metric_rollups"
+}
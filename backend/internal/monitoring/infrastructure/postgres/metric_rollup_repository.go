@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"time"
+	"uptrackai/internal/monitoring/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PostgresMetricRollupRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresMetricRollupRepository(db *gorm.DB) *PostgresMetricRollupRepository {
+	return &PostgresMetricRollupRepository{db: db}
+}
+
+// Rollup agrega metrics en buckets alineados al epoch (UTC) con una sola pasada y los upsertea.
+// Se calcula siempre desde las sesiones crudas para que el p95 de 1h y 1d sea exacto.
+// Sesión correcta = tiempo de respuesta > 0, sin clase de error y no DOWN; el resto suma a failure_count.
+func (r *PostgresMetricRollupRepository) Rollup(resolution domain.MetricResolution, from time.Time, to time.Time) error {
+	bucketSeconds := int64(resolution.BucketSize().Seconds())
+
+	return r.db.Exec(`
+		INSERT INTO metric_rollups
+			(monitoring_target_id, resolution, bucket_start, count, failure_count,
+			 min_response_time_ms, max_response_time_ms, avg_response_time_ms, p95_response_time_ms, updated_at)
+		SELECT
+			monitoring_target_id,
+			?,
+			to_timestamp(floor(extract(epoch FROM timestamp) / ?) * ?) AS bucket_start,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE NOT (response_time_ms > 0 AND COALESCE(error_class, '') = '' AND COALESCE(status, '') <> 'DOWN')),
+			COALESCE(MIN(response_time_ms) FILTER (WHERE response_time_ms > 0 AND COALESCE(error_class, '') = '' AND COALESCE(status, '') <> 'DOWN'), 0),
+			COALESCE(MAX(response_time_ms) FILTER (WHERE response_time_ms > 0 AND COALESCE(error_class, '') = '' AND COALESCE(status, '') <> 'DOWN'), 0),
+			COALESCE(AVG(response_time_ms) FILTER (WHERE response_time_ms > 0 AND COALESCE(error_class, '') = '' AND COALESCE(status, '') <> 'DOWN'), 0),
+			COALESCE(ROUND(percentile_cont(0.95) WITHIN GROUP (ORDER BY response_time_ms)
+				FILTER (WHERE response_time_ms > 0 AND COALESCE(error_class, '') = '' AND COALESCE(status, '') <> 'DOWN'))::int, 0),
+			NOW()
+		FROM metrics
+		WHERE timestamp >= ? AND timestamp < ?
+		GROUP BY 1, 3
+		ON CONFLICT (monitoring_target_id, resolution, bucket_start) DO UPDATE SET
+			count                = EXCLUDED.count,
+			failure_count        = EXCLUDED.failure_count,
+			min_response_time_ms = EXCLUDED.min_response_time_ms,
+			max_response_time_ms = EXCLUDED.max_response_time_ms,
+			avg_response_time_ms = EXCLUDED.avg_response_time_ms,
+			p95_response_time_ms = EXCLUDED.p95_response_time_ms,
+			updated_at           = EXCLUDED.updated_at`,
+		resolution.String(), bucketSeconds, bucketSeconds, from, to).Error
+}
+
+// LatestBucket devuelve el último bucket calculado de la resolución (cero si la tabla está vacía)
+func (r *PostgresMetricRollupRepository) LatestBucket(resolution domain.MetricResolution) (time.Time, error) {
+	var latest *time.Time
+	err := r.db.Model(&MetricRollupEntity{}).
+		Select("MAX(bucket_start)").
+		Where("resolution = ?", resolution.String()).
+		Scan(&latest).Error
+	if err != nil || latest == nil {
+		return time.Time{}, err
+	}
+	return *latest, nil
+}
+
+// ListByTarget devuelve los buckets del target en [from, to) en orden cronológico
+func (r *PostgresMetricRollupRepository) ListByTarget(targetId domain.TargetId, resolution domain.MetricResolution, from time.Time, to time.Time) ([]domain.MetricRollup, error) {
+	var entities []MetricRollupEntity
+	err := r.db.Where("monitoring_target_id = ? AND resolution = ? AND bucket_start >= ? AND bucket_start < ?",
+		uuid.MustParse(string(targetId)), resolution.String(), from, to).
+		Order("bucket_start ASC").
+		Find(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	rollups := make([]domain.MetricRollup, 0, len(entities))
+	for _, e := range entities {
+		rollups = append(rollups, domain.MetricRollup{
+			TargetId:          domain.TargetId(e.MonitoringTargetID.String()),
+			Resolution:        domain.MetricResolution(e.Resolution),
+			BucketStart:       e.BucketStart,
+			Count:             e.Count,
+			FailureCount:      e.FailureCount,
+			MinResponseTimeMs: e.MinResponseTimeMs,
+			MaxResponseTimeMs: e.MaxResponseTimeMs,
+			AvgResponseTimeMs: e.AvgResponseTimeMs,
+			P95ResponseTimeMs: e.P95ResponseTimeMs,
+		})
+	}
+	return rollups, nil
+}
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"time"
 	"uptrackai/internal/monitoring/domain"
 
 	"github.com/google/uuid"
@@ -15,7 +16,7 @@ func NewPostgresMetricsRepository(db *gorm.DB) *PostgresMetricsRepository {
 	return &PostgresMetricsRepository{db: db}
 }
 
-// Save guarda métricas de seguimiento continuo (solo checks correctos con responseTime válido)
+// Save guarda el punto de la sesión con su estado real (también DOWN y DEGRADED)
 func (r *PostgresMetricsRepository) Save(result *domain.CheckResult) error {
 	entity := r.toEntity(result)
 	return r.db.Create(entity).Error
@@ -43,16 +44,109 @@ func (r *PostgresMetricsRepository) GetByTargetID(targetId domain.TargetId, limi
 	return results, nil
 }
 
+// GetByTargetIDBetween obtiene las sesiones de un rango corto (series en resolución raw)
+func (r *PostgresMetricsRepository) GetByTargetIDBetween(targetId domain.TargetId, from time.Time, to time.Time) ([]*domain.CheckResult, error) {
+	var entities []MetricEntity
+	targetUUID := uuid.MustParse(string(targetId))
+
+	err := r.db.Where("monitoring_target_id = ? AND timestamp >= ? AND timestamp < ?", targetUUID, from, to).
+		Order("timestamp ASC").
+		Find(&entities).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*domain.CheckResult, 0, len(entities))
+	for _, e := range entities {
+		result, _ := r.toDomain(&e)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// CountWithinLatency cuenta sesiones para SLOs de latencia: una sesión con clase de error
+// (caída, 5xx, aserción) nunca cuenta como buena aunque haya respondido rápido
+func (r *PostgresMetricsRepository) CountWithinLatency(targetId domain.TargetId, from time.Time, to time.Time, thresholdMs int) (int64, int64, error) {
+	var counts struct {
+		Good  int64
+		Total int64
+	}
+	err := r.db.Model(&MetricEntity{}).
+		Select("COUNT(*) AS total, COUNT(*) FILTER (WHERE response_time_ms <= ? AND COALESCE(error_class, '') = '' AND COALESCE(status, '') <> 'DOWN') AS good", thresholdMs).
+		Where("monitoring_target_id = ? AND timestamp >= ? AND timestamp < ?", uuid.MustParse(string(targetId)), from, to).
+		Scan(&counts).Error
+	if err != nil {
+		return 0, 0, err
+	}
+	return counts.Good, counts.Total, nil
+}
+
 // --- MAPPERS ---
 
+// SummarizeLatency agrega sesiones, fallas, promedio y p95 de varios targets en una sola consulta
+func (r *PostgresMetricsRepository) SummarizeLatency(targetIds []domain.TargetId, from time.Time, to time.Time) (map[domain.TargetId]domain.LatencySummary, error) {
+	summaries := make(map[domain.TargetId]domain.LatencySummary, len(targetIds))
+	if len(targetIds) == 0 {
+		return summaries, nil
+	}
+
+	var rows []struct {
+		MonitoringTargetID uuid.UUID
+		Sessions           int64
+		Failures           int64
+		AvgMs              float64
+		P95Ms              int
+	}
+	err := r.db.Raw(`
+		SELECT
+			monitoring_target_id,
+			COUNT(*) AS sessions,
+			COUNT(*) FILTER (WHERE NOT (`+healthySessionSQL+`)) AS failures,
+			COALESCE(AVG(response_time_ms) FILTER (WHERE `+healthySessionSQL+`), 0) AS avg_ms,
+			COALESCE(ROUND(percentile_cont(0.95) WITHIN GROUP (ORDER BY response_time_ms)
+				FILTER (WHERE `+healthySessionSQL+`))::int, 0) AS p95_ms
+		FROM metrics
+		WHERE monitoring_target_id IN ? AND timestamp >= ? AND timestamp < ?
+		GROUP BY monitoring_target_id`, targetUUIDs(targetIds), from, to).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		summaries[domain.TargetId(row.MonitoringTargetID.String())] = domain.LatencySummary{
+			Sessions:          row.Sessions,
+			Failures:          row.Failures,
+			AvgResponseTimeMs: row.AvgMs,
+			P95ResponseTimeMs: row.P95Ms,
+		}
+	}
+	return summaries, nil
+}
+
+// healthySessionSQL - Sesión correcta en la tabla metrics (misma regla que los rollups)
+const healthySessionSQL = `response_time_ms > 0 AND COALESCE(error_class, '') = '' AND COALESCE(status, '') <> 'DOWN'`
+
 func (r *PostgresMetricsRepository) toEntity(result *domain.CheckResult) *MetricEntity {
 	targetIdUUID := uuid.MustParse(result.MonitoringTargetId().String())
 
-	return &MetricEntity{
+	reachable := result.Reachable()
+	entity := &MetricEntity{
 		MonitoringTargetID: targetIdUUID,
 		Timestamp:          result.Timestamp(),
 		ResponseTimeMs:     result.ResponseTimeMs(),
+		SessionID:          encodeSessionId(result.SessionId()),
+		ErrorClass:         result.ErrorClass().String(),
+		Status:             result.Status().String(),
+		Reachable:          &reachable,
 	}
+	if counts := result.SessionCounts(); counts != nil {
+		entity.MaxResponseTimeMs = &counts.MaxResponseTimeMs
+		entity.SuccessCount = &counts.SuccessCount
+		entity.FailureCount = &counts.FailureCount
+	}
+	return entity
 }
 
 func (r *PostgresMetricsRepository) toDomain(entity *MetricEntity) (*domain.CheckResult, error) {
@@ -61,13 +155,34 @@ func (r *PostgresMetricsRepository) toDomain(entity *MetricEntity) (*domain.Chec
 		return nil, err
 	}
 
-	return domain.NewFullCheckResult(
+	// Filas antiguas sin estado: no se inventa un UP, se informa UNKNOWN
+	status := domain.TargetStatusUnknown
+	if entity.Status != "" {
+		status = domain.TargetStatus(entity.Status)
+	}
+	reachable := entity.ResponseTimeMs > 0 && entity.ErrorClass == ""
+	if entity.Reachable != nil {
+		reachable = *entity.Reachable
+	}
+
+	result := domain.NewFullCheckResult(
 		domain.CheckResultId(uuid.New().String()), // Generate new ID since we don't store it
 		targetId,         // Use the target ID from DB
 		entity.Timestamp, // Use the actual timestamp from DB
 		entity.ResponseTimeMs,
-		true, // Metrics solo guarda UP (reachable)
-		domain.TargetStatusUp,
+		reachable,
+		status,
 		"", // No error message for metrics
-	), nil
+	)
+	result.AssignSession(decodeSessionId(entity.SessionID))
+	result.Classify(domain.ErrorClass(entity.ErrorClass))
+	if entity.SuccessCount != nil && entity.FailureCount != nil {
+		counts := domain.SessionCounts{SuccessCount: *entity.SuccessCount, FailureCount: *entity.FailureCount}
+		if entity.MaxResponseTimeMs != nil {
+			counts.MaxResponseTimeMs = *entity.MaxResponseTimeMs
+		}
+		result.AttachSessionCounts(&counts)
+	}
+
+	return result, nil
 }
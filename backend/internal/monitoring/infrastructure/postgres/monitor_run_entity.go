@@ -0,0 +1,18 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MonitorRunEntity - Ejecuciones del scheduler y su último heartbeat
+type MonitorRunEntity struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	StartedAt  time.Time `gorm:"not null;index"`
+	LastSeenAt time.Time `gorm:"not null;index"`
+}
+
+func (MonitorRunEntity) TableName() string {
+	return "monitor_runs"
+}
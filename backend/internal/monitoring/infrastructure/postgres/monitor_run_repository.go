@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"errors"
+	"time"
+	"uptrackai/internal/monitoring/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresMonitorRunRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresMonitorRunRepository(db *gorm.DB) *PostgresMonitorRunRepository {
+	return &PostgresMonitorRunRepository{db: db}
+}
+
+// Save hace upsert: el primer heartbeat crea la fila, los siguientes solo mueven last_seen_at
+func (r *PostgresMonitorRunRepository) Save(run *domain.MonitorRun) error {
+	entity := &MonitorRunEntity{
+		ID:         uuid.MustParse(run.ID().String()),
+		StartedAt:  run.StartedAt(),
+		LastSeenAt: run.LastSeenAt(),
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"last_seen_at"}),
+	}).Create(entity).Error
+}
+
+func (r *PostgresMonitorRunRepository) ListBetween(from time.Time, to time.Time) ([]*domain.MonitorRun, error) {
+	var entities []MonitorRunEntity
+	if err := r.db.Where("started_at <= ? AND last_seen_at >= ?", to, from).
+		Order("started_at ASC").
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	// La ejecución previa marca desde cuándo el monitor estaba caído al empezar la ventana
+	var previous MonitorRunEntity
+	err := r.db.Where("last_seen_at < ?", from).Order("last_seen_at DESC").First(&previous).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	runs := make([]*domain.MonitorRun, 0, len(entities)+1)
+	if err == nil {
+		runs = append(runs, toMonitorRun(&previous))
+	}
+	for i := range entities {
+		runs = append(runs, toMonitorRun(&entities[i]))
+	}
+	return runs, nil
+}
+
+func toMonitorRun(entity *MonitorRunEntity) *domain.MonitorRun {
+	return domain.NewFullMonitorRun(domain.MonitorRunId(entity.ID.String()), entity.StartedAt, entity.LastSeenAt)
+}
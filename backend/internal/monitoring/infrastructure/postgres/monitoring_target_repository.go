@@ -1,7 +1,9 @@
 package postgres
 
 import (
+	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 	domain "uptrackai/internal/monitoring/domain"
 	userdomain "uptrackai/internal/user/domain"
@@ -44,7 +46,8 @@ func (r *PostgresMonitoringTargetRepository) Save(target *domain.MonitoringTarge
 		// ⚠️ GORM Save con ID existente hace UPDATE.
 		// Si el registro no existiera (caso raro de race condition o borrado manual), Save daría 0 rows affected pero no error.
 		// Para robustez usamos Clauses(clause.OnConflict{UpdateAll: true}) que hace "INSERT ... ON CONFLICT UPDATE"
-		err = r.db.Clauses(clause.OnConflict{
+		// Las columnas de hosting las escribe solo UpdateHostingInfo (job aparte): no se pisan con datos viejos
+		err = r.db.Omit(hostingColumns...).Clauses(clause.OnConflict{
 			UpdateAll: true,
 		}).Create(entity).Error
 	}
@@ -176,6 +179,22 @@ func (r *PostgresMonitoringTargetRepository) ToggleActive(id domain.TargetId, is
 	return r.db.Model(&MonitoringTargetEntity{}).Where("id = ?", targetUUID).Update("is_active", isActive).Error
 }
 
+var hostingColumns = []string{"hosting_ips", "hosting_provider", "hosting_asn", "hosting_resolved_at"}
+
+// UpdateHostingInfo actualiza solo las columnas de hosting (el scheduler guarda el resto en paralelo)
+func (r *PostgresMonitoringTargetRepository) UpdateHostingInfo(id domain.TargetId, info domain.HostingInfo) error {
+	targetUUID, err := uuid.Parse(string(id))
+	if err != nil {
+		return err
+	}
+	return r.db.Model(&MonitoringTargetEntity{}).Where("id = ?", targetUUID).Updates(map[string]interface{}{
+		"hosting_ips":         strings.Join(info.IPs(), ","),
+		"hosting_provider":    info.Provider(),
+		"hosting_asn":         info.ASN(),
+		"hosting_resolved_at": encodeResolvedAt(info),
+	}).Error
+}
+
 // --- MAPPERS (privados, dentro del mismo archivo) ---
 
 func (r *PostgresMonitoringTargetRepository) toEntity(target *domain.MonitoringTarget) *MonitoringTargetEntity {
@@ -184,19 +203,34 @@ func (r *PostgresMonitoringTargetRepository) toEntity(target *domain.MonitoringT
 	userIdUUID := uuid.MustParse(target.UserId().String())
 
 	entity := &MonitoringTargetEntity{
-		ID:                   targetIdUUID,
-		UserID:               userIdUUID,
-		Name:                 target.Name(),
-		URL:                  target.Url(),
-		TargetType:           string(target.TargetType()),
-		IsActive:             target.IsActive(),
-		PreviousStatus:       string(target.PreviousStatus()),
-		CurrentStatus:        string(target.CurrentStatus()),
-		CheckIntervalSeconds: target.Configuration().CheckIntervalSeconds(),
-		TimeoutSeconds:       target.Configuration().TimeoutSeconds(),
-		RetryCount:           target.Configuration().RetryCount(),
-		RetryDelaySeconds:    target.Configuration().RetryDelaySeconds(),
-		NextCheckAt:          target.NextCheckAt(), // IMPORTANTE: Guardar el próximo chequeo calculado
+		ID:                      targetIdUUID,
+		UserID:                  userIdUUID,
+		Name:                    target.Name(),
+		URL:                     target.Url(),
+		TargetType:              string(target.TargetType()),
+		IsActive:                target.IsActive(),
+		PreviousStatus:          string(target.PreviousStatus()),
+		CurrentStatus:           string(target.CurrentStatus()),
+		CheckIntervalSeconds:    target.Configuration().CheckIntervalSeconds(),
+		TimeoutSeconds:          target.Configuration().TimeoutSeconds(),
+		RetryCount:              target.Configuration().RetryCount(),
+		RetryDelaySeconds:       target.Configuration().RetryDelaySeconds(),
+		DegradationFactor:       target.Configuration().Thresholds().DegradationFactor(),
+		MinLatencyThresholdMs:   target.Configuration().Thresholds().MinLatencyThresholdMs(),
+		MaxLatencyThresholdMs:   target.Configuration().Thresholds().MaxLatencyThresholdMs(),
+		UnstableMinPings:        target.Configuration().Thresholds().UnstableMinPings(),
+		UnstableMaxPings:        target.Configuration().Thresholds().UnstableMaxPings(),
+		BaselineMetric:          string(target.Configuration().Thresholds().BaselineMetric()),
+		AnomalyZThreshold:       target.Configuration().Thresholds().ZScoreThreshold(),
+		ErrorClassPolicy:        encodeErrorClassPolicy(target.Configuration().Thresholds().ErrorClassPolicy()),
+		HostingIPs:              strings.Join(target.HostingInfo().IPs(), ","),
+		HostingProvider:         target.HostingInfo().Provider(),
+		HostingASN:              target.HostingInfo().ASN(),
+		HostingResolvedAt:       encodeResolvedAt(target.HostingInfo()),
+		RawSamplesEnabled:       target.Configuration().RawSamplesEnabled(),
+		RawSamplesRetentionDays: target.Configuration().RawSamplesRetentionDays(),
+		AvailableStatuses:       encodeAvailabilityPolicy(target.Configuration().AvailabilityPolicy()),
+		NextCheckAt:             target.NextCheckAt(), // IMPORTANTE: Guardar el próximo chequeo calculado
 	}
 
 	// Solo mapear CreatedAt si ya existe (update), no en create
@@ -239,6 +273,33 @@ func (r *PostgresMonitoringTargetRepository) toDomain(entity *MonitoringTargetEn
 		interval,
 	)
 
+	// Filas antiguas (o valores corruptos) caen en los umbrales por defecto
+	thresholds, err := domain.NewAnalyzerThresholds(
+		entity.DegradationFactor,
+		entity.MinLatencyThresholdMs,
+		entity.MaxLatencyThresholdMs,
+		entity.UnstableMinPings,
+		entity.UnstableMaxPings,
+	)
+	if err != nil {
+		thresholds = domain.NewDefaultAnalyzerThresholds()
+	}
+	if withBaseline, err := thresholds.WithBaselineMetric(domain.BaselineMetric(entity.BaselineMetric)); err == nil {
+		thresholds = withBaseline
+	}
+	if withZScore, err := thresholds.WithZScoreThreshold(entity.AnomalyZThreshold); err == nil {
+		thresholds = withZScore
+	}
+	config.UpdateThresholds(thresholds.WithErrorClassPolicy(decodeErrorClassPolicy(entity.ErrorClassPolicy)))
+
+	if err := config.EnableRawSamples(entity.RawSamplesRetentionDays); err != nil {
+		_ = config.EnableRawSamples(domain.DefaultRawSamplesRetentionDays)
+	}
+	if !entity.RawSamplesEnabled {
+		config.DisableRawSamples()
+	}
+	config.UpdateAvailabilityPolicy(decodeAvailabilityPolicy(entity.AvailableStatuses))
+
 	previousStatus := domain.TargetStatus(entity.PreviousStatus)
 	currentStatus := domain.TargetStatus(entity.CurrentStatus)
 
@@ -248,7 +309,7 @@ func (r *PostgresMonitoringTargetRepository) toDomain(entity *MonitoringTargetEn
 		lastChecked = entity.UpdatedAt
 	}
 
-	return domain.NewFullMonitoringTarget(
+	target := domain.NewFullMonitoringTarget(
 		targetId,
 		userId,
 		entity.Name,
@@ -260,5 +321,84 @@ func (r *PostgresMonitoringTargetRepository) toDomain(entity *MonitoringTargetEn
 		currentStatus,
 		entity.CreatedAt,
 		lastChecked,
-	), nil
+	)
+	target.AssignHostingInfo(decodeHostingInfo(entity))
+
+	return target, nil
+}
+
+func encodeAvailabilityPolicy(policy domain.AvailabilityPolicy) string {
+	statuses := make([]string, 0, len(policy.Statuses()))
+	for _, status := range policy.Statuses() {
+		statuses = append(statuses, status.String())
+	}
+	return strings.Join(statuses, ",")
+}
+
+// decodeAvailabilityPolicy vuelve al default (solo UP) si la columna está vacía o es inválida
+func decodeAvailabilityPolicy(raw string) domain.AvailabilityPolicy {
+	if strings.TrimSpace(raw) == "" {
+		return domain.NewDefaultAvailabilityPolicy()
+	}
+	statuses := make([]domain.TargetStatus, 0)
+	for _, status := range strings.Split(raw, ",") {
+		statuses = append(statuses, domain.TargetStatus(strings.TrimSpace(status)))
+	}
+	policy, err := domain.NewAvailabilityPolicy(statuses)
+	if err != nil {
+		return domain.NewDefaultAvailabilityPolicy()
+	}
+	return policy
+}
+
+// encodeErrorClassPolicy guarda solo las reglas propias del target (nil si usa el default)
+func encodeErrorClassPolicy(policy domain.ErrorClassPolicy) *string {
+	overrides := policy.Overrides()
+	if len(overrides) == 0 {
+		return nil
+	}
+
+	raw, err := json.Marshal(overrides)
+	if err != nil {
+		return nil
+	}
+	encoded := string(raw)
+	return &encoded
+}
+
+// decodeErrorClassPolicy vuelve al default si el JSON guardado ya no es válido
+func decodeErrorClassPolicy(raw *string) domain.ErrorClassPolicy {
+	if raw == nil || *raw == "" {
+		return domain.NewDefaultErrorClassPolicy()
+	}
+
+	var overrides map[domain.ErrorClass]domain.TargetStatus
+	if err := json.Unmarshal([]byte(*raw), &overrides); err != nil {
+		return domain.NewDefaultErrorClassPolicy()
+	}
+	policy, err := domain.NewErrorClassPolicy(overrides)
+	if err != nil {
+		return domain.NewDefaultErrorClassPolicy()
+	}
+	return policy
+}
+
+func encodeResolvedAt(info domain.HostingInfo) *time.Time {
+	if !info.IsResolved() {
+		return nil
+	}
+	resolvedAt := info.ResolvedAt()
+	return &resolvedAt
+}
+
+func decodeHostingInfo(entity *MonitoringTargetEntity) domain.HostingInfo {
+	if entity.HostingResolvedAt == nil {
+		return domain.HostingInfo{}
+	}
+
+	var ips []string
+	if entity.HostingIPs != "" {
+		ips = strings.Split(entity.HostingIPs, ",")
+	}
+	return domain.NewHostingInfo(ips, entity.HostingProvider, entity.HostingASN, *entity.HostingResolvedAt)
 }
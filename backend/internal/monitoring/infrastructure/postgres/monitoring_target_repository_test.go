@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+	"uptrackai/internal/monitoring/domain"
+	userdomain "uptrackai/internal/user/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunDB - GORM sin conexión: arma las sentencias y aplica los defaults como en producción
+func dryRunDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 port=1"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected a dry-run connection, got %v", err)
+	}
+	return db
+}
+
+func TestMonitoringTargetRepository_SaveKeepsZeroMinLatency(t *testing.T) {
+	repo := NewPostgresMonitoringTargetRepository(dryRunDB(t))
+
+	thresholds, err := domain.NewAnalyzerThresholds(domain.DefaultDegradationFactor, 0, 0,
+		domain.DefaultUnstableMinPings, domain.DefaultUnstableMaxPings)
+	if err != nil {
+		t.Fatalf("Expected valid thresholds, got %v", err)
+	}
+	config := domain.NewDefaultCheckConfiguration()
+	config.UpdateThresholds(thresholds)
+	target := domain.NewFullMonitoringTarget(domain.TargetId(uuid.NewString()), userdomain.UserId(uuid.NewString()),
+		"API", "https://api.example.com", domain.TargetTypeAPI, config, true,
+		domain.TargetStatusUp, domain.TargetStatusUp, time.Now(), time.Now())
+
+	// Tanto el upsert de un target existente como el alta de uno nuevo
+	for _, saved := range []*domain.MonitoringTarget{target, withoutId(target)} {
+		loaded, err := repo.Save(saved)
+		if err != nil {
+			t.Fatalf("Expected save to succeed, got %v", err)
+		}
+		if got := loaded.Configuration().Thresholds().MinLatencyThresholdMs(); got != 0 {
+			t.Errorf("Expected min latency threshold 0 to round-trip, got %d", got)
+		}
+	}
+}
+
+// withoutId - Copia del target como alta nueva
+func withoutId(target *domain.MonitoringTarget) *domain.MonitoringTarget {
+	return domain.NewFullMonitoringTarget("", target.UserId(), target.Name(), target.Url(), target.TargetType(),
+		target.Configuration(), target.IsActive(), target.PreviousStatus(), target.CurrentStatus(), target.CreatedAt(), target.LastCheckedAt())
+}
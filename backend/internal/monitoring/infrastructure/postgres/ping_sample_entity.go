@@ -0,0 +1,24 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PingSampleEntity - Pings individuales de una sesión (opt-in por target, retención corta)
+type PingSampleEntity struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TargetID       uuid.UUID `gorm:"type:uuid;not null;index:idx_ping_target_session"`
+	SessionID      uuid.UUID `gorm:"type:uuid;not null;index:idx_ping_target_session"`
+	Sequence       int       `gorm:"not null"`
+	Timestamp      time.Time `gorm:"not null;index"` // Para la purga por retención
+	ResponseTimeMs int       `gorm:"not null"`
+	Status         string    `gorm:"type:varchar(50);not null"`
+	ErrorMessage   string    `gorm:"type:text"`
+	ErrorClass     string    `gorm:"type:varchar(30)"`
+}
+
+func (PingSampleEntity) TableName() string {
+	return "ping_samples"
+}
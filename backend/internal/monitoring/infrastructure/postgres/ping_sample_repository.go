@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"uptrackai/internal/monitoring/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PostgresPingSampleRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresPingSampleRepository(db *gorm.DB) *PostgresPingSampleRepository {
+	return &PostgresPingSampleRepository{db: db}
+}
+
+// SaveBatch guarda todos los pings de una sesión en un solo INSERT
+func (r *PostgresPingSampleRepository) SaveBatch(samples []*domain.PingSample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	entities := make([]PingSampleEntity, 0, len(samples))
+	for _, s := range samples {
+		entities = append(entities, *r.toEntity(s))
+	}
+	return r.db.Create(&entities).Error
+}
+
+// GetBySession obtiene los pings de una sesión en orden
+func (r *PostgresPingSampleRepository) GetBySession(targetId domain.TargetId, sessionId domain.SessionId) ([]*domain.PingSample, error) {
+	sessionUUID, err := uuid.Parse(sessionId.String())
+	if err != nil {
+		return nil, domain.ErrSessionNotFound
+	}
+
+	var entities []PingSampleEntity
+	err = r.db.Where("target_id = ? AND session_id = ?", uuid.MustParse(targetId.String()), sessionUUID).
+		Order("sequence ASC").
+		Find(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]*domain.PingSample, 0, len(entities))
+	for _, e := range entities {
+		samples = append(samples, r.toDomain(&e))
+	}
+	return samples, nil
+}
+
+// --- MAPPERS ---
+
+func (r *PostgresPingSampleRepository) toEntity(sample *domain.PingSample) *PingSampleEntity {
+	return &PingSampleEntity{
+		ID:             uuid.Must(uuid.NewV7()),
+		TargetID:       uuid.MustParse(sample.TargetId().String()),
+		SessionID:      uuid.MustParse(sample.SessionId().String()),
+		Sequence:       sample.Sequence(),
+		Timestamp:      sample.Timestamp(),
+		ResponseTimeMs: sample.ResponseTimeMs(),
+		Status:         sample.Status().String(),
+		ErrorMessage:   sample.ErrorMessage(),
+		ErrorClass:     sample.ErrorClass().String(),
+	}
+}
+
+func (r *PostgresPingSampleRepository) toDomain(entity *PingSampleEntity) *domain.PingSample {
+	return domain.NewFullPingSample(
+		domain.SessionId(entity.SessionID.String()),
+		domain.TargetId(entity.TargetID.String()),
+		entity.Sequence,
+		entity.Timestamp,
+		entity.ResponseTimeMs,
+		domain.TargetStatus(entity.Status),
+		entity.ErrorMessage,
+		domain.ErrorClass(entity.ErrorClass),
+	)
+}
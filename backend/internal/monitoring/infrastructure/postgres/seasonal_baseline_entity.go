@@ -0,0 +1,23 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SeasonalBaselineEntity - Línea base de latencia por hora de la semana (0..167, UTC) de cada target
+// Se reconstruye periódicamente a partir de la tabla metrics
+type SeasonalBaselineEntity struct {
+	TargetID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	HourOfWeek        int       `gorm:"primaryKey;autoIncrement:false"`
+	AvgResponseTimeMs int       `gorm:"not null;default:0"`
+	P95ResponseTimeMs int       `gorm:"not null;default:0"`
+	SampleCount       int       `gorm:"not null;default:0"`
+	WeekCount         int       `gorm:"not null;default:0"` // Semanas distintas en las que se observó esta hora
+	UpdatedAt         time.Time `gorm:"autoUpdateTime"`
+}
+
+func (SeasonalBaselineEntity) TableName() string {
+	return "seasonal_baselines"
+}
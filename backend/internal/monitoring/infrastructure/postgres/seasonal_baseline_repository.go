@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"time"
+	"uptrackai/internal/monitoring/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PostgresSeasonalBaselineRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresSeasonalBaselineRepository(db *gorm.DB) *PostgresSeasonalBaselineRepository {
+	return &PostgresSeasonalBaselineRepository{db: db}
+}
+
+// GetByTargetID obtiene los buckets de un target (vacío si todavía no se construyó ninguno)
+func (r *PostgresSeasonalBaselineRepository) GetByTargetID(targetId domain.TargetId) (*domain.SeasonalBaseline, error) {
+	targetUUID := uuid.MustParse(targetId.String())
+
+	var entities []SeasonalBaselineEntity
+	if err := r.db.Where("target_id = ?", targetUUID).Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	buckets := make([]domain.SeasonalBucket, 0, len(entities))
+	for _, e := range entities {
+		buckets = append(buckets, domain.NewSeasonalBucket(
+			e.HourOfWeek,
+			e.AvgResponseTimeMs,
+			e.P95ResponseTimeMs,
+			e.SampleCount,
+			e.WeekCount,
+		))
+	}
+
+	return domain.NewSeasonalBaseline(targetId, buckets), nil
+}
+
+// RebuildFromMetrics recalcula los buckets de todos los targets en una sola pasada sobre metrics.
+// Solo entran sesiones sanas, con el mismo criterio que los rollups: con tiempo de respuesta, sin
+// clase de error y no DOWN (las latencias parciales de una caída ensuciarían la línea "normal").
+// Los buckets se actualizan en el lugar; los que no recibieron datos en la ventana se borran después.
+func (r *PostgresSeasonalBaselineRepository) RebuildFromMetrics(since time.Time) error {
+	// Postgres guarda microsegundos: truncado, el DELETE final no confunde lo recién escrito con lo viejo
+	rebuiltAt := time.Now().Truncate(time.Microsecond)
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Exec(`
+			INSERT INTO seasonal_baselines
+				(target_id, hour_of_week, avg_response_time_ms, p95_response_time_ms, sample_count, week_count, updated_at)
+			SELECT
+				monitoring_target_id,
+				EXTRACT(DOW FROM timestamp AT TIME ZONE 'UTC')::int * 24 + EXTRACT(HOUR FROM timestamp AT TIME ZONE 'UTC')::int,
+				ROUND(AVG(response_time_ms))::int,
+				ROUND(percentile_cont(0.95) WITHIN GROUP (ORDER BY response_time_ms))::int,
+				COUNT(*),
+				COUNT(DISTINCT date_trunc('week', timestamp AT TIME ZONE 'UTC')),
+				?
+			FROM metrics
+			WHERE timestamp >= ? AND response_time_ms > 0
+				AND COALESCE(status, '') <> 'DOWN' AND COALESCE(error_class, '') = ''
+			GROUP BY 1, 2
+			ON CONFLICT (target_id, hour_of_week) DO UPDATE SET
+				avg_response_time_ms = EXCLUDED.avg_response_time_ms,
+				p95_response_time_ms = EXCLUDED.p95_response_time_ms,
+				sample_count = EXCLUDED.sample_count,
+				week_count = EXCLUDED.week_count,
+				updated_at = EXCLUDED.updated_at`, rebuiltAt, since).Error
+		if err != nil {
+			return err
+		}
+
+		// Horas sin sesiones sanas en la ventana: no se tocaron en esta pasada
+		return tx.Exec(`DELETE FROM seasonal_baselines WHERE updated_at < ?`, rebuiltAt).Error
+	})
+}
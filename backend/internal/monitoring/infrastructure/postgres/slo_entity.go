@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SLOEntity - Objetivos de nivel de servicio por target, con el último estado de alerta evaluado
+type SLOEntity struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TargetID           uuid.UUID  `gorm:"type:uuid;not null;index"`
+	UserID             uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Name               string     `gorm:"type:varchar(255)"`
+	Kind               string     `gorm:"type:varchar(20);not null"`
+	Objective          float64    `gorm:"not null"`
+	WindowDays         int        `gorm:"not null"`
+	LatencyThresholdMs int        `gorm:"default:0"`
+	AlertLevel         string     `gorm:"type:varchar(10);default:'NONE'"`
+	AlertChangedAt     *time.Time `gorm:"default:null"`
+	CreatedAt          time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time  `gorm:"autoUpdateTime"`
+}
+
+func (SLOEntity) TableName() string {
+	return "slos"
+}
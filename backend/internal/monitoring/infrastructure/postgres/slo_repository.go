@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"errors"
+	"uptrackai/internal/monitoring/domain"
+	userdomain "uptrackai/internal/user/domain"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PostgresSLORepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresSLORepository(db *gorm.DB) *PostgresSLORepository {
+	return &PostgresSLORepository{db: db}
+}
+
+func (r *PostgresSLORepository) Save(slo *domain.SLO) error {
+	if slo.ID() == "" {
+		if err := slo.AssignId(domain.SLOId(uuid.Must(uuid.NewV7()).String())); err != nil {
+			return err
+		}
+	}
+	return r.db.Clauses(clause.OnConflict{UpdateAll: true}).Create(r.toEntity(slo)).Error
+}
+
+func (r *PostgresSLORepository) GetByID(id domain.SLOId) (*domain.SLO, error) {
+	sloUUID, err := uuid.Parse(id.String())
+	if err != nil {
+		return nil, domain.ErrSLONotFound
+	}
+
+	var entity SLOEntity
+	if err := r.db.First(&entity, "id = ?", sloUUID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrSLONotFound
+		}
+		return nil, err
+	}
+	return r.toDomain(&entity), nil
+}
+
+func (r *PostgresSLORepository) Delete(id domain.SLOId) error {
+	sloUUID, err := uuid.Parse(id.String())
+	if err != nil {
+		return domain.ErrSLONotFound
+	}
+
+	result := r.db.Delete(&SLOEntity{}, "id = ?", sloUUID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrSLONotFound
+	}
+	return nil
+}
+
+func (r *PostgresSLORepository) ListByTarget(targetId domain.TargetId) ([]*domain.SLO, error) {
+	var entities []SLOEntity
+	if err := r.db.Where("target_id = ?", uuid.MustParse(targetId.String())).Order("created_at ASC").Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	return r.toDomainList(entities), nil
+}
+
+func (r *PostgresSLORepository) List() ([]*domain.SLO, error) {
+	var entities []SLOEntity
+	if err := r.db.Order("target_id, created_at ASC").Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	return r.toDomainList(entities), nil
+}
+
+// --- MAPPERS ---
+
+func (r *PostgresSLORepository) toEntity(slo *domain.SLO) *SLOEntity {
+	return &SLOEntity{
+		ID:                 uuid.MustParse(slo.ID().String()),
+		TargetID:           uuid.MustParse(slo.TargetId().String()),
+		UserID:             uuid.MustParse(slo.UserId().String()),
+		Name:               slo.Name(),
+		Kind:               slo.Kind().String(),
+		Objective:          slo.Objective(),
+		WindowDays:         slo.WindowDays(),
+		LatencyThresholdMs: slo.LatencyThresholdMs(),
+		AlertLevel:         slo.AlertLevel().String(),
+		AlertChangedAt:     encodeOptionalTime(slo.AlertChangedAt()),
+		CreatedAt:          slo.CreatedAt(),
+	}
+}
+
+func (r *PostgresSLORepository) toDomain(entity *SLOEntity) *domain.SLO {
+	return domain.NewFullSLO(
+		domain.SLOId(entity.ID.String()),
+		domain.TargetId(entity.TargetID.String()),
+		userdomain.UserId(entity.UserID.String()),
+		entity.Name,
+		domain.SLOKind(entity.Kind),
+		entity.Objective,
+		entity.WindowDays,
+		entity.LatencyThresholdMs,
+		domain.BurnAlertLevel(entity.AlertLevel),
+		decodeOptionalTime(entity.AlertChangedAt),
+		entity.CreatedAt,
+	)
+}
+
+func (r *PostgresSLORepository) toDomainList(entities []SLOEntity) []*domain.SLO {
+	slos := make([]*domain.SLO, 0, len(entities))
+	for i := range entities {
+		slos = append(slos, r.toDomain(&entities[i]))
+	}
+	return slos
+}
@@ -11,6 +11,12 @@ type TargetStatisticsEntity struct {
 	TargetID          uuid.UUID `gorm:"type:uuid;primaryKey"`
 	AvgResponseTimeMs int       `gorm:"not null;default:0"`
 	TotalChecksCount  int       `gorm:"not null;default:0"`
+	LatencySketch     string    `gorm:"type:jsonb;not null;default:'[]'"` // Histogramas diarios (p50/p90/p95/p99)
+	EwmaMeanMs        float64   `gorm:"not null;default:0"`               // Detector de anomalías
+	EwmaVariance      float64   `gorm:"not null;default:0"`
+	EwmaSamples       int       `gorm:"not null;default:0"`
+	RecentStates      string    `gorm:"type:text;not null;default:''"` // Últimos estados de sesión separados por coma
+	IsFlapping        bool      `gorm:"not null;default:false"`
 	LastUpdatedAt     time.Time `gorm:"autoUpdateTime"`
 	CreatedAt         time.Time `gorm:"autoCreateTime"`
 }
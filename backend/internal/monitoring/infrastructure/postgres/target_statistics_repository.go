@@ -1,6 +1,8 @@
 package postgres
 
 import (
+	"encoding/json"
+	"strings"
 	"uptrackai/internal/monitoring/domain"
 
 	"github.com/google/uuid"
@@ -34,6 +36,28 @@ func (r *PostgresTargetStatisticsRepository) Get(targetId domain.TargetId) (*dom
 	return r.toDomain(&entity)
 }
 
+// GetByTargets obtiene las estadísticas de varios targets en una sola consulta
+func (r *PostgresTargetStatisticsRepository) GetByTargets(targetIds []domain.TargetId) (map[domain.TargetId]*domain.TargetStatistics, error) {
+	stats := make(map[domain.TargetId]*domain.TargetStatistics, len(targetIds))
+	if len(targetIds) == 0 {
+		return stats, nil
+	}
+
+	var entities []TargetStatisticsEntity
+	if err := r.db.Where("target_id IN ?", targetUUIDs(targetIds)).Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range entities {
+		targetStats, err := r.toDomain(&entities[i])
+		if err != nil {
+			return nil, err
+		}
+		stats[targetStats.TargetId()] = targetStats
+	}
+	return stats, nil
+}
+
 // Save guarda o actualiza las estadísticas
 func (r *PostgresTargetStatisticsRepository) Save(stats *domain.TargetStatistics) error {
 	entity := r.toEntity(stats)
@@ -51,6 +75,12 @@ func (r *PostgresTargetStatisticsRepository) toEntity(stats *domain.TargetStatis
 		TargetID:          targetUUID,
 		AvgResponseTimeMs: stats.AvgResponseTimeMs(),
 		TotalChecksCount:  stats.TotalChecksCount(),
+		LatencySketch:     encodeLatencySketch(stats.LatencySketch()),
+		EwmaMeanMs:        stats.EWMA().Mean(),
+		EwmaVariance:      stats.EWMA().Variance(),
+		EwmaSamples:       stats.EWMA().Samples(),
+		RecentStates:      encodeRecentStates(stats.FlapHistory().RecentStates()),
+		IsFlapping:        stats.FlapHistory().IsFlapping(),
 	}
 }
 
@@ -64,5 +94,65 @@ func (r *PostgresTargetStatisticsRepository) toDomain(entity *TargetStatisticsEn
 		targetId,
 		entity.AvgResponseTimeMs,
 		entity.TotalChecksCount,
+		decodeLatencySketch(entity.LatencySketch),
+		domain.NewEWMAStats(entity.EwmaMeanMs, entity.EwmaVariance, entity.EwmaSamples),
+		domain.NewFlapHistory(decodeRecentStates(entity.RecentStates), entity.IsFlapping),
 	), nil
 }
+
+func encodeRecentStates(states []domain.TargetStatus) string {
+	parts := make([]string, 0, len(states))
+	for _, s := range states {
+		parts = append(parts, string(s))
+	}
+	return strings.Join(parts, ",")
+}
+
+func decodeRecentStates(raw string) []domain.TargetStatus {
+	if raw == "" {
+		return nil
+	}
+
+	parts := strings.Split(raw, ",")
+	states := make([]domain.TargetStatus, 0, len(parts))
+	for _, p := range parts {
+		states = append(states, domain.TargetStatus(p))
+	}
+	return states
+}
+
+// latencySketchDayJSON - Formato persistido de cada histograma diario
+type latencySketchDayJSON struct {
+	Day     int64       `json:"day"`
+	Buckets map[int]int `json:"buckets"`
+}
+
+func encodeLatencySketch(sketch *domain.LatencySketch) string {
+	days := make([]latencySketchDayJSON, 0, len(sketch.Days()))
+	for _, d := range sketch.Days() {
+		days = append(days, latencySketchDayJSON{Day: d.Day, Buckets: d.Buckets})
+	}
+
+	raw, err := json.Marshal(days)
+	if err != nil {
+		return "[]"
+	}
+	return string(raw)
+}
+
+// decodeLatencySketch reconstruye el sketch; si el JSON está corrupto se empieza de cero
+func decodeLatencySketch(raw string) *domain.LatencySketch {
+	var days []latencySketchDayJSON
+	if raw == "" || json.Unmarshal([]byte(raw), &days) != nil {
+		return domain.NewLatencySketch()
+	}
+
+	domainDays := make([]domain.LatencySketchDay, 0, len(days))
+	for _, d := range days {
+		if d.Buckets == nil {
+			d.Buckets = make(map[int]int)
+		}
+		domainDays = append(domainDays, domain.LatencySketchDay{Day: d.Day, Buckets: d.Buckets})
+	}
+	return domain.NewLatencySketchFromDays(domainDays)
+}
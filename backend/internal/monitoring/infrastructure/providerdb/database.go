@@ -0,0 +1,243 @@
+package providerdb
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"log"
+	"net/netip"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"uptrackai/internal/monitoring/domain"
+)
+
+//go:embed providers.csv
+var bundledDatabase string
+
+// ipRange - Rango [start, end] de IPs que pertenecen a un ASN/proveedor
+type ipRange struct {
+	start  netip.Addr
+	end    netip.Addr
+	record domain.ProviderRecord
+}
+
+// Database - Base offline IP -> proveedor/ASN. Implementa domain.ProviderDatabase.
+// Si se carga desde archivo, ReloadIfChanged la vuelve a leer cuando el archivo cambia.
+type Database struct {
+	mu      sync.RWMutex
+	ranges  []ipRange // Ordenados por start
+	path    string    // Vacío = base embebida
+	modTime time.Time
+}
+
+// NewBundledDatabase carga la semilla incluida en el binario
+func NewBundledDatabase() *Database {
+	ranges, err := parse(strings.NewReader(bundledDatabase))
+	if err != nil {
+		// Solo puede fallar si se edita mal providers.csv: se sigue sin proveedores (la correlación por IP funciona igual)
+		log.Printf("❌ Base de proveedores embebida inválida: %v", err)
+	}
+	return &Database{ranges: ranges}
+}
+
+// NewFileDatabase carga la base desde un archivo (CSV propio o TSV de ip2asn)
+func NewFileDatabase(path string) (*Database, error) {
+	db := &Database{path: path}
+	if _, err := db.ReloadIfChanged(); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// Load usa el archivo indicado o, si no hay, la semilla embebida
+func Load(path string) *Database {
+	if path == "" {
+		return NewBundledDatabase()
+	}
+
+	db, err := NewFileDatabase(path)
+	if err != nil {
+		log.Printf("⚠️  No se pudo cargar la base de proveedores %s (%v), usando la embebida", path, err)
+		return NewBundledDatabase()
+	}
+	return db
+}
+
+// ReloadIfChanged relee el archivo si cambió su fecha de modificación
+func (d *Database) ReloadIfChanged() (bool, error) {
+	if d.path == "" {
+		return false, nil
+	}
+
+	info, err := os.Stat(d.path)
+	if err != nil {
+		return false, err
+	}
+
+	d.mu.RLock()
+	unchanged := info.ModTime().Equal(d.modTime)
+	d.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	file, err := os.Open(d.path)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	ranges, err := parse(file)
+	if err != nil {
+		return false, err
+	}
+
+	d.mu.Lock()
+	d.ranges = ranges
+	d.modTime = info.ModTime()
+	d.mu.Unlock()
+	return true, nil
+}
+
+// Len devuelve cuántos rangos tiene la base
+func (d *Database) Len() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.ranges)
+}
+
+// Lookup busca el proveedor/ASN de una IP
+func (d *Database) Lookup(ip string) (domain.ProviderRecord, bool) {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return domain.ProviderRecord{}, false
+	}
+	addr = addr.Unmap()
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	// Último rango cuyo inicio es <= ip
+	i := sort.Search(len(d.ranges), func(i int) bool {
+		return d.ranges[i].start.Compare(addr) > 0
+	}) - 1
+	if i < 0 {
+		return domain.ProviderRecord{}, false
+	}
+
+	r := d.ranges[i]
+	if r.start.BitLen() != addr.BitLen() || addr.Compare(r.end) > 0 {
+		return domain.ProviderRecord{}, false
+	}
+	return r.record, true
+}
+
+// parse lee líneas "cidr,asn,proveedor" o "inicio\tfin\tasn\tpaís\tdescripción" (ip2asn)
+func parse(reader io.Reader) ([]ipRange, error) {
+	ranges := make([]ipRange, 0, 64)
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var (
+			r   ipRange
+			err error
+		)
+		if strings.Contains(line, "\t") {
+			r, err = parseIP2ASNLine(line)
+		} else {
+			r, err = parseCIDRLine(line)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("línea %d: %w", lineNumber, err)
+		}
+		if r.record.ASN == 0 {
+			continue // ip2asn marca los rangos sin anunciar con ASN 0
+		}
+		ranges = append(ranges, r)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].start.Compare(ranges[j].start) < 0
+	})
+	return ranges, nil
+}
+
+func parseCIDRLine(line string) (ipRange, error) {
+	fields := strings.Split(line, ",")
+	if len(fields) != 3 {
+		return ipRange{}, fmt.Errorf("se esperaban 3 campos (cidr,asn,proveedor): %q", line)
+	}
+
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(fields[0]))
+	if err != nil {
+		return ipRange{}, err
+	}
+	asn, err := strconv.Atoi(strings.TrimSpace(fields[1]))
+	if err != nil {
+		return ipRange{}, fmt.Errorf("asn inválido: %w", err)
+	}
+
+	prefix = prefix.Masked()
+	return ipRange{
+		start:  prefix.Addr(),
+		end:    lastAddr(prefix),
+		record: domain.ProviderRecord{ASN: asn, Provider: strings.TrimSpace(fields[2])},
+	}, nil
+}
+
+func parseIP2ASNLine(line string) (ipRange, error) {
+	fields := strings.Split(line, "\t")
+	if len(fields) < 5 {
+		return ipRange{}, fmt.Errorf("se esperaban 5 campos ip2asn: %q", line)
+	}
+
+	start, err := netip.ParseAddr(fields[0])
+	if err != nil {
+		return ipRange{}, err
+	}
+	end, err := netip.ParseAddr(fields[1])
+	if err != nil {
+		return ipRange{}, err
+	}
+	asn, err := strconv.Atoi(fields[2])
+	if err != nil {
+		return ipRange{}, fmt.Errorf("asn inválido: %w", err)
+	}
+
+	return ipRange{
+		start:  start.Unmap(),
+		end:    end.Unmap(),
+		record: domain.ProviderRecord{ASN: asn, Provider: strings.TrimSpace(fields[4])},
+	}, nil
+}
+
+// lastAddr devuelve la última IP de un prefijo (broadcast en IPv4)
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().AsSlice()
+	hostBits := len(bytes)*8 - prefix.Bits()
+	for i := len(bytes) - 1; i >= 0 && hostBits > 0; i-- {
+		take := hostBits
+		if take > 8 {
+			take = 8
+		}
+		bytes[i] |= byte(1<<take - 1)
+		hostBits -= take
+	}
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
@@ -2,9 +2,14 @@ package monitoring
 
 import (
 	"log"
+	"os"
+	"strconv"
+	"time"
 	"uptrackai/internal/monitoring/application"
 	"uptrackai/internal/monitoring/domain"
+	"uptrackai/internal/monitoring/infrastructure"
 	"uptrackai/internal/monitoring/infrastructure/postgres"
+	"uptrackai/internal/monitoring/infrastructure/providerdb"
 	"uptrackai/internal/monitoring/presentation"
 	"uptrackai/internal/monitoring/scheduler"
 	notificationApp "uptrackai/internal/notifications/application"
@@ -19,6 +24,13 @@ type Module struct {
 	metricsRepo         domain.MetricsRepository
 	checkRepo           domain.CheckResultRepository
 	statsRepo           domain.TargetStatisticsRepository
+	seasonalRepo        domain.SeasonalBaselineRepository
+	pingRepo            domain.PingSampleRepository
+	incidentRepo        domain.IncidentRepository
+	runRepo             domain.MonitorRunRepository
+	sloRepo             domain.SLORepository
+	sliMeter            *domain.SLIMeter
+	rollupRepo          domain.MetricRollupRepository
 	NotificationService *notificationApp.NotificationService
 	Dispatcher          *scheduler.NotificationDispatcher
 	Orchestrator        *scheduler.Orchestrator
@@ -29,30 +41,46 @@ func NewModule(db *gorm.DB, notificationService *notificationApp.NotificationSer
 	metricsRepo := postgres.NewPostgresMetricsRepository(db)
 	checkRepo := postgres.NewPostgresCheckResultRepository(db)
 	statsRepo := postgres.NewPostgresTargetStatisticsRepository(db)
+	seasonalRepo := postgres.NewPostgresSeasonalBaselineRepository(db)
+	pingRepo := postgres.NewPostgresPingSampleRepository(db)
+	incidentRepo := postgres.NewPostgresIncidentRepository(db)
+	maintenanceRepo := postgres.NewPostgresMaintenanceWindowRepository(db)
+	runRepo := postgres.NewPostgresMonitorRunRepository(db)
+	sloRepo := postgres.NewPostgresSLORepository(db)
+	rollupRepo := postgres.NewPostgresMetricRollupRepository(db)
 
 	service := application.NewMonitoringApplicationService(
 		targetRepo,
 		metricsRepo,
 		checkRepo,
 		statsRepo,
+		pingRepo,
+		incidentRepo,
+		maintenanceRepo,
+		runRepo,
+		sloRepo,
+		rollupRepo,
 	)
 
+	// Dry-run: mismo pipeline del scheduler, sin persistencia
+	service.SetProber(scheduler.NewDryRunProber())
+
+	// Líneas de tiempo de incidentes: qué alertas salieron y por qué canales
+	if notificationService != nil {
+		service.SetNotificationHistory(infrastructure.NewNotificationHistoryReader(notificationService))
+	}
+
 	handler := presentation.NewMonitoringHandler(service)
 
-	// Initialize Notification Dispatcher
-	dispatcher := scheduler.NewNotificationDispatcher(100)
-
-	// Start Notification Consumer
-	// This runs in the background and processes alerts from the monitoring system
-	go func() {
-		log.Println("🔔 Notification Dispatcher started")
-		for event := range dispatcher.Events() {
-			err := notificationService.Notify(event)
-			if err != nil {
-				log.Printf("❌ Error processing notification: %v", err)
-			}
-		}
-	}()
+	// Las alertas se persisten en el outbox de notifications; su worker las entrega
+	var notifier scheduler.AlertNotifier
+	if notificationService != nil {
+		notifier = notificationService
+	}
+	dispatcher := scheduler.NewNotificationDispatcher(notifier)
+
+	// Reconocer o cerrar un incidente también avisa (PagerDuty y similares sincronizan su incidente)
+	service.SetIncidentActivityPublisher(infrastructure.NewIncidentActivityNotifier(dispatcher))
 
 	return &Module{
 		Handler:             handler,
@@ -61,6 +89,13 @@ func NewModule(db *gorm.DB, notificationService *notificationApp.NotificationSer
 		metricsRepo:         metricsRepo,
 		checkRepo:           checkRepo,
 		statsRepo:           statsRepo,
+		seasonalRepo:        seasonalRepo,
+		pingRepo:            pingRepo,
+		incidentRepo:        incidentRepo,
+		runRepo:             runRepo,
+		sloRepo:             sloRepo,
+		rollupRepo:          rollupRepo,
+		sliMeter:            domain.NewSLIMeter(checkRepo, metricsRepo, maintenanceRepo, runRepo),
 		NotificationService: notificationService,
 		Dispatcher:          dispatcher,
 	}
@@ -89,14 +124,52 @@ func (m *Module) StartScheduler() {
 		m.metricsRepo,
 		m.checkRepo,
 		m.statsRepo,
+		m.seasonalRepo,
+		m.pingRepo,
+		m.incidentRepo,
 		m.Dispatcher,
 		notificationChecker,
 	)
 
+	// Heartbeat: los huecos entre ejecuciones se excluyen del uptime
+	heartbeat := scheduler.NewMonitorHeartbeat(m.runRepo, domain.MonitorHeartbeatInterval)
+	heartbeat.Start() // Non-blocking
+
+	// SLOs: presupuesto de error y alertas de quemado multi-ventana
+	sloEvaluator := scheduler.NewSLOEvaluator(m.sloRepo, m.targetRepo, m.sliMeter, m.Dispatcher, time.Minute)
+	sloEvaluator.Start() // Non-blocking
+
 	// Iniciar Polling Scheduler
 	pollingScheduler := scheduler.NewPollingScheduler(m.targetRepo, m.Orchestrator)
 	pollingScheduler.Start() // Non-blocking
 
+	// Líneas base estacionales: se recalculan cada hora desde metrics
+	baselineBuilder := scheduler.NewBaselineBuilder(m.seasonalRepo, time.Hour)
+	baselineBuilder.Start() // Non-blocking
+
+	// Rollups 1m/1h/1d: los rangos largos se sirven sin leer metrics crudo
+	metricsRollup := scheduler.NewMetricsRollup(m.rollupRepo, time.Minute)
+	metricsRollup.Start() // Non-blocking
+
+	// Hosting: IPs -> proveedor/ASN con la base offline (PROVIDER_DB_PATH reemplaza la embebida)
+	providers := providerdb.Load(os.Getenv("PROVIDER_DB_PATH"))
+	hostingResolver := scheduler.NewHostingResolver(m.targetRepo, providers, 10*time.Minute)
+	hostingResolver.Start() // Non-blocking
+
+	// Pronóstico de latencia: aviso INFO opt-in cuando el p95 diario se acerca al umbral de degradación
+	if os.Getenv("LATENCY_FORECAST_ALERTS") == "true" {
+		horizonDays := domain.DefaultForecastHorizonDays
+		if raw := os.Getenv("LATENCY_FORECAST_HORIZON_DAYS"); raw != "" {
+			if days, err := strconv.Atoi(raw); err == nil && days > 0 {
+				horizonDays = days
+			} else {
+				log.Printf("⚠️  LATENCY_FORECAST_HORIZON_DAYS inválido: %q (se usa %d)", raw, horizonDays)
+			}
+		}
+		latencyForecaster := scheduler.NewLatencyForecaster(m.targetRepo, m.statsRepo, m.rollupRepo, m.Dispatcher, horizonDays, 6*time.Hour)
+		latencyForecaster.Start() // Non-blocking
+	}
+
 	// Bloquear main goroutine
 	select {}
 }
@@ -1,6 +1,7 @@
 package presentation
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"uptrackai/internal/monitoring/application"
 	"uptrackai/internal/monitoring/domain"
 	"uptrackai/internal/server/middleware"
+	userdomain "uptrackai/internal/user/domain"
 
 	"github.com/gin-gonic/gin"
 )
@@ -37,6 +39,7 @@ func NewMonitoringHandler(appService *application.MonitoringApplicationService)
 func (h *MonitoringHandler) RegisterRoutes(router *gin.RouterGroup) {
 	router.GET("/targets", h.GetAllTargets)
 	router.POST("/targets", h.CreateTarget)
+	router.POST("/targets/test", h.TestTarget)
 	router.DELETE("/targets/:id", h.DeleteTarget)
 	router.PATCH("/targets/:id/toggle", h.ToggleActive)
 	router.PUT("/targets/:id/configuration", h.UpdateConfiguration)
@@ -44,6 +47,23 @@ func (h *MonitoringHandler) RegisterRoutes(router *gin.RouterGroup) {
 	router.GET("/targets/:id/metrics", h.GetTargetMetrics)
 	router.GET("/targets/:id/history", h.GetTargetHistory)
 	router.GET("/targets/:id/statistics", h.GetTargetStatistics)
+	router.GET("/targets/:id/sessions/:sessionId/pings", h.GetSessionPings)
+	router.GET("/targets/:id/maintenance", h.ListMaintenanceWindows)
+	router.POST("/targets/:id/maintenance", h.CreateMaintenanceWindow)
+	router.DELETE("/targets/:id/maintenance/:windowId", h.DeleteMaintenanceWindow)
+	router.GET("/targets/:id/slos", h.ListSLOs)
+	router.POST("/targets/:id/slos", h.CreateSLO)
+	router.GET("/targets/:id/slos/:sloId", h.GetSLO)
+	router.DELETE("/targets/:id/slos/:sloId", h.DeleteSLO)
+
+	router.GET("/incidents", h.ListIncidents)
+	router.GET("/incidents/:id", h.GetIncident)
+	router.GET("/incidents/:id/timeline", h.GetIncidentTimeline)
+	router.POST("/incidents/:id/acknowledge", h.AcknowledgeIncident)
+	router.POST("/incidents/:id/comments", h.CommentIncident)
+	router.POST("/incidents/:id/resolve", h.ResolveIncident)
+
+	router.GET("/reports/targets", h.GetTargetRanking)
 }
 
 // GetAllTargets obtiene todos los targets de monitoreo
@@ -160,6 +180,53 @@ func (h *MonitoringHandler) CreateTarget(c *gin.Context) {
 	c.JSON(http.StatusCreated, response)
 }
 
+// TestTarget ejecuta una sesión de sondeo sin persistir nada (dry-run)
+// @Summary Dry-run a monitoring target
+// @Description Run one probe session with the proposed target configuration and return status, latency, TLS info and assertion failures. Nothing is persisted.
+// @Tags monitoring
+// @Accept json
+// @Produce json
+// @Param request body CreateTargetRequest true "Target data (same payload as creation)"
+// @Success 200 {object} app.APIResponse{data=ProbeReportResponse}
+// @Failure 400 {object} app.APIResponse "Invalid request"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 503 {object} app.APIResponse "Prober not available"
+// @Security BearerAuth
+// @Router /targets/test [post]
+func (h *MonitoringHandler) TestTarget(c *gin.Context) {
+	var req CreateTargetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_body", "Invalid request body: "+err.Error())
+		return
+	}
+
+	userId, exists := middleware.GetUserID(c)
+	if !exists {
+		buildMonitoringErrorResponse(c, http.StatusUnauthorized, "user_id_missing", "User ID not found in context")
+		return
+	}
+
+	cmd := application.TestTargetCommand{
+		UserID:     userId,
+		Name:       req.Name,
+		URL:        req.URL,
+		TargetType: domain.TargetType(req.Type),
+	}
+	dto, err := h.appService.TestTarget(cmd)
+	if err != nil {
+		if errors.Is(err, application.ErrProberUnavailable) {
+			buildMonitoringErrorResponse(c, http.StatusServiceUnavailable, "prober_unavailable", err.Error())
+			return
+		}
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "probe_failed", "Failed to probe target: "+err.Error())
+		return
+	}
+
+	response := app.BuildOKResponse("target_probed", true, dto).
+		WithLink("create", "/api/v1/targets")
+	c.JSON(http.StatusOK, response)
+}
+
 // DeleteTarget elimina un target existente
 // @Summary Delete monitoring target
 // @Description Delete a specific monitoring target. User must own the target.
@@ -323,6 +390,21 @@ func (h *MonitoringHandler) UpdateConfiguration(c *gin.Context) {
 		CheckIntervalSeconds int  `json:"check_interval_seconds" binding:"required,min=30,max=3600"`
 		AlertOnFailure       bool `json:"alert_on_failure"`
 		AlertOnRecovery      bool `json:"alert_on_recovery"`
+		// Umbrales del analizador (opcionales)
+		DegradationFactor     *float64 `json:"degradation_factor" binding:"omitempty,min=1"`
+		MinLatencyThresholdMs *int     `json:"min_latency_threshold_ms" binding:"omitempty,min=0"`
+		MaxLatencyThresholdMs *int     `json:"max_latency_threshold_ms" binding:"omitempty,min=0"`
+		UnstableMinPings      *int     `json:"unstable_min_pings" binding:"omitempty,min=3,max=12"`
+		UnstableMaxPings      *int     `json:"unstable_max_pings" binding:"omitempty,min=3,max=12"`
+		BaselineMetric        *string  `json:"baseline_metric" binding:"omitempty,oneof=MEAN P95"`
+		AnomalyZThreshold     *float64 `json:"anomaly_z_threshold" binding:"omitempty,min=1,max=10"`
+		// Pings crudos por sesión (opt-in)
+		StoreRawSamples         *bool `json:"store_raw_samples"`
+		RawSamplesRetentionDays *int  `json:"raw_samples_retention_days" binding:"omitempty,min=1,max=14"`
+		// Qué estado confirma cada clase de error (dns, http_4xx, ...)
+		ErrorClassPolicy map[string]string `json:"error_class_policy"`
+		// Estados que cuentan como disponibles para el uptime (UP obligatorio)
+		AvailableStatuses []string `json:"available_statuses"`
 	}
 
 	if err := c.ShouldBindJSON(&requestBody); err != nil {
@@ -331,19 +413,48 @@ func (h *MonitoringHandler) UpdateConfiguration(c *gin.Context) {
 	}
 
 	cmd := application.UpdateConfigurationCommand{
-		TargetID:             domain.TargetId(targetID),
-		UserID:               userId,
-		Role:                 role,
-		TimeoutSeconds:       requestBody.TimeoutSeconds,
-		RetryCount:           requestBody.RetryCount,
-		RetryDelaySeconds:    requestBody.RetryDelaySeconds,
-		CheckIntervalSeconds: requestBody.CheckIntervalSeconds,
-		AlertOnFailure:       requestBody.AlertOnFailure,
-		AlertOnRecovery:      requestBody.AlertOnRecovery,
+		TargetID:                domain.TargetId(targetID),
+		UserID:                  userId,
+		Role:                    role,
+		TimeoutSeconds:          requestBody.TimeoutSeconds,
+		RetryCount:              requestBody.RetryCount,
+		RetryDelaySeconds:       requestBody.RetryDelaySeconds,
+		CheckIntervalSeconds:    requestBody.CheckIntervalSeconds,
+		AlertOnFailure:          requestBody.AlertOnFailure,
+		AlertOnRecovery:         requestBody.AlertOnRecovery,
+		DegradationFactor:       requestBody.DegradationFactor,
+		MinLatencyThresholdMs:   requestBody.MinLatencyThresholdMs,
+		MaxLatencyThresholdMs:   requestBody.MaxLatencyThresholdMs,
+		UnstableMinPings:        requestBody.UnstableMinPings,
+		UnstableMaxPings:        requestBody.UnstableMaxPings,
+		BaselineMetric:          requestBody.BaselineMetric,
+		AnomalyZThreshold:       requestBody.AnomalyZThreshold,
+		StoreRawSamples:         requestBody.StoreRawSamples,
+		RawSamplesRetentionDays: requestBody.RawSamplesRetentionDays,
+		ErrorClassPolicy:        requestBody.ErrorClassPolicy,
+		AvailableStatuses:       requestBody.AvailableStatuses,
 	}
 
 	dto, err := h.appService.UpdateConfiguration(cmd)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidDegradationFactor) ||
+			errors.Is(err, domain.ErrInvalidLatencyThreshold) ||
+			errors.Is(err, domain.ErrInvalidUnstableBand) ||
+			errors.Is(err, domain.ErrInvalidBaselineMetric) ||
+			errors.Is(err, domain.ErrInvalidZScoreThreshold) ||
+			errors.Is(err, domain.ErrInvalidErrorClass) ||
+			errors.Is(err, domain.ErrInvalidErrorClassStatus) {
+			buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_thresholds", err.Error())
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidAvailabilityStatus) || errors.Is(err, domain.ErrAvailabilityRequiresUp) {
+			buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_available_statuses", err.Error())
+			return
+		}
+		if errors.Is(err, domain.ErrInvalidRawSamplesRetention) {
+			buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_raw_samples_retention", err.Error())
+			return
+		}
 		if err.Error() == "unauthorized: user does not own this target" {
 			buildMonitoringErrorResponse(c, http.StatusForbidden, "forbidden", err.Error())
 			return
@@ -407,15 +518,21 @@ func (h *MonitoringHandler) GetTargetByID(c *gin.Context) {
 
 // GetTargetMetrics obtiene el historial de métricas de un target
 // @Summary Get target metrics
-// @Description Retrieve metrics history for a specific monitoring target
+// @Description Retrieve metrics history for a specific monitoring target. Without `from` it returns the last N sessions.
+// @Description With `from` it returns a series for the range: raw sessions up to 6h, then 1m/1h/1d rollups (min, max, avg, p95, count, failures) so long ranges stay fast.
 // @Tags monitoring
 // @Accept json
 // @Produce json
 // @Param id path string true "Target ID"
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(100)
+// @Param from query string false "Range start (RFC3339, now or relative such as -24h or -7d)"
+// @Param to query string false "Range end (RFC3339, now or relative; defaults to now)"
+// @Param resolution query string false "Force a resolution instead of the automatic one (ignored with step)" Enums(raw, 1m, 1h, 1d)
+// @Param step query string false "Bucket width for chart-ready output, at least 1m (e.g. 5m, 6h, 1d)"
 // @Success 200 {object} app.APIResponse{data=[]MetricResponse}
-// @Failure 400 {object} app.APIResponse "Invalid target ID"
+// @Success 200 {object} app.APIResponse{data=MetricSeriesResponse} "When from is given"
+// @Failure 400 {object} app.APIResponse "Invalid target ID, range, resolution or step"
 // @Failure 401 {object} app.APIResponse "Unauthorized"
 // @Failure 403 {object} app.APIResponse "Forbidden"
 // @Security BearerAuth
@@ -436,6 +553,12 @@ func (h *MonitoringHandler) GetTargetMetrics(c *gin.Context) {
 		return
 	}
 
+	// Con rango: serie con resolución automática (o la pedida)
+	if c.Query("from") != "" {
+		h.getTargetMetricSeries(c, targetId, userId)
+		return
+	}
+
 	// Parsear parámetros de paginación
 	page := 1
 	if pageParam := c.Query("page"); pageParam != "" {
@@ -472,17 +595,75 @@ func (h *MonitoringHandler) GetTargetMetrics(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+func (h *MonitoringHandler) getTargetMetricSeries(c *gin.Context, targetId domain.TargetId, userId userdomain.UserId) {
+	from, err := parseTimeParam(c.Query("from"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_from", "from must be RFC3339, now or relative (e.g. -24h)")
+		return
+	}
+	to, err := parseTimeParam(c.Query("to"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_to", "to must be RFC3339, now or relative (e.g. -24h)")
+		return
+	}
+	step, err := domain.ParseMetricStep(c.Query("step"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_step", err.Error())
+		return
+	}
+
+	dto, err := h.appService.GetTargetMetricSeries(application.GetTargetMetricSeriesQuery{
+		TargetID:   targetId,
+		UserID:     userId,
+		From:       from,
+		To:         to,
+		Resolution: c.Query("resolution"),
+		Step:       step,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTimeRange):
+			buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_range", err.Error())
+		case errors.Is(err, domain.ErrInvalidMetricResolution), errors.Is(err, domain.ErrMetricResolutionTooFine):
+			buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_resolution", err.Error())
+		case isMetricStepError(err):
+			buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_step", err.Error())
+		case strings.HasPrefix(err.Error(), "unauthorized"):
+			buildMonitoringErrorResponse(c, http.StatusForbidden, "forbidden", err.Error())
+		default:
+			buildMonitoringErrorResponse(c, http.StatusInternalServerError, "metrics_failed", "Failed to fetch metrics")
+		}
+		return
+	}
+
+	response := app.BuildOKResponse("target_metrics_retrieved", true, dto).
+		WithLink("self", "/api/v1/targets/"+targetId.String()+"/metrics").
+		WithLink("target", "/api/v1/targets/"+targetId.String())
+	c.JSON(http.StatusOK, response)
+}
+
+// isMetricStepError agrupa los errores de un step que no se puede servir
+func isMetricStepError(err error) bool {
+	return errors.Is(err, domain.ErrInvalidMetricStep) ||
+		errors.Is(err, domain.ErrMetricStepTooFine) ||
+		errors.Is(err, domain.ErrMetricStepUnaligned)
+}
+
 // GetTargetHistory obtiene el historial de cambios de estado (check results)
 // @Summary Get target status history
-// @Description Retrieve status change history for a specific monitoring target
+// @Description Retrieve status change history for a specific monitoring target. With `from` it returns every change in the range (oldest first) instead of the latest `limit`; adding `step` returns a timeline with the worst status of each bucket.
 // @Tags monitoring
 // @Accept json
 // @Produce json
 // @Param id path string true "Target ID"
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(50)
+// @Param from query string false "Range start (RFC3339, now or relative such as -24h or -7d)"
+// @Param to query string false "Range end (RFC3339, now or relative; defaults to now)"
+// @Param step query string false "Bucket width for the status timeline, at least 1m (e.g. 15m, 1h); requires from"
 // @Success 200 {object} app.APIResponse{data=[]CheckResultResponse}
-// @Failure 400 {object} app.APIResponse "Invalid target ID"
+// @Success 200 {object} app.APIResponse{data=StatusTimelineResponse} "When step is given"
+// @Failure 400 {object} app.APIResponse "Invalid target ID, range or step"
 // @Failure 401 {object} app.APIResponse "Unauthorized"
 // @Failure 403 {object} app.APIResponse "Forbidden"
 // @Security BearerAuth
@@ -518,13 +699,50 @@ func (h *MonitoringHandler) GetTargetHistory(c *gin.Context) {
 		}
 	}
 
+	// Rango opcional (RFC3339 o relativo); con step se devuelve la línea de tiempo por tramos
+	from, err := parseTimeParam(c.Query("from"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_from", "from must be RFC3339, now or relative (e.g. -24h)")
+		return
+	}
+	to, err := parseTimeParam(c.Query("to"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_to", "to must be RFC3339, now or relative (e.g. -24h)")
+		return
+	}
+	step, err := domain.ParseMetricStep(c.Query("step"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_step", err.Error())
+		return
+	}
+	if from.IsZero() && (!to.IsZero() || step > 0) {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_range", "to and step require from")
+		return
+	}
+	if step > 0 {
+		h.getTargetStatusTimeline(c, application.GetTargetStatusTimelineQuery{
+			TargetID: targetId,
+			UserID:   userId,
+			From:     from,
+			To:       to,
+			Step:     step,
+		})
+		return
+	}
+
 	// Ejecutar query a través de application service (retorna DTOs)
 	query := application.GetTargetHistoryQuery{
 		TargetID: targetId,
 		UserID:   userId,
 		Limit:    limit,
+		From:     from,
+		To:       to,
 	}
 	dtos, err := h.appService.GetTargetHistory(query)
+	if errors.Is(err, domain.ErrInvalidTimeRange) {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_range", err.Error())
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
@@ -539,13 +757,38 @@ func (h *MonitoringHandler) GetTargetHistory(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+func (h *MonitoringHandler) getTargetStatusTimeline(c *gin.Context, query application.GetTargetStatusTimelineQuery) {
+	dto, err := h.appService.GetTargetStatusTimeline(query)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTimeRange):
+			buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_range", err.Error())
+		case isMetricStepError(err):
+			buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_step", err.Error())
+		case strings.HasPrefix(err.Error(), "unauthorized"):
+			buildMonitoringErrorResponse(c, http.StatusForbidden, "forbidden", err.Error())
+		default:
+			buildMonitoringErrorResponse(c, http.StatusInternalServerError, "history_failed", "Failed to fetch history")
+		}
+		return
+	}
+
+	response := app.BuildOKResponse("target_history_retrieved", true, dto).
+		WithLink("self", "/api/v1/targets/"+query.TargetID.String()+"/history").
+		WithLink("target", "/api/v1/targets/"+query.TargetID.String())
+	c.JSON(http.StatusOK, response)
+}
+
 // GetTargetStatistics obtiene las estadísticas agregadas de un target
 // @Summary Get target statistics
-// @Description Retrieve aggregated statistics for a specific monitoring target
+// @Description Retrieve aggregated statistics for a specific monitoring target, including uptime for the 24h/7d/30d/90d windows. Pass from (and optionally to) to also get the uptime of an arbitrary range under the "range" key. The forecast field fits a linear trend over the last 28 days of daily p95 and projects when it would cross the target's degradation threshold (null with fewer than 7 days of data).
 // @Tags monitoring
 // @Accept json
 // @Produce json
 // @Param id path string true "Target ID"
+// @Param from query string false "Range start (RFC3339, now or relative such as -7d)"
+// @Param to query string false "Range end (RFC3339, now or relative; defaults to now)"
+// @Param forecast_days query int false "Forecast horizon in days: at_risk is true when the crossing falls within it" default(14)
 // @Success 200 {object} app.APIResponse{data=StatisticsResponse}
 // @Failure 400 {object} app.APIResponse "Invalid target ID"
 // @Failure 401 {object} app.APIResponse "Unauthorized"
@@ -568,13 +811,43 @@ func (h *MonitoringHandler) GetTargetStatistics(c *gin.Context) {
 		return
 	}
 
+	// Rango arbitrario de uptime (opcional)
+	from, err := parseTimeParam(c.Query("from"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_from", "from must be RFC3339, now or relative (e.g. -24h)")
+		return
+	}
+	to, err := parseTimeParam(c.Query("to"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_to", "to must be RFC3339, now or relative (e.g. -24h)")
+		return
+	}
+	if from.IsZero() && !to.IsZero() {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_range", "to requires from")
+		return
+	}
+	forecastDays := 0
+	if daysParam := c.Query("forecast_days"); daysParam != "" {
+		if forecastDays, err = strconv.Atoi(daysParam); err != nil || forecastDays <= 0 {
+			buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_forecast_days", "forecast_days must be a positive integer")
+			return
+		}
+	}
+
 	// Ejecutar query a través de application service (retorna DTO)
 	query := application.GetTargetStatisticsQuery{
-		TargetID: targetId,
-		UserID:   userId,
+		TargetID:            targetId,
+		UserID:              userId,
+		From:                from,
+		To:                  to,
+		ForecastHorizonDays: forecastDays,
 	}
 	dto, err := h.appService.GetTargetStatistics(query)
 	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTimeRange) {
+			buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_range", err.Error())
+			return
+		}
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
@@ -587,3 +860,64 @@ func (h *MonitoringHandler) GetTargetStatistics(c *gin.Context) {
 		WithLink("history", "/api/v1/targets/"+idStr+"/history")
 	c.JSON(http.StatusOK, response)
 }
+
+// GetSessionPings obtiene los pings crudos de una sesión de verificación
+// @Summary Get raw pings of a check session
+// @Description Retrieve the individual pings of a check session (only for targets with store_raw_samples enabled, within their retention)
+// @Tags monitoring
+// @Accept json
+// @Produce json
+// @Param id path string true "Target ID"
+// @Param sessionId path string true "Session ID (session_id from metrics or history)"
+// @Success 200 {object} app.APIResponse{data=[]PingSampleResponse}
+// @Failure 400 {object} app.APIResponse "Invalid target or session ID"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Failure 404 {object} app.APIResponse "No pings stored for this session"
+// @Security BearerAuth
+// @Router /targets/{id}/sessions/{sessionId}/pings [get]
+func (h *MonitoringHandler) GetSessionPings(c *gin.Context) {
+	idStr := c.Param("id")
+	targetId, err := domain.NewTargetId(idStr)
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_id", "Invalid target ID format")
+		return
+	}
+
+	sessionStr := c.Param("sessionId")
+	sessionId, err := domain.NewSessionId(sessionStr)
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_session_id", "Invalid session ID format")
+		return
+	}
+
+	userId, exists := middleware.GetUserID(c)
+	if !exists {
+		buildMonitoringErrorResponse(c, http.StatusUnauthorized, "user_id_missing", "User ID not found in context")
+		return
+	}
+
+	dtos, err := h.appService.GetSessionPings(application.GetSessionPingsQuery{
+		TargetID:  targetId,
+		SessionID: sessionId,
+		UserID:    userId,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			buildMonitoringErrorResponse(c, http.StatusNotFound, "session_not_found", err.Error())
+			return
+		}
+		if err.Error() == "unauthorized: user does not own this target" {
+			buildMonitoringErrorResponse(c, http.StatusForbidden, "forbidden", err.Error())
+			return
+		}
+		buildMonitoringErrorResponse(c, http.StatusInternalServerError, "fetch_pings_failed", "Failed to fetch session pings")
+		return
+	}
+
+	response := app.BuildOKResponse("session_pings_retrieved", true, dtos).
+		WithLink("self", "/api/v1/targets/"+idStr+"/sessions/"+sessionStr+"/pings").
+		WithLink("target", "/api/v1/targets/"+idStr).
+		WithLink("history", "/api/v1/targets/"+idStr+"/history")
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,337 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"uptrackai/internal/app"
+	"uptrackai/internal/monitoring/application"
+	"uptrackai/internal/monitoring/domain"
+	"uptrackai/internal/server/middleware"
+	userdomain "uptrackai/internal/user/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxIncidentsPerPage = 500
+
+// ListIncidents lista los incidentes del usuario con filtros y MTTA/MTTR
+// @Summary List incidents
+// @Description List incidents of the authenticated user's targets (newest first), with MTTA/MTTR computed over the filtered set
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Param status query string false "OPEN | ACKNOWLEDGED | RESOLVED"
+// @Param target_id query string false "Only incidents of this target"
+// @Param from query string false "Opened at or after (RFC3339)"
+// @Param to query string false "Opened before (RFC3339)"
+// @Param limit query int false "Max incidents" default(50)
+// @Success 200 {object} app.APIResponse{data=IncidentListResponse}
+// @Failure 400 {object} app.APIResponse "Invalid filter"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 500 {object} app.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /incidents [get]
+func (h *MonitoringHandler) ListIncidents(c *gin.Context) {
+	userId, exists := middleware.GetUserID(c)
+	if !exists {
+		buildMonitoringErrorResponse(c, http.StatusUnauthorized, "user_id_missing", "User ID not found in context")
+		return
+	}
+
+	query := application.ListIncidentsQuery{UserID: userId, Limit: 50}
+
+	if statusParam := c.Query("status"); statusParam != "" {
+		status, err := domain.NewIncidentStatus(statusParam)
+		if err != nil {
+			buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_status", err.Error())
+			return
+		}
+		query.Status = status
+	}
+	if targetParam := c.Query("target_id"); targetParam != "" {
+		query.TargetID = domain.TargetId(targetParam)
+	}
+
+	var err error
+	if query.Since, err = parseTimeParam(c.Query("from")); err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_from", "from must be RFC3339, now or relative (e.g. -24h)")
+		return
+	}
+	if query.Until, err = parseTimeParam(c.Query("to")); err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_to", "to must be RFC3339, now or relative (e.g. -24h)")
+		return
+	}
+
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 {
+			query.Limit = min(parsedLimit, maxIncidentsPerPage)
+		}
+	}
+
+	dto, err := h.appService.ListIncidents(query)
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusInternalServerError, "fetch_incidents_failed", "Failed to fetch incidents")
+		return
+	}
+
+	response := app.BuildOKResponse("incidents_retrieved", true, dto).
+		WithLink("self", "/api/v1/incidents")
+	c.JSON(http.StatusOK, response)
+}
+
+// GetIncident obtiene un incidente con su línea de tiempo
+// @Summary Get incident
+// @Description Retrieve an incident with its timeline, duration and time to acknowledge/resolve
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Param id path string true "Incident ID"
+// @Success 200 {object} app.APIResponse{data=IncidentResponse}
+// @Failure 400 {object} app.APIResponse "Invalid incident ID"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Failure 404 {object} app.APIResponse "Incident not found"
+// @Security BearerAuth
+// @Router /incidents/{id} [get]
+func (h *MonitoringHandler) GetIncident(c *gin.Context) {
+	incidentId, userId, ok := incidentRequestContext(c)
+	if !ok {
+		return
+	}
+
+	dto, err := h.appService.GetIncident(application.GetIncidentQuery{IncidentID: incidentId, UserID: userId})
+	if err != nil {
+		writeIncidentError(c, err)
+		return
+	}
+
+	respondIncident(c, "incident_retrieved", dto)
+}
+
+// GetIncidentTimeline exporta la línea de tiempo del incidente para el post-mortem
+// @Summary Export incident timeline
+// @Description Timeline of the incident built from status changes, the first failing ping (when raw samples are stored), notifications sent (with channels), acknowledgements and recovery. format=markdown returns a post-mortem draft as text/markdown.
+// @Tags incidents
+// @Accept json
+// @Produce json,text/markdown
+// @Param id path string true "Incident ID"
+// @Param format query string false "json | markdown" default(json)
+// @Success 200 {object} app.APIResponse{data=IncidentTimelineResponse}
+// @Failure 400 {object} app.APIResponse "Invalid incident ID or format"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Failure 404 {object} app.APIResponse "Incident not found"
+// @Security BearerAuth
+// @Router /incidents/{id}/timeline [get]
+func (h *MonitoringHandler) GetIncidentTimeline(c *gin.Context) {
+	incidentId, userId, ok := incidentRequestContext(c)
+	if !ok {
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", "json"))
+	if format != "json" && format != "markdown" && format != "md" {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_format", "format must be json or markdown")
+		return
+	}
+
+	dto, err := h.appService.GetIncidentTimeline(application.GetIncidentTimelineQuery{IncidentID: incidentId, UserID: userId})
+	if err != nil {
+		writeIncidentError(c, err)
+		return
+	}
+
+	if format != "json" {
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(application.RenderTimelineMarkdown(*dto)))
+		return
+	}
+
+	response := app.BuildOKResponse("incident_timeline_retrieved", true, dto).
+		WithLink("self", "/api/v1/incidents/"+dto.IncidentID+"/timeline").
+		WithLink("markdown", "/api/v1/incidents/"+dto.IncidentID+"/timeline?format=markdown").
+		WithLink("incident", "/api/v1/incidents/"+dto.IncidentID)
+	c.JSON(http.StatusOK, response)
+}
+
+// AcknowledgeIncident marca que alguien se hizo cargo del incidente
+// @Summary Acknowledge incident
+// @Description Record who is handling the incident (with an optional note). Sets the time to acknowledge.
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Param id path string true "Incident ID"
+// @Param request body IncidentNoteRequest false "Optional note"
+// @Success 200 {object} app.APIResponse{data=IncidentResponse}
+// @Failure 400 {object} app.APIResponse "Invalid incident ID"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Failure 404 {object} app.APIResponse "Incident not found"
+// @Failure 409 {object} app.APIResponse "Already acknowledged or resolved, or modified concurrently"
+// @Security BearerAuth
+// @Router /incidents/{id}/acknowledge [post]
+func (h *MonitoringHandler) AcknowledgeIncident(c *gin.Context) {
+	incidentId, userId, ok := incidentRequestContext(c)
+	if !ok {
+		return
+	}
+	req, ok := bindIncidentNote(c)
+	if !ok {
+		return
+	}
+
+	dto, err := h.appService.AcknowledgeIncident(application.AcknowledgeIncidentCommand{
+		IncidentID: incidentId,
+		UserID:     userId,
+		Note:       req.Note,
+	})
+	if err != nil {
+		writeIncidentError(c, err)
+		return
+	}
+
+	respondIncident(c, "incident_acknowledged", dto)
+}
+
+// CommentIncident agrega un comentario a la línea de tiempo
+// @Summary Comment on incident
+// @Description Add a note to the incident timeline (also allowed once resolved, for post-mortems)
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Param id path string true "Incident ID"
+// @Param request body IncidentNoteRequest true "Comment"
+// @Success 201 {object} app.APIResponse{data=IncidentResponse}
+// @Failure 400 {object} app.APIResponse "Invalid incident ID or empty note"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Failure 404 {object} app.APIResponse "Incident not found"
+// @Failure 409 {object} app.APIResponse "Modified concurrently"
+// @Security BearerAuth
+// @Router /incidents/{id}/comments [post]
+func (h *MonitoringHandler) CommentIncident(c *gin.Context) {
+	incidentId, userId, ok := incidentRequestContext(c)
+	if !ok {
+		return
+	}
+	req, ok := bindIncidentNote(c)
+	if !ok {
+		return
+	}
+
+	dto, err := h.appService.CommentIncident(application.CommentIncidentCommand{
+		IncidentID: incidentId,
+		UserID:     userId,
+		Note:       req.Note,
+	})
+	if err != nil {
+		writeIncidentError(c, err)
+		return
+	}
+
+	response := app.BuildOKResponse("incident_commented", true, dto).
+		WithLink("self", "/api/v1/incidents/"+dto.ID).
+		WithLink("target", "/api/v1/targets/"+dto.TargetID)
+	c.JSON(http.StatusCreated, response)
+}
+
+// ResolveIncident cierra el incidente a mano
+// @Summary Close incident
+// @Description Resolve the incident manually without waiting for the target to recover
+// @Tags incidents
+// @Accept json
+// @Produce json
+// @Param id path string true "Incident ID"
+// @Param request body IncidentNoteRequest false "Optional resolution note"
+// @Success 200 {object} app.APIResponse{data=IncidentResponse}
+// @Failure 400 {object} app.APIResponse "Invalid incident ID"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Failure 404 {object} app.APIResponse "Incident not found"
+// @Failure 409 {object} app.APIResponse "Already resolved, or modified concurrently"
+// @Security BearerAuth
+// @Router /incidents/{id}/resolve [post]
+func (h *MonitoringHandler) ResolveIncident(c *gin.Context) {
+	incidentId, userId, ok := incidentRequestContext(c)
+	if !ok {
+		return
+	}
+	req, ok := bindIncidentNote(c)
+	if !ok {
+		return
+	}
+
+	dto, err := h.appService.ResolveIncident(application.ResolveIncidentCommand{
+		IncidentID: incidentId,
+		UserID:     userId,
+		Note:       req.Note,
+	})
+	if err != nil {
+		writeIncidentError(c, err)
+		return
+	}
+
+	respondIncident(c, "incident_resolved", dto)
+}
+
+// --- helpers ---
+
+func incidentRequestContext(c *gin.Context) (domain.IncidentId, userdomain.UserId, bool) {
+	incidentId, err := domain.NewIncidentId(c.Param("id"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_id", "Invalid incident ID format")
+		return "", "", false
+	}
+
+	userId, exists := middleware.GetUserID(c)
+	if !exists {
+		buildMonitoringErrorResponse(c, http.StatusUnauthorized, "user_id_missing", "User ID not found in context")
+		return "", "", false
+	}
+	return incidentId, userId, true
+}
+
+// bindIncidentNote acepta body vacío (la nota es opcional salvo en comentarios)
+func bindIncidentNote(c *gin.Context) (IncidentNoteRequest, bool) {
+	var req IncidentNoteRequest
+	if c.Request.ContentLength == 0 {
+		return req, true
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return req, false
+	}
+	return req, true
+}
+
+func writeIncidentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrIncidentNotFound):
+		buildMonitoringErrorResponse(c, http.StatusNotFound, "incident_not_found", err.Error())
+	case strings.HasPrefix(err.Error(), "unauthorized"):
+		buildMonitoringErrorResponse(c, http.StatusForbidden, "forbidden", err.Error())
+	case errors.Is(err, domain.ErrIncidentResolved), errors.Is(err, domain.ErrIncidentAlreadyAcknowledged):
+		buildMonitoringErrorResponse(c, http.StatusConflict, "incident_conflict", err.Error())
+	case errors.Is(err, domain.ErrIncidentConflict):
+		buildMonitoringErrorResponse(c, http.StatusConflict, "incident_modified", domain.ErrIncidentConflict.Error())
+	case errors.Is(err, domain.ErrIncidentNoteEmpty):
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "empty_note", err.Error())
+	default:
+		buildMonitoringErrorResponse(c, http.StatusInternalServerError, "incident_update_failed", "Failed to process incident")
+	}
+}
+
+func respondIncident(c *gin.Context, message string, dto *application.IncidentDTO) {
+	response := app.BuildOKResponse(message, true, dto).
+		WithLink("self", "/api/v1/incidents/"+dto.ID).
+		WithLink("target", "/api/v1/targets/"+dto.TargetID).
+		WithLink("history", "/api/v1/targets/"+dto.TargetID+"/history")
+	c.JSON(http.StatusOK, response)
+}
+
+// parseTimeParam interpreta un query param opcional: RFC3339, now o relativo como -24h (vacío = tiempo cero)
+func parseTimeParam(value string) (time.Time, error) {
+	return domain.ParseTimeBound(value, time.Now())
+}
@@ -0,0 +1,186 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+	"uptrackai/internal/app"
+	"uptrackai/internal/monitoring/application"
+	"uptrackai/internal/monitoring/domain"
+	"uptrackai/internal/server/middleware"
+	userdomain "uptrackai/internal/user/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maintenanceLookback - Por defecto se listan las ventanas de los últimos 90 días y las futuras
+const maintenanceLookback = 90 * 24 * time.Hour
+
+// ListMaintenanceWindows lista las ventanas de mantenimiento de un target
+// @Summary List maintenance windows
+// @Description List maintenance windows of a target that overlap the given range (defaults to the last 90 days plus all scheduled ones). Maintenance is excluded from uptime.
+// @Tags monitoring
+// @Accept json
+// @Produce json
+// @Param id path string true "Target ID"
+// @Param from query string false "Range start (RFC3339)"
+// @Param to query string false "Range end (RFC3339)"
+// @Success 200 {object} app.APIResponse{data=[]MaintenanceWindowResponse}
+// @Failure 400 {object} app.APIResponse "Invalid target ID or range"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Security BearerAuth
+// @Router /targets/{id}/maintenance [get]
+func (h *MonitoringHandler) ListMaintenanceWindows(c *gin.Context) {
+	targetId, userId, ok := maintenanceRequestContext(c)
+	if !ok {
+		return
+	}
+
+	from, err := parseTimeParam(c.Query("from"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_from", "from must be RFC3339, now or relative (e.g. -24h)")
+		return
+	}
+	to, err := parseTimeParam(c.Query("to"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_to", "to must be RFC3339, now or relative (e.g. -24h)")
+		return
+	}
+	if from.IsZero() {
+		from = time.Now().Add(-maintenanceLookback)
+	}
+
+	dtos, err := h.appService.ListMaintenanceWindows(application.ListMaintenanceWindowsQuery{
+		TargetID: targetId,
+		UserID:   userId,
+		From:     from,
+		To:       to,
+	})
+	if err != nil {
+		writeMaintenanceError(c, err)
+		return
+	}
+
+	response := app.BuildOKResponse("maintenance_windows_retrieved", true, dtos).
+		WithLink("self", "/api/v1/targets/"+targetId.String()+"/maintenance").
+		WithLink("target", "/api/v1/targets/"+targetId.String()).
+		WithLink("statistics", "/api/v1/targets/"+targetId.String()+"/statistics")
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateMaintenanceWindow programa una ventana de mantenimiento
+// @Summary Schedule a maintenance window
+// @Description Schedule a maintenance window for a target. Time inside it is excluded from uptime/SLA figures (past windows are allowed, to correct history).
+// @Tags monitoring
+// @Accept json
+// @Produce json
+// @Param id path string true "Target ID"
+// @Param request body MaintenanceWindowRequest true "Maintenance window"
+// @Success 201 {object} app.APIResponse{data=MaintenanceWindowResponse}
+// @Failure 400 {object} app.APIResponse "Invalid request"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Security BearerAuth
+// @Router /targets/{id}/maintenance [post]
+func (h *MonitoringHandler) CreateMaintenanceWindow(c *gin.Context) {
+	targetId, userId, ok := maintenanceRequestContext(c)
+	if !ok {
+		return
+	}
+
+	var req MaintenanceWindowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	dto, err := h.appService.CreateMaintenanceWindow(application.CreateMaintenanceWindowCommand{
+		TargetID: targetId,
+		UserID:   userId,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		writeMaintenanceError(c, err)
+		return
+	}
+
+	response := app.BuildOKResponse("maintenance_window_created", true, dto).
+		WithLink("self", "/api/v1/targets/"+targetId.String()+"/maintenance/"+dto.ID).
+		WithLink("target", "/api/v1/targets/"+targetId.String())
+	c.JSON(http.StatusCreated, response)
+}
+
+// DeleteMaintenanceWindow cancela una ventana de mantenimiento
+// @Summary Delete a maintenance window
+// @Description Delete a maintenance window. Uptime for the period is recalculated without the exclusion.
+// @Tags monitoring
+// @Produce json
+// @Param id path string true "Target ID"
+// @Param windowId path string true "Maintenance window ID"
+// @Success 200 {object} app.APIResponse
+// @Failure 400 {object} app.APIResponse "Invalid ID"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Failure 404 {object} app.APIResponse "Maintenance window not found"
+// @Security BearerAuth
+// @Router /targets/{id}/maintenance/{windowId} [delete]
+func (h *MonitoringHandler) DeleteMaintenanceWindow(c *gin.Context) {
+	targetId, userId, ok := maintenanceRequestContext(c)
+	if !ok {
+		return
+	}
+
+	windowId, err := domain.NewMaintenanceWindowId(c.Param("windowId"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_id", "Invalid maintenance window ID format")
+		return
+	}
+
+	err = h.appService.DeleteMaintenanceWindow(application.DeleteMaintenanceWindowCommand{
+		TargetID: targetId,
+		WindowID: windowId,
+		UserID:   userId,
+	})
+	if err != nil {
+		writeMaintenanceError(c, err)
+		return
+	}
+
+	response := app.BuildOKResponse("maintenance_window_deleted", true, nil).
+		WithLink("maintenance", "/api/v1/targets/"+targetId.String()+"/maintenance")
+	c.JSON(http.StatusOK, response)
+}
+
+func maintenanceRequestContext(c *gin.Context) (domain.TargetId, userdomain.UserId, bool) {
+	targetId, err := domain.NewTargetId(c.Param("id"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_id", "Invalid target ID format")
+		return "", "", false
+	}
+
+	userId, exists := middleware.GetUserID(c)
+	if !exists {
+		buildMonitoringErrorResponse(c, http.StatusUnauthorized, "user_id_missing", "User ID not found in context")
+		return "", "", false
+	}
+	return targetId, userId, true
+}
+
+func writeMaintenanceError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrMaintenanceWindowNotFound):
+		buildMonitoringErrorResponse(c, http.StatusNotFound, "maintenance_window_not_found", err.Error())
+	case errors.Is(err, domain.ErrTargetNotFound):
+		buildMonitoringErrorResponse(c, http.StatusNotFound, "target_not_found", err.Error())
+	case strings.HasPrefix(err.Error(), "unauthorized"):
+		buildMonitoringErrorResponse(c, http.StatusForbidden, "forbidden", err.Error())
+	case errors.Is(err, domain.ErrInvalidMaintenanceWindow):
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_maintenance_window", err.Error())
+	default:
+		buildMonitoringErrorResponse(c, http.StatusInternalServerError, "maintenance_failed", "Failed to process maintenance window")
+	}
+}
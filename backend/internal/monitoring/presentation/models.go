@@ -12,6 +12,8 @@ type TargetResponse struct {
 	LastCheckedAt     *time.Time `json:"last_checked_at,omitempty"`
 	AvgResponseTimeMs int        `json:"avg_response_time_ms" example:"150"`
 	CreatedAt         time.Time  `json:"created_at"`
+	// Uptime % por ventana (24h, 7d, 30d, 90d); null = sin tiempo medido
+	Uptime map[string]*float64 `json:"uptime,omitempty"`
 }
 
 // TargetDetailResponse incluye configuración del target
@@ -42,24 +44,179 @@ type CreateTargetRequest struct {
 
 // MetricResponse representa una métrica individual
 type MetricResponse struct {
-	Timestamp      time.Time `json:"timestamp"`
-	ResponseTimeMs int       `json:"response_time_ms"`
+	Timestamp         time.Time `json:"timestamp"`
+	Status            string    `json:"status" example:"UP" enums:"UP,DOWN,DEGRADED,FLAPPING,UNSTABLE,UNKNOWN"`
+	Reachable         bool      `json:"reachable" example:"true"`
+	ResponseTimeMs    int       `json:"response_time_ms"`
+	MaxResponseTimeMs *int      `json:"max_response_time_ms,omitempty" example:"480"`
+	SuccessCount      *int      `json:"success_count,omitempty" example:"4"`
+	FailureCount      *int      `json:"failure_count,omitempty" example:"1"`
+	SessionID         string    `json:"session_id,omitempty"`
+	ErrorClass        string    `json:"error_class,omitempty" example:"read_timeout"`
+}
+
+// MetricSeriesResponse representa una serie de métricas de un rango
+type MetricSeriesResponse struct {
+	TargetID   string                `json:"target_id"`
+	Resolution string                `json:"resolution" example:"1h" enums:"raw,1m,1h,1d"`
+	Step       string                `json:"step,omitempty" example:"6h"`
+	From       string                `json:"from" example:"2024-01-01T00:00:00Z"`
+	To         string                `json:"to" example:"2024-01-08T00:00:00Z"`
+	Points     []MetricPointResponse `json:"points"`
+}
+
+// MetricPointResponse representa un bucket de la serie (en raw, una sesión)
+type MetricPointResponse struct {
+	Timestamp         time.Time `json:"timestamp"`
+	Count             int64     `json:"count" example:"60"`
+	FailureCount      int64     `json:"failure_count" example:"1"`
+	MinResponseTimeMs int       `json:"min_response_time_ms" example:"80"`
+	MaxResponseTimeMs int       `json:"max_response_time_ms" example:"950"`
+	AvgResponseTimeMs float64   `json:"avg_response_time_ms" example:"143.5"`
+	P95ResponseTimeMs int       `json:"p95_response_time_ms" example:"410"`
+	SessionID         string    `json:"session_id,omitempty"`
+	ErrorClass        string    `json:"error_class,omitempty" example:"read_timeout"`
+}
+
+// StatusTimelineResponse representa el historial de estados en tramos de ancho fijo
+type StatusTimelineResponse struct {
+	TargetID string                 `json:"target_id"`
+	Step     string                 `json:"step" example:"1h"`
+	From     string                 `json:"from" example:"2024-01-01T00:00:00Z"`
+	To       string                 `json:"to" example:"2024-01-02T00:00:00Z"`
+	Buckets  []StatusBucketResponse `json:"buckets"`
+}
+
+// StatusBucketResponse representa un tramo: el peor estado que tuvo y cuántos cambios hubo
+type StatusBucketResponse struct {
+	Start   time.Time `json:"start"`
+	Status  string    `json:"status" example:"DOWN"`
+	Changes int       `json:"changes" example:"2"`
 }
 
 // CheckResultResponse representa un cambio de estado (alerta)
 type CheckResultResponse struct {
+	Timestamp         time.Time                  `json:"timestamp"`
+	Status            string                     `json:"status"`
+	ResponseTimeMs    int                        `json:"response_time_ms"`
+	ErrorMessage      string                     `json:"error_message,omitempty"`
+	DegradationReason *DegradationReasonResponse `json:"degradation_reason,omitempty"`
+	SessionID         string                     `json:"session_id,omitempty"`                // Ver /targets/{id}/sessions/{sessionId}/pings
+	ErrorClass        string                     `json:"error_class,omitempty" example:"dns"` // dns | connect_refused | connect_timeout | tls | read_timeout | http_5xx | http_4xx | assertion
+}
+
+// PingSampleResponse representa un ping crudo de una sesión
+type PingSampleResponse struct {
+	Sequence       int       `json:"sequence" example:"1"`
 	Timestamp      time.Time `json:"timestamp"`
-	Status         string    `json:"status"`
-	ResponseTimeMs int       `json:"response_time_ms"`
+	Status         string    `json:"status" example:"UP"`
+	ResponseTimeMs int       `json:"response_time_ms" example:"245"`
 	ErrorMessage   string    `json:"error_message,omitempty"`
+	ErrorClass     string    `json:"error_class,omitempty" example:"connect_timeout"`
+}
+
+// DegradationReasonResponse explica por qué un cambio a DEGRADED se disparó
+type DegradationReasonResponse struct {
+	Rule     string  `json:"rule" example:"ZSCORE"` // ZSCORE | FACTOR | ABSOLUTE
+	Metric   string  `json:"metric" example:"response_time_ms"`
+	Observed float64 `json:"observed" example:"950"`
+	Baseline float64 `json:"baseline" example:"310"`
+	Score    float64 `json:"score" example:"4.27"`
 }
 
 // StatisticsResponse representa las estadísticas de un target
 type StatisticsResponse struct {
-	TargetID          string  `json:"target_id"`
-	TotalChecks       int     `json:"total_checks"`
-	AvgResponseTimeMs int     `json:"avg_response_time_ms"`
-	SuccessRate       float64 `json:"success_rate"`
+	TargetID              string  `json:"target_id"`
+	TotalChecks           int     `json:"total_checks"`
+	AvgResponseTimeMs     int     `json:"avg_response_time_ms"`
+	P50ResponseTimeMs     int     `json:"p50_response_time_ms" example:"120"`
+	P90ResponseTimeMs     int     `json:"p90_response_time_ms" example:"180"`
+	P95ResponseTimeMs     int     `json:"p95_response_time_ms" example:"240"`
+	P99ResponseTimeMs     int     `json:"p99_response_time_ms" example:"610"`
+	PercentileSampleCount int     `json:"percentile_sample_count" example:"4032"`
+	IsFlapping            bool    `json:"is_flapping" example:"false"`
+	StateChangePercent    float64 `json:"state_change_percent" example:"12.5"`
+	SuccessRate           float64 `json:"success_rate"`
+	// Estados que cuentan como disponibles y uptime por ventana ("24h", "7d", "30d", "90d", "range")
+	AvailableStatuses []string                        `json:"available_statuses" example:"UP,DEGRADED"`
+	Availability      map[string]AvailabilityResponse `json:"availability"`
+	Forecast          *LatencyForecastResponse        `json:"forecast"`
+}
+
+// LatencyForecastResponse representa la tendencia del p95 diario frente al umbral de degradación
+type LatencyForecastResponse struct {
+	SampleDays          int      `json:"sample_days" example:"28"`
+	SlopeMsPerDay       float64  `json:"slope_ms_per_day" example:"6.4"`
+	CurrentP95Ms        float64  `json:"current_p95_ms" example:"412.5"`
+	ThresholdMs         *int     `json:"threshold_ms" example:"500"`
+	HorizonDays         int      `json:"horizon_days" example:"14"`
+	DaysToThreshold     *float64 `json:"days_to_threshold" example:"13.7"`
+	ProjectedCrossingAt string   `json:"projected_crossing_at,omitempty" example:"2024-01-22T10:00:00Z"`
+	AtRisk              bool     `json:"at_risk" example:"true"`
+}
+
+// AvailabilityResponse representa el uptime de una ventana.
+// Mantenimiento, caídas del propio monitor y tramos sin datos quedan fuera del porcentaje.
+type AvailabilityResponse struct {
+	From                string   `json:"from" example:"2024-01-01T00:00:00Z"`
+	To                  string   `json:"to" example:"2024-01-31T00:00:00Z"`
+	UptimePercent       *float64 `json:"uptime_percent" example:"99.952"`
+	AvailableSeconds    int64    `json:"available_seconds" example:"2590000"`
+	DowntimeSeconds     int64    `json:"downtime_seconds" example:"1240"`
+	MaintenanceSeconds  int64    `json:"maintenance_seconds" example:"3600"`
+	SelfDowntimeSeconds int64    `json:"self_downtime_seconds" example:"0"`
+	UnknownSeconds      int64    `json:"unknown_seconds" example:"0"`
+}
+
+// MaintenanceWindowRequest representa la petición para programar un mantenimiento
+type MaintenanceWindowRequest struct {
+	StartsAt time.Time `json:"starts_at" binding:"required" example:"2024-01-20T02:00:00Z"`
+	EndsAt   time.Time `json:"ends_at" binding:"required" example:"2024-01-20T04:00:00Z"`
+	Reason   string    `json:"reason" binding:"max=500" example:"Database upgrade"`
+}
+
+// MaintenanceWindowResponse representa una ventana de mantenimiento
+type MaintenanceWindowResponse struct {
+	ID        string `json:"id"`
+	TargetID  string `json:"target_id"`
+	StartsAt  string `json:"starts_at" example:"2024-01-20T02:00:00Z"`
+	EndsAt    string `json:"ends_at" example:"2024-01-20T04:00:00Z"`
+	Reason    string `json:"reason,omitempty" example:"Database upgrade"`
+	CreatedBy string `json:"created_by,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+// SLORequest representa la petición para definir un SLO
+type SLORequest struct {
+	Name               string  `json:"name" binding:"max=100" example:"API availability"`
+	Kind               string  `json:"kind" binding:"required" example:"AVAILABILITY" enums:"AVAILABILITY,LATENCY"`
+	Objective          float64 `json:"objective" binding:"required" example:"99.9"`
+	WindowDays         int     `json:"window_days" binding:"required" example:"30"`
+	LatencyThresholdMs int     `json:"latency_threshold_ms" example:"500"` // Solo LATENCY
+}
+
+// SLOResponse representa un SLO con su presupuesto de error
+type SLOResponse struct {
+	ID                   string             `json:"id"`
+	TargetID             string             `json:"target_id"`
+	Name                 string             `json:"name,omitempty" example:"API availability"`
+	Kind                 string             `json:"kind" example:"AVAILABILITY"`
+	Objective            float64            `json:"objective" example:"99.9"`
+	WindowDays           int                `json:"window_days" example:"30"`
+	LatencyThresholdMs   int                `json:"latency_threshold_ms,omitempty" example:"500"`
+	SLI                  *float64           `json:"sli" example:"99.95"`
+	ErrorBudgetRemaining *float64           `json:"error_budget_remaining" example:"50"`
+	BurnRates            []BurnRateResponse `json:"burn_rates"`
+	AlertLevel           string             `json:"alert_level" example:"NONE" enums:"NONE,SLOW,FAST"`
+	AlertChangedAt       string             `json:"alert_changed_at,omitempty" example:"2024-01-20T02:00:00Z"`
+	CreatedAt            string             `json:"created_at"`
+}
+
+// BurnRateResponse representa la tasa de quemado de una ventana
+type BurnRateResponse struct {
+	Window  string  `json:"window" example:"1h0m0s"`
+	Rate    float64 `json:"rate" example:"2.5"`
+	HasData bool    `json:"has_data"`
 }
 
 // ToggleActiveRequest representa la petición para activar/desactivar un target
@@ -75,4 +232,136 @@ type UpdateConfigurationRequest struct {
 	CheckIntervalSeconds int  `json:"check_interval_seconds" binding:"required,min=30,max=3600" example:"60"`
 	AlertOnFailure       bool `json:"alert_on_failure" example:"true"`
 	AlertOnRecovery      bool `json:"alert_on_recovery" example:"true"`
+	// Umbrales del analizador: si se omiten se conservan los actuales
+	DegradationFactor       *float64 `json:"degradation_factor,omitempty" binding:"omitempty,min=1" example:"3"`
+	MinLatencyThresholdMs   *int     `json:"min_latency_threshold_ms,omitempty" binding:"omitempty,min=0" example:"200"`
+	MaxLatencyThresholdMs   *int     `json:"max_latency_threshold_ms,omitempty" binding:"omitempty,min=0" example:"1500"` // 0 = sin límite absoluto
+	UnstableMinPings        *int     `json:"unstable_min_pings,omitempty" binding:"omitempty,min=3,max=12" example:"5"`
+	UnstableMaxPings        *int     `json:"unstable_max_pings,omitempty" binding:"omitempty,min=3,max=12" example:"9"`
+	BaselineMetric          *string  `json:"baseline_metric,omitempty" binding:"omitempty,oneof=MEAN P95" example:"P95"`
+	AnomalyZThreshold       *float64 `json:"anomaly_z_threshold,omitempty" binding:"omitempty,min=1,max=10" example:"3"` // Menor = más sensible
+	StoreRawSamples         *bool    `json:"store_raw_samples,omitempty" example:"true"`
+	RawSamplesRetentionDays *int     `json:"raw_samples_retention_days,omitempty" binding:"omitempty,min=1,max=14" example:"3"`
+	// Clase de error -> estado (UP | DEGRADED | DOWN); las clases omitidas conservan su regla
+	ErrorClassPolicy map[string]string `json:"error_class_policy,omitempty" example:"http_4xx:UP"`
+	// Estados que cuentan como disponibles para el uptime (UP obligatorio)
+	AvailableStatuses []string `json:"available_statuses,omitempty" example:"UP,DEGRADED"`
+}
+
+// TLSInfoResponse representa el certificado TLS presentado por el target
+type TLSInfoResponse struct {
+	Version       string    `json:"version" example:"TLS 1.3"`
+	Issuer        string    `json:"issuer" example:"R3"`
+	Subject       string    `json:"subject" example:"example.com"`
+	NotAfter      time.Time `json:"not_after"`
+	DaysRemaining int       `json:"days_remaining" example:"62"`
+}
+
+// ProbeReportResponse representa el resultado de un sondeo en seco (POST /targets/test)
+type ProbeReportResponse struct {
+	Passed            bool             `json:"passed" example:"true"`
+	Status            string           `json:"status" example:"UP"`
+	Reachable         bool             `json:"reachable" example:"true"`
+	ResponseTimeMs    int              `json:"response_time_ms" example:"120"`
+	MaxResponseTimeMs int              `json:"max_response_time_ms" example:"180"`
+	PingCount         int              `json:"ping_count" example:"3"`
+	Stable            bool             `json:"stable" example:"true"`
+	HTTPStatusCode    int              `json:"http_status_code,omitempty" example:"200"`
+	TLS               *TLSInfoResponse `json:"tls,omitempty"`
+	AssertionFailures []string         `json:"assertion_failures"`
+	ErrorMessage      string           `json:"error_message,omitempty"`
+	ErrorClass        string           `json:"error_class,omitempty" example:"tls"`
+}
+
+// IncidentEventResponse representa una entrada de la línea de tiempo de un incidente
+type IncidentEventResponse struct {
+	Type   string    `json:"type" example:"ACKNOWLEDGED"` // OPENED | STATUS_CHANGED | ACKNOWLEDGED | COMMENTED | RESOLVED
+	At     time.Time `json:"at"`
+	Status string    `json:"status,omitempty" example:"DOWN"`
+	Author string    `json:"author,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Note   string    `json:"note,omitempty" example:"Investigating DB connection pool"`
+}
+
+// IncidentResponse representa un incidente (caída atendible) de un target
+type IncidentResponse struct {
+	ID                       string                  `json:"id"`
+	TargetID                 string                  `json:"target_id"`
+	Status                   string                  `json:"status" example:"OPEN"` // OPEN | ACKNOWLEDGED | RESOLVED
+	TriggerStatus            string                  `json:"trigger_status" example:"DOWN"`
+	LastStatus               string                  `json:"last_status" example:"DOWN"`
+	OpenedAt                 time.Time               `json:"opened_at"`
+	AcknowledgedAt           *time.Time              `json:"acknowledged_at,omitempty"`
+	AcknowledgedBy           string                  `json:"acknowledged_by,omitempty"`
+	ResolvedAt               *time.Time              `json:"resolved_at,omitempty"`
+	ResolvedBy               string                  `json:"resolved_by,omitempty"`
+	DurationSeconds          int64                   `json:"duration_seconds" example:"420"`
+	TimeToAcknowledgeSeconds *int64                  `json:"time_to_acknowledge_seconds,omitempty" example:"95"`
+	TimeToResolveSeconds     *int64                  `json:"time_to_resolve_seconds,omitempty" example:"420"`
+	Events                   []IncidentEventResponse `json:"events"`
+}
+
+// IncidentListResponse representa incidentes filtrados con su MTTA/MTTR
+type IncidentListResponse struct {
+	Incidents    []IncidentResponse `json:"incidents"`
+	Total        int                `json:"total" example:"12"`
+	Open         int                `json:"open" example:"1"`
+	MTTASeconds  int64              `json:"mtta_seconds" example:"180"`
+	MTTRSeconds  int64              `json:"mttr_seconds" example:"1260"`
+	Acknowledged int                `json:"acknowledged" example:"10"`
+	Resolved     int                `json:"resolved" example:"11"`
+}
+
+// IncidentNoteRequest representa la nota de un acknowledge, comentario o cierre
+type IncidentNoteRequest struct {
+	Note string `json:"note" example:"Rolled back the last deploy"`
+}
+
+// TimelineEntryResponse representa un hecho de la línea de tiempo de un incidente
+type TimelineEntryResponse struct {
+	At       time.Time `json:"at"`
+	Kind     string    `json:"kind" example:"NOTIFICATION"` // FIRST_FAILURE | CONFIRMED | INCIDENT_OPENED | STATUS_CHANGE | NOTIFICATION | ACKNOWLEDGED | COMMENT | RECOVERED | RESOLVED
+	Status   string    `json:"status,omitempty" example:"DOWN"`
+	Summary  string    `json:"summary" example:"Status Change: My Website [CRITICAL]"`
+	Actor    string    `json:"actor,omitempty"`
+	Channels []string  `json:"channels,omitempty" example:"TELEGRAM"`
+}
+
+// IncidentTimelineResponse representa la línea de tiempo exportable de un incidente
+type IncidentTimelineResponse struct {
+	IncidentID               string                  `json:"incident_id"`
+	TargetID                 string                  `json:"target_id"`
+	TargetName               string                  `json:"target_name" example:"My Website"`
+	TargetURL                string                  `json:"target_url" example:"https://example.com"`
+	Status                   string                  `json:"status" example:"RESOLVED"`
+	OpenedAt                 time.Time               `json:"opened_at"`
+	ResolvedAt               *time.Time              `json:"resolved_at,omitempty"`
+	DurationSeconds          int64                   `json:"duration_seconds" example:"420"`
+	TimeToAcknowledgeSeconds *int64                  `json:"time_to_acknowledge_seconds,omitempty" example:"95"`
+	TimeToResolveSeconds     *int64                  `json:"time_to_resolve_seconds,omitempty" example:"420"`
+	GeneratedAt              time.Time               `json:"generated_at"`
+	Entries                  []TimelineEntryResponse `json:"entries"`
+}
+
+// TargetRankingReportResponse representa el reporte comparativo de targets
+type TargetRankingReportResponse struct {
+	From    string                  `json:"from" example:"2024-01-01T00:00:00Z"`
+	To      string                  `json:"to" example:"2024-01-08T00:00:00Z"`
+	SortBy  string                  `json:"sort_by" example:"availability" enums:"availability,p95,incidents,mttr,trend"`
+	Targets []TargetRankingResponse `json:"targets"`
+}
+
+// TargetRankingResponse representa un target en el reporte (null = sin datos en la ventana)
+type TargetRankingResponse struct {
+	Rank                int      `json:"rank" example:"1"`
+	TargetID            string   `json:"target_id"`
+	TargetName          string   `json:"target_name" example:"My Website"`
+	URL                 string   `json:"url" example:"https://example.com"`
+	UptimePercent       *float64 `json:"uptime_percent" example:"99.512"`
+	Sessions            int64    `json:"sessions" example:"10080"`
+	FailedSessions      int64    `json:"failed_sessions" example:"42"`
+	AvgResponseTimeMs   *float64 `json:"avg_response_time_ms" example:"182.4"`
+	P95ResponseTimeMs   *int     `json:"p95_response_time_ms" example:"410"`
+	Incidents           int      `json:"incidents" example:"3"`
+	MTTRSeconds         *int64   `json:"mttr_seconds" example:"540"`
+	LatencyTrendPercent *float64 `json:"latency_trend_percent" example:"12.5"`
 }
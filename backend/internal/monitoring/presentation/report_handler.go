@@ -0,0 +1,93 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"uptrackai/internal/app"
+	"uptrackai/internal/monitoring/application"
+	"uptrackai/internal/monitoring/domain"
+	"uptrackai/internal/server/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTargetRanking compara todos los targets del usuario en una ventana
+// @Summary Rank targets
+// @Description Rank every target of the user for the window by availability, p95 latency, incidents, MTTR or latency trend (window average vs the target's historical average), worst first. Targets without data for the chosen metric go last. format=csv returns a spreadsheet-ready file.
+// @Tags monitoring
+// @Produce json
+// @Produce text/csv
+// @Param from query string false "Window start (RFC3339, now or relative such as -7d)" default(-7d)
+// @Param to query string false "Window end (RFC3339, now or relative; defaults to now)"
+// @Param sort query string false "Ranking metric" Enums(availability, p95, incidents, mttr, trend) default(availability)
+// @Param format query string false "json | csv" default(json)
+// @Success 200 {object} app.APIResponse{data=TargetRankingReportResponse}
+// @Failure 400 {object} app.APIResponse "Invalid range, metric or format"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Security BearerAuth
+// @Router /reports/targets [get]
+func (h *MonitoringHandler) GetTargetRanking(c *gin.Context) {
+	userId, exists := middleware.GetUserID(c)
+	if !exists {
+		buildMonitoringErrorResponse(c, http.StatusUnauthorized, "user_id_missing", "User ID not found in context")
+		return
+	}
+	role, exists := middleware.GetRole(c)
+	if !exists {
+		buildMonitoringErrorResponse(c, http.StatusUnauthorized, "role_missing", "Role not found in context")
+		return
+	}
+
+	format := strings.ToLower(c.DefaultQuery("format", "json"))
+	if format != "json" && format != "csv" {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_format", "format must be json or csv")
+		return
+	}
+	from, err := parseTimeParam(c.DefaultQuery("from", "-7d"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_from", "from must be RFC3339, now or relative (e.g. -24h)")
+		return
+	}
+	to, err := parseTimeParam(c.Query("to"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_to", "to must be RFC3339, now or relative (e.g. -24h)")
+		return
+	}
+
+	dto, err := h.appService.GetTargetRanking(application.GetTargetRankingQuery{
+		UserID: userId,
+		Role:   role,
+		From:   from,
+		To:     to,
+		SortBy: c.Query("sort"),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidTimeRange):
+			buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_range", err.Error())
+		case errors.Is(err, domain.ErrInvalidRankingMetric):
+			buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_sort", err.Error())
+		default:
+			buildMonitoringErrorResponse(c, http.StatusInternalServerError, "report_failed", "Failed to build the targets report")
+		}
+		return
+	}
+
+	if format == "csv" {
+		body, err := application.RenderTargetRankingCSV(*dto)
+		if err != nil {
+			buildMonitoringErrorResponse(c, http.StatusInternalServerError, "report_failed", "Failed to render the targets report")
+			return
+		}
+		c.Header("Content-Disposition", `attachment; filename="targets-ranking.csv"`)
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", body)
+		return
+	}
+
+	response := app.BuildOKResponse("targets_report_retrieved", true, dto).
+		WithLink("self", "/api/v1/reports/targets").
+		WithLink("csv", "/api/v1/reports/targets?format=csv").
+		WithLink("targets", "/api/v1/targets")
+	c.JSON(http.StatusOK, response)
+}
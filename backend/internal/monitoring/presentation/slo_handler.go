@@ -0,0 +1,192 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"uptrackai/internal/app"
+	"uptrackai/internal/monitoring/application"
+	"uptrackai/internal/monitoring/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListSLOs lista los SLOs de un target con su estado actual
+// @Summary List SLOs
+// @Description List the SLOs of a target with their current SLI, remaining error budget and burn rates (fast: 1h/5m, slow: 6h/30m).
+// @Tags monitoring
+// @Produce json
+// @Param id path string true "Target ID"
+// @Success 200 {object} app.APIResponse{data=[]SLOResponse}
+// @Failure 400 {object} app.APIResponse "Invalid target ID"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Security BearerAuth
+// @Router /targets/{id}/slos [get]
+func (h *MonitoringHandler) ListSLOs(c *gin.Context) {
+	targetId, userId, ok := maintenanceRequestContext(c)
+	if !ok {
+		return
+	}
+
+	dtos, err := h.appService.ListSLOs(application.ListSLOsQuery{
+		TargetID: targetId,
+		UserID:   userId,
+	})
+	if err != nil {
+		writeSLOError(c, err)
+		return
+	}
+
+	response := app.BuildOKResponse("slos_retrieved", true, dtos).
+		WithLink("self", "/api/v1/targets/"+targetId.String()+"/slos").
+		WithLink("target", "/api/v1/targets/"+targetId.String())
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateSLO define un SLO sobre un target
+// @Summary Create an SLO
+// @Description Define an availability SLO (e.g. 99.9% over 30 days) or a latency SLO (e.g. 95% of checks under 500ms). Burn-rate alerts are sent through the notification channels.
+// @Tags monitoring
+// @Accept json
+// @Produce json
+// @Param id path string true "Target ID"
+// @Param request body SLORequest true "SLO"
+// @Success 201 {object} app.APIResponse{data=SLOResponse}
+// @Failure 400 {object} app.APIResponse "Invalid request"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Security BearerAuth
+// @Router /targets/{id}/slos [post]
+func (h *MonitoringHandler) CreateSLO(c *gin.Context) {
+	targetId, userId, ok := maintenanceRequestContext(c)
+	if !ok {
+		return
+	}
+
+	var req SLORequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	dto, err := h.appService.CreateSLO(application.CreateSLOCommand{
+		TargetID:           targetId,
+		UserID:             userId,
+		Name:               req.Name,
+		Kind:               req.Kind,
+		Objective:          req.Objective,
+		WindowDays:         req.WindowDays,
+		LatencyThresholdMs: req.LatencyThresholdMs,
+	})
+	if err != nil {
+		writeSLOError(c, err)
+		return
+	}
+
+	response := app.BuildOKResponse("slo_created", true, dto).
+		WithLink("self", "/api/v1/targets/"+targetId.String()+"/slos/"+dto.ID).
+		WithLink("target", "/api/v1/targets/"+targetId.String())
+	c.JSON(http.StatusCreated, response)
+}
+
+// GetSLO obtiene un SLO con su presupuesto de error
+// @Summary Get an SLO
+// @Description Get an SLO with its current SLI, remaining error budget and burn rates.
+// @Tags monitoring
+// @Produce json
+// @Param id path string true "Target ID"
+// @Param sloId path string true "SLO ID"
+// @Success 200 {object} app.APIResponse{data=SLOResponse}
+// @Failure 400 {object} app.APIResponse "Invalid ID"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Failure 404 {object} app.APIResponse "SLO not found"
+// @Security BearerAuth
+// @Router /targets/{id}/slos/{sloId} [get]
+func (h *MonitoringHandler) GetSLO(c *gin.Context) {
+	targetId, userId, ok := maintenanceRequestContext(c)
+	if !ok {
+		return
+	}
+
+	sloId, err := domain.NewSLOId(c.Param("sloId"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_id", "Invalid SLO ID format")
+		return
+	}
+
+	dto, err := h.appService.GetSLO(application.GetSLOQuery{
+		TargetID: targetId,
+		SLOID:    sloId,
+		UserID:   userId,
+	})
+	if err != nil {
+		writeSLOError(c, err)
+		return
+	}
+
+	response := app.BuildOKResponse("slo_retrieved", true, dto).
+		WithLink("self", "/api/v1/targets/"+targetId.String()+"/slos/"+dto.ID).
+		WithLink("slos", "/api/v1/targets/"+targetId.String()+"/slos")
+	c.JSON(http.StatusOK, response)
+}
+
+// DeleteSLO elimina un SLO
+// @Summary Delete an SLO
+// @Description Delete an SLO. It stops being evaluated and no more burn-rate alerts are sent for it.
+// @Tags monitoring
+// @Produce json
+// @Param id path string true "Target ID"
+// @Param sloId path string true "SLO ID"
+// @Success 200 {object} app.APIResponse
+// @Failure 400 {object} app.APIResponse "Invalid ID"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Failure 404 {object} app.APIResponse "SLO not found"
+// @Security BearerAuth
+// @Router /targets/{id}/slos/{sloId} [delete]
+func (h *MonitoringHandler) DeleteSLO(c *gin.Context) {
+	targetId, userId, ok := maintenanceRequestContext(c)
+	if !ok {
+		return
+	}
+
+	sloId, err := domain.NewSLOId(c.Param("sloId"))
+	if err != nil {
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_id", "Invalid SLO ID format")
+		return
+	}
+
+	err = h.appService.DeleteSLO(application.DeleteSLOCommand{
+		TargetID: targetId,
+		SLOID:    sloId,
+		UserID:   userId,
+	})
+	if err != nil {
+		writeSLOError(c, err)
+		return
+	}
+
+	response := app.BuildOKResponse("slo_deleted", true, nil).
+		WithLink("slos", "/api/v1/targets/"+targetId.String()+"/slos")
+	c.JSON(http.StatusOK, response)
+}
+
+func writeSLOError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSLONotFound):
+		buildMonitoringErrorResponse(c, http.StatusNotFound, "slo_not_found", err.Error())
+	case errors.Is(err, domain.ErrTargetNotFound):
+		buildMonitoringErrorResponse(c, http.StatusNotFound, "target_not_found", err.Error())
+	case strings.HasPrefix(err.Error(), "unauthorized"):
+		buildMonitoringErrorResponse(c, http.StatusForbidden, "forbidden", err.Error())
+	case errors.Is(err, domain.ErrInvalidSLOKind),
+		errors.Is(err, domain.ErrInvalidSLOObjective),
+		errors.Is(err, domain.ErrInvalidSLOWindow),
+		errors.Is(err, domain.ErrInvalidSLOLatencyThreshold):
+		buildMonitoringErrorResponse(c, http.StatusBadRequest, "invalid_slo", err.Error())
+	default:
+		buildMonitoringErrorResponse(c, http.StatusInternalServerError, "slo_failed", "Failed to process SLO")
+	}
+}
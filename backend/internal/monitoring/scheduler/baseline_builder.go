@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+	"uptrackai/internal/monitoring/domain"
+)
+
+// BaselineBuilder reconstruye periódicamente las líneas base estacionales (hora de la semana)
+// a partir de la tabla metrics. Es un job aparte: el cálculo es una sola consulta agregada
+// y no tiene sentido hacerlo en el camino crítico de cada worker.
+type BaselineBuilder struct {
+	seasonalRepo domain.SeasonalBaselineRepository
+	interval     time.Duration
+	stopChan     chan struct{}
+}
+
+func NewBaselineBuilder(seasonalRepo domain.SeasonalBaselineRepository, interval time.Duration) *BaselineBuilder {
+	return &BaselineBuilder{
+		seasonalRepo: seasonalRepo,
+		interval:     interval,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start construye una vez al arrancar y luego cada `interval` (non-blocking)
+func (b *BaselineBuilder) Start() {
+	log.Printf("📐 Baseline Builder iniciado (Intervalo: %s)", b.interval)
+	go b.runLoop()
+}
+
+func (b *BaselineBuilder) Stop() {
+	close(b.stopChan)
+}
+
+func (b *BaselineBuilder) runLoop() {
+	b.rebuild()
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			b.rebuild()
+		case <-b.stopChan:
+			return
+		}
+	}
+}
+
+func (b *BaselineBuilder) rebuild() {
+	since := time.Now().AddDate(0, 0, -7*domain.SeasonalWindowWeeks)
+	if err := b.seasonalRepo.RebuildFromMetrics(since); err != nil {
+		log.Printf("❌ Error reconstruyendo líneas base estacionales: %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"uptrackai/internal/monitoring/domain"
+)
+
+// DryRunProber ejecuta una sesión completa de sondeo sin tocar repositorios.
+// Reutiliza el mismo pipeline del Orchestrator (HealthChecker -> Metrics -> Analyzer)
+// pero sin histórico, sin persistencia y sin notificaciones.
+type DryRunProber struct {
+	healthChecker  *HealthChecker
+	metricsCalc    *MetricsCalculator
+	resultAnalyzer *ResultAnalyzer
+}
+
+func NewDryRunProber() *DryRunProber {
+	return &DryRunProber{
+		healthChecker:  NewHealthChecker(),
+		metricsCalc:    NewMetricsCalculator(),
+		resultAnalyzer: NewResultAnalyzer(),
+	}
+}
+
+// Probe sondea el target con su configuración propuesta y devuelve el reporte
+func (p *DryRunProber) Probe(target *domain.MonitoringTarget) *domain.ProbeReport {
+	session := p.healthChecker.Check(target)
+	metrics := p.metricsCalc.Calculate(session)
+
+	// Sin histórico: un target nuevo no tiene línea base contra la cual degradar
+	analysis := p.resultAnalyzer.Analyze(
+		session,
+		metrics,
+		domain.NewTargetStatistics(target.ID()),
+		domain.NewSeasonalBaseline(target.ID(), nil),
+		target.Configuration().Thresholds(),
+	)
+
+	report := &domain.ProbeReport{
+		Status:            analysis.Status,
+		Reachable:         metrics.SuccessCount > 0,
+		AvgResponseTimeMs: metrics.AvgResponseTimeMs,
+		MaxResponseTimeMs: metrics.MaxResponseTimeMs,
+		PingCount:         len(session.Results),
+		Stable:            session.Stable,
+		HTTPStatusCode:    session.LastStatusCode,
+		TLS:               session.TLS,
+		AssertionFailures: session.AssertionFailures,
+		ErrorClass:        metrics.ErrorClass,
+	}
+
+	// Último error de red (DNS, timeout, TLS...) si lo hubo
+	for i := len(session.Results) - 1; i >= 0; i-- {
+		if msg := session.Results[i].ErrorMessage(); msg != "" {
+			report.ErrorMessage = msg
+			break
+		}
+	}
+
+	return report
+}
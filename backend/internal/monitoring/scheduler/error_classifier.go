@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"strings"
+	"syscall"
+	"uptrackai/internal/monitoring/domain"
+)
+
+// classifyNetworkError traduce el error de transporte de net/http a una clase estable.
+// connected indica si se llegó a obtener una conexión (httptrace.GotConn): con eso se
+// distingue un timeout de conexión de uno de lectura, que net/http reporta igual.
+func classifyNetworkError(err error, connected bool) domain.ErrorClass {
+	if err == nil {
+		return domain.ErrorClassNone
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return domain.ErrorClassDNS
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return domain.ErrorClassConnectRefused
+	}
+
+	if isTLSError(err) {
+		return domain.ErrorClassTLS
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		if connected {
+			return domain.ErrorClassReadTimeout
+		}
+		return domain.ErrorClassConnectTimeout
+	}
+
+	return domain.ErrorClassNone
+}
+
+// isTLSError reconoce errores de handshake y de verificación del certificado
+func isTLSError(err error) bool {
+	var (
+		verifyErr   *tls.CertificateVerificationError
+		recordErr   tls.RecordHeaderError
+		alertErr    tls.AlertError
+		unknownCA   x509.UnknownAuthorityError
+		invalidCert x509.CertificateInvalidError
+		hostnameErr x509.HostnameError
+	)
+	if errors.As(err, &verifyErr) || errors.As(err, &recordErr) || errors.As(err, &alertErr) ||
+		errors.As(err, &unknownCA) || errors.As(err, &invalidCert) || errors.As(err, &hostnameErr) {
+		return true
+	}
+
+	// Algunos errores del handshake solo llegan como texto ("tls: ...", "x509: ...")
+	msg := err.Error()
+	return strings.Contains(msg, "tls: ") || strings.Contains(msg, "x509: ")
+}
@@ -1,17 +1,33 @@
 package scheduler
 
 import (
+	"crypto/tls"
+	"fmt"
 	"net/http"
+	"net/http/httptrace"
 	"time"
 	"uptrackai/internal/monitoring/domain"
+
+	"github.com/google/uuid"
 )
 
 // CheckSessionResult contiene los resultados crudos de una sesión de verificación
 type CheckSessionResult struct {
-	TargetID    domain.TargetId
-	Results     []*domain.CheckResult
-	Stable      bool // True si se encontraron 3 consecutivos iguales
-	TotalChecks int
+	TargetID          domain.TargetId
+	SessionID         domain.SessionId // Identifica la sesión (metrics, check_results y ping_samples)
+	Results           []*domain.CheckResult
+	Stable            bool // True si se encontraron 3 consecutivos iguales
+	TotalChecks       int
+	LastStatusCode    int             // Último código HTTP recibido (0 si nunca hubo respuesta)
+	TLS               *domain.TLSInfo // Certificado del último ping HTTPS exitoso
+	AssertionFailures []string        // Respuestas fuera del rango esperado (2xx), sin duplicados
+}
+
+// pingOutcome agrupa lo que devuelve un ping individual además del CheckResult
+type pingOutcome struct {
+	result     *domain.CheckResult
+	statusCode int
+	tls        *domain.TLSInfo
 }
 
 type HealthChecker struct {
@@ -27,6 +43,11 @@ func NewHealthChecker() *HealthChecker {
 func (h *HealthChecker) Check(target *domain.MonitoringTarget) CheckSessionResult {
 	const maxPings = 12
 	results := make([]*domain.CheckResult, 0, maxPings)
+	session := CheckSessionResult{
+		TargetID:  target.ID(),
+		SessionID: domain.SessionId(uuid.Must(uuid.NewV7()).String()),
+	}
+	seenFailures := make(map[string]bool)
 
 	// Configurar cliente con timeout específico del target
 	client := &http.Client{
@@ -34,16 +55,29 @@ func (h *HealthChecker) Check(target *domain.MonitoringTarget) CheckSessionResul
 	}
 
 	for i := 0; i < maxPings; i++ {
-		result := h.performSingleCheck(client, target)
-		results = append(results, result)
+		outcome := h.performSingleCheck(client, target)
+		results = append(results, outcome.result)
+
+		if outcome.statusCode != 0 {
+			session.LastStatusCode = outcome.statusCode
+			if outcome.statusCode < 200 || outcome.statusCode >= 300 {
+				failure := fmt.Sprintf("se esperaba HTTP 2xx, se recibió %d", outcome.statusCode)
+				if !seenFailures[failure] {
+					seenFailures[failure] = true
+					session.AssertionFailures = append(session.AssertionFailures, failure)
+				}
+			}
+		}
+		if outcome.tls != nil {
+			session.TLS = outcome.tls
+		}
 
 		// Verificar estabilidad (3 consecutivos iguales)
 		if h.hasThreeConsecutive(results) {
-			return CheckSessionResult{
-				TargetID: target.ID(),
-				Results:  results,
-				Stable:   true,
-			}
+			session.Results = results
+			session.TotalChecks = len(results)
+			session.Stable = true
+			return session
 		}
 
 		// Pequeña pausa entre pings para no saturar (hardcoded por ahora, podría ser config)
@@ -51,21 +85,33 @@ func (h *HealthChecker) Check(target *domain.MonitoringTarget) CheckSessionResul
 	}
 
 	// Si llegamos aquí, no hubo estabilidad en 12 intentos
-	return CheckSessionResult{
-		TargetID: target.ID(),
-		Results:  results,
-		Stable:   false,
-	}
+	session.Results = results
+	session.TotalChecks = len(results)
+	session.Stable = false
+	return session
 }
 
-func (h *HealthChecker) performSingleCheck(client *http.Client, target *domain.MonitoringTarget) *domain.CheckResult {
-	start := time.Now()
+func (h *HealthChecker) performSingleCheck(client *http.Client, target *domain.MonitoringTarget) pingOutcome {
+	// Saber si se obtuvo conexión permite separar connect_timeout de read_timeout
+	connected := false
+	trace := &httptrace.ClientTrace{
+		GotConn: func(httptrace.GotConnInfo) { connected = true },
+	}
 
-	resp, err := client.Get(target.Url())
+	req, err := http.NewRequest(http.MethodGet, target.Url(), nil)
+	if err != nil {
+		return pingOutcome{result: domain.NewCheckResultWithError(target.ID(), 0, err.Error())}
+	}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), trace))
+
+	start := time.Now()
+	resp, err := client.Do(req)
 	elapsed := int(time.Since(start).Milliseconds())
 
 	if err != nil {
-		return domain.NewCheckResultWithError(target.ID(), elapsed, err.Error())
+		result := domain.NewCheckResultWithError(target.ID(), elapsed, err.Error())
+		result.Classify(classifyNetworkError(err, connected))
+		return pingOutcome{result: result}
 	}
 	defer resp.Body.Close()
 
@@ -82,7 +128,30 @@ func (h *HealthChecker) performSingleCheck(client *http.Client, target *domain.M
 	// En el código original: reachable := resp.StatusCode < 500
 	reachable := resp.StatusCode < 500
 
-	return domain.NewCheckResult(target.ID(), elapsed, reachable, status)
+	result := domain.NewCheckResult(target.ID(), elapsed, reachable, status)
+	result.Classify(domain.ClassifyHTTPStatus(resp.StatusCode))
+
+	return pingOutcome{
+		result:     result,
+		statusCode: resp.StatusCode,
+		tls:        extractTLSInfo(resp.TLS),
+	}
+}
+
+// extractTLSInfo toma el certificado hoja de la conexión (nil si no es HTTPS)
+func extractTLSInfo(state *tls.ConnectionState) *domain.TLSInfo {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return nil
+	}
+
+	cert := state.PeerCertificates[0]
+	return &domain.TLSInfo{
+		Version:       tls.VersionName(state.Version),
+		Issuer:        cert.Issuer.CommonName,
+		Subject:       cert.Subject.CommonName,
+		NotAfter:      cert.NotAfter,
+		DaysRemaining: int(time.Until(cert.NotAfter).Hours() / 24),
+	}
 }
 
 func (h *HealthChecker) hasThreeConsecutive(results []*domain.CheckResult) bool {
@@ -0,0 +1,130 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"net"
+	"net/url"
+	"sort"
+	"time"
+	"uptrackai/internal/monitoring/domain"
+)
+
+const hostingLookupTimeout = 5 * time.Second
+
+// reloadable - Bases de proveedores que pueden releerse desde disco
+type reloadable interface {
+	ReloadIfChanged() (bool, error)
+}
+
+// HostingResolver resuelve periódicamente las IPs de cada target y su proveedor/ASN
+// contra la base offline. Solo usa DNS: nada de WHOIS en línea.
+type HostingResolver struct {
+	targetRepo domain.MonitoringTargetRepository
+	providers  domain.ProviderDatabase
+	resolver   *net.Resolver
+	interval   time.Duration
+	stopChan   chan struct{}
+}
+
+func NewHostingResolver(targetRepo domain.MonitoringTargetRepository, providers domain.ProviderDatabase, interval time.Duration) *HostingResolver {
+	return &HostingResolver{
+		targetRepo: targetRepo,
+		providers:  providers,
+		resolver:   net.DefaultResolver,
+		interval:   interval,
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// Start resuelve una vez al arrancar y luego cada `interval` (non-blocking)
+func (h *HostingResolver) Start() {
+	log.Printf("🌐 Hosting Resolver iniciado (Intervalo: %s)", h.interval)
+	go h.runLoop()
+}
+
+func (h *HostingResolver) Stop() {
+	close(h.stopChan)
+}
+
+func (h *HostingResolver) runLoop() {
+	h.refresh()
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			h.refresh()
+		case <-h.stopChan:
+			return
+		}
+	}
+}
+
+// refresh solo toca los targets sin resolver o con resolución vieja
+func (h *HostingResolver) refresh() {
+	forceAll := false
+	if db, ok := h.providers.(reloadable); ok {
+		reloaded, err := db.ReloadIfChanged()
+		if err != nil {
+			log.Printf("⚠️  Error recargando base de proveedores: %v", err)
+		}
+		forceAll = reloaded // Base nueva: re-mapear todos los targets
+	}
+
+	targets, err := h.targetRepo.List()
+	if err != nil {
+		log.Printf("❌ Error listando targets para resolver hosting: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, target := range targets {
+		if !forceAll && !target.HostingInfo().NeedsRefresh(now) {
+			continue
+		}
+
+		info, err := h.resolve(target.Url(), now)
+		if err != nil {
+			log.Printf("⚠️  No se pudo resolver hosting de %s: %v", target.Name(), err)
+			continue
+		}
+		if err := h.targetRepo.UpdateHostingInfo(target.ID(), info); err != nil {
+			log.Printf("⚠️  Error guardando hosting de %s: %v", target.Name(), err)
+		}
+	}
+}
+
+// resolve obtiene las IPs del host y el proveedor de la primera IP que esté en la base
+func (h *HostingResolver) resolve(rawURL string, now time.Time) (domain.HostingInfo, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return domain.HostingInfo{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hostingLookupTimeout)
+	defer cancel()
+
+	addrs, err := h.resolver.LookupIPAddr(ctx, parsed.Hostname())
+	if err != nil {
+		return domain.HostingInfo{}, err
+	}
+
+	ips := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		ips = append(ips, addr.IP.String())
+	}
+	sort.Strings(ips) // Orden estable: el DNS rota las respuestas
+
+	provider, asn := "", 0
+	for _, ip := range ips {
+		if record, ok := h.providers.Lookup(ip); ok {
+			provider, asn = record.Provider, record.ASN
+			break
+		}
+	}
+
+	return domain.NewHostingInfo(ips, provider, asn, now), nil
+}
@@ -0,0 +1,148 @@
+package scheduler
+
+import (
+	"fmt"
+	"log"
+	"time"
+	"uptrackai/internal/monitoring/domain"
+	notificationdomain "uptrackai/internal/notifications/domain"
+)
+
+// LatencyForecaster ajusta la tendencia del p95 diario de cada target activo y avisa con
+// severidad INFO cuando se proyecta que cruzará su umbral de degradación dentro del horizonte.
+// Cada target avisa como mucho una vez por horizonte para no insistir sobre la misma tendencia.
+type LatencyForecaster struct {
+	targetRepo  domain.MonitoringTargetRepository
+	statsRepo   domain.TargetStatisticsRepository
+	rollupRepo  domain.MetricRollupRepository
+	dispatcher  *NotificationDispatcher
+	horizonDays int
+	interval    time.Duration
+	notifiedAt  map[domain.TargetId]time.Time
+	stopChan    chan struct{}
+}
+
+func NewLatencyForecaster(targetRepo domain.MonitoringTargetRepository, statsRepo domain.TargetStatisticsRepository, rollupRepo domain.MetricRollupRepository, dispatcher *NotificationDispatcher, horizonDays int, interval time.Duration) *LatencyForecaster {
+	if horizonDays <= 0 {
+		horizonDays = domain.DefaultForecastHorizonDays
+	}
+	return &LatencyForecaster{
+		targetRepo:  targetRepo,
+		statsRepo:   statsRepo,
+		rollupRepo:  rollupRepo,
+		dispatcher:  dispatcher,
+		horizonDays: horizonDays,
+		interval:    interval,
+		notifiedAt:  make(map[domain.TargetId]time.Time),
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Start evalúa una vez al arrancar y luego cada `interval` (non-blocking)
+func (f *LatencyForecaster) Start() {
+	log.Printf("📈 Latency Forecaster iniciado (Horizonte: %dd, Intervalo: %s)", f.horizonDays, f.interval)
+	go f.runLoop()
+}
+
+func (f *LatencyForecaster) Stop() {
+	close(f.stopChan)
+}
+
+func (f *LatencyForecaster) runLoop() {
+	f.evaluate()
+
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			f.evaluate()
+		case <-f.stopChan:
+			return
+		}
+	}
+}
+
+func (f *LatencyForecaster) evaluate() {
+	targets, err := f.targetRepo.List()
+	if err != nil {
+		log.Printf("❌ Error listando targets para el pronóstico de latencia: %v", err)
+		return
+	}
+
+	now := time.Now()
+	cooldown := time.Duration(f.horizonDays) * 24 * time.Hour
+	pending := make([]*domain.MonitoringTarget, 0, len(targets))
+	ids := make([]domain.TargetId, 0, len(targets))
+	for _, target := range targets {
+		if !target.IsActive() {
+			continue
+		}
+		if last, ok := f.notifiedAt[target.ID()]; ok && now.Sub(last) < cooldown {
+			continue
+		}
+		pending = append(pending, target)
+		ids = append(ids, target.ID())
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	// Sin estadísticas no hay línea base: solo aplica el umbral absoluto
+	stats, err := f.statsRepo.GetByTargets(ids)
+	if err != nil {
+		log.Printf("⚠️  Error obteniendo estadísticas para el pronóstico de latencia: %v", err)
+		stats = map[domain.TargetId]*domain.TargetStatistics{}
+	}
+
+	lookback := domain.ForecastRange(now)
+	for _, target := range pending {
+		daily, err := f.rollupRepo.ListByTarget(target.ID(), domain.MetricResolutionDay, lookback.Start, lookback.End)
+		if err != nil {
+			log.Printf("❌ Error leyendo rollups diarios de %s: %v", target.ID(), err)
+			continue
+		}
+
+		forecast, ok := domain.ForecastTargetLatency(target, stats[target.ID()], daily, f.horizonDays, now)
+		if !ok || !forecast.WillCrossWithinHorizon() {
+			continue
+		}
+		f.notifiedAt[target.ID()] = now
+		f.dispatch(target, forecast)
+	}
+}
+
+func (f *LatencyForecaster) dispatch(target *domain.MonitoringTarget, forecast domain.LatencyForecast) {
+	if f.dispatcher == nil {
+		return
+	}
+
+	days := *forecast.DaysToThreshold
+	message := fmt.Sprintf("Daily p95 latency is %.0fms and rising %.1fms/day; it is projected to cross the %dms degradation threshold in %.1f days.",
+		forecast.CurrentMs, forecast.SlopeMsPerDay, forecast.ThresholdMs, days)
+	if days == 0 {
+		message = fmt.Sprintf("Daily p95 latency trend (%.0fms) is already above the %dms degradation threshold.",
+			forecast.CurrentMs, forecast.ThresholdMs)
+	}
+
+	event := notificationdomain.NewAlertEvent(
+		target.UserId().String(),
+		"Latency Trending Up: "+target.Name(),
+		message,
+		notificationdomain.SeverityInfo,
+		notificationdomain.SeverityOk,
+		"Target: "+target.Name(),
+		notificationdomain.AlertTypeForecast,
+		map[string]string{
+			notificationdomain.MetadataTargetID: target.ID().String(),
+			"threshold_ms":                      fmt.Sprintf("%d", forecast.ThresholdMs),
+			"current_p95_ms":                    fmt.Sprintf("%.1f", forecast.CurrentMs),
+			"slope_ms_per_day":                  fmt.Sprintf("%.2f", forecast.SlopeMsPerDay),
+			"days_to_threshold":                 fmt.Sprintf("%.1f", days),
+		},
+	)
+
+	f.dispatcher.Dispatch(*event)
+	log.Printf("📈 Pronóstico de latencia | target %s cruza %dms en %.1f días", target.Name(), forecast.ThresholdMs, days)
+}
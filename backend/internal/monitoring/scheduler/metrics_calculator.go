@@ -12,6 +12,7 @@ type SessionMetrics struct {
 	SuccessCount      int
 	FailureCount      int
 	LastStatus        domain.TargetStatus
+	ErrorClass        domain.ErrorClass // Clase del fallo del último ping (vacía si fue exitoso)
 }
 
 type MetricsCalculator struct{}
@@ -59,7 +60,7 @@ func (m *MetricsCalculator) Calculate(session CheckSessionResult) SessionMetrics
 		avg = totalTimeUp / upCount
 	}
 
-	lastStatus := session.Results[len(session.Results)-1].Status()
+	last := session.Results[len(session.Results)-1]
 
 	return SessionMetrics{
 		AvgResponseTimeMs: avg,
@@ -67,6 +68,7 @@ func (m *MetricsCalculator) Calculate(session CheckSessionResult) SessionMetrics
 		TotalChecks:       len(session.Results),
 		SuccessCount:      success,
 		FailureCount:      failure,
-		LastStatus:        lastStatus,
+		LastStatus:        last.Status(),
+		ErrorClass:        last.ErrorClass(),
 	}
 }
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+	"uptrackai/internal/monitoring/domain"
+)
+
+// MetricsRollup agrega periódicamente metrics en buckets de 1m, 1h y 1d.
+// Cada pasada recalcula desde el último bucket guardado (que pudo quedar a medias);
+// con la tabla vacía reconstruye todo el histórico.
+type MetricsRollup struct {
+	rollupRepo domain.MetricRollupRepository
+	interval   time.Duration
+	lastRun    map[domain.MetricResolution]time.Time
+	stopChan   chan struct{}
+}
+
+func NewMetricsRollup(rollupRepo domain.MetricRollupRepository, interval time.Duration) *MetricsRollup {
+	return &MetricsRollup{
+		rollupRepo: rollupRepo,
+		interval:   interval,
+		lastRun:    make(map[domain.MetricResolution]time.Time),
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// Start agrega una vez al arrancar y luego cada `interval` (non-blocking)
+func (r *MetricsRollup) Start() {
+	log.Printf("📊 Metrics Rollup iniciado (Intervalo: %s)", r.interval)
+	go r.runLoop()
+}
+
+func (r *MetricsRollup) Stop() {
+	close(r.stopChan)
+}
+
+func (r *MetricsRollup) runLoop() {
+	r.rollup()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.rollup()
+		case <-r.stopChan:
+			return
+		}
+	}
+}
+
+func (r *MetricsRollup) rollup() {
+	now := time.Now()
+
+	for _, resolution := range domain.RollupResolutions {
+		// Las resoluciones gruesas no necesitan recalcularse en cada pasada
+		if last, ok := r.lastRun[resolution]; ok && now.Sub(last) < resolution.RefreshInterval() {
+			continue
+		}
+
+		from, err := r.rollupRepo.LatestBucket(resolution)
+		if err != nil {
+			log.Printf("❌ Error leyendo el último bucket %s: %v", resolution, err)
+			continue
+		}
+		if err := r.rollupRepo.Rollup(resolution, from, now); err != nil {
+			log.Printf("❌ Error agregando métricas %s: %v", resolution, err)
+			continue
+		}
+		if from.IsZero() {
+			log.Printf("📊 Métricas %s reconstruidas desde el inicio", resolution)
+		}
+		r.lastRun[resolution] = now
+	}
+}
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+	"uptrackai/internal/monitoring/domain"
+
+	"github.com/google/uuid"
+)
+
+// MonitorHeartbeat registra que el scheduler está vivo. Cada arranque abre una ejecución
+// nueva; los huecos entre ejecuciones se excluyen del uptime de los targets.
+type MonitorHeartbeat struct {
+	runRepo  domain.MonitorRunRepository
+	interval time.Duration
+	run      *domain.MonitorRun
+	stopChan chan struct{}
+}
+
+func NewMonitorHeartbeat(runRepo domain.MonitorRunRepository, interval time.Duration) *MonitorHeartbeat {
+	return &MonitorHeartbeat{
+		runRepo:  runRepo,
+		interval: interval,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start abre la ejecución y late cada `interval` (non-blocking)
+func (h *MonitorHeartbeat) Start() {
+	h.run = domain.StartMonitorRun(domain.MonitorRunId(uuid.Must(uuid.NewV7()).String()), time.Now())
+	log.Printf("💓 Monitor Heartbeat iniciado (Ejecución: %s, Intervalo: %s)", h.run.ID(), h.interval)
+	go h.runLoop()
+}
+
+func (h *MonitorHeartbeat) Stop() {
+	close(h.stopChan)
+}
+
+func (h *MonitorHeartbeat) runLoop() {
+	h.save()
+
+	ticker := time.NewTicker(h.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			h.run.Beat(time.Now())
+			h.save()
+		case <-h.stopChan:
+			return
+		}
+	}
+}
+
+func (h *MonitorHeartbeat) save() {
+	if err := h.runRepo.Save(h.run); err != nil {
+		log.Printf("❌ Error registrando heartbeat del monitor: %v", err)
+	}
+}
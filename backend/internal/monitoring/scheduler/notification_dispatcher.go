@@ -1,33 +1,76 @@
 package scheduler
 
 import (
+	"log"
+	"time"
+
 	notificationdomain "uptrackai/internal/notifications/domain"
 )
 
+// AlertNotifier guarda la alerta en el historial y en el outbox del módulo notifications
+type AlertNotifier interface {
+	Notify(event notificationdomain.AlertEvent) error
+	// NotifyHeld la guarda igual, con el primer envío demorado hasta `until`
+	NotifyHeld(event notificationdomain.AlertEvent, until time.Time) (notificationdomain.NotificationId, error)
+	// GroupHeld reemplaza una alerta retenida por su versión agrupada (una hija correlacionada no sale)
+	GroupHeld(notificationId notificationdomain.NotificationId, event notificationdomain.AlertEvent) error
+	// ReleaseHeld adelanta el envío de una alerta retenida
+	ReleaseHeld(notificationId notificationdomain.NotificationId) error
+}
+
+// NotificationDispatcher escribe las alertas en el outbox persistido, en el mismo flujo que
+// detectó el cambio de estado. No envía nada: el worker del outbox entrega cada canal con
+// reintentos, así que una caída del proceso o de un canal no pierde la alerta.
 type NotificationDispatcher struct {
-	alertChannel chan notificationdomain.AlertEvent
+	notifier AlertNotifier
 }
 
-func NewNotificationDispatcher(bufferSize int) *NotificationDispatcher {
-	return &NotificationDispatcher{
-		alertChannel: make(chan notificationdomain.AlertEvent, bufferSize),
-	}
+func NewNotificationDispatcher(notifier AlertNotifier) *NotificationDispatcher {
+	return &NotificationDispatcher{notifier: notifier}
 }
 
-// Dispatch envía un evento al canal de alertas.
-// Es thread-safe y diseñado para ser usado por múltiples workers.
+// Dispatch persiste el evento. Es thread-safe: solo espera a la base de datos, nunca a un canal lento.
 func (d *NotificationDispatcher) Dispatch(event notificationdomain.AlertEvent) {
-	// Enviamos al canal. Si el buffer está lleno, esto bloqueará al worker.
-	// En un sistema real de alta carga, podríamos querer métricas de "dropped events" o un buffer muy grande.
-	d.alertChannel <- event
+	if d.notifier == nil {
+		log.Printf("⚠️ Alerta %q descartada: módulo de notificaciones no disponible", event.Title)
+		return
+	}
+	if err := d.notifier.Notify(event); err != nil {
+		log.Printf("❌ Error encolando la alerta %q: %v", event.Title, err)
+	}
+}
+
+// Hold persiste el evento con la entrega demorada hasta `until`. Si el proceso se cae antes,
+// el worker del outbox la entrega al vencer la retención, como cualquier otra alerta.
+func (d *NotificationDispatcher) Hold(event notificationdomain.AlertEvent, until time.Time) (notificationdomain.NotificationId, bool) {
+	if d.notifier == nil {
+		log.Printf("⚠️ Alerta %q descartada: módulo de notificaciones no disponible", event.Title)
+		return "", false
+	}
+	notificationId, err := d.notifier.NotifyHeld(event, until)
+	if err != nil {
+		log.Printf("❌ Error encolando la alerta retenida %q: %v", event.Title, err)
+		return "", false
+	}
+	return notificationId, true
 }
 
-// Events retorna el canal de lectura para el worker de notificaciones
-func (d *NotificationDispatcher) Events() <-chan notificationdomain.AlertEvent {
-	return d.alertChannel
+// Group reemplaza una alerta retenida por su versión agrupada
+func (d *NotificationDispatcher) Group(notificationId notificationdomain.NotificationId, event notificationdomain.AlertEvent) {
+	if d.notifier == nil {
+		return
+	}
+	if err := d.notifier.GroupHeld(notificationId, event); err != nil {
+		log.Printf("❌ Error agrupando la alerta retenida %s: %v", notificationId, err)
+	}
 }
 
-// Close cierra el canal
-func (d *NotificationDispatcher) Close() {
-	close(d.alertChannel)
+// Release entrega ya una alerta retenida
+func (d *NotificationDispatcher) Release(notificationId notificationdomain.NotificationId) {
+	if d.notifier == nil {
+		return
+	}
+	if err := d.notifier.ReleaseHeld(notificationId); err != nil {
+		log.Printf("❌ Error liberando la alerta retenida %s: %v", notificationId, err)
+	}
 }
@@ -1,8 +1,12 @@
 package scheduler
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	"strings"
+	"sync"
+	"time"
 	"uptrackai/internal/monitoring/domain"
 	notificationdomain "uptrackai/internal/notifications/domain"
 )
@@ -20,9 +24,20 @@ type Orchestrator struct {
 	stateUpdater        *StateUpdater
 	dispatcher          *NotificationDispatcher
 	statsRepo           domain.TargetStatisticsRepository
+	seasonalRepo        domain.SeasonalBaselineRepository
+	incidentRepo        domain.IncidentRepository
 	notificationChecker NotificationChecker
 	severityMapper      *notificationdomain.SeverityMapper
 
+	// Caídas recientes por IP/ASN/proveedor (compartido entre workers)
+	correlator   *domain.OutageCorrelator
+	correlatorMu sync.Mutex
+	// Alertas DOWN retenidas en el outbox hasta saber si la caída es compartida (protegidas por correlatorMu)
+	heldAlerts      map[domain.TargetId]*heldAlert
+	correlationHold time.Duration
+	// Targets cuya caída se avisó como hija de un incidente: su recuperación también lo es
+	correlatedDown map[domain.TargetId]domain.InfrastructureCause
+
 	workerPool           *WorkerPool
 	onProcessingComplete func(domain.TargetId)
 }
@@ -38,6 +53,9 @@ func NewOrchestrator(
 	metricsRepo domain.MetricsRepository,
 	checkRepo domain.CheckResultRepository,
 	statsRepo domain.TargetStatisticsRepository,
+	seasonalRepo domain.SeasonalBaselineRepository,
+	pingRepo domain.PingSampleRepository,
+	incidentRepo domain.IncidentRepository,
 	dispatcher *NotificationDispatcher,
 	notificationChecker NotificationChecker,
 ) *Orchestrator {
@@ -46,11 +64,17 @@ func NewOrchestrator(
 		healthChecker:       NewHealthChecker(),
 		metricsCalc:         NewMetricsCalculator(),
 		resultAnalyzer:      NewResultAnalyzer(),
-		stateUpdater:        NewStateUpdater(targetRepo, metricsRepo, checkRepo),
+		stateUpdater:        NewStateUpdater(targetRepo, metricsRepo, checkRepo, pingRepo),
 		dispatcher:          dispatcher,
 		statsRepo:           statsRepo,
+		seasonalRepo:        seasonalRepo,
+		incidentRepo:        incidentRepo,
 		notificationChecker: notificationChecker,
 		severityMapper:      notificationdomain.NewSeverityMapper(),
+		correlator:          domain.NewOutageCorrelator(domain.CorrelationWindow, domain.MinCorrelatedTargets),
+		heldAlerts:          make(map[domain.TargetId]*heldAlert),
+		correlationHold:     domain.CorrelationHold,
+		correlatedDown:      make(map[domain.TargetId]domain.InfrastructureCause),
 	}
 
 	// Create worker pool with processing function
@@ -105,14 +129,22 @@ func (o *Orchestrator) processTarget(target *domain.MonitoringTarget) {
 		historical = domain.NewTargetStatistics(target.ID())
 	}
 
+	// Líneas base por hora de la semana (vacías mientras aprende -> se usa la global)
+	seasonal, err := o.seasonalRepo.GetByTargetID(target.ID())
+	if err != nil {
+		seasonal = domain.NewSeasonalBaseline(target.ID(), nil)
+	}
+
 	// 4. Analizar Resultados
-	newStatus := o.resultAnalyzer.Analyze(session, metrics, historical)
+	thresholds := target.Configuration().Thresholds()
+	analysis := o.resultAnalyzer.Analyze(session, metrics, historical, seasonal, thresholds)
+	newStatus := analysis.Status
 
 	// Capturar estado previo para detectar cambios (Eventos)
 	previousStatus := target.CurrentStatus()
 
 	// 5. Actualizar Estado (DB & Memoria)
-	o.stateUpdater.Update(target, newStatus, metrics)
+	o.stateUpdater.Update(target, session, analysis, metrics)
 
 	// 6. Actualizar Estadísticas Históricas (Async o Sync?)
 	// Lo hacemos aquí sync por simplicidad, pero podría ser otro job
@@ -142,16 +174,63 @@ func (o *Orchestrator) processTarget(target *domain.MonitoringTarget) {
 
 	// 2. Actualizar estado (Mutación)
 	historical.UpdateState(newAvg, maxChecks)
+
+	// 3. Alimentar los percentiles con cada ping sano (a diferencia del promedio,
+	//    los picos sí entran: justamente son los que debe reflejar p99)
+	historical.RecordLatencies(upResponseTimes(session), time.Now())
+
+	// 4. Detector de anomalías: solo sesiones sanas o degradadas por latencia
+	//    (las muestras anómalas se recortan a la sensibilidad del target)
+	if newStatus == domain.TargetStatusUp || analysis.Reason != nil {
+		historical.RecordEWMASample(metrics.AvgResponseTimeMs, thresholds.ZScoreThreshold())
+	}
+
+	// 5. Flapping entre sesiones (% de cambios en las últimas N sesiones, con histéresis)
+	flapTransition := historical.RecordSessionState(newStatus)
 	_ = o.statsRepo.Save(historical)
 
+	// 6. Incidente: se abre al pasar a CRITICAL, acumula transiciones y se cierra al volver a UP
+	incident := o.trackIncident(target, newStatus, previousStatus)
+
 	// 7. Notificar si es necesario
 	// Eliminamos la verificación de canales activos aqu para permitir que se generen
 	// alertas internas (historial frontend) incluso si no hay Telegram/Email configurado.
 	if o.notificationChecker != nil {
+		// Mientras flapea, las transiciones individuales se silencian: solo se avisa al entrar y al salir
+		if flapTransition != domain.FlapTransitionNone {
+			o.dispatchFlapEvent(target, historical, flapTransition, newStatus, previousStatus)
+		}
+		suppressed := flapTransition != domain.FlapTransitionNone || historical.IsFlapping()
+
 		newSeverity := o.severityMapper.Map(string(newStatus))
 		prevSeverity := o.severityMapper.Map(string(previousStatus))
 
 		message := fmt.Sprintf("Target %s is now %s", target.Name(), newStatus)
+		metadata := map[string]string{
+			notificationdomain.MetadataTargetID:       target.ID().String(),
+			notificationdomain.MetadataURL:            target.Url(),
+			notificationdomain.MetadataResponseTime:   fmt.Sprintf("%dms", metrics.AvgResponseTimeMs),
+			notificationdomain.MetadataStatus:         string(newStatus),
+			notificationdomain.MetadataPreviousStatus: string(previousStatus),
+		}
+
+		if incident != nil {
+			metadata[notificationdomain.MetadataIncidentID] = incident.ID().String()
+		}
+
+		// Clase del fallo: las reglas de los canales pueden filtrar por ella
+		if metrics.ErrorClass != domain.ErrorClassNone && newStatus != domain.TargetStatusUp {
+			metadata[notificationdomain.MetadataErrorClass] = metrics.ErrorClass.String()
+			message = fmt.Sprintf("%s (%s)", message, metrics.ErrorClass)
+		}
+
+		// Explicar por qué se disparó (no solo "Target X is now DEGRADED")
+		if analysis.Reason != nil {
+			message = fmt.Sprintf("%s: %s", message, analysis.Reason)
+			for k, v := range analysis.Reason.Metadata() {
+				metadata[k] = v
+			}
+		}
 
 		event := notificationdomain.NewAlertEvent(
 			target.UserId().String(),
@@ -161,18 +240,13 @@ func (o *Orchestrator) processTarget(target *domain.MonitoringTarget) {
 			prevSeverity,
 			"Target: "+target.Name(),
 			notificationdomain.AlertTypeMonitoring,
-			map[string]string{
-				"url":           target.Url(),
-				"response_time": fmt.Sprintf("%dms", metrics.AvgResponseTimeMs),
-			},
+			metadata,
 		)
 
-		if event.ShouldNotify() {
-			if o.dispatcher != nil {
-				o.dispatcher.Dispatch(*event)
-				log.Printf("📢 ALERT DISPATCHED | Target: %s | Severity: %s", target.Name(), newSeverity)
-			}
-		}
+		// Caídas que comparten infraestructura con otras: un solo aviso de incidente,
+		// y las alertas individuales quedan etiquetadas con la causa sospechada
+		notify := event.ShouldNotify() && !suppressed
+		o.correlateOutage(target, *event, newStatus, previousStatus, notify)
 	}
 
 	// 8. Log de Transición de Estado (Solo si hubo cambio relevante)
@@ -184,6 +258,308 @@ func (o *Orchestrator) processTarget(target *domain.MonitoringTarget) {
 	}
 }
 
+// dispatchFlapEvent emite la alerta única de inicio o fin de flapping
+func (o *Orchestrator) dispatchFlapEvent(
+	target *domain.MonitoringTarget,
+	historical *domain.TargetStatistics,
+	transition domain.FlapTransition,
+	newStatus domain.TargetStatus,
+	previousStatus domain.TargetStatus,
+) {
+	if o.dispatcher == nil {
+		return
+	}
+
+	percent := historical.FlapHistory().StateChangePercent()
+	metadata := map[string]string{
+		notificationdomain.MetadataTargetID:    target.ID().String(),
+		notificationdomain.MetadataURL:         target.Url(),
+		notificationdomain.MetadataFlapPercent: fmt.Sprintf("%.1f", percent),
+	}
+
+	var event *notificationdomain.AlertEvent
+	if transition == domain.FlapTransitionStarted {
+		metadata[notificationdomain.MetadataFlapping] = notificationdomain.FlappingStarted
+		event = notificationdomain.NewAlertEvent(
+			target.UserId().String(),
+			"Flapping Started: "+target.Name(),
+			fmt.Sprintf("Target %s is flapping (%.0f%% state changes over the last %d checks). Individual status alerts are paused.",
+				target.Name(), percent, len(historical.FlapHistory().RecentStates())),
+			notificationdomain.SeverityWarning,
+			o.severityMapper.Map(string(previousStatus)),
+			"Target: "+target.Name(),
+			notificationdomain.AlertTypeMonitoring,
+			metadata,
+		)
+	} else {
+		metadata[notificationdomain.MetadataFlapping] = notificationdomain.FlappingEnded
+		event = notificationdomain.NewAlertEvent(
+			target.UserId().String(),
+			"Flapping Ended: "+target.Name(),
+			fmt.Sprintf("Target %s stopped flapping and is now %s", target.Name(), newStatus),
+			o.severityMapper.Map(string(newStatus)),
+			notificationdomain.SeverityWarning,
+			"Target: "+target.Name(),
+			notificationdomain.AlertTypeMonitoring,
+			metadata,
+		)
+	}
+
+	o.dispatcher.Dispatch(*event)
+	log.Printf("📢 FLAPPING %s | Target: %s | %.1f%%", metadata[notificationdomain.MetadataFlapping], target.Name(), percent)
+}
+
+// trackIncident abre, actualiza o resuelve el incidente del target ante un cambio de estado
+func (o *Orchestrator) trackIncident(
+	target *domain.MonitoringTarget,
+	newStatus domain.TargetStatus,
+	previousStatus domain.TargetStatus,
+) *domain.Incident {
+	if o.incidentRepo == nil || newStatus == previousStatus {
+		return nil
+	}
+
+	// Si la API guardó el incidente en el medio (reconocido, comentado), se recarga y se reaplica
+	for attempt := 1; ; attempt++ {
+		incident, err := o.applyIncidentTransition(target, newStatus, time.Now())
+		if err != nil {
+			log.Printf("⚠️ Error updating incident for %s: %v", target.Name(), err)
+			return nil
+		}
+		if incident == nil {
+			return nil
+		}
+
+		err = o.incidentRepo.Save(incident)
+		if errors.Is(err, domain.ErrIncidentConflict) && attempt < domain.IncidentSaveAttempts {
+			continue
+		}
+		if err != nil {
+			log.Printf("⚠️ Error saving incident for %s: %v", target.Name(), err)
+			return nil
+		}
+		return incident
+	}
+}
+
+// applyIncidentTransition carga el incidente activo y le aplica el cambio de estado
+// (nil si el cambio no abre ni toca ningún incidente)
+func (o *Orchestrator) applyIncidentTransition(
+	target *domain.MonitoringTarget,
+	newStatus domain.TargetStatus,
+	now time.Time,
+) (*domain.Incident, error) {
+	incident, err := o.incidentRepo.GetActiveByTarget(target.ID())
+	switch {
+	case errors.Is(err, domain.ErrIncidentNotFound):
+		if o.severityMapper.Map(newStatus.String()) != notificationdomain.SeverityCritical {
+			return nil, nil
+		}
+		return domain.OpenIncident(target.ID(), target.UserId(), newStatus, now), nil
+	case err != nil:
+		return nil, err
+	case newStatus == domain.TargetStatusUp:
+		err = incident.ResolveOnRecovery(newStatus, now)
+	default:
+		err = incident.RecordTransition(newStatus, now)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("incident %s: %w", incident.ID(), err)
+	}
+	return incident, nil
+}
+
+// heldAlert - Alerta DOWN ya guardada en el outbox con el envío demorado, a la espera de
+// otras caídas con la misma causa
+type heldAlert struct {
+	notificationId notificationdomain.NotificationId
+	event          notificationdomain.AlertEvent
+	causes         []domain.InfrastructureCause
+	until          time.Time
+}
+
+// correlateOutage registra caídas/recuperaciones y emite la alerta del cambio de estado.
+// La caída aislada de un target con infraestructura conocida se guarda en el outbox retenida
+// correlationHold: si en ese tiempo caen otros por la misma causa, el usuario recibe una sola
+// alerta de incidente y las individuales quedan como hijas etiquetadas (solo historial). Si no,
+// el worker del outbox la entrega al terminar la retención, aunque el proceso se haya reiniciado.
+// Las recuperaciones de las hijas tampoco salen solas: cuando vuelve el último target, cada
+// usuario que recibió la alerta del incidente recibe una de resolución.
+func (o *Orchestrator) correlateOutage(
+	target *domain.MonitoringTarget,
+	event notificationdomain.AlertEvent,
+	newStatus domain.TargetStatus,
+	previousStatus domain.TargetStatus,
+	notify bool,
+) {
+	wentDown := newStatus == domain.TargetStatusDown && previousStatus != domain.TargetStatusDown
+	recovered := newStatus != domain.TargetStatusDown && previousStatus == domain.TargetStatusDown
+	if !wentDown && !recovered {
+		if notify {
+			o.dispatch(event)
+		}
+		return
+	}
+
+	// Se emite con el lock tomado: otro worker no puede formar el incidente entre que esta
+	// alerta se retiene y queda registrada en heldAlerts
+	o.correlatorMu.Lock()
+	defer o.correlatorMu.Unlock()
+
+	now := time.Now()
+	o.forgetEndedHolds(now)
+
+	if recovered {
+		resolved := o.correlator.RecordRecovery(target.ID())
+		// La caída retenida sale antes que la recuperación
+		if held, ok := o.heldAlerts[target.ID()]; ok {
+			delete(o.heldAlerts, target.ID())
+			if o.dispatcher != nil {
+				o.dispatcher.Release(held.notificationId)
+			}
+		}
+		if cause, ok := o.correlatedDown[target.ID()]; ok {
+			delete(o.correlatedDown, target.ID())
+			event = tagWithCause(event, cause)
+		}
+		if notify {
+			o.dispatch(event)
+		}
+		for _, incident := range resolved {
+			for _, userId := range incident.NotifiedUsers {
+				o.dispatch(infrastructureResolvedEvent(userId, incident.Cause))
+			}
+			log.Printf("🌐 INFRASTRUCTURE INCIDENT RESOLVED | %s | %d users", incident.Cause, len(incident.NotifiedUsers))
+		}
+		return
+	}
+
+	hosting := target.HostingInfo()
+	result := o.correlator.RecordFailure(domain.CorrelatedFailure{
+		TargetId:   target.ID(),
+		TargetName: target.Name(),
+		UserId:     target.UserId().String(),
+		At:         now,
+	}, hosting)
+
+	if result.Cause == nil {
+		if !notify {
+			return
+		}
+		if causes := hosting.Causes(); o.correlationHold > 0 && len(causes) > 0 && o.dispatcher != nil {
+			until := now.Add(o.correlationHold)
+			if notificationId, ok := o.dispatcher.Hold(event, until); ok {
+				o.heldAlerts[target.ID()] = &heldAlert{notificationId: notificationId, event: event, causes: causes, until: until}
+			}
+			return
+		}
+		o.dispatch(event)
+		return
+	}
+
+	cause := *result.Cause
+	for userId, targetNames := range result.PendingUsers {
+		o.dispatch(infrastructureEvent(userId, cause, targetNames))
+		log.Printf("🌐 INFRASTRUCTURE INCIDENT | %s | %d targets | user %s", cause, result.Affected, userId)
+	}
+	for targetId, held := range o.heldAlerts {
+		if !hasCause(held.causes, cause) {
+			continue
+		}
+		delete(o.heldAlerts, targetId)
+		o.dispatcher.Group(held.notificationId, tagWithCause(held.event, cause))
+		o.correlatedDown[targetId] = cause
+	}
+	o.correlatedDown[target.ID()] = cause
+	if notify {
+		o.dispatch(tagWithCause(event, cause))
+	}
+}
+
+// forgetEndedHolds suelta las alertas cuya retención terminó: el outbox ya las está entregando.
+// Requiere correlatorMu.
+func (o *Orchestrator) forgetEndedHolds(now time.Time) {
+	for targetId, held := range o.heldAlerts {
+		if !now.Before(held.until) {
+			delete(o.heldAlerts, targetId)
+		}
+	}
+}
+
+// dispatch encola la alerta para su envío inmediato
+func (o *Orchestrator) dispatch(event notificationdomain.AlertEvent) {
+	if o.dispatcher == nil {
+		return
+	}
+	o.dispatcher.Dispatch(event)
+	log.Printf("📢 ALERT DISPATCHED | %s | Severity: %s", event.Source, event.Severity)
+}
+
+// tagWithCause marca la alerta como hija del incidente de infraestructura
+func tagWithCause(event notificationdomain.AlertEvent, cause domain.InfrastructureCause) notificationdomain.AlertEvent {
+	metadata := make(map[string]string, len(event.Metadata)+1)
+	for k, v := range event.Metadata {
+		metadata[k] = v
+	}
+	metadata[notificationdomain.MetadataSuspectedCause] = cause.String()
+	event.Metadata = metadata
+	event.Message = fmt.Sprintf("%s [suspected common cause: %s]", event.Message, cause)
+	return event
+}
+
+// infrastructureResolvedEvent cierra el incidente para un usuario que recibió su alerta
+func infrastructureResolvedEvent(userId string, cause domain.InfrastructureCause) notificationdomain.AlertEvent {
+	return *notificationdomain.NewAlertEvent(
+		userId,
+		"Infrastructure Incident Resolved: "+cause.String(),
+		fmt.Sprintf("All your targets sharing %s %s are back up.", cause.Kind, cause.Value),
+		notificationdomain.SeverityOk,
+		notificationdomain.SeverityCritical,
+		"Infrastructure: "+cause.String(),
+		notificationdomain.AlertTypeInfrastructure,
+		map[string]string{notificationdomain.MetadataInfrastructureCause: cause.String()},
+	)
+}
+
+func hasCause(causes []domain.InfrastructureCause, cause domain.InfrastructureCause) bool {
+	for _, candidate := range causes {
+		if candidate == cause {
+			return true
+		}
+	}
+	return false
+}
+
+// infrastructureEvent es la alerta única del incidente para un usuario, con sus targets afectados
+func infrastructureEvent(userId string, cause domain.InfrastructureCause, targetNames []string) notificationdomain.AlertEvent {
+	return *notificationdomain.NewAlertEvent(
+		userId,
+		"Infrastructure Incident: "+cause.String(),
+		fmt.Sprintf("%d of your targets sharing %s %s went down within %s: %s. "+
+			"This looks like an infrastructure problem rather than your application.",
+			len(targetNames), cause.Kind, cause.Value, domain.CorrelationWindow, strings.Join(targetNames, ", ")),
+		notificationdomain.SeverityCritical,
+		notificationdomain.SeverityOk,
+		"Infrastructure: "+cause.String(),
+		notificationdomain.AlertTypeInfrastructure,
+		map[string]string{
+			notificationdomain.MetadataInfrastructureCause: cause.String(),
+			notificationdomain.MetadataAffectedTargets:     fmt.Sprintf("%d", len(targetNames)),
+		},
+	)
+}
+
+// upResponseTimes extrae los tiempos de respuesta de los pings UP de la sesión
+func upResponseTimes(session CheckSessionResult) []int {
+	times := make([]int, 0, len(session.Results))
+	for _, r := range session.Results {
+		if r.Status() == domain.TargetStatusUp {
+			times = append(times, r.ResponseTimeMs())
+		}
+	}
+	return times
+}
+
 // RunBatch ejecuta un lote de targets de manera asíncrona
 func (o *Orchestrator) RunBatch(targets []*domain.MonitoringTarget) {
 	// Enviar trabajos de manera asíncrona
@@ -0,0 +1,324 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"uptrackai/internal/monitoring/domain"
+	notificationdomain "uptrackai/internal/notifications/domain"
+	"uptrackai/internal/notifications/infrastructure/sender"
+	userdomain "uptrackai/internal/user/domain"
+)
+
+// queuedAlert - Alerta tal como quedaría en el historial y el outbox
+type queuedAlert struct {
+	id        notificationdomain.NotificationId
+	event     notificationdomain.AlertEvent
+	heldUntil time.Time
+	released  bool
+	cancelled bool // Agrupada antes de salir: sus envíos no se hacen
+}
+
+// MockAlertNotifier - Registra las alertas que llegarían al módulo de notificaciones
+type MockAlertNotifier struct {
+	mu     sync.Mutex
+	alerts []*queuedAlert
+}
+
+func (m *MockAlertNotifier) Notify(event notificationdomain.AlertEvent) error {
+	_, err := m.NotifyHeld(event, time.Time{})
+	return err
+}
+
+func (m *MockAlertNotifier) NotifyHeld(event notificationdomain.AlertEvent, until time.Time) (notificationdomain.NotificationId, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := notificationdomain.NotificationId(fmt.Sprintf("notification-%d", len(m.alerts)+1))
+	m.alerts = append(m.alerts, &queuedAlert{id: id, event: event, heldUntil: until})
+	return id, nil
+}
+
+func (m *MockAlertNotifier) GroupHeld(notificationId notificationdomain.NotificationId, event notificationdomain.AlertEvent) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	alert := m.find(notificationId)
+	alert.event = event
+	alert.cancelled = event.IsCorrelatedChild()
+	return nil
+}
+
+func (m *MockAlertNotifier) ReleaseHeld(notificationId notificationdomain.NotificationId) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.find(notificationId).released = true
+	return nil
+}
+
+func (m *MockAlertNotifier) find(notificationId notificationdomain.NotificationId) *queuedAlert {
+	for _, alert := range m.alerts {
+		if alert.id == notificationId {
+			return alert
+		}
+	}
+	panic("unknown notification " + notificationId)
+}
+
+func (m *MockAlertNotifier) Alerts() []queuedAlert {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	alerts := make([]queuedAlert, len(m.alerts))
+	for i, alert := range m.alerts {
+		alerts[i] = *alert
+	}
+	return alerts
+}
+
+func (m *MockAlertNotifier) Events() []notificationdomain.AlertEvent {
+	events := make([]notificationdomain.AlertEvent, 0)
+	for _, alert := range m.Alerts() {
+		events = append(events, alert.event)
+	}
+	return events
+}
+
+// externalAlerts son las alertas que salen por los canales del usuario (las hijas correlacionadas no)
+func (m *MockAlertNotifier) externalAlerts(userId string) []notificationdomain.AlertEvent {
+	alerts := make([]notificationdomain.AlertEvent, 0)
+	for _, alert := range m.Alerts() {
+		if alert.event.UserID == userId && !alert.cancelled && !alert.event.IsCorrelatedChild() {
+			alerts = append(alerts, alert.event)
+		}
+	}
+	return alerts
+}
+
+func newCorrelationOrchestrator(notifier AlertNotifier, hold time.Duration) *Orchestrator {
+	return &Orchestrator{
+		dispatcher:      NewNotificationDispatcher(notifier),
+		correlator:      domain.NewOutageCorrelator(domain.CorrelationWindow, domain.MinCorrelatedTargets),
+		heldAlerts:      make(map[domain.TargetId]*heldAlert),
+		correlationHold: hold,
+		correlatedDown:  make(map[domain.TargetId]domain.InfrastructureCause),
+	}
+}
+
+// cloudflareTarget - Target detrás de Cloudflare con IP propia (la causa común es su ASN)
+func cloudflareTarget(id string, userId string) *domain.MonitoringTarget {
+	target := domain.NewFullMonitoringTarget(domain.TargetId(id), userdomain.UserId(userId), "site-"+id,
+		"https://site-"+id+".example.com", domain.TargetTypeWEB, domain.NewDefaultCheckConfiguration(),
+		true, domain.TargetStatusUp, domain.TargetStatusUp, time.Now(), time.Now())
+	target.AssignHostingInfo(domain.NewHostingInfo([]string{"104.16.0." + id}, "Cloudflare", 13335, time.Now()))
+	return target
+}
+
+// goDown simula la transición UP -> DOWN de processTarget
+func goDown(o *Orchestrator, target *domain.MonitoringTarget) {
+	event := notificationdomain.NewAlertEvent(target.UserId().String(), "Status Change: "+target.Name(),
+		fmt.Sprintf("Target %s is now DOWN", target.Name()), notificationdomain.SeverityCritical, notificationdomain.SeverityOk,
+		"Target: "+target.Name(), notificationdomain.AlertTypeMonitoring,
+		map[string]string{notificationdomain.MetadataTargetID: target.ID().String()})
+	o.correlateOutage(target, *event, domain.TargetStatusDown, domain.TargetStatusUp, true)
+}
+
+func TestCorrelateOutage_OneExternalAlertPerUser(t *testing.T) {
+	notifier := &MockAlertNotifier{}
+	o := newCorrelationOrchestrator(notifier, time.Hour)
+
+	goDown(o, cloudflareTarget("1", "user-a"))
+	goDown(o, cloudflareTarget("2", "user-a"))
+	// Las primeras caídas ya están en el outbox, retenidas: un reinicio no las pierde
+	for _, alert := range notifier.Alerts() {
+		if alert.heldUntil.IsZero() || alert.released || alert.cancelled {
+			t.Fatalf("Expected the first failures persisted on hold, got %+v", alert)
+		}
+	}
+	goDown(o, cloudflareTarget("3", "user-b"))
+
+	// Cada usuario: solo la alerta del incidente, con sus propios targets
+	external := notifier.externalAlerts("user-a")
+	if len(external) != 1 || external[0].Type != notificationdomain.AlertTypeInfrastructure {
+		t.Fatalf("Expected one infrastructure alert for user-a, got %+v", external)
+	}
+	if external[0].Metadata[notificationdomain.MetadataInfrastructureCause] != "asn:13335" {
+		t.Errorf("Unexpected cause %q", external[0].Metadata[notificationdomain.MetadataInfrastructureCause])
+	}
+	if external[0].Metadata[notificationdomain.MetadataAffectedTargets] != "2" || !strings.HasPrefix(external[0].Message, "2 of your targets") {
+		t.Errorf("Expected user-a's own 2 targets, got %q (%s)", external[0].Metadata[notificationdomain.MetadataAffectedTargets], external[0].Message)
+	}
+	if !strings.Contains(external[0].Message, "site-1, site-2") || strings.Contains(external[0].Message, "site-3") {
+		t.Errorf("Expected only user-a's targets in the message, got %s", external[0].Message)
+	}
+	if external := notifier.externalAlerts("user-b"); len(external) != 1 || external[0].Metadata[notificationdomain.MetadataAffectedTargets] != "1" {
+		t.Errorf("Expected one infrastructure alert for user-b with 1 target, got %+v", external)
+	}
+
+	// Las alertas individuales, incluidas las retenidas, quedan en el historial etiquetadas
+	children := 0
+	for _, alert := range notifier.Alerts() {
+		if alert.event.IsCorrelatedChild() {
+			children++
+			if !strings.Contains(alert.event.Message, "asn:13335") {
+				t.Errorf("Expected the cause in the child message, got %s", alert.event.Message)
+			}
+			if !alert.heldUntil.IsZero() && !alert.cancelled {
+				t.Errorf("Expected the held deliveries of %s cancelled", alert.id)
+			}
+		}
+	}
+	if children != 3 {
+		t.Errorf("Expected 3 tagged child alerts, got %d", children)
+	}
+	if len(o.heldAlerts) != 0 {
+		t.Errorf("Expected no alert left on hold, got %d", len(o.heldAlerts))
+	}
+}
+
+func TestCorrelateOutage_LateFailureJoinsIncident(t *testing.T) {
+	notifier := &MockAlertNotifier{}
+	o := newCorrelationOrchestrator(notifier, time.Hour)
+	for _, id := range []string{"1", "2", "3"} {
+		goDown(o, cloudflareTarget(id, "user-a"))
+	}
+
+	// El usuario ya conoce el incidente: la nueva caída sale etiquetada, sin retener ni repetir el aviso
+	goDown(o, cloudflareTarget("4", "user-a"))
+	if external := notifier.externalAlerts("user-a"); len(external) != 1 {
+		t.Errorf("Expected a single infrastructure alert, got %+v", external)
+	}
+	alerts := notifier.Alerts()
+	if last := alerts[len(alerts)-1]; !last.event.IsCorrelatedChild() || !last.heldUntil.IsZero() ||
+		last.event.Metadata[notificationdomain.MetadataTargetID] != "4" {
+		t.Errorf("Expected the late failure as a tagged child, got %+v", last)
+	}
+}
+
+func TestCorrelateOutage_EndedHoldIsNotGrouped(t *testing.T) {
+	notifier := &MockAlertNotifier{}
+	o := newCorrelationOrchestrator(notifier, time.Millisecond)
+
+	goDown(o, cloudflareTarget("1", "user-a"))
+	time.Sleep(5 * time.Millisecond)
+
+	// La retención terminó: el outbox ya entrega la caída, el incidente no la retira
+	o.correlationHold = time.Hour
+	goDown(o, cloudflareTarget("2", "user-a"))
+	goDown(o, cloudflareTarget("3", "user-a"))
+
+	first := notifier.Alerts()[0]
+	if first.cancelled || first.event.IsCorrelatedChild() {
+		t.Errorf("Expected the DOWN alert sent after its hold to stay as is, got %+v", first)
+	}
+	if external := notifier.externalAlerts("user-a"); len(external) != 2 {
+		t.Errorf("Expected the first DOWN alert and the infrastructure alert, got %+v", external)
+	}
+}
+
+func TestCorrelateOutage_RecoveryReleasesHeldAlertFirst(t *testing.T) {
+	notifier := &MockAlertNotifier{}
+	o := newCorrelationOrchestrator(notifier, time.Hour)
+	target := cloudflareTarget("1", "user-a")
+
+	goDown(o, target)
+	up := notificationdomain.NewAlertEvent("user-a", "Status Change: site-1", "Target site-1 is now UP",
+		notificationdomain.SeverityOk, notificationdomain.SeverityCritical, "Target: site-1", notificationdomain.AlertTypeMonitoring, nil)
+	o.correlateOutage(target, *up, domain.TargetStatusUp, domain.TargetStatusDown, true)
+
+	alerts := notifier.Alerts()
+	if len(alerts) != 2 || !alerts[0].released || alerts[1].event.Severity != notificationdomain.SeverityOk {
+		t.Fatalf("Expected the held DOWN alert released before the UP alert, got %+v", alerts)
+	}
+	if len(o.heldAlerts) != 0 {
+		t.Errorf("Expected no alert left on hold, got %d", len(o.heldAlerts))
+	}
+}
+
+func TestCorrelateOutage_NoHostingIsNotHeld(t *testing.T) {
+	notifier := &MockAlertNotifier{}
+	o := newCorrelationOrchestrator(notifier, time.Hour)
+	target := cloudflareTarget("1", "user-a")
+	target.AssignHostingInfo(domain.HostingInfo{})
+
+	goDown(o, target)
+	if alerts := notifier.Alerts(); len(alerts) != 1 || !alerts[0].heldUntil.IsZero() {
+		t.Errorf("Expected an immediate alert without hosting info, got %+v", alerts)
+	}
+}
+
+// goUp simula la transición DOWN -> UP de processTarget
+func goUp(o *Orchestrator, target *domain.MonitoringTarget) {
+	event := notificationdomain.NewAlertEvent(target.UserId().String(), "Status Change: "+target.Name(),
+		fmt.Sprintf("Target %s is now UP", target.Name()), notificationdomain.SeverityOk, notificationdomain.SeverityCritical,
+		"Target: "+target.Name(), notificationdomain.AlertTypeMonitoring,
+		map[string]string{notificationdomain.MetadataTargetID: target.ID().String()})
+	o.correlateOutage(target, *event, domain.TargetStatusUp, domain.TargetStatusDown, true)
+}
+
+func TestCorrelateOutage_IncidentResolvedOncePerUser(t *testing.T) {
+	notifier := &MockAlertNotifier{}
+	o := newCorrelationOrchestrator(notifier, time.Hour)
+	targets := []*domain.MonitoringTarget{
+		cloudflareTarget("1", "user-a"), cloudflareTarget("2", "user-a"), cloudflareTarget("3", "user-b"),
+	}
+	for _, target := range targets {
+		goDown(o, target)
+	}
+	downs := len(notifier.Alerts())
+
+	// Mientras quede un target caído el incidente sigue abierto
+	goUp(o, targets[0])
+	goUp(o, targets[1])
+	if external := notifier.externalAlerts("user-a"); len(external) != 1 {
+		t.Fatalf("Expected no recovery alert while the incident is open, got %+v", external)
+	}
+	goUp(o, targets[2])
+
+	// Las recuperaciones de las hijas quedan solo en el historial
+	for _, alert := range notifier.Alerts()[downs:] {
+		if alert.event.Type == notificationdomain.AlertTypeMonitoring && !alert.event.IsCorrelatedChild() {
+			t.Errorf("Expected the child recovery tagged with the cause, got %+v", alert.event)
+		}
+	}
+	for _, userId := range []string{"user-a", "user-b"} {
+		external := notifier.externalAlerts(userId)
+		if len(external) != 2 {
+			t.Fatalf("Expected the incident alert and its recovery for %s, got %+v", userId, external)
+		}
+		resolved := external[1]
+		if resolved.Type != notificationdomain.AlertTypeInfrastructure || resolved.Severity != notificationdomain.SeverityOk ||
+			resolved.Metadata[notificationdomain.MetadataInfrastructureCause] != "asn:13335" {
+			t.Errorf("Expected an OK infrastructure alert for asn:13335, got %+v", resolved)
+		}
+	}
+	if len(o.correlatedDown) != 0 {
+		t.Errorf("Expected no correlated target left, got %d", len(o.correlatedDown))
+	}
+}
+
+func TestCorrelateOutage_PagerDutyResolvesInfrastructureIncident(t *testing.T) {
+	notifier := &MockAlertNotifier{}
+	o := newCorrelationOrchestrator(notifier, time.Hour)
+	targets := []*domain.MonitoringTarget{
+		cloudflareTarget("1", "user-a"), cloudflareTarget("2", "user-a"), cloudflareTarget("3", "user-a"),
+	}
+	for _, target := range targets {
+		goDown(o, target)
+	}
+	for _, target := range targets {
+		goUp(o, target)
+	}
+
+	// Lo que llega a PagerDuty: el aviso del incidente y su resolución, con la misma clave
+	external := notifier.externalAlerts("user-a")
+	if len(external) != 2 {
+		t.Fatalf("Expected the infrastructure DOWN and UP alerts, got %+v", external)
+	}
+	down, up := external[0], external[1]
+	if sender.PagerDutyEventAction(down) != sender.PagerDutyActionTrigger || sender.PagerDutyEventAction(up) != sender.PagerDutyActionResolve {
+		t.Errorf("Expected trigger then resolve, got %q then %q", sender.PagerDutyEventAction(down), sender.PagerDutyEventAction(up))
+	}
+	if key := sender.PagerDutyDedupKey(down); key != "uptrack:infrastructure:asn:13335" || sender.PagerDutyDedupKey(up) != key {
+		t.Errorf("Expected the cause as shared dedup key, got %q and %q", key, sender.PagerDutyDedupKey(up))
+	}
+}
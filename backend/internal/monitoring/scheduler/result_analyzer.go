@@ -1,9 +1,17 @@
 package scheduler
 
 import (
+	"time"
 	"uptrackai/internal/monitoring/domain"
 )
 
+// AnalysisResult es la decisión del analizador: el estado final y, si es DEGRADED por
+// performance, la razón legible por máquina que lo explica
+type AnalysisResult struct {
+	Status domain.TargetStatus
+	Reason *domain.DegradationReason
+}
+
 type ResultAnalyzer struct{}
 
 func NewResultAnalyzer() *ResultAnalyzer {
@@ -11,41 +19,113 @@ func NewResultAnalyzer() *ResultAnalyzer {
 }
 
 // Analyze determina el estado final del target basado en la sesión actual y el histórico
+// Si el bucket estacional de esta hora de la semana ya aprendió, reemplaza a la línea base global.
+// Los umbrales (sensibilidad, factor de degradación, piso de latencia, banda UNSTABLE) vienen de la configuración del target
 func (a *ResultAnalyzer) Analyze(
 	session CheckSessionResult,
 	metrics SessionMetrics,
 	historical *domain.TargetStatistics,
-) domain.TargetStatus {
+	seasonal *domain.SeasonalBaseline,
+	thresholds domain.AnalyzerThresholds,
+) AnalysisResult {
 
 	// 1. Si no hubo estabilidad (no se consiguieron 3 iguales en 12 intentos) -> FLAPPING
 	if !session.Stable {
-		return domain.TargetStatusFlapping
+		return AnalysisResult{Status: domain.TargetStatusFlapping}
 	}
 
 	// 2. Estado base confirmado (el de los 3 iguales)
-	finalStatus := metrics.LastStatus
-
-	// 3. Regla de Degradación de Performance
-	// Si es UP pero lento (> 3x promedio histórico) -> DEGRADED
-	// EXCEPCIÓN: Si el tiempo actual es muy bajo (ej: < 200ms), no consideramos degradación
-	// para evitar falsos positivos en sistemas hiper-rápidos (ej: 10ms -> 25ms).
-	const MinLatencyThreshold = 200 // ms
+	result := AnalysisResult{Status: metrics.LastStatus}
 
-	if finalStatus == domain.TargetStatusUp {
-		historicalAvg := historical.AvgResponseTimeMs()
+	// 2b. Si falló, la política del target decide qué significa cada clase
+	//     (ej: un 401 esperado es UP, un read_timeout solo DEGRADED)
+	if result.Status != domain.TargetStatusUp && metrics.ErrorClass != domain.ErrorClassNone {
+		if status, ok := thresholds.ErrorClassPolicy().StatusFor(metrics.ErrorClass); ok {
+			result.Status = status
+		}
+	}
 
-		// Solo marcamos DEGRADED si supera el TRIPLE del histórico Y ADEMÁS supera el umbral mínimo perceptible
-		if historicalAvg > 0 && metrics.AvgResponseTimeMs >= historicalAvg*3 && metrics.AvgResponseTimeMs > MinLatencyThreshold {
-			finalStatus = domain.TargetStatusDegraded
+	// 3. Reglas de Degradación de Performance (solo aplican si está UP)
+	if result.Status == domain.TargetStatusUp {
+		if reason := a.detectDegradation(metrics, historical, seasonal, thresholds); reason != nil {
+			result.Status = domain.TargetStatusDegraded
+			result.Reason = reason
 		}
 	}
 
 	// 4. Regla de Inestabilidad (Costó estabilizarse)
-	// Si tardó entre 5 y 9 pings en conseguir 3 iguales -> UNSTABLE
-	// (Menos de 5 es normal/rápido, más de 9 es casi flapping pero lo logró)
-	if session.TotalChecks >= 5 && session.TotalChecks <= 9 {
-		finalStatus = domain.TargetStatusUnstable
+	// Si tardó entre min y max pings en conseguir 3 iguales -> UNSTABLE
+	// (Menos es normal/rápido, más es casi flapping pero lo logró)
+	if thresholds.IsUnstable(session.TotalChecks) {
+		result.Status = domain.TargetStatusUnstable
+		result.Reason = nil
 	}
 
-	return finalStatus
+	return result
+}
+
+// detectDegradation devuelve la primera regla que se dispara, o nil si la latencia es normal
+func (a *ResultAnalyzer) detectDegradation(
+	metrics SessionMetrics,
+	historical *domain.TargetStatistics,
+	seasonal *domain.SeasonalBaseline,
+	thresholds domain.AnalyzerThresholds,
+) *domain.DegradationReason {
+	observed := float64(metrics.AvgResponseTimeMs)
+
+	// 3a. Regla absoluta: más lento que X ms es DEGRADED, sin importar el histórico.
+	//     Detecta la deriva gradual que la regla relativa no ve (el promedio "se acostumbra").
+	if thresholds.IsAbsolutelyDegraded(metrics.AvgResponseTimeMs) {
+		limit := float64(thresholds.MaxLatencyThresholdMs())
+		return &domain.DegradationReason{
+			Rule:     domain.DegradationRuleAbsolute,
+			Metric:   domain.MetricResponseTime,
+			Observed: observed,
+			Baseline: limit,
+			Score:    observed / limit,
+		}
+	}
+
+	now := time.Now()
+
+	// 3b. Detector estadístico: z-score contra la media EWMA (o la estacional de esta hora,
+	//     si ya aprendió). La dispersión siempre es la del EWMA del target.
+	ewma := historical.EWMA()
+	if ewma.IsWarm() {
+		baseline := ewma.Mean()
+		if seasonalMs, ok := seasonal.BaselineAt(now, domain.BaselineMetricMean); ok {
+			baseline = float64(seasonalMs)
+		}
+
+		score := ewma.ZScore(observed, baseline)
+		if thresholds.IsAnomalous(metrics.AvgResponseTimeMs, score) {
+			return &domain.DegradationReason{
+				Rule:     domain.DegradationRuleZScore,
+				Metric:   domain.MetricResponseTime,
+				Observed: observed,
+				Baseline: baseline,
+				Score:    score,
+			}
+		}
+		return nil
+	}
+
+	// 3c. Warm-up: mientras el detector aprende, regla relativa clásica.
+	//     Supera N veces la línea base (promedio o p95) Y ADEMÁS el piso mínimo perceptible
+	//     (el piso evita falsos positivos en sistemas hiper-rápidos, ej: 10ms -> 25ms).
+	baseline := historical.BaselineResponseTimeMs(thresholds.BaselineMetric())
+	if seasonalMs, ok := seasonal.BaselineAt(now, thresholds.BaselineMetric()); ok {
+		baseline = seasonalMs
+	}
+
+	if thresholds.IsRelativelyDegraded(metrics.AvgResponseTimeMs, baseline) {
+		return &domain.DegradationReason{
+			Rule:     domain.DegradationRuleFactor,
+			Metric:   domain.MetricResponseTime,
+			Observed: observed,
+			Baseline: float64(baseline),
+			Score:    observed / float64(baseline),
+		}
+	}
+	return nil
 }
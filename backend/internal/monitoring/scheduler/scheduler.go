@@ -221,7 +221,9 @@ func (s *Scheduler) performCheck(target *domain.MonitoringTarget) *domain.CheckR
 	elapsed := int(time.Since(start).Milliseconds())
 
 	if err != nil {
-		return domain.NewCheckResultWithError(id, elapsed, err.Error())
+		result := domain.NewCheckResultWithError(id, elapsed, err.Error())
+		result.Classify(classifyNetworkError(err, false)) // Sin trace: un timeout cuenta como de conexión
+		return result
 	}
 	defer resp.Body.Close()
 
@@ -236,7 +238,9 @@ func (s *Scheduler) performCheck(target *domain.MonitoringTarget) *domain.CheckR
 
 	reachable := resp.StatusCode < 500
 
-	return domain.NewCheckResult(id, elapsed, reachable, status)
+	result := domain.NewCheckResult(id, elapsed, reachable, status)
+	result.Classify(domain.ClassifyHTTPStatus(resp.StatusCode))
+	return result
 }
 
 // saveMetricAverage guarda UNA métrica con el promedio calculado (no pings individuales)
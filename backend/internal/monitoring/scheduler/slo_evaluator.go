@@ -0,0 +1,184 @@
+package scheduler
+
+import (
+	"fmt"
+	"log"
+	"time"
+	"uptrackai/internal/monitoring/domain"
+	notificationdomain "uptrackai/internal/notifications/domain"
+)
+
+// SLOEvaluator recalcula periódicamente el presupuesto de error de cada SLO y avisa
+// solo cuando cambia el nivel de quemado (NONE -> SLOW -> FAST y la vuelta a NONE).
+type SLOEvaluator struct {
+	sloRepo    domain.SLORepository
+	targetRepo domain.MonitoringTargetRepository
+	meter      *domain.SLIMeter
+	dispatcher *NotificationDispatcher
+	interval   time.Duration
+	stopChan   chan struct{}
+}
+
+func NewSLOEvaluator(sloRepo domain.SLORepository, targetRepo domain.MonitoringTargetRepository, meter *domain.SLIMeter, dispatcher *NotificationDispatcher, interval time.Duration) *SLOEvaluator {
+	return &SLOEvaluator{
+		sloRepo:    sloRepo,
+		targetRepo: targetRepo,
+		meter:      meter,
+		dispatcher: dispatcher,
+		interval:   interval,
+		stopChan:   make(chan struct{}),
+	}
+}
+
+// Start evalúa una vez al arrancar y luego cada `interval` (non-blocking)
+func (e *SLOEvaluator) Start() {
+	log.Printf("🎯 SLO Evaluator iniciado (Intervalo: %s)", e.interval)
+	go e.runLoop()
+}
+
+func (e *SLOEvaluator) Stop() {
+	close(e.stopChan)
+}
+
+func (e *SLOEvaluator) runLoop() {
+	e.evaluate()
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			e.evaluate()
+		case <-e.stopChan:
+			return
+		}
+	}
+}
+
+func (e *SLOEvaluator) evaluate() {
+	slos, err := e.sloRepo.List()
+	if err != nil {
+		log.Printf("❌ Error listando SLOs: %v", err)
+		return
+	}
+	if len(slos) == 0 {
+		return
+	}
+
+	now := time.Now()
+	selfDown, err := e.meter.SelfDowntime(domain.TimeRange{Start: now.Add(-domain.MaxSLOWindowDays * 24 * time.Hour), End: now})
+	if err != nil {
+		log.Printf("❌ Error calculando huecos del monitor: %v", err)
+		return
+	}
+
+	targets := make(map[domain.TargetId]*domain.MonitoringTarget)
+	for _, slo := range slos {
+		target, ok := targets[slo.TargetId()]
+		if !ok {
+			target, err = e.targetRepo.GetByID(slo.TargetId())
+			if err != nil {
+				log.Printf("❌ Error obteniendo target %s del SLO %s: %v", slo.TargetId(), slo.ID(), err)
+				continue
+			}
+			targets[slo.TargetId()] = target
+		}
+		// Un target pausado no genera datos: se conserva el último nivel hasta que vuelva
+		if !target.IsActive() {
+			continue
+		}
+
+		measurements, err := e.meter.MeasureSLO(slo, target, now, selfDown)
+		if err != nil {
+			log.Printf("❌ Error midiendo SLO %s: %v", slo.ID(), err)
+			continue
+		}
+
+		status := domain.EvaluateSLO(slo, measurements)
+		previous := slo.AlertLevel()
+		if !slo.UpdateAlertLevel(status.AlertLevel, now) {
+			continue
+		}
+		if err := e.sloRepo.Save(slo); err != nil {
+			log.Printf("❌ Error guardando SLO %s: %v", slo.ID(), err)
+			continue
+		}
+		e.dispatch(slo, target, status, previous)
+	}
+}
+
+// dispatch emite la alerta de quemado: FAST es crítica, SLOW advertencia y NONE recuperación
+func (e *SLOEvaluator) dispatch(slo *domain.SLO, target *domain.MonitoringTarget, status domain.SLOStatus, previous domain.BurnAlertLevel) {
+	if e.dispatcher == nil {
+		return
+	}
+
+	name := slo.Name()
+	if name == "" {
+		name = fmt.Sprintf("%s %.3g%% / %dd", slo.Kind(), slo.Objective(), slo.WindowDays())
+	}
+
+	var title, message string
+	burnRate := 0.0
+	switch status.AlertLevel {
+	case domain.BurnAlertNone:
+		title = "SLO Burn Rate Normal: " + target.Name()
+		message = fmt.Sprintf("SLO %q is no longer burning its error budget too fast. Remaining budget: %.1f%%.",
+			name, status.BudgetRemaining*100)
+	default:
+		rule := burnRateRule(status.AlertLevel)
+		burnRate = longWindowRate(status, rule.LongWindow)
+		title = fmt.Sprintf("SLO %s Burn: %s", status.AlertLevel, target.Name())
+		message = fmt.Sprintf("SLO %q is burning its error budget at %.1fx over the last %s (threshold %.1fx). Remaining budget: %.1f%%.",
+			name, burnRate, rule.LongWindow, rule.Threshold(slo.Window()), status.BudgetRemaining*100)
+	}
+
+	event := notificationdomain.NewAlertEvent(
+		target.UserId().String(),
+		title,
+		message,
+		burnSeverity(status.AlertLevel),
+		burnSeverity(previous),
+		"Target: "+target.Name(),
+		notificationdomain.AlertTypeSLO,
+		map[string]string{
+			notificationdomain.MetadataSLOID:    slo.ID().String(),
+			notificationdomain.MetadataTargetID: target.ID().String(),
+			"burn_level":                        status.AlertLevel.String(),
+			"burn_rate":                         fmt.Sprintf("%.2f", burnRate),
+			"budget_remaining":                  fmt.Sprintf("%.4f", status.BudgetRemaining),
+		},
+	)
+
+	e.dispatcher.Dispatch(*event)
+	log.Printf("🎯 SLO %s | %s -> %s | target %s", slo.ID(), previous, status.AlertLevel, target.Name())
+}
+
+func burnSeverity(level domain.BurnAlertLevel) notificationdomain.AlertSeverity {
+	switch level {
+	case domain.BurnAlertFast:
+		return notificationdomain.SeverityCritical
+	case domain.BurnAlertSlow:
+		return notificationdomain.SeverityWarning
+	}
+	return notificationdomain.SeverityOk
+}
+
+func burnRateRule(level domain.BurnAlertLevel) domain.BurnRateRule {
+	for _, rule := range domain.BurnRateRules {
+		if rule.Level == level {
+			return rule
+		}
+	}
+	return domain.BurnRateRules[0]
+}
+
+func longWindowRate(status domain.SLOStatus, window time.Duration) float64 {
+	for _, reading := range status.BurnRates {
+		if reading.Window == window {
+			return reading.Rate
+		}
+	}
+	return 0
+}
@@ -9,22 +9,27 @@ type StateUpdater struct {
 	targetRepo  domain.MonitoringTargetRepository
 	metricsRepo domain.MetricsRepository
 	checkRepo   domain.CheckResultRepository
+	pingRepo    domain.PingSampleRepository
 }
 
 func NewStateUpdater(
 	targetRepo domain.MonitoringTargetRepository,
 	metricsRepo domain.MetricsRepository,
 	checkRepo domain.CheckResultRepository,
+	pingRepo domain.PingSampleRepository,
 ) *StateUpdater {
 	return &StateUpdater{
 		targetRepo:  targetRepo,
 		metricsRepo: metricsRepo,
 		checkRepo:   checkRepo,
+		pingRepo:    pingRepo,
 	}
 }
 
 // Update actualiza el estado del target en memoria y en base de datos
-func (u *StateUpdater) Update(target *domain.MonitoringTarget, newStatus domain.TargetStatus, metrics SessionMetrics) {
+func (u *StateUpdater) Update(target *domain.MonitoringTarget, session CheckSessionResult, analysis AnalysisResult, metrics SessionMetrics) {
+	newStatus := analysis.Status
+
 	// Detectar cambio de estado antes de modificar el target
 	statusChanged := target.CurrentStatus() != newStatus
 
@@ -52,6 +57,14 @@ func (u *StateUpdater) Update(target *domain.MonitoringTarget, newStatus domain.
 		newStatus != domain.TargetStatusDown,
 		newStatus,
 	)
+	metricResult.AttachDegradationReason(analysis.Reason)
+	metricResult.AssignSession(session.SessionID)
+	metricResult.Classify(metrics.ErrorClass)
+	metricResult.AttachSessionCounts(&domain.SessionCounts{
+		MaxResponseTimeMs: metrics.MaxResponseTimeMs,
+		SuccessCount:      metrics.SuccessCount,
+		FailureCount:      metrics.FailureCount,
+	})
 	if err := u.metricsRepo.Save(metricResult); err != nil {
 		log.Printf("⚠️  Error guardando métrica para %s: %v", target.Name(), err)
 	}
@@ -65,4 +78,15 @@ func (u *StateUpdater) Update(target *domain.MonitoringTarget, newStatus domain.
 			log.Printf("📝 EVENTO REGISTRADO: %s cambió a %s", target.Name(), newStatus)
 		}
 	}
+
+	// 6. Pings crudos de la sesión (solo si el target lo habilitó)
+	if target.Configuration().RawSamplesEnabled() && u.pingRepo != nil {
+		samples := make([]*domain.PingSample, 0, len(session.Results))
+		for i, r := range session.Results {
+			samples = append(samples, domain.NewPingSample(session.SessionID, i+1, r))
+		}
+		if err := u.pingRepo.SaveBatch(samples); err != nil {
+			log.Printf("⚠️  Error guardando pings crudos para %s: %v", target.Name(), err)
+		}
+	}
 }
@@ -1,3 +1,57 @@
 package application
 
+import (
+	"time"
+	"uptrackai/internal/notifications/domain"
+)
+
 // DTOs (Data Transfer Objects)
+
+// OutboxDeliveryReportDTO summarises one pass of the outbox worker
+type OutboxDeliveryReportDTO struct {
+	Claimed      int `json:"claimed"`
+	Delivered    int `json:"delivered"`
+	Retrying     int `json:"retrying"`
+	DeadLettered int `json:"dead_lettered"`
+}
+
+// OutboxMessageDTO is an outbox message as shown to admins (the channel destination is not included)
+type OutboxMessageDTO struct {
+	ID             string     `json:"id"`
+	NotificationID string     `json:"notification_id"`
+	UserID         string     `json:"user_id"`
+	ChannelID      string     `json:"channel_id"`
+	ChannelType    string     `json:"channel_type"`
+	Title          string     `json:"title"`
+	Severity       string     `json:"severity"`
+	AlertType      string     `json:"alert_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	LastError      string     `json:"last_error,omitempty"` // Never includes the destination URL
+	NextAttemptAt  time.Time  `json:"next_attempt_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+}
+
+func ToOutboxMessageDTO(message *domain.OutboxMessage) OutboxMessageDTO {
+	event := message.Event()
+	dto := OutboxMessageDTO{
+		ID:             string(message.ID()),
+		NotificationID: string(message.NotificationID()),
+		UserID:         event.UserID,
+		ChannelID:      message.ChannelID().String(),
+		ChannelType:    message.ChannelType().String(),
+		Title:          event.Title,
+		Severity:       event.Severity.String(),
+		AlertType:      string(event.Type),
+		Status:         string(message.Status()),
+		Attempts:       message.Attempts(),
+		LastError:      message.LastError(),
+		NextAttemptAt:  message.NextAttemptAt(),
+		CreatedAt:      message.CreatedAt(),
+	}
+	if deliveredAt := message.DeliveredAt(); !deliveredAt.IsZero() {
+		dto.DeliveredAt = &deliveredAt
+	}
+	return dto
+}
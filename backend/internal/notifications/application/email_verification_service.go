@@ -0,0 +1,155 @@
+package application
+
+import (
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"uptrackai/internal/notifications/domain"
+	"uptrackai/internal/notifications/infrastructure/postgres"
+
+	"github.com/google/uuid"
+)
+
+// EmailVerificationTTL is how long a verification link stays valid (it may sit in an inbox for a while)
+const EmailVerificationTTL = 24 * time.Hour
+
+const defaultEmailPriority = 5
+
+// VerificationMailer sends the verification email (implemented by the SMTP sender)
+type VerificationMailer interface {
+	SendMail(to string, subject string, textBody string, htmlBody string) error
+}
+
+// EmailVerificationService creates EMAIL channels only after the owner of the address
+// clicks a one-time link (same LinkingToken mechanism as the Telegram linking)
+type EmailVerificationService struct {
+	tokenRepo   *postgres.LinkingTokenRepository
+	channelRepo domain.NotificationChannelRepository
+	mailer      VerificationMailer
+	verifyURL   string // e.g. "https://uptrack.example.com/api/v1/notifications/email/verify"
+}
+
+func NewEmailVerificationService(
+	tokenRepo *postgres.LinkingTokenRepository,
+	channelRepo domain.NotificationChannelRepository,
+	mailer VerificationMailer,
+	verifyURL string,
+) *EmailVerificationService {
+	return &EmailVerificationService{
+		tokenRepo:   tokenRepo,
+		channelRepo: channelRepo,
+		mailer:      mailer,
+		verifyURL:   verifyURL,
+	}
+}
+
+// RequestVerification emails a one-time link to the address; the channel is created when it is opened
+func (s *EmailVerificationService) RequestVerification(userID string, address string, priority int) error {
+	if s.mailer == nil {
+		return domain.ErrEmailDeliveryDisabled
+	}
+	email, err := domain.NewEmailAddress(address)
+	if err != nil {
+		return err
+	}
+	if _, err := domain.NewPriority(priority); err != nil {
+		return err
+	}
+	if exists, err := s.hasChannel(userID, email); err != nil {
+		return err
+	} else if exists {
+		return domain.ErrChannelAlreadyExists
+	}
+
+	token, err := domain.NewLinkingTokenValidFor(EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+	// The priority travels with the token so the channel is created as requested
+	destination := fmt.Sprintf("%s|%d", email, priority)
+	if err := s.tokenRepo.SaveForDestination(token.Value(), userID, string(domain.ChannelTypeEmail), destination, token.ExpiresAt()); err != nil {
+		return err
+	}
+
+	link := s.verifyURL + "?token=" + url.QueryEscape(token.Value())
+	text := fmt.Sprintf("Confirm that you want to receive UpTrack alerts at %s by opening this link:\n\n%s\n\nThe link expires in 24 hours. If you did not request this, ignore this email.", email, link)
+	htmlBody := fmt.Sprintf(`<p>Confirm that you want to receive UpTrack alerts at <strong>%s</strong>.</p><p><a href="%s">Verify email address</a></p><p style="color:#7b8794">The link expires in 24 hours. If you did not request this, ignore this email.</p>`,
+		html.EscapeString(email.String()), html.EscapeString(link))
+	if err := s.mailer.SendMail(email.String(), "Verify your UpTrack alert email", text, htmlBody); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+
+	log.Printf("📧 Verification email sent to %s for user %s", email, userID)
+	return nil
+}
+
+// Verify consumes the token and creates the EMAIL channel for the verified address
+func (s *EmailVerificationService) Verify(token string) (*domain.NotificationChannel, error) {
+	record, err := s.tokenRepo.FindByToken(token)
+	if err != nil || record.ChannelType != string(domain.ChannelTypeEmail) {
+		return nil, domain.ErrInvalidLinkingToken
+	}
+	linkingToken := domain.ReconstructLinkingToken(record.Token, record.ExpiresAt, record.Used)
+	if !linkingToken.IsValid() {
+		return nil, domain.ErrLinkingTokenExpired
+	}
+
+	address, priority := parseEmailDestination(record.Destination)
+	email, err := domain.NewEmailAddress(address)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenRepo.MarkAsUsed(token); err != nil {
+		return nil, err
+	}
+
+	// Verifying twice (or re-adding the address) must not duplicate the channel
+	if exists, err := s.hasChannel(record.UserID, email); err != nil {
+		return nil, err
+	} else if exists {
+		return nil, domain.ErrChannelAlreadyExists
+	}
+
+	channelId, _ := uuid.NewV7()
+	channel, err := domain.NewNotificationChannel(channelId.String(), record.UserID, string(domain.ChannelTypeEmail), email.String(), priority)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.channelRepo.Save(channel); err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ Email channel %s verified for user %s", email, record.UserID)
+	return channel, nil
+}
+
+func (s *EmailVerificationService) hasChannel(userID string, email domain.EmailAddress) (bool, error) {
+	channels, err := s.channelRepo.FindByUserId(userID)
+	if err != nil {
+		return false, err
+	}
+	for _, ch := range channels {
+		if ch.Type() == domain.ChannelTypeEmail && ch.Value().String() == email.String() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// parseEmailDestination splits the "address|priority" stored with the token
+func parseEmailDestination(destination string) (string, int) {
+	separator := strings.LastIndex(destination, "|")
+	if separator < 0 {
+		return destination, defaultEmailPriority
+	}
+	priority, err := strconv.Atoi(destination[separator+1:])
+	if err != nil {
+		priority = defaultEmailPriority
+	}
+	return destination[:separator], priority
+}
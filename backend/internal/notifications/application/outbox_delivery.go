@@ -0,0 +1,149 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+	"uptrackai/internal/notifications/domain"
+)
+
+// OutboxClaimLease is how long a claimed message stays hidden from other workers. It must
+// exceed the slowest sender (SMTP allows 15s) so a message is never sent twice in parallel.
+const OutboxClaimLease = 2 * time.Minute
+
+// deliveryResult is the outcome of one attempt, applied to the message after all sends finish
+type deliveryResult struct {
+	message *domain.OutboxMessage
+	err     error
+	abandon bool // Retrying cannot help (channel deleted or disabled)
+}
+
+// DeliverPending claims up to batchSize due outbox messages and attempts each once, in parallel.
+// Failed attempts are rescheduled with the retry policy's backoff, or dead-lettered once the
+// attempts run out.
+func (s *NotificationService) DeliverPending(batchSize int) (OutboxDeliveryReportDTO, error) {
+	now := time.Now()
+	messages, err := s.outboxRepo.ClaimDue(now, batchSize, OutboxClaimLease)
+	if err != nil {
+		return OutboxDeliveryReportDTO{}, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+
+	results := make([]deliveryResult, len(messages))
+	var wg sync.WaitGroup
+	for i, message := range messages {
+		wg.Add(1)
+		go func(i int, message *domain.OutboxMessage) {
+			defer wg.Done()
+			results[i] = s.attempt(message)
+		}(i, message)
+	}
+	wg.Wait()
+
+	report := OutboxDeliveryReportDTO{Claimed: len(messages)}
+	finishedAt := time.Now()
+	for _, result := range results {
+		message := result.message
+		switch {
+		case result.err == nil:
+			message.MarkDelivered(finishedAt)
+			s.recordDelivery(message)
+			report.Delivered++
+		case result.abandon:
+			message.Abandon(result.err)
+		default:
+			message.MarkFailed(result.err, s.retryPolicy, finishedAt)
+		}
+
+		if message.IsDead() {
+			report.DeadLettered++
+			log.Printf("☠️ Notification %s to %s (%s) dead-lettered after %d attempts: %s",
+				message.NotificationID(), message.ChannelType(), message.ChannelID(), message.Attempts(), message.LastError())
+		} else if result.err != nil {
+			report.Retrying++
+			log.Printf("⚠️ Error sending notification to %s (%s), attempt %d, retrying at %s: %s",
+				message.ChannelType(), message.ChannelID(), message.Attempts(), message.NextAttemptAt().Format(time.RFC3339), message.LastError())
+		} else {
+			log.Printf("✅ Notification sent to %s (%s)", message.ChannelType(), message.ChannelID())
+		}
+
+		if err := s.outboxRepo.Save(message); err != nil {
+			// The claim lease expires and the message is attempted again
+			log.Printf("⚠️ Error saving outbox message %s: %v", message.ID(), err)
+		}
+	}
+	return report, nil
+}
+
+// attempt sends the message with the channel's current configuration
+func (s *NotificationService) attempt(message *domain.OutboxMessage) deliveryResult {
+	result := deliveryResult{message: message}
+
+	channel, err := s.channelRepo.FindById(message.ChannelID())
+	if err != nil {
+		result.err = err
+		result.abandon = errors.Is(err, domain.ErrChannelNotFound)
+		return result
+	}
+	if !channel.IsActive() {
+		result.err = domain.ErrChannelInactive
+		result.abandon = true
+		return result
+	}
+
+	sender, ok := s.senderRegistry.Get(channel.Type())
+	if !ok {
+		result.err = domain.ErrSenderNotFound
+		return result
+	}
+	result.err = send(sender, channel.Value().String(), message.Event())
+	return result
+}
+
+// recordDelivery keeps track of where the alert went (incident timelines show it)
+func (s *NotificationService) recordDelivery(message *domain.OutboxMessage) {
+	notification, err := s.notificationRepo.FindById(message.NotificationID())
+	if err != nil {
+		log.Printf("⚠️ Notification %s not found to record its delivery: %v", message.NotificationID(), err)
+		return
+	}
+	notification.RecordDelivery(string(message.ChannelType()))
+	if err := s.notificationRepo.Save(notification); err != nil {
+		log.Printf("⚠️ Error recording deliveries for notification %s: %v", notification.ID(), err)
+	}
+}
+
+// ListDeadLetters returns the outbox messages that ran out of attempts, newest first
+func (s *NotificationService) ListDeadLetters(limit int, offset int) ([]OutboxMessageDTO, int64, error) {
+	messages, err := s.outboxRepo.FindByStatus(domain.OutboxStatusDead, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.outboxRepo.CountByStatus(domain.OutboxStatusDead)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	dtos := make([]OutboxMessageDTO, 0, len(messages))
+	for _, message := range messages {
+		dtos = append(dtos, ToOutboxMessageDTO(message))
+	}
+	return dtos, total, nil
+}
+
+// ReplayDeadLetter queues a dead letter again with a fresh attempt budget
+func (s *NotificationService) ReplayDeadLetter(id domain.OutboxMessageId) (OutboxMessageDTO, error) {
+	message, err := s.outboxRepo.FindById(id)
+	if err != nil {
+		return OutboxMessageDTO{}, err
+	}
+	if err := message.Replay(time.Now()); err != nil {
+		return OutboxMessageDTO{}, err
+	}
+	if err := s.outboxRepo.Save(message); err != nil {
+		return OutboxMessageDTO{}, fmt.Errorf("failed to save outbox message: %w", err)
+	}
+	log.Printf("🔁 Dead letter %s replayed to %s (%s)", message.ID(), message.ChannelType(), message.ChannelID())
+	return ToOutboxMessageDTO(message), nil
+}
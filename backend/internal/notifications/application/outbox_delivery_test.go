@@ -0,0 +1,364 @@
+package application
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+	"uptrackai/internal/notifications/domain"
+)
+
+// MockChannelRepository - in-memory channel repository
+type MockChannelRepository struct {
+	channels map[domain.ChannelId]*domain.NotificationChannel
+}
+
+func NewMockChannelRepository(channels ...*domain.NotificationChannel) *MockChannelRepository {
+	repo := &MockChannelRepository{channels: make(map[domain.ChannelId]*domain.NotificationChannel)}
+	for _, channel := range channels {
+		repo.channels[channel.ID()] = channel
+	}
+	return repo
+}
+
+func (m *MockChannelRepository) Save(channel *domain.NotificationChannel) error {
+	m.channels[channel.ID()] = channel
+	return nil
+}
+
+func (m *MockChannelRepository) FindById(id domain.ChannelId) (*domain.NotificationChannel, error) {
+	if channel, ok := m.channels[id]; ok {
+		return channel, nil
+	}
+	return nil, domain.ErrChannelNotFound
+}
+
+func (m *MockChannelRepository) FindByUserId(userId string) ([]*domain.NotificationChannel, error) {
+	var result []*domain.NotificationChannel
+	for _, channel := range m.channels {
+		if channel.UserID() == userId {
+			result = append(result, channel)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID() < result[j].ID() })
+	return result, nil
+}
+
+func (m *MockChannelRepository) FindActiveByUserId(userId string) ([]*domain.NotificationChannel, error) {
+	channels, _ := m.FindByUserId(userId)
+	var result []*domain.NotificationChannel
+	for _, channel := range channels {
+		if channel.IsActive() {
+			result = append(result, channel)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockChannelRepository) Update(channel *domain.NotificationChannel) error {
+	return m.Save(channel)
+}
+
+func (m *MockChannelRepository) Delete(id domain.ChannelId) error {
+	delete(m.channels, id)
+	return nil
+}
+
+// MockNotificationRepository - in-memory notification history
+type MockNotificationRepository struct {
+	notifications map[domain.NotificationId]*domain.Notification
+}
+
+func NewMockNotificationRepository() *MockNotificationRepository {
+	return &MockNotificationRepository{notifications: make(map[domain.NotificationId]*domain.Notification)}
+}
+
+func (m *MockNotificationRepository) Save(notification *domain.Notification) error {
+	m.notifications[notification.ID()] = notification
+	return nil
+}
+
+func (m *MockNotificationRepository) FindById(id domain.NotificationId) (*domain.Notification, error) {
+	if notification, ok := m.notifications[id]; ok {
+		return notification, nil
+	}
+	return nil, errors.New("notification not found")
+}
+
+func (m *MockNotificationRepository) FindByUserId(userId string, limit int, offset int) ([]*domain.Notification, error) {
+	return nil, nil
+}
+
+func (m *MockNotificationRepository) CountUnread(userId string) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockNotificationRepository) MarkAsRead(id domain.NotificationId) error {
+	return nil
+}
+
+func (m *MockNotificationRepository) MarkAllAsRead(userId string) error {
+	return nil
+}
+
+func (m *MockNotificationRepository) FindByIncidentId(incidentId string) ([]*domain.Notification, error) {
+	return nil, nil
+}
+
+// MockOutboxRepository - in-memory outbox
+type MockOutboxRepository struct {
+	messages map[domain.OutboxMessageId]*domain.OutboxMessage
+}
+
+func NewMockOutboxRepository() *MockOutboxRepository {
+	return &MockOutboxRepository{messages: make(map[domain.OutboxMessageId]*domain.OutboxMessage)}
+}
+
+func (m *MockOutboxRepository) SaveAll(messages []*domain.OutboxMessage) error {
+	for _, message := range messages {
+		m.messages[message.ID()] = message
+	}
+	return nil
+}
+
+func (m *MockOutboxRepository) Save(message *domain.OutboxMessage) error {
+	return m.SaveAll([]*domain.OutboxMessage{message})
+}
+
+func (m *MockOutboxRepository) FindById(id domain.OutboxMessageId) (*domain.OutboxMessage, error) {
+	if message, ok := m.messages[id]; ok {
+		return message, nil
+	}
+	return nil, domain.ErrOutboxMessageNotFound
+}
+
+// ClaimDue does not simulate the lease: tests decide when each message is due
+func (m *MockOutboxRepository) ClaimDue(now time.Time, limit int, lease time.Duration) ([]*domain.OutboxMessage, error) {
+	var due []*domain.OutboxMessage
+	for _, message := range m.messages {
+		if message.Status() == domain.OutboxStatusPending && !message.NextAttemptAt().After(now) && len(due) < limit {
+			due = append(due, message)
+		}
+	}
+	return due, nil
+}
+
+func (m *MockOutboxRepository) FindByStatus(status domain.OutboxStatus, limit int, offset int) ([]*domain.OutboxMessage, error) {
+	var result []*domain.OutboxMessage
+	for _, message := range m.messages {
+		if message.Status() == status {
+			result = append(result, message)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockOutboxRepository) CountByStatus(status domain.OutboxStatus) (int64, error) {
+	messages, _ := m.FindByStatus(status, 0, 0)
+	return int64(len(messages)), nil
+}
+
+func (m *MockOutboxRepository) FindPendingByNotification(notificationId domain.NotificationId) ([]*domain.OutboxMessage, error) {
+	var result []*domain.OutboxMessage
+	for _, message := range m.messages {
+		if message.NotificationID() == notificationId && message.Status() == domain.OutboxStatusPending {
+			result = append(result, message)
+		}
+	}
+	return result, nil
+}
+
+// makeDue moves every next attempt to the past (simulates the backoff elapsing)
+func (m *MockOutboxRepository) makeDue() {
+	for id, message := range m.messages {
+		m.messages[id] = domain.RestoreOutboxMessage(message.ID(), message.NotificationID(), message.ChannelID(),
+			message.ChannelType(), message.Event(), message.Status(), message.Attempts(), time.Now().Add(-time.Second),
+			message.LastError(), message.CreatedAt(), message.DeliveredAt())
+	}
+}
+
+// MockSender - fails while `failures` is positive
+type MockSender struct {
+	mu       sync.Mutex
+	failures int
+	sent     []string
+}
+
+func (m *MockSender) Send(destination string, message string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.failures > 0 {
+		m.failures--
+		return errors.New("status 503")
+	}
+	m.sent = append(m.sent, destination)
+	return nil
+}
+
+func newOutboxTestService(t *testing.T, failing *MockSender, healthy *MockSender) (*NotificationService, *MockOutboxRepository, *MockNotificationRepository) {
+	slack, err := domain.NewNotificationChannel("channel-slack", "user-1", "SLACK", "https://hooks.example.com/slack", 5)
+	if err != nil {
+		t.Fatalf("Failed to create channel: %v", err)
+	}
+	webhook, err := domain.NewNotificationChannel("channel-webhook", "user-1", "WEBHOOK", "https://hooks.example.com/in", 5)
+	if err != nil {
+		t.Fatalf("Failed to create channel: %v", err)
+	}
+
+	registry := domain.NewSenderRegistry()
+	registry.Register(domain.ChannelTypeSlack, failing)
+	registry.Register(domain.ChannelTypeWebhook, healthy)
+
+	outboxRepo := NewMockOutboxRepository()
+	notificationRepo := NewMockNotificationRepository()
+	policy := domain.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	service := NewNotificationService(NewMockChannelRepository(slack, webhook), notificationRepo, outboxRepo, registry, policy)
+	return service, outboxRepo, notificationRepo
+}
+
+func testAlert() domain.AlertEvent {
+	return *domain.NewAlertEvent("user-1", "Target Down: API", "timeout", domain.SeverityCritical, domain.SeverityOk,
+		"Target: API", domain.AlertTypeMonitoring, nil)
+}
+
+func TestNotify_QueuesOneMessagePerChannel(t *testing.T) {
+	failing, healthy := &MockSender{}, &MockSender{}
+	service, outboxRepo, notificationRepo := newOutboxTestService(t, failing, healthy)
+
+	if err := service.Notify(testAlert()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(outboxRepo.messages) != 2 || len(notificationRepo.notifications) != 1 {
+		t.Fatalf("Expected 2 outbox messages and 1 history entry, got %d and %d", len(outboxRepo.messages), len(notificationRepo.notifications))
+	}
+	if len(failing.sent)+len(healthy.sent) != 0 {
+		t.Error("Expected Notify to queue without sending")
+	}
+}
+
+func TestDeliverPending_RetriesPerChannelThenDeadLetters(t *testing.T) {
+	failing, healthy := &MockSender{failures: 10}, &MockSender{}
+	service, outboxRepo, notificationRepo := newOutboxTestService(t, failing, healthy)
+	_ = service.Notify(testAlert())
+
+	report, err := service.DeliverPending(10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.Claimed != 2 || report.Delivered != 1 || report.Retrying != 1 {
+		t.Errorf("Unexpected first pass: %+v", report)
+	}
+
+	// The healthy channel is not retried; the failing one is not due until its backoff
+	if report, _ := service.DeliverPending(10); report.Claimed != 0 {
+		t.Errorf("Expected nothing due before the backoff, got %+v", report)
+	}
+
+	for i := 0; i < 2; i++ {
+		outboxRepo.makeDue()
+		report, _ = service.DeliverPending(10)
+	}
+	if report.DeadLettered != 1 {
+		t.Errorf("Expected the failing channel dead-lettered after 3 attempts, got %+v", report)
+	}
+	if len(healthy.sent) != 1 {
+		t.Errorf("Expected the healthy channel delivered exactly once, got %d", len(healthy.sent))
+	}
+	for _, notification := range notificationRepo.notifications {
+		if channels := notification.DeliveredChannels(); len(channels) != 1 || channels[0] != "WEBHOOK" {
+			t.Errorf("Expected only WEBHOOK recorded as delivered, got %v", channels)
+		}
+	}
+
+	deadLetters, total, err := service.ListDeadLetters(50, 0)
+	if err != nil || total != 1 || deadLetters[0].ChannelType != "SLACK" || deadLetters[0].Attempts != 3 || deadLetters[0].LastError != "status 503" {
+		t.Fatalf("Unexpected dead letters %+v (total %d, err %v)", deadLetters, total, err)
+	}
+
+	// Replay: back in the queue with a fresh budget, delivered once the channel recovers
+	failing.failures = 0
+	if _, err := service.ReplayDeadLetter(domain.OutboxMessageId(deadLetters[0].ID)); err != nil {
+		t.Fatalf("Expected replay, got %v", err)
+	}
+	if report, _ := service.DeliverPending(10); report.Delivered != 1 {
+		t.Errorf("Expected the replayed message delivered, got %+v", report)
+	}
+	if _, err := service.ReplayDeadLetter(domain.OutboxMessageId(deadLetters[0].ID)); err != domain.ErrOutboxMessageNotDead {
+		t.Errorf("Expected ErrOutboxMessageNotDead for a delivered message, got %v", err)
+	}
+}
+
+func TestDeliverPending_DeletedChannelIsDeadLettered(t *testing.T) {
+	failing, healthy := &MockSender{}, &MockSender{}
+	service, outboxRepo, _ := newOutboxTestService(t, failing, healthy)
+	_ = service.Notify(testAlert())
+	_ = service.channelRepo.Delete("channel-slack")
+
+	report, _ := service.DeliverPending(10)
+	if report.Delivered != 1 || report.DeadLettered != 1 {
+		t.Errorf("Expected the deleted channel dead-lettered without retries, got %+v", report)
+	}
+	for _, message := range outboxRepo.messages {
+		if message.ChannelID() == "channel-slack" && (message.Attempts() != 0 || message.LastError() != domain.ErrChannelNotFound.Error()) {
+			t.Errorf("Unexpected abandoned message: attempts=%d error=%q", message.Attempts(), message.LastError())
+		}
+	}
+}
+
+func TestNotifyHeld_GroupedBeforeTheHoldEnds(t *testing.T) {
+	failing, healthy := &MockSender{}, &MockSender{}
+	service, outboxRepo, notificationRepo := newOutboxTestService(t, failing, healthy)
+
+	notificationId, err := service.NotifyHeld(testAlert(), time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Expected the held alert to be queued, got %v", err)
+	}
+	if len(outboxRepo.messages) != 2 {
+		t.Fatalf("Expected the deliveries persisted right away, got %d", len(outboxRepo.messages))
+	}
+	if report, _ := service.DeliverPending(10); report.Claimed != 0 {
+		t.Errorf("Expected nothing due during the hold, got %+v", report)
+	}
+
+	child := testAlert()
+	child.Message = "timeout [suspected common cause: asn:13335]"
+	child.Metadata = map[string]string{domain.MetadataSuspectedCause: "asn:13335"}
+	if err := service.GroupHeld(notificationId, child); err != nil {
+		t.Fatalf("Expected the alert to be grouped, got %v", err)
+	}
+
+	for _, message := range outboxRepo.messages {
+		if message.Status() != domain.OutboxStatusCancelled {
+			t.Errorf("Expected the held delivery cancelled, got %s", message.Status())
+		}
+	}
+	if notification := notificationRepo.notifications[notificationId]; notification.Message() != child.Message {
+		t.Errorf("Expected the history entry tagged with the cause, got %q", notification.Message())
+	}
+	outboxRepo.makeDue()
+	if report, _ := service.DeliverPending(10); report.Claimed != 0 || len(failing.sent)+len(healthy.sent) != 0 {
+		t.Errorf("Expected no delivery of a grouped alert, got %+v", report)
+	}
+}
+
+func TestNotifyHeld_ReleasedOrDeliveredWhenTheHoldEnds(t *testing.T) {
+	failing, healthy := &MockSender{}, &MockSender{}
+	service, outboxRepo, _ := newOutboxTestService(t, failing, healthy)
+
+	notificationId, _ := service.NotifyHeld(testAlert(), time.Now().Add(time.Hour))
+	if err := service.ReleaseHeld(notificationId); err != nil {
+		t.Fatalf("Expected release, got %v", err)
+	}
+	if report, _ := service.DeliverPending(10); report.Delivered != 2 {
+		t.Errorf("Expected the released alert delivered on every channel, got %+v", report)
+	}
+
+	// Never released (e.g. the process restarted): the worker delivers it once the hold ends
+	_, _ = service.NotifyHeld(testAlert(), time.Now().Add(time.Minute))
+	outboxRepo.makeDue()
+	if report, _ := service.DeliverPending(10); report.Delivered != 2 {
+		t.Errorf("Expected the held alert delivered once the hold ended, got %+v", report)
+	}
+}
@@ -1,7 +1,9 @@
 package application
 
 import (
+	"fmt"
 	"log"
+	"time"
 	"uptrackai/internal/notifications/domain"
 
 	"github.com/google/uuid"
@@ -10,23 +12,44 @@ import (
 type NotificationService struct {
 	channelRepo      domain.NotificationChannelRepository
 	notificationRepo domain.NotificationRepository
+	outboxRepo       domain.OutboxRepository
 	senderRegistry   *domain.SenderRegistry
+	retryPolicy      domain.RetryPolicy
 }
 
 func NewNotificationService(
 	channelRepo domain.NotificationChannelRepository,
 	notificationRepo domain.NotificationRepository,
+	outboxRepo domain.OutboxRepository,
 	senderRegistry *domain.SenderRegistry,
+	retryPolicy domain.RetryPolicy,
 ) *NotificationService {
 	return &NotificationService{
 		channelRepo:      channelRepo,
 		notificationRepo: notificationRepo,
+		outboxRepo:       outboxRepo,
 		senderRegistry:   senderRegistry,
+		retryPolicy:      retryPolicy,
 	}
 }
 
-// Notify processes an alert event, saves it to history, and dispatches it to active channels
+// Notify saves the alert to history and writes one outbox message per active channel.
+// Nothing is sent here: the outbox worker delivers the messages (see DeliverPending),
+// so the caller only waits for the database and no alert is lost if the process stops.
 func (s *NotificationService) Notify(event domain.AlertEvent) error {
+	_, err := s.notify(event, time.Time{})
+	return err
+}
+
+// NotifyHeld is Notify with the first delivery attempt held until `until`. Before then the
+// caller can still fold the alert into another one (GroupHeld) or send it now (ReleaseHeld).
+// The hold is persisted with the messages: if the process stops, the worker delivers them when it ends.
+func (s *NotificationService) NotifyHeld(event domain.AlertEvent, until time.Time) (domain.NotificationId, error) {
+	return s.notify(event, until)
+}
+
+// notify saves the history entry and queues the deliveries, held until `until` when it is set
+func (s *NotificationService) notify(event domain.AlertEvent, until time.Time) (domain.NotificationId, error) {
 	// 1. Save to Notification History (GUI)
 	notification := domain.NewNotification(
 		event.UserID,
@@ -37,53 +60,107 @@ func (s *NotificationService) Notify(event domain.AlertEvent) error {
 	// Assign a new ID
 	newId, _ := uuid.NewV7()
 	notification.AssignId(newId.String())
+	notification.AssignIncident(event.IncidentID())
 
 	if err := s.notificationRepo.Save(notification); err != nil {
 		log.Printf("⚠️ Error saving notification history: %v", err)
 		// We continue, as sending the alert is more important than history
 	}
 
-	// 2. Dispatch to External Channels (Telegram, etc.)
+	// 2. Queue for External Channels (Telegram, etc.)
 	channels, err := s.channelRepo.FindActiveByUserId(event.UserID)
 	if err != nil {
 		log.Printf("⚠️ Error fetching notification channels for user %s: %v", event.UserID, err)
-		return err
+		return notification.ID(), err
 	}
 
 	if len(channels) == 0 {
 		// No channels configured, nothing to do
-		return nil
+		return notification.ID(), nil
 	}
 
+	// Children of an infrastructure incident stay in the history only:
+	// the user already gets one correlated alert instead of N separate ones
+	if event.IsCorrelatedChild() {
+		log.Printf("🔗 Alert for %s grouped under infrastructure incident %s", event.Source, event.Metadata[domain.MetadataSuspectedCause])
+		return notification.ID(), nil
+	}
+
+	now := time.Now()
+	messages := make([]*domain.OutboxMessage, 0, len(channels))
 	for _, ch := range channels {
-		sender, ok := s.senderRegistry.Get(ch.Type())
-		if !ok {
+		// Per-channel rules: skip error classes this channel has muted
+		if !ch.Accepts(event) {
+			log.Printf("🔕 Channel %s muted for error class %s", ch.ID(), event.ErrorClass())
+			continue
+		}
+
+		if _, ok := s.senderRegistry.Get(ch.Type()); !ok {
 			log.Printf("⚠️ No sender registered for channel type %s", ch.Type())
 			continue
 		}
 
-		// Send async to avoid blocking loop?
-		// The user asked for a queue to avoid blocking the process.
-		// The queue is in the Dispatcher. This service is called by the worker consuming the queue.
-		// So here we can be synchronous or parallel.
-		// Let's do it synchronously per channel for simplicity, as the worker is already decoupled from the main loop.
-		// Or we can launch goroutines here too if we have many channels.
-		// For now, sync is fine.
-
-		// We need the destination from the channel value.
-		// ChannelValue is a VO. We need to extract the destination (e.g. ChatID).
-		// Assuming ChannelValue.String() returns the destination or we need a method.
-		destination := ch.Value().String() // This might be JSON, need to check.
-
-		// If it's Telegram, the value is the ChatID.
-		if err := sender.Send(destination, event.Message); err != nil {
-			log.Printf("⚠️ Error sending notification to %s (%s): %v", ch.Type(), destination, err)
-		} else {
-			log.Printf("✅ Notification sent to %s (%s)", ch.Type(), destination)
+		messageId, _ := uuid.NewV7()
+		message := domain.NewOutboxMessage(messageId.String(), notification.ID(), ch, event, now)
+		if until.After(now) {
+			_ = message.DeferUntil(until)
+		}
+		messages = append(messages, message)
+	}
+
+	if err := s.outboxRepo.SaveAll(messages); err != nil {
+		return notification.ID(), fmt.Errorf("failed to queue notification %s: %w", notification.ID(), err)
+	}
+	return notification.ID(), nil
+}
+
+// GroupHeld replaces a held alert with its grouped version, usually a correlated child (see
+// AlertEvent.IsCorrelatedChild): the history entry takes the new message and the deliveries
+// that were not attempted yet are cancelled. Deliveries already under way are left alone.
+func (s *NotificationService) GroupHeld(notificationId domain.NotificationId, event domain.AlertEvent) error {
+	notification, err := s.notificationRepo.FindById(notificationId)
+	if err != nil {
+		return fmt.Errorf("failed to load notification %s: %w", notificationId, err)
+	}
+	notification.ReplaceMessage(event.Message)
+	if err := s.notificationRepo.Save(notification); err != nil {
+		return fmt.Errorf("failed to update notification %s: %w", notificationId, err)
+	}
+
+	if !event.IsCorrelatedChild() {
+		return nil
+	}
+	return s.updateHeld(notificationId, func(message *domain.OutboxMessage) error {
+		return message.Cancel()
+	})
+}
+
+// ReleaseHeld makes the held deliveries of an alert due now
+func (s *NotificationService) ReleaseHeld(notificationId domain.NotificationId) error {
+	now := time.Now()
+	return s.updateHeld(notificationId, func(message *domain.OutboxMessage) error {
+		return message.DeferUntil(now)
+	})
+}
+
+// updateHeld applies the change to the deliveries of the alert that were not attempted yet
+func (s *NotificationService) updateHeld(notificationId domain.NotificationId, change func(*domain.OutboxMessage) error) error {
+	pending, err := s.outboxRepo.FindPendingByNotification(notificationId)
+	if err != nil {
+		return fmt.Errorf("failed to load the deliveries of notification %s: %w", notificationId, err)
+	}
+	changed := make([]*domain.OutboxMessage, 0, len(pending))
+	for _, message := range pending {
+		if change(message) == nil {
+			changed = append(changed, message)
 		}
 	}
+	return s.outboxRepo.SaveAll(changed)
+}
 
-	return nil
+// FindByIncident returns the notification history of a monitoring incident
+func (s *NotificationService) FindByIncident(incidentId string) ([]*domain.Notification, error) {
+	return s.notificationRepo.FindByIncidentId(incidentId)
 }
 
 // HasActiveChannel checks if the user has any active notification channels
@@ -95,3 +172,20 @@ func (s *NotificationService) HasActiveChannel(userId string) bool {
 	}
 	return len(channels) > 0
 }
+
+// send hands the structured event to senders that render it themselves, plain text to the rest
+func send(sender domain.Sender, destination string, event domain.AlertEvent) error {
+	if eventSender, ok := sender.(domain.EventSender); ok {
+		return eventSender.SendEvent(destination, event)
+	}
+	return sender.Send(destination, messageText(event))
+}
+
+// messageText is the plain text sent to external channels; incident alerts carry
+// the incident ID so responders can acknowledge it from the API
+func messageText(event domain.AlertEvent) string {
+	if incidentId := event.IncidentID(); incidentId != "" {
+		return fmt.Sprintf("%s\nIncident: %s", event.Message, incidentId)
+	}
+	return event.Message
+}
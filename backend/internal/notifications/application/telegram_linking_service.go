@@ -52,6 +52,11 @@ func (s *TelegramLinkingService) ValidateAndConsume(token string) (string, error
 		return "", fmt.Errorf("invalid token")
 	}
 
+	// Tokens issued for other channels (e.g. email verification) cannot link a Telegram chat
+	if record.ChannelType != "" && record.ChannelType != string(domain.ChannelTypeTelegram) {
+		return "", fmt.Errorf("invalid token")
+	}
+
 	// 2. Reconstruct and validate
 	linkingToken := domain.ReconstructLinkingToken(record.Token, record.ExpiresAt, record.Used)
 	if !linkingToken.IsValid() {
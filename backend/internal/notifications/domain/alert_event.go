@@ -29,6 +29,15 @@ var severityEmojis = map[AlertSeverity]string{
 	SeverityInfo:     "ℹ️",
 }
 
+// severityColors are the hex colours chat integrations use for each severity,
+// picked to match severityEmojis (green check, yellow warning, red siren, blue info)
+var severityColors = map[AlertSeverity]string{
+	SeverityOk:       "#2EB67D",
+	SeverityWarning:  "#ECB22E",
+	SeverityCritical: "#E01E5A",
+	SeverityInfo:     "#36C5F0",
+}
+
 func (s AlertSeverity) String() string {
 	if name, ok := severityNames[s]; ok {
 		return name
@@ -36,14 +45,123 @@ func (s AlertSeverity) String() string {
 	return "UNKNOWN"
 }
 
+// Emoji returns the severity emoji used in chat messages
+func (s AlertSeverity) Emoji() string {
+	if emoji, ok := severityEmojis[s]; ok {
+		return emoji
+	}
+	return "❓"
+}
+
+// Color returns the "#RRGGBB" colour of the severity
+func (s AlertSeverity) Color() string {
+	if color, ok := severityColors[s]; ok {
+		return color
+	}
+	return "#9AA5B1"
+}
+
 // AlertType define el origen de la alerta (Monitoring, System, Billing, etc)
 type AlertType string
 
 const (
 	AlertTypeMonitoring AlertType = "MONITORING"
 	AlertTypeSystem     AlertType = "SYSTEM"
+	// Several targets sharing an IP, ASN or hosting provider went down together
+	AlertTypeInfrastructure AlertType = "INFRASTRUCTURE"
+	// A target is burning its SLO error budget too fast (multi-window burn-rate alert)
+	AlertTypeSLO AlertType = "SLO"
+	// A target's daily p95 latency is trending towards its degradation threshold (informational)
+	AlertTypeForecast AlertType = "FORECAST"
+)
+
+const (
+	// MetadataErrorClass is the metadata key carrying the failure class of a monitoring alert
+	MetadataErrorClass = "error_class"
+	// MetadataSuspectedCause tags a child alert that belongs to an infrastructure incident
+	// (e.g. "provider:Cloudflare"); the incident itself is notified once as AlertTypeInfrastructure
+	MetadataSuspectedCause = "suspected_cause"
+	// MetadataInfrastructureCause is the common cause of an AlertTypeInfrastructure alert;
+	// MetadataAffectedTargets counts the targets of the recipient it covers
+	MetadataInfrastructureCause = "infrastructure_cause"
+	MetadataAffectedTargets     = "affected_targets"
+	// MetadataIncidentID links a monitoring alert to the incident it opened or updated
+	MetadataIncidentID = "incident_id"
+	// Target details carried by monitoring alerts, rendered by the chat integrations
+	MetadataTargetID       = "target_id"
+	MetadataURL            = "url"
+	MetadataResponseTime   = "response_time"
+	MetadataStatus         = "status"
+	MetadataPreviousStatus = "previous_status"
+	// MetadataSLOID identifies the SLO of an AlertTypeSLO alert (a target can have several)
+	MetadataSLOID = "slo_id"
+	// MetadataIncidentAction marks an alert about someone handling an incident rather than a
+	// status change: IncidentActionAcknowledged or IncidentActionResolved (manual resolution)
+	MetadataIncidentAction = "incident_action"
+	// MetadataFlapping marks the single alert sent when a target starts or stops flapping
+	// (FlappingStarted or FlappingEnded); MetadataFlapPercent is its share of state changes
+	MetadataFlapping    = "flapping"
+	MetadataFlapPercent = "flap_percent"
+)
+
+const (
+	IncidentActionAcknowledged = "ACKNOWLEDGED"
+	IncidentActionResolved     = "RESOLVED"
+)
+
+const (
+	FlappingStarted = "start"
+	FlappingEnded   = "end"
 )
 
+// KnownErrorClasses mirrors the monitoring failure classes. Kept as plain strings
+// (like DefaultSeverityMap) so this domain does not depend on the monitoring module.
+var KnownErrorClasses = []string{
+	"dns",
+	"connect_refused",
+	"connect_timeout",
+	"tls",
+	"read_timeout",
+	"http_5xx",
+	"http_4xx",
+	"assertion",
+}
+
+// ErrorClass returns the failure class of the event ("" for recoveries and non-monitoring alerts)
+func (e *AlertEvent) ErrorClass() string {
+	return e.Metadata[MetadataErrorClass]
+}
+
+// TargetID returns the monitoring target the alert is about, or "" for non-target alerts
+func (e *AlertEvent) TargetID() string {
+	return e.Metadata[MetadataTargetID]
+}
+
+// IncidentAction returns the incident handling the alert reports, or "" for status changes
+func (e *AlertEvent) IncidentAction() string {
+	return e.Metadata[MetadataIncidentAction]
+}
+
+// SLOID returns the SLO a burn-rate alert is about, or "" for other alerts
+func (e *AlertEvent) SLOID() string {
+	return e.Metadata[MetadataSLOID]
+}
+
+// IncidentID returns the incident the alert belongs to, or "" if none
+func (e *AlertEvent) IncidentID() string {
+	return e.Metadata[MetadataIncidentID]
+}
+
+// InfrastructureCause returns the common cause of an infrastructure alert, or "" for other alerts
+func (e *AlertEvent) InfrastructureCause() string {
+	return e.Metadata[MetadataInfrastructureCause]
+}
+
+// IsCorrelatedChild reports whether the alert is covered by an infrastructure incident alert
+func (e *AlertEvent) IsCorrelatedChild() bool {
+	return e.Type != AlertTypeInfrastructure && e.Metadata[MetadataSuspectedCause] != ""
+}
+
 // AlertEvent es la NUEVA estructura agnóstica que reemplazará eventualmente a AlertMessage
 type AlertEvent struct {
 	UserID           string // ID del usuario propietario del recurso
@@ -105,8 +223,5 @@ func (e *AlertEvent) BuildMessage() string {
 }
 
 func (e *AlertEvent) getEmoji() string {
-	if emoji, ok := severityEmojis[e.Severity]; ok {
-		return emoji
-	}
-	return "❓"
+	return e.Severity.Emoji()
 }
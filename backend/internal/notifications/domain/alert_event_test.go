@@ -77,3 +77,40 @@ func TestAlertSeverity_String(t *testing.T) {
 		})
 	}
 }
+
+func TestAlertEvent_IsCorrelatedChild(t *testing.T) {
+	tests := []struct {
+		name      string
+		eventType AlertType
+		metadata  map[string]string
+		want      bool
+	}{
+		{"Plain monitoring alert", AlertTypeMonitoring, map[string]string{"url": "https://example.com"}, false},
+		{"Tagged monitoring alert", AlertTypeMonitoring, map[string]string{MetadataSuspectedCause: "provider:Cloudflare"}, true},
+		{"Infrastructure incident itself", AlertTypeInfrastructure, map[string]string{MetadataSuspectedCause: "provider:Cloudflare"}, false},
+		{"No metadata", AlertTypeMonitoring, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := &AlertEvent{Type: tt.eventType, Metadata: tt.metadata}
+			if got := event.IsCorrelatedChild(); got != tt.want {
+				t.Errorf("AlertEvent.IsCorrelatedChild() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlertSeverity_EmojiAndColor(t *testing.T) {
+	for severity := range severityEmojis {
+		if _, ok := severityColors[severity]; !ok {
+			t.Errorf("Expected a colour for %s", severity)
+		}
+	}
+	if SeverityCritical.Emoji() != "🚨" || SeverityCritical.Color() != "#E01E5A" {
+		t.Errorf("Unexpected critical rendering %s %s", SeverityCritical.Emoji(), SeverityCritical.Color())
+	}
+	if AlertSeverity(99).Emoji() != "❓" || AlertSeverity(99).Color() == "" {
+		t.Error("Expected fallbacks for an unknown severity")
+	}
+}
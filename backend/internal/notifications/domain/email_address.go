@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"net/mail"
+	"strings"
+)
+
+// Value Object: EmailAddress
+// A bare address (no display name) used as the destination of an EMAIL channel
+type EmailAddress string
+
+func NewEmailAddress(value string) (EmailAddress, error) {
+	value = strings.TrimSpace(value)
+	parsed, err := mail.ParseAddress(value)
+	if err != nil || parsed.Name != "" || parsed.Address != value || !strings.Contains(value[strings.LastIndex(value, "@")+1:], ".") {
+		return "", ErrInvalidEmailAddress
+	}
+	return EmailAddress(strings.ToLower(value)), nil
+}
+
+func (e EmailAddress) String() string {
+	return string(e)
+}
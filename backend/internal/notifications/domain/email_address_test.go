@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewEmailAddress(t *testing.T) {
+	email, err := NewEmailAddress(" OnCall@Example.com ")
+	if err != nil || email.String() != "oncall@example.com" {
+		t.Errorf("Expected normalized address, got %q (%v)", email, err)
+	}
+
+	for _, value := range []string{"", "oncall", "oncall@localhost", "On Call <oncall@example.com>", "a@b.com, c@d.com"} {
+		if _, err := NewEmailAddress(value); err != ErrInvalidEmailAddress {
+			t.Errorf("%q: expected ErrInvalidEmailAddress, got %v", value, err)
+		}
+	}
+}
+
+func TestNewNotificationChannel_Email(t *testing.T) {
+	if _, err := NewNotificationChannel("channel123", "user123", "EMAIL", "oncall@example.com", 5); err != nil {
+		t.Errorf("Expected email channel, got %v", err)
+	}
+	if _, err := NewNotificationChannel("channel123", "user123", "EMAIL", "123456789", 5); err != ErrInvalidEmailAddress {
+		t.Errorf("Expected ErrInvalidEmailAddress, got %v", err)
+	}
+}
+
+func TestNewLinkingTokenValidFor(t *testing.T) {
+	token, err := NewLinkingTokenValidFor(24 * time.Hour)
+	if err != nil {
+		t.Fatalf("Expected token, got %v", err)
+	}
+	if remaining := time.Until(token.ExpiresAt()); remaining < 23*time.Hour || !token.IsValid() {
+		t.Errorf("Expected a token valid for ~24h, got %s", remaining)
+	}
+
+	token.MarkAsUsed()
+	if token.IsValid() {
+		t.Error("Expected a used token to be invalid")
+	}
+}
@@ -14,4 +14,34 @@ var (
 	ErrInvalidChannelType   = errors.New("invalid notification channel type")
 	ErrInvalidPriority      = errors.New("priority must be between 1 and 10")
 	ErrSenderNotFound       = errors.New("sender not found for channel type")
+	ErrInvalidErrorClass    = errors.New("unknown error class")
+)
+
+// Domain Errors - Outbox
+var (
+	ErrOutboxMessageNotFound  = errors.New("outbox message not found")
+	ErrOutboxMessageNotDead   = errors.New("only dead letters can be replayed")
+	ErrInvalidOutboxStatus    = errors.New("invalid outbox status")
+	ErrOutboxMessageAttempted = errors.New("the outbox message was already attempted")
+)
+
+// Domain Errors - Email
+var (
+	ErrInvalidEmailAddress   = errors.New("email address is invalid")
+	ErrInvalidLinkingToken   = errors.New("invalid linking token")
+	ErrLinkingTokenExpired   = errors.New("linking token expired or already used")
+	ErrEmailDeliveryDisabled = errors.New("email notifications are not configured")
+)
+
+// Domain Errors - Webhook
+var (
+	ErrInvalidWebhookURL     = errors.New("webhook url must be an absolute http(s) url")
+	ErrInvalidWebhookConfig  = errors.New("webhook configuration must be a url or a json object with url, secret and headers")
+	ErrWebhookHeaderReserved = errors.New("webhook header is reserved")
+)
+
+// Domain Errors - PagerDuty
+var (
+	ErrInvalidPagerDutyRoutingKey = errors.New("pagerduty routing key is required and cannot contain spaces")
+	ErrInvalidPagerDutyConfig     = errors.New("pagerduty configuration must be a routing key or a json object with routing_key and url")
 )
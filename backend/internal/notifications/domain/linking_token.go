@@ -16,6 +16,11 @@ type LinkingToken struct {
 
 // NewLinkingToken creates a new secure token valid for 15 minutes
 func NewLinkingToken() (*LinkingToken, error) {
+	return NewLinkingTokenValidFor(15 * time.Minute)
+}
+
+// NewLinkingTokenValidFor creates a token with a custom lifetime (e.g. email links sit in an inbox)
+func NewLinkingTokenValidFor(ttl time.Duration) (*LinkingToken, error) {
 	// Generate a cryptographically secure random token (32 bytes = 256 bits)
 	bytes := make([]byte, 32)
 	if _, err := rand.Read(bytes); err != nil {
@@ -27,7 +32,7 @@ func NewLinkingToken() (*LinkingToken, error) {
 
 	return &LinkingToken{
 		value:     token,
-		expiresAt: time.Now().Add(15 * time.Minute),
+		expiresAt: time.Now().Add(ttl),
 		used:      false,
 	}, nil
 }
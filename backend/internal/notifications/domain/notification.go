@@ -15,6 +15,10 @@ type Notification struct {
 	severity  AlertSeverity
 	isRead    bool
 	createdAt time.Time
+	// incidentId links the notification to the monitoring incident it was raised for (optional)
+	incidentId string
+	// deliveredChannels lists the external channels the alert reached (e.g. "TELEGRAM")
+	deliveredChannels []string
 }
 
 func NewNotification(userId, title, message string, severity AlertSeverity) *Notification {
@@ -57,6 +61,14 @@ func (n *Notification) CreatedAt() time.Time {
 	return n.createdAt
 }
 
+func (n *Notification) IncidentID() string {
+	return n.incidentId
+}
+
+func (n *Notification) DeliveredChannels() []string {
+	return n.deliveredChannels
+}
+
 // Methods for hydration/persistence
 
 func (n *Notification) AssignId(id string) {
@@ -71,5 +83,19 @@ func (n *Notification) SetCreatedAt(t time.Time) {
 	n.createdAt = t
 }
 
+func (n *Notification) AssignIncident(incidentId string) {
+	n.incidentId = incidentId
+}
+
+// ReplaceMessage rewrites the text of an alert that was regrouped before it was delivered
+func (n *Notification) ReplaceMessage(message string) {
+	n.message = message
+}
+
+// RecordDelivery marks a successful send through an external channel
+func (n *Notification) RecordDelivery(channel string) {
+	n.deliveredChannels = append(n.deliveredChannels, channel)
+}
+
 // Methods
 // Methods for hydration/persistence
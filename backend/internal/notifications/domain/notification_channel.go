@@ -7,14 +7,16 @@ import (
 // Aggregate Root: NotificationChannel
 // Represents a configured notification method for a user (e.g., a specific Telegram chat or Slack webhook)
 type NotificationChannel struct {
-	id        ChannelId
-	userId    string // Reference to the User Aggregate
-	chType    ChannelType
-	value     ChannelValue // The actual configuration (URL, ID, etc.)
-	priority  Priority
-	isActive  bool
-	createdAt time.Time
-	updatedAt time.Time
+	id       ChannelId
+	userId   string // Reference to the User Aggregate
+	chType   ChannelType
+	value    ChannelValue // The actual configuration (URL, ID, etc.)
+	priority Priority
+	isActive bool
+	// Error classes this channel ignores (e.g. do not page on-call for http_4xx)
+	mutedErrorClasses []string
+	createdAt         time.Time
+	updatedAt         time.Time
 }
 
 // NewNotificationChannel Factory
@@ -44,6 +46,9 @@ func NewNotificationChannel(
 	if err != nil {
 		return nil, err
 	}
+	if err := validateChannelValue(cType, cValue); err != nil {
+		return nil, err
+	}
 
 	cPriority, err := NewPriority(priority)
 	if err != nil {
@@ -88,6 +93,10 @@ func (n *NotificationChannel) IsActive() bool {
 	return n.isActive
 }
 
+func (n *NotificationChannel) MutedErrorClasses() []string {
+	return n.mutedErrorClasses
+}
+
 func (n *NotificationChannel) CreatedAt() time.Time {
 	return n.createdAt
 }
@@ -117,6 +126,9 @@ func (n *NotificationChannel) UpdateConfiguration(value string, priority int) er
 	if err != nil {
 		return err
 	}
+	if err := validateChannelValue(n.chType, newValue); err != nil {
+		return err
+	}
 
 	newPriority, err := NewPriority(priority)
 	if err != nil {
@@ -138,3 +150,65 @@ func (n *NotificationChannel) ChangePriority(priority int) error {
 	n.updatedAt = time.Now()
 	return nil
 }
+
+// MuteErrorClasses replaces the set of error classes this channel ignores
+func (n *NotificationChannel) MuteErrorClasses(classes []string) error {
+	muted := make([]string, 0, len(classes))
+	seen := make(map[string]bool, len(classes))
+	for _, class := range classes {
+		if !isKnownErrorClass(class) {
+			return ErrInvalidErrorClass
+		}
+		if seen[class] {
+			continue
+		}
+		seen[class] = true
+		muted = append(muted, class)
+	}
+
+	n.mutedErrorClasses = muted
+	n.updatedAt = time.Now()
+	return nil
+}
+
+// Accepts reports whether the event should be delivered through this channel.
+// Events without an error class (recoveries, system alerts) are never muted.
+func (n *NotificationChannel) Accepts(event AlertEvent) bool {
+	class := event.ErrorClass()
+	if class == "" {
+		return true
+	}
+	for _, muted := range n.mutedErrorClasses {
+		if muted == class {
+			return false
+		}
+	}
+	return true
+}
+
+// validateChannelValue checks type-specific destinations (free-form values are accepted as is)
+func validateChannelValue(chType ChannelType, value ChannelValue) error {
+	switch chType {
+	case ChannelTypeWebhook:
+		_, err := ParseWebhookConfig(value.String())
+		return err
+	case ChannelTypeEmail:
+		_, err := NewEmailAddress(value.String())
+		return err
+	case ChannelTypePagerDuty:
+		_, err := ParsePagerDutyConfig(value.String())
+		return err
+	case ChannelTypeSlack, ChannelTypeTeams, ChannelTypeDiscord:
+		return validateWebhookURL(value.String())
+	}
+	return nil
+}
+
+func isKnownErrorClass(class string) bool {
+	for _, known := range KnownErrorClasses {
+		if known == class {
+			return true
+		}
+	}
+	return false
+}
@@ -100,3 +100,34 @@ func TestNotificationChannel_ActivateDeactivate(t *testing.T) {
 		t.Error("Expected active")
 	}
 }
+
+func TestNotificationChannel_MutedErrorClasses(t *testing.T) {
+	channel, _ := NewNotificationChannel("channel123", "user456", "TELEGRAM", "123456789", 5)
+
+	if err := channel.MuteErrorClasses([]string{"http_4xx", "http_4xx", "assertion"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(channel.MutedErrorClasses()) != 2 {
+		t.Errorf("Expected duplicates to be removed, got %v", channel.MutedErrorClasses())
+	}
+
+	muted := AlertEvent{Metadata: map[string]string{MetadataErrorClass: "http_4xx"}}
+	if channel.Accepts(muted) {
+		t.Error("Expected http_4xx alert to be muted")
+	}
+
+	outage := AlertEvent{Metadata: map[string]string{MetadataErrorClass: "dns"}}
+	if !channel.Accepts(outage) {
+		t.Error("Expected dns alert to be delivered")
+	}
+
+	// Recoveries carry no error class and are never muted
+	recovery := AlertEvent{Metadata: map[string]string{}}
+	if !channel.Accepts(recovery) {
+		t.Error("Expected recovery to be delivered")
+	}
+
+	if err := channel.MuteErrorClasses([]string{"timeout"}); err != ErrInvalidErrorClass {
+		t.Errorf("Expected ErrInvalidErrorClass, got %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+type OutboxMessageId string
+
+// OutboxStatus is the delivery state of an outbox message
+type OutboxStatus string
+
+const (
+	OutboxStatusPending   OutboxStatus = "PENDING"   // Waiting for its first or next attempt
+	OutboxStatusDelivered OutboxStatus = "DELIVERED" // The sender accepted it
+	OutboxStatusDead      OutboxStatus = "DEAD"      // Gave up: needs an admin replay
+	OutboxStatusCancelled OutboxStatus = "CANCELLED" // Withdrawn before its first attempt (grouped under another alert)
+)
+
+func NewOutboxStatus(value string) (OutboxStatus, error) {
+	switch OutboxStatus(value) {
+	case OutboxStatusPending, OutboxStatusDelivered, OutboxStatusDead, OutboxStatusCancelled:
+		return OutboxStatus(value), nil
+	}
+	return "", ErrInvalidOutboxStatus
+}
+
+// RetryPolicy controls the exponential backoff between delivery attempts of one channel
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration // Wait after the first failure; doubles on every retry
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy retries for about 3 hours: 30s, 1m, 2m, 4m ... capped at 1h
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 10,
+		BaseDelay:   30 * time.Second,
+		MaxDelay:    time.Hour,
+	}
+}
+
+// Backoff returns the wait after `attempts` failed attempts
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			return p.MaxDelay
+		}
+	}
+	if delay > p.MaxDelay {
+		return p.MaxDelay
+	}
+	return delay
+}
+
+// OutboxMessage is the pending delivery of one alert to one channel. It is persisted in the
+// same flow that raised the alert, so a crash or a failing channel never loses it, and each
+// channel retries on its own schedule.
+type OutboxMessage struct {
+	id             OutboxMessageId
+	notificationId NotificationId // History entry updated when the delivery succeeds
+	channelId      ChannelId
+	channelType    ChannelType
+	event          AlertEvent
+	status         OutboxStatus
+	attempts       int
+	nextAttemptAt  time.Time
+	lastError      string
+	createdAt      time.Time
+	deliveredAt    time.Time
+}
+
+func NewOutboxMessage(id string, notificationId NotificationId, channel *NotificationChannel, event AlertEvent, now time.Time) *OutboxMessage {
+	return &OutboxMessage{
+		id:             OutboxMessageId(id),
+		notificationId: notificationId,
+		channelId:      channel.ID(),
+		channelType:    channel.Type(),
+		event:          event,
+		status:         OutboxStatusPending,
+		nextAttemptAt:  now,
+		createdAt:      now,
+	}
+}
+
+// RestoreOutboxMessage rebuilds a message from persistence
+func RestoreOutboxMessage(id OutboxMessageId, notificationId NotificationId, channelId ChannelId, channelType ChannelType,
+	event AlertEvent, status OutboxStatus, attempts int, nextAttemptAt time.Time, lastError string,
+	createdAt time.Time, deliveredAt time.Time) *OutboxMessage {
+	return &OutboxMessage{
+		id:             id,
+		notificationId: notificationId,
+		channelId:      channelId,
+		channelType:    channelType,
+		event:          event,
+		status:         status,
+		attempts:       attempts,
+		nextAttemptAt:  nextAttemptAt,
+		lastError:      lastError,
+		createdAt:      createdAt,
+		deliveredAt:    deliveredAt,
+	}
+}
+
+// Getters
+func (m *OutboxMessage) ID() OutboxMessageId {
+	return m.id
+}
+
+func (m *OutboxMessage) NotificationID() NotificationId {
+	return m.notificationId
+}
+
+func (m *OutboxMessage) ChannelID() ChannelId {
+	return m.channelId
+}
+
+func (m *OutboxMessage) ChannelType() ChannelType {
+	return m.channelType
+}
+
+func (m *OutboxMessage) Event() AlertEvent {
+	return m.event
+}
+
+func (m *OutboxMessage) Status() OutboxStatus {
+	return m.status
+}
+
+func (m *OutboxMessage) Attempts() int {
+	return m.attempts
+}
+
+func (m *OutboxMessage) NextAttemptAt() time.Time {
+	return m.nextAttemptAt
+}
+
+func (m *OutboxMessage) LastError() string {
+	return m.lastError
+}
+
+func (m *OutboxMessage) CreatedAt() time.Time {
+	return m.createdAt
+}
+
+func (m *OutboxMessage) DeliveredAt() time.Time {
+	return m.deliveredAt
+}
+
+func (m *OutboxMessage) IsDead() bool {
+	return m.status == OutboxStatusDead
+}
+
+// Behavior
+
+// IsUnattempted reports whether the message is still waiting for its first attempt
+func (m *OutboxMessage) IsUnattempted() bool {
+	return m.status == OutboxStatusPending && m.attempts == 0
+}
+
+// DeferUntil moves the first attempt: a held alert waits until `at`, a released one is due now
+func (m *OutboxMessage) DeferUntil(at time.Time) error {
+	if !m.IsUnattempted() {
+		return ErrOutboxMessageAttempted
+	}
+	m.nextAttemptAt = at
+	return nil
+}
+
+// Cancel withdraws a message that was never attempted (e.g. its alert was grouped under an
+// infrastructure incident before the hold ended)
+func (m *OutboxMessage) Cancel() error {
+	if !m.IsUnattempted() {
+		return ErrOutboxMessageAttempted
+	}
+	m.status = OutboxStatusCancelled
+	return nil
+}
+
+// MarkDelivered records a successful attempt
+func (m *OutboxMessage) MarkDelivered(now time.Time) {
+	m.attempts++
+	m.status = OutboxStatusDelivered
+	m.deliveredAt = now
+	m.lastError = ""
+}
+
+// MarkFailed records a failed attempt and schedules the next one with exponential backoff.
+// Once the policy runs out of attempts the message becomes a dead letter.
+func (m *OutboxMessage) MarkFailed(cause error, policy RetryPolicy, now time.Time) {
+	m.attempts++
+	m.lastError = deliveryError(cause)
+	if m.attempts >= policy.MaxAttempts {
+		m.status = OutboxStatusDead
+		return
+	}
+	m.nextAttemptAt = now.Add(policy.Backoff(m.attempts))
+}
+
+// Abandon turns the message into a dead letter without retrying (e.g. the channel was deleted)
+func (m *OutboxMessage) Abandon(cause error) {
+	m.lastError = deliveryError(cause)
+	m.status = OutboxStatusDead
+}
+
+// Replay puts a dead letter back in the queue with a fresh attempt budget
+func (m *OutboxMessage) Replay(now time.Time) error {
+	if m.status != OutboxStatusDead {
+		return ErrOutboxMessageNotDead
+	}
+	m.status = OutboxStatusPending
+	m.attempts = 0
+	m.nextAttemptAt = now
+	return nil
+}
+
+// deliveryError is the error text stored and shown to admins. A *url.Error prints the request
+// URL, which for webhooks and bot APIs carries the credentials: only Op and the cause are kept.
+func deliveryError(cause error) string {
+	var urlErr *url.Error
+	if errors.As(cause, &urlErr) {
+		return fmt.Sprintf("%s request failed: %v", urlErr.Op, urlErr.Err)
+	}
+	return cause.Error()
+}
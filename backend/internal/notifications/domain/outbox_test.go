@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func testOutboxMessage(t *testing.T, now time.Time) *OutboxMessage {
+	channel, err := NewNotificationChannel("channel-1", "user-1", "SLACK", "https://hooks.slack.com/services/x", 5)
+	if err != nil {
+		t.Fatalf("Failed to create channel: %v", err)
+	}
+	event := NewAlertEvent("user-1", "Target Down: API", "timeout", SeverityCritical, SeverityOk, "Target: API", AlertTypeMonitoring, nil)
+	return NewOutboxMessage("message-1", "notification-1", channel, *event, now)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, BaseDelay: 30 * time.Second, MaxDelay: 5 * time.Minute}
+	expected := map[int]time.Duration{
+		1: 30 * time.Second,
+		2: time.Minute,
+		3: 2 * time.Minute,
+		4: 4 * time.Minute,
+		5: 5 * time.Minute, // Capped
+		9: 5 * time.Minute,
+	}
+	for attempts, delay := range expected {
+		if got := policy.Backoff(attempts); got != delay {
+			t.Errorf("Backoff(%d): expected %s, got %s", attempts, delay, got)
+		}
+	}
+}
+
+func TestOutboxMessage_RetriesThenDeadLetters(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	message := testOutboxMessage(t, now)
+
+	if message.Status() != OutboxStatusPending || !message.NextAttemptAt().Equal(now) {
+		t.Fatalf("Expected a pending message due now, got %s at %s", message.Status(), message.NextAttemptAt())
+	}
+
+	message.MarkFailed(errors.New("status 500"), policy, now)
+	if message.Status() != OutboxStatusPending || message.Attempts() != 1 || !message.NextAttemptAt().Equal(now.Add(time.Minute)) {
+		t.Errorf("Expected a retry in 1m, got %s attempts=%d next=%s", message.Status(), message.Attempts(), message.NextAttemptAt())
+	}
+	message.MarkFailed(errors.New("status 500"), policy, now)
+	if !message.NextAttemptAt().Equal(now.Add(2 * time.Minute)) {
+		t.Errorf("Expected the delay to double, got %s", message.NextAttemptAt())
+	}
+	message.MarkFailed(errors.New("status 502"), policy, now)
+	if !message.IsDead() || message.LastError() != "status 502" {
+		t.Errorf("Expected a dead letter after 3 attempts, got %s (%s)", message.Status(), message.LastError())
+	}
+}
+
+func TestOutboxMessage_Replay(t *testing.T) {
+	now := time.Now()
+	message := testOutboxMessage(t, now)
+
+	if err := message.Replay(now); err != ErrOutboxMessageNotDead {
+		t.Errorf("Expected ErrOutboxMessageNotDead for a pending message, got %v", err)
+	}
+
+	message.Abandon(ErrChannelNotFound)
+	later := now.Add(time.Hour)
+	if err := message.Replay(later); err != nil {
+		t.Fatalf("Expected the dead letter to be replayed, got %v", err)
+	}
+	if message.Status() != OutboxStatusPending || message.Attempts() != 0 || !message.NextAttemptAt().Equal(later) {
+		t.Errorf("Expected a fresh pending message, got %s attempts=%d next=%s", message.Status(), message.Attempts(), message.NextAttemptAt())
+	}
+
+	message.MarkDelivered(later)
+	if message.Status() != OutboxStatusDelivered || message.LastError() != "" || message.Attempts() != 1 {
+		t.Errorf("Unexpected delivered message: %s attempts=%d error=%q", message.Status(), message.Attempts(), message.LastError())
+	}
+}
+
+func TestOutboxMessage_LastErrorHidesURL(t *testing.T) {
+	now := time.Now()
+	message := testOutboxMessage(t, now)
+
+	cause := &url.Error{Op: "Post", URL: "https://api.telegram.org/bot123:s3cr3t/sendMessage", Err: syscall.ECONNREFUSED}
+	message.MarkFailed(cause, DefaultRetryPolicy(), now)
+	if strings.Contains(message.LastError(), "s3cr3t") || !strings.Contains(message.LastError(), "connection refused") {
+		t.Errorf("Expected the cause without the URL, got %q", message.LastError())
+	}
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// PagerDutyConfig is the destination of a PAGERDUTY channel. The channel value is either the
+// integration (routing) key or a JSON object {"routing_key": "...", "url": "..."}; the URL
+// overrides the sender's default Events API v2 endpoint (Opsgenie and other compatible receivers).
+type PagerDutyConfig struct {
+	RoutingKey string `json:"routing_key"`
+	URL        string `json:"url,omitempty"`
+}
+
+// ParsePagerDutyConfig reads and validates a PAGERDUTY channel value
+func ParsePagerDutyConfig(value string) (PagerDutyConfig, error) {
+	value = strings.TrimSpace(value)
+
+	var config PagerDutyConfig
+	if strings.HasPrefix(value, "{") {
+		if err := json.Unmarshal([]byte(value), &config); err != nil {
+			return PagerDutyConfig{}, ErrInvalidPagerDutyConfig
+		}
+	} else {
+		config.RoutingKey = value
+	}
+
+	if config.RoutingKey == "" || strings.ContainsAny(config.RoutingKey, " \t\r\n") {
+		return PagerDutyConfig{}, ErrInvalidPagerDutyRoutingKey
+	}
+	if config.URL != "" {
+		if err := validateWebhookURL(config.URL); err != nil {
+			return PagerDutyConfig{}, err
+		}
+	}
+	return config, nil
+}
+
+// Redacted masks the routing key: anyone holding it can open incidents on the service
+func (c PagerDutyConfig) Redacted() string {
+	encoded, _ := json.Marshal(PagerDutyConfig{RoutingKey: redactedValue, URL: c.URL})
+	return string(encoded)
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePagerDutyConfig(t *testing.T) {
+	config, err := ParsePagerDutyConfig(" R0ut1ngK3y ")
+	if err != nil || config.RoutingKey != "R0ut1ngK3y" || config.URL != "" {
+		t.Errorf("Expected a bare routing key to be accepted, got %+v (%v)", config, err)
+	}
+
+	config, err = ParsePagerDutyConfig(`{"routing_key":"abc","url":"http://127.0.0.1:9000/v2/enqueue"}`)
+	if err != nil || config.RoutingKey != "abc" || config.URL != "http://127.0.0.1:9000/v2/enqueue" {
+		t.Errorf("Expected JSON config to be accepted, got %+v (%v)", config, err)
+	}
+
+	cases := map[string]error{
+		"":                                      ErrInvalidPagerDutyRoutingKey,
+		"two words":                             ErrInvalidPagerDutyRoutingKey,
+		`{"routing_key":`:                       ErrInvalidPagerDutyConfig,
+		`{"url":"https://events.example.com"}`:  ErrInvalidPagerDutyRoutingKey,
+		`{"routing_key":"abc","url":"ftp://x"}`: ErrInvalidWebhookURL,
+	}
+	for value, expected := range cases {
+		if _, err := ParsePagerDutyConfig(value); err != expected {
+			t.Errorf("%q: expected %v, got %v", value, expected, err)
+		}
+	}
+}
+
+func TestPagerDutyConfig_Redacted(t *testing.T) {
+	redacted := PagerDutyConfig{RoutingKey: "R0ut1ngK3y", URL: "https://events.example.com"}.Redacted()
+	if strings.Contains(redacted, "R0ut1ngK3y") || !strings.Contains(redacted, "https://events.example.com") {
+		t.Errorf("Expected the routing key masked, got %s", redacted)
+	}
+}
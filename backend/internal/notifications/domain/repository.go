@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // Repository interface for NotificationChannel Aggregate
 type NotificationChannelRepository interface {
 	// Save persists a new notification channel
@@ -29,4 +31,23 @@ type NotificationRepository interface {
 	CountUnread(userId string) (int64, error)
 	MarkAsRead(id NotificationId) error
 	MarkAllAsRead(userId string) error
+	// FindByIncidentId returns the alerts raised for a monitoring incident, oldest first
+	FindByIncidentId(incidentId string) ([]*Notification, error)
+}
+
+// OutboxRepository persists the per-channel deliveries of alerts
+type OutboxRepository interface {
+	// SaveAll inserts or updates the messages in one transaction
+	SaveAll(messages []*OutboxMessage) error
+	Save(message *OutboxMessage) error
+	FindById(id OutboxMessageId) (*OutboxMessage, error)
+	// ClaimDue returns up to `limit` pending messages whose next attempt is due and pushes their
+	// next attempt `lease` into the future, so a crashed worker's claims are retried and
+	// concurrent workers never pick the same message
+	ClaimDue(now time.Time, limit int, lease time.Duration) ([]*OutboxMessage, error)
+	// FindByStatus lists messages in a status, newest first
+	FindByStatus(status OutboxStatus, limit int, offset int) ([]*OutboxMessage, error)
+	CountByStatus(status OutboxStatus) (int64, error)
+	// FindPendingByNotification returns the pending deliveries of one alert
+	FindPendingByNotification(notificationId NotificationId) ([]*OutboxMessage, error)
 }
@@ -5,6 +5,12 @@ type Sender interface {
 	Send(destination string, message string) error
 }
 
+// EventSender is implemented by senders that render the structured AlertEvent themselves
+// (e.g. webhooks) instead of the plain text message. Notify prefers it over Send.
+type EventSender interface {
+	SendEvent(destination string, event AlertEvent) error
+}
+
 // SenderRegistry manages the available senders for each channel type
 // This avoids using switch statements or reflection to select the correct sender
 type SenderRegistry struct {
@@ -24,14 +24,22 @@ type ChannelType string
 const (
 	ChannelTypeTelegram ChannelType = "TELEGRAM"
 	ChannelTypeSlack    ChannelType = "SLACK"
-	// ChannelTypeEmail    ChannelType = "EMAIL" // Future support
-	// ChannelTypeWebhook  ChannelType = "WEBHOOK" // Future support
+	// SMTP delivery to a verified address (created through the one-time verification link)
+	ChannelTypeEmail ChannelType = "EMAIL"
+	// Generic HTTP POST of the signed AlertEvent JSON (value: URL or WebhookConfig JSON)
+	ChannelTypeWebhook ChannelType = "WEBHOOK"
+	// Microsoft Teams incoming webhook (Adaptive Card)
+	ChannelTypeTeams ChannelType = "TEAMS"
+	// Discord channel webhook (embed)
+	ChannelTypeDiscord ChannelType = "DISCORD"
+	// Incident management through the PagerDuty Events API v2 (value: routing key or PagerDutyConfig JSON)
+	ChannelTypePagerDuty ChannelType = "PAGERDUTY"
 )
 
 func NewChannelType(value string) (ChannelType, error) {
 	upper := strings.ToUpper(strings.TrimSpace(value))
 	switch ChannelType(upper) {
-	case ChannelTypeTelegram, ChannelTypeSlack:
+	case ChannelTypeTelegram, ChannelTypeSlack, ChannelTypeWebhook, ChannelTypeEmail, ChannelTypeTeams, ChannelTypeDiscord, ChannelTypePagerDuty:
 		return ChannelType(upper), nil
 	default:
 		return "", ErrInvalidChannelType
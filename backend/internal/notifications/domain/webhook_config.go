@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// WebhookPayloadVersion is bumped on breaking changes to the webhook JSON body
+	WebhookPayloadVersion = "1"
+
+	WebhookSignatureHeader = "X-UpTrack-Signature"
+	WebhookTimestampHeader = "X-UpTrack-Timestamp"
+	WebhookVersionHeader   = "X-UpTrack-Webhook-Version"
+
+	redactedValue = "********"
+)
+
+// reservedWebhookHeaders are set by the sender and cannot be overridden per channel
+var reservedWebhookHeaders = []string{
+	"Content-Type",
+	"Content-Length",
+	"Host",
+	WebhookSignatureHeader,
+	WebhookTimestampHeader,
+	WebhookVersionHeader,
+}
+
+// WebhookConfig is the destination of a WEBHOOK channel. The channel value is either
+// a bare URL or a JSON object: {"url": "...", "secret": "...", "headers": {"X-Api-Key": "..."}}.
+// Without a secret the request is sent unsigned.
+type WebhookConfig struct {
+	URL     string            `json:"url"`
+	Secret  string            `json:"secret,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// ParseWebhookConfig reads and validates a WEBHOOK channel value
+func ParseWebhookConfig(value string) (WebhookConfig, error) {
+	value = strings.TrimSpace(value)
+
+	var config WebhookConfig
+	if strings.HasPrefix(value, "{") {
+		if err := json.Unmarshal([]byte(value), &config); err != nil {
+			return WebhookConfig{}, ErrInvalidWebhookConfig
+		}
+	} else {
+		config.URL = value
+	}
+
+	if err := validateWebhookURL(config.URL); err != nil {
+		return WebhookConfig{}, err
+	}
+
+	for name := range config.Headers {
+		canonical := http.CanonicalHeaderKey(strings.TrimSpace(name))
+		if canonical == "" {
+			return WebhookConfig{}, ErrInvalidWebhookConfig
+		}
+		for _, reserved := range reservedWebhookHeaders {
+			if canonical == http.CanonicalHeaderKey(reserved) {
+				return WebhookConfig{}, ErrWebhookHeaderReserved
+			}
+		}
+	}
+	return config, nil
+}
+
+// validateWebhookURL accepts absolute http(s) URLs (incoming webhooks of chat tools included)
+func validateWebhookURL(raw string) error {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+	return nil
+}
+
+// Redacted returns the configuration as shown back to the user: the secret and header
+// values (usually API keys) never leave the server
+func (c WebhookConfig) Redacted() string {
+	if c.Secret == "" && len(c.Headers) == 0 {
+		return c.URL
+	}
+	redacted := WebhookConfig{URL: c.URL}
+	if c.Secret != "" {
+		redacted.Secret = redactedValue
+	}
+	if len(c.Headers) > 0 {
+		redacted.Headers = make(map[string]string, len(c.Headers))
+		for name := range c.Headers {
+			redacted.Headers[name] = redactedValue
+		}
+	}
+	encoded, _ := json.Marshal(redacted)
+	return string(encoded)
+}
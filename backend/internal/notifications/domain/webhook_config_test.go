@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseWebhookConfig(t *testing.T) {
+	config, err := ParseWebhookConfig("https://automation.example.com/hooks/uptrack")
+	if err != nil || config.URL != "https://automation.example.com/hooks/uptrack" || config.Secret != "" {
+		t.Errorf("Expected a bare URL to be accepted, got %+v (%v)", config, err)
+	}
+
+	config, err = ParseWebhookConfig(`{"url":"http://localhost:9000/in","secret":"s3cr3t","headers":{"X-Api-Key":"abc"}}`)
+	if err != nil {
+		t.Fatalf("Expected JSON config to be accepted, got %v", err)
+	}
+	if config.Secret != "s3cr3t" || config.Headers["X-Api-Key"] != "abc" {
+		t.Errorf("Unexpected config %+v", config)
+	}
+
+	cases := map[string]error{
+		"ftp://example.com": ErrInvalidWebhookURL,
+		"/relative/path":    ErrInvalidWebhookURL,
+		`{"url":`:           ErrInvalidWebhookConfig,
+		`{"url":"https://x.io","headers":{"":"a"}}`:                         ErrInvalidWebhookConfig,
+		`{"url":"https://x.io","headers":{"x-uptrack-signature":"forged"}}`: ErrWebhookHeaderReserved,
+		`{"url":"https://x.io","headers":{"content-type":"text/plain"}}`:    ErrWebhookHeaderReserved,
+	}
+	for value, expected := range cases {
+		if _, err := ParseWebhookConfig(value); err != expected {
+			t.Errorf("%s: expected %v, got %v", value, expected, err)
+		}
+	}
+}
+
+func TestWebhookConfig_Redacted(t *testing.T) {
+	plain := WebhookConfig{URL: "https://x.io/hook"}
+	if plain.Redacted() != "https://x.io/hook" {
+		t.Errorf("Expected the bare URL back, got %s", plain.Redacted())
+	}
+
+	signed := WebhookConfig{URL: "https://x.io/hook", Secret: "s3cr3t", Headers: map[string]string{"X-Api-Key": "abc"}}
+	redacted := signed.Redacted()
+	if strings.Contains(redacted, "s3cr3t") || strings.Contains(redacted, "abc") || !strings.Contains(redacted, "X-Api-Key") {
+		t.Errorf("Expected secret and header values masked, got %s", redacted)
+	}
+}
+
+func TestNewNotificationChannel_Webhook(t *testing.T) {
+	if _, err := NewNotificationChannel("channel123", "user123", "webhook", "https://x.io/hook", 5); err != nil {
+		t.Errorf("Expected webhook channel, got %v", err)
+	}
+	if _, err := NewNotificationChannel("channel123", "user123", "WEBHOOK", "not a url", 5); err != ErrInvalidWebhookURL {
+		t.Errorf("Expected ErrInvalidWebhookURL, got %v", err)
+	}
+
+	channel, _ := NewNotificationChannel("channel123", "user123", "WEBHOOK", "https://x.io/hook", 5)
+	if err := channel.UpdateConfiguration("mailto:ops@example.com", 5); err != ErrInvalidWebhookURL {
+		t.Errorf("Expected ErrInvalidWebhookURL on update, got %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package postgres
 
 import (
+	"errors"
+	"strings"
 	"time"
 	"uptrackai/internal/notifications/domain"
 
@@ -8,14 +10,16 @@ import (
 )
 
 type NotificationChannelEntity struct {
-	ID        string    `gorm:"primaryKey;type:varchar(100)"`
-	UserID    string    `gorm:"type:varchar(36);not null;index"`
-	Type      string    `gorm:"type:varchar(20);not null"`
-	Value     string    `gorm:"type:text;not null"` // JSON or simple string depending on config
-	Priority  int       `gorm:"not null;default:0"`
-	IsActive  bool      `gorm:"not null;default:true"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
-	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+	ID       string `gorm:"primaryKey;type:varchar(100)"`
+	UserID   string `gorm:"type:varchar(36);not null;index"`
+	Type     string `gorm:"type:varchar(20);not null"`
+	Value    string `gorm:"type:text;not null"` // JSON or simple string depending on config
+	Priority int    `gorm:"not null;default:0"`
+	IsActive bool   `gorm:"not null;default:true"`
+	// Comma-separated error classes the channel ignores (e.g. "http_4xx,assertion")
+	MutedErrorClasses string    `gorm:"type:text;not null;default:''"`
+	CreatedAt         time.Time `gorm:"autoCreateTime"`
+	UpdatedAt         time.Time `gorm:"autoUpdateTime"`
 }
 
 func (NotificationChannelEntity) TableName() string {
@@ -39,6 +43,9 @@ func (r *PostgresNotificationChannelRepository) Save(channel *domain.Notificatio
 func (r *PostgresNotificationChannelRepository) FindById(id domain.ChannelId) (*domain.NotificationChannel, error) {
 	var entity NotificationChannelEntity
 	if err := r.db.First(&entity, "id = ?", id.String()).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrChannelNotFound
+		}
 		return nil, err
 	}
 	return r.toDomain(&entity)
@@ -73,25 +80,37 @@ func (r *PostgresNotificationChannelRepository) Delete(id domain.ChannelId) erro
 
 func (r *PostgresNotificationChannelRepository) toEntity(d *domain.NotificationChannel) *NotificationChannelEntity {
 	return &NotificationChannelEntity{
-		ID:        d.ID().String(),
-		UserID:    d.UserID(),
-		Type:      d.Type().String(),
-		Value:     d.Value().String(),
-		Priority:  d.Priority().Int(),
-		IsActive:  d.IsActive(),
-		CreatedAt: d.CreatedAt(),
-		UpdatedAt: d.UpdatedAt(),
+		ID:                d.ID().String(),
+		UserID:            d.UserID(),
+		Type:              d.Type().String(),
+		Value:             d.Value().String(),
+		Priority:          d.Priority().Int(),
+		IsActive:          d.IsActive(),
+		MutedErrorClasses: strings.Join(d.MutedErrorClasses(), ","),
+		CreatedAt:         d.CreatedAt(),
+		UpdatedAt:         d.UpdatedAt(),
 	}
 }
 
 func (r *PostgresNotificationChannelRepository) toDomain(e *NotificationChannelEntity) (*domain.NotificationChannel, error) {
-	return domain.NewNotificationChannel(
+	channel, err := domain.NewNotificationChannel(
 		e.ID,
 		e.UserID,
 		e.Type,
 		e.Value,
 		e.Priority,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.MutedErrorClasses != "" {
+		// Unknown classes (e.g. removed in a later version) are dropped instead of hiding the channel
+		for _, class := range strings.Split(e.MutedErrorClasses, ",") {
+			_ = channel.MuteErrorClasses(append(channel.MutedErrorClasses(), class))
+		}
+	}
+	return channel, nil
 }
 
 func (r *PostgresNotificationChannelRepository) toDomainList(entities []NotificationChannelEntity) ([]*domain.NotificationChannel, error) {
@@ -6,13 +6,17 @@ import (
 	"gorm.io/gorm"
 )
 
-// TelegramLinkingToken represents a temporary token for user-telegram account linking
+// TelegramLinkingToken represents a temporary token for linking a notification channel.
+// Telegram tokens leave ChannelType empty (the chat ID arrives with /start); email
+// verification tokens carry the address being verified in Destination.
 type TelegramLinkingToken struct {
-	Token     string    `gorm:"primaryKey;type:varchar(64)"`
-	UserID    string    `gorm:"type:varchar(36);not null;index"`
-	ExpiresAt time.Time `gorm:"not null;index"`
-	Used      bool      `gorm:"default:false;not null"`
-	CreatedAt time.Time `gorm:"autoCreateTime"`
+	Token       string    `gorm:"primaryKey;type:varchar(64)"`
+	UserID      string    `gorm:"type:varchar(36);not null;index"`
+	ChannelType string    `gorm:"type:varchar(20);not null;default:''"`
+	Destination string    `gorm:"type:text;not null;default:''"`
+	ExpiresAt   time.Time `gorm:"not null;index"`
+	Used        bool      `gorm:"default:false;not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
 }
 
 func (TelegramLinkingToken) TableName() string {
@@ -38,6 +42,19 @@ func (r *LinkingTokenRepository) Save(token string, userID string, expiresAt tim
 	return r.db.Create(&record).Error
 }
 
+// SaveForDestination stores a token that links a specific destination (e.g. the email address to verify)
+func (r *LinkingTokenRepository) SaveForDestination(token string, userID string, channelType string, destination string, expiresAt time.Time) error {
+	record := TelegramLinkingToken{
+		Token:       token,
+		UserID:      userID,
+		ChannelType: channelType,
+		Destination: destination,
+		ExpiresAt:   expiresAt,
+		Used:        false,
+	}
+	return r.db.Create(&record).Error
+}
+
 func (r *LinkingTokenRepository) FindByToken(token string) (*TelegramLinkingToken, error) {
 	var record TelegramLinkingToken
 	err := r.db.Where("token = ?", token).First(&record).Error
@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"strings"
 	"time"
 	"uptrackai/internal/notifications/domain"
 
@@ -15,6 +16,10 @@ type NotificationEntity struct {
 	Severity  int       `gorm:"not null"` // 0: OK, 1: Warning, 2: Critical
 	IsRead    bool      `gorm:"not null;default:false"`
 	CreatedAt time.Time `gorm:"autoCreateTime;index"`
+	// Monitoring incident this alert belongs to (empty for non-incident alerts)
+	IncidentID string `gorm:"type:varchar(36);index"`
+	// Comma-separated channel types the alert was delivered to
+	DeliveredChannels string `gorm:"type:text;not null;default:''"`
 }
 
 func (NotificationEntity) TableName() string {
@@ -31,13 +36,15 @@ func NewPostgresNotificationRepository(db *gorm.DB) *PostgresNotificationReposit
 
 func (r *PostgresNotificationRepository) Save(n *domain.Notification) error {
 	entity := NotificationEntity{
-		ID:        string(n.ID()),
-		UserID:    n.UserID(),
-		Title:     n.Title(),
-		Message:   n.Message(),
-		Severity:  int(n.Severity()),
-		IsRead:    n.IsRead(),
-		CreatedAt: n.CreatedAt(),
+		ID:                string(n.ID()),
+		UserID:            n.UserID(),
+		Title:             n.Title(),
+		Message:           n.Message(),
+		Severity:          int(n.Severity()),
+		IsRead:            n.IsRead(),
+		CreatedAt:         n.CreatedAt(),
+		IncidentID:        n.IncidentID(),
+		DeliveredChannels: strings.Join(n.DeliveredChannels(), ","),
 	}
 	return r.db.Save(&entity).Error
 }
@@ -67,6 +74,21 @@ func (r *PostgresNotificationRepository) FindByUserId(userId string, limit int,
 	return notifications, nil
 }
 
+func (r *PostgresNotificationRepository) FindByIncidentId(incidentId string) ([]*domain.Notification, error) {
+	var entities []NotificationEntity
+	if err := r.db.Where("incident_id = ?", incidentId).
+		Order("created_at asc").
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+
+	notifications := make([]*domain.Notification, len(entities))
+	for i, e := range entities {
+		notifications[i] = r.toDomain(&e)
+	}
+	return notifications, nil
+}
+
 func (r *PostgresNotificationRepository) CountUnread(userId string) (int64, error) {
 	var count int64
 	err := r.db.Model(&NotificationEntity{}).
@@ -110,5 +132,11 @@ func (r *PostgresNotificationRepository) toDomain(e *NotificationEntity) *domain
 		n.MarkAsRead()
 	}
 	n.SetCreatedAt(e.CreatedAt)
+	n.AssignIncident(e.IncidentID)
+	if e.DeliveredChannels != "" {
+		for _, channel := range strings.Split(e.DeliveredChannels, ",") {
+			n.RecordDelivery(channel)
+		}
+	}
 	return n
 }
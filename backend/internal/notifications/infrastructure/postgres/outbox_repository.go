@@ -0,0 +1,193 @@
+package postgres
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	"uptrackai/internal/notifications/domain"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OutboxMessageEntity struct {
+	ID             string `gorm:"primaryKey;type:varchar(36)"` // UUID v7
+	NotificationID string `gorm:"type:varchar(36);not null"`
+	ChannelID      string `gorm:"type:varchar(100);not null;index"`
+	ChannelType    string `gorm:"type:varchar(20);not null"`
+	Event          string `gorm:"type:jsonb;not null"` // AlertEvent as JSON
+	Status         string `gorm:"type:varchar(20);not null;index:idx_outbox_due,priority:1"`
+	Attempts       int    `gorm:"not null;default:0"`
+	// Pending messages are picked by (status, next_attempt_at)
+	NextAttemptAt time.Time  `gorm:"not null;index:idx_outbox_due,priority:2"`
+	LastError     string     `gorm:"type:text;not null;default:''"`
+	CreatedAt     time.Time  `gorm:"not null;index"`
+	DeliveredAt   *time.Time `gorm:"default:null"`
+}
+
+// TableName: the retention module purges the delivered and dead-lettered rows
+func (OutboxMessageEntity) TableName() string {
+	return "notification_outbox"
+}
+
+type PostgresOutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresOutboxRepository(db *gorm.DB) *PostgresOutboxRepository {
+	return &PostgresOutboxRepository{db: db}
+}
+
+func (r *PostgresOutboxRepository) Save(message *domain.OutboxMessage) error {
+	return r.SaveAll([]*domain.OutboxMessage{message})
+}
+
+func (r *PostgresOutboxRepository) SaveAll(messages []*domain.OutboxMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	entities := make([]OutboxMessageEntity, 0, len(messages))
+	for _, message := range messages {
+		entity, err := r.toEntity(message)
+		if err != nil {
+			return err
+		}
+		entities = append(entities, entity)
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&entities).Error
+	})
+}
+
+func (r *PostgresOutboxRepository) FindById(id domain.OutboxMessageId) (*domain.OutboxMessage, error) {
+	var entity OutboxMessageEntity
+	if err := r.db.First(&entity, "id = ?", string(id)).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrOutboxMessageNotFound
+		}
+		return nil, err
+	}
+	return r.toDomain(&entity)
+}
+
+func (r *PostgresOutboxRepository) ClaimDue(now time.Time, limit int, lease time.Duration) ([]*domain.OutboxMessage, error) {
+	var entities []OutboxMessageEntity
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// SKIP LOCKED: another worker (or instance) claiming at the same time gets other rows
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", string(domain.OutboxStatusPending), now).
+			Order("next_attempt_at asc").
+			Limit(limit).
+			Find(&entities).Error; err != nil {
+			return err
+		}
+		if len(entities) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(entities))
+		for i, entity := range entities {
+			ids[i] = entity.ID
+		}
+		return tx.Model(&OutboxMessageEntity{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomainList(entities)
+}
+
+func (r *PostgresOutboxRepository) FindByStatus(status domain.OutboxStatus, limit int, offset int) ([]*domain.OutboxMessage, error) {
+	var entities []OutboxMessageEntity
+	if err := r.db.Where("status = ?", string(status)).
+		Order("created_at desc").
+		Limit(limit).
+		Offset(offset).
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	return r.toDomainList(entities)
+}
+
+func (r *PostgresOutboxRepository) CountByStatus(status domain.OutboxStatus) (int64, error) {
+	var count int64
+	err := r.db.Model(&OutboxMessageEntity{}).Where("status = ?", string(status)).Count(&count).Error
+	return count, err
+}
+
+func (r *PostgresOutboxRepository) FindPendingByNotification(notificationId domain.NotificationId) ([]*domain.OutboxMessage, error) {
+	var entities []OutboxMessageEntity
+	if err := r.db.Where("notification_id = ? AND status = ?", string(notificationId), string(domain.OutboxStatusPending)).
+		Find(&entities).Error; err != nil {
+		return nil, err
+	}
+	return r.toDomainList(entities)
+}
+
+func (r *PostgresOutboxRepository) toEntity(message *domain.OutboxMessage) (OutboxMessageEntity, error) {
+	event, err := json.Marshal(message.Event())
+	if err != nil {
+		return OutboxMessageEntity{}, fmt.Errorf("failed to encode outbox event: %w", err)
+	}
+
+	entity := OutboxMessageEntity{
+		ID:             string(message.ID()),
+		NotificationID: string(message.NotificationID()),
+		ChannelID:      message.ChannelID().String(),
+		ChannelType:    message.ChannelType().String(),
+		Event:          string(event),
+		Status:         string(message.Status()),
+		Attempts:       message.Attempts(),
+		NextAttemptAt:  message.NextAttemptAt(),
+		LastError:      message.LastError(),
+		CreatedAt:      message.CreatedAt(),
+	}
+	if deliveredAt := message.DeliveredAt(); !deliveredAt.IsZero() {
+		entity.DeliveredAt = &deliveredAt
+	}
+	return entity, nil
+}
+
+func (r *PostgresOutboxRepository) toDomain(e *OutboxMessageEntity) (*domain.OutboxMessage, error) {
+	var event domain.AlertEvent
+	if err := json.Unmarshal([]byte(e.Event), &event); err != nil {
+		return nil, fmt.Errorf("failed to decode outbox event %s: %w", e.ID, err)
+	}
+	status, err := domain.NewOutboxStatus(e.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	var deliveredAt time.Time
+	if e.DeliveredAt != nil {
+		deliveredAt = *e.DeliveredAt
+	}
+	return domain.RestoreOutboxMessage(
+		domain.OutboxMessageId(e.ID),
+		domain.NotificationId(e.NotificationID),
+		domain.ChannelId(e.ChannelID),
+		domain.ChannelType(e.ChannelType),
+		event,
+		status,
+		e.Attempts,
+		e.NextAttemptAt,
+		e.LastError,
+		e.CreatedAt,
+		deliveredAt,
+	), nil
+}
+
+func (r *PostgresOutboxRepository) toDomainList(entities []OutboxMessageEntity) ([]*domain.OutboxMessage, error) {
+	messages := make([]*domain.OutboxMessage, 0, len(entities))
+	for i := range entities {
+		message, err := r.toDomain(&entities[i])
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
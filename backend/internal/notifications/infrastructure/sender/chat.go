@@ -0,0 +1,159 @@
+package sender
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"uptrackai/internal/notifications/domain"
+)
+
+// Shared helpers for the chat integrations (Slack, Teams, Discord) that POST JSON to an incoming webhook
+
+// newHookClient is the HTTP client for outbound webhooks. A redirect is not a delivery:
+// the 3xx is reported instead of re-POSTing the alert elsewhere.
+func newHookClient() *http.Client {
+	return &http.Client{
+		Timeout: 10 * time.Second,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// postJSON sends the payload and treats anything but a 2xx as a failed delivery
+func postJSON(client *http.Client, url string, payload interface{}) error {
+	// Chat markup relies on <, > and & (Slack links, Discord mentions): keep them readable
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(payload); err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	resp, err := client.Post(url, "application/json", &body)
+	if err != nil {
+		return hideURL(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook rejected: status %d, body: %s", resp.StatusCode, string(respBody))
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// targetLink points to the target page of the web app ("" without base URL or target)
+func targetLink(appBaseURL string, event domain.AlertEvent) string {
+	targetId := event.TargetID()
+	if appBaseURL == "" || targetId == "" {
+		return ""
+	}
+	return strings.TrimRight(appBaseURL, "/") + "/target/" + targetId
+}
+
+// statusLabels returns the current and previous status: the monitoring status when the
+// alert carries it (DOWN, DEGRADED...), the severity otherwise
+func statusLabels(event domain.AlertEvent) (string, string) {
+	current := event.Metadata[domain.MetadataStatus]
+	if current == "" {
+		current = event.Severity.String()
+	}
+	previous := event.Metadata[domain.MetadataPreviousStatus]
+	if previous == "" {
+		previous = event.PreviousSeverity.String()
+	}
+	return current, previous
+}
+
+// chatField is a label/value pair shown in a chat card
+type chatField struct {
+	Name  string
+	Value string
+}
+
+// detailKeys are rendered explicitly (or as the target link) and not repeated as extra metadata
+var detailKeys = map[string]bool{
+	domain.MetadataTargetID:       true,
+	domain.MetadataURL:            true,
+	domain.MetadataResponseTime:   true,
+	domain.MetadataStatus:         true,
+	domain.MetadataPreviousStatus: true,
+}
+
+// alertFields lists what chat cards show: the status transition and target details first,
+// then the rest of the metadata sorted by key
+func alertFields(event domain.AlertEvent) []chatField {
+	current, previous := statusLabels(event)
+	fields := []chatField{
+		{Name: "Status", Value: current},
+		{Name: "Previous status", Value: previous},
+	}
+	if url := event.Metadata[domain.MetadataURL]; url != "" {
+		fields = append(fields, chatField{Name: "Target URL", Value: url})
+	}
+	if responseTime := event.Metadata[domain.MetadataResponseTime]; responseTime != "" {
+		fields = append(fields, chatField{Name: "Response time", Value: responseTime})
+	}
+	for _, key := range sortedKeys(event.Metadata) {
+		if detailKeys[key] || event.Metadata[key] == "" {
+			continue
+		}
+		fields = append(fields, chatField{Name: metadataLabel(key), Value: event.Metadata[key]})
+	}
+	return fields
+}
+
+// metadataLabel turns a metadata key into a label ("error_class" -> "Error class")
+func metadataLabel(key string) string {
+	label := strings.ReplaceAll(key, "_", " ")
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// fitFields keeps the fields that fit in the platform limits (count and characters). When some
+// do not fit, the last kept slot becomes a note saying how many were left out.
+func fitFields(fields []chatField, maxFields int, maxChars int) []chatField {
+	used := 0
+	for i, field := range fields {
+		cost := len([]rune(field.Name)) + len([]rune(field.Value))
+		if i < maxFields && used+cost <= maxChars {
+			used += cost
+			continue
+		}
+
+		kept := fields[:i:i]
+		note := chatField{Name: "…", Value: ""}
+		for {
+			note.Value = fmt.Sprintf("%d more fields omitted", len(fields)-len(kept))
+			noteCost := len([]rune(note.Name)) + len([]rune(note.Value))
+			if len(kept) == 0 || (len(kept) < maxFields && used+noteCost <= maxChars) {
+				break
+			}
+			last := kept[len(kept)-1]
+			used -= len([]rune(last.Name)) + len([]rune(last.Value))
+			kept = kept[:len(kept)-1]
+		}
+		return append(kept, note)
+	}
+	return fields
+}
+
+// truncate cuts s to max runes, marking the cut with an ellipsis
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	if max <= 1 {
+		return string(runes[:max])
+	}
+	return string(runes[:max-1]) + "…"
+}
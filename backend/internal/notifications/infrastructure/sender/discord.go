@@ -0,0 +1,128 @@
+package sender
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"uptrackai/internal/notifications/domain"
+)
+
+// Embed limits (https://discord.com/developers/docs/resources/message#embed-object-embed-limits)
+const (
+	discordContentMaxChars     = 2000
+	discordTitleMaxChars       = 256
+	discordDescriptionMaxChars = 4096
+	discordFieldNameMaxChars   = 256
+	discordFieldValueMaxChars  = 1024
+	discordFooterMaxChars      = 2048
+	discordMaxFields           = 25
+	discordEmbedMaxChars       = 6000 // Sum of title, description, fields and footer
+	discordInlineMaxChars      = 40   // Longer values get a full-width field
+)
+
+// DiscordSender posts alerts to a Discord channel webhook (the channel value) as an embed
+type DiscordSender struct {
+	client     *http.Client
+	appBaseURL string // Web app URL linked from the embed title ("" = no link)
+}
+
+func NewDiscordSender(appBaseURL string) *DiscordSender {
+	return &DiscordSender{
+		client:     newHookClient(),
+		appBaseURL: appBaseURL,
+	}
+}
+
+// Send posts a plain text message
+func (s *DiscordSender) Send(destination string, message string) error {
+	return postJSON(s.client, destination, DiscordMessage{
+		Username:        "UpTrack",
+		Content:         truncate(message, discordContentMaxChars),
+		AllowedMentions: discordNoMentions(),
+	})
+}
+
+func (s *DiscordSender) SendEvent(destination string, event domain.AlertEvent) error {
+	if err := postJSON(s.client, destination, BuildDiscordMessage(event, s.appBaseURL)); err != nil {
+		log.Printf("❌ Failed to send Discord message: %v", err)
+		return err
+	}
+	log.Printf("✅ Discord message sent for %s", event.Source)
+	return nil
+}
+
+type DiscordMessage struct {
+	Username        string                 `json:"username"`
+	Content         string                 `json:"content,omitempty"`
+	Embeds          []DiscordEmbed         `json:"embeds,omitempty"`
+	AllowedMentions map[string]interface{} `json:"allowed_mentions"`
+}
+
+type DiscordEmbed struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	URL         string              `json:"url,omitempty"`
+	Color       int                 `json:"color"`
+	Fields      []DiscordEmbedField `json:"fields"`
+	Footer      DiscordEmbedFooter  `json:"footer"`
+	Timestamp   string              `json:"timestamp"`
+}
+
+type DiscordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+type DiscordEmbedFooter struct {
+	Text string `json:"text"`
+}
+
+// BuildDiscordMessage lays out the alert as one embed coloured by severity. Metadata fields
+// share what is left of the 6000 character embed budget; the ones that do not fit are summarised.
+func BuildDiscordMessage(event domain.AlertEvent, appBaseURL string) DiscordMessage {
+	embed := DiscordEmbed{
+		Title:       truncate(event.Severity.Emoji()+" "+event.Title, discordTitleMaxChars),
+		Description: truncate(event.Message, discordDescriptionMaxChars),
+		URL:         targetLink(appBaseURL, event),
+		Color:       discordColor(event.Severity),
+		Footer:      DiscordEmbedFooter{Text: truncate(string(event.Type)+" · "+event.Source, discordFooterMaxChars)},
+		Timestamp:   event.Timestamp.UTC().Format(time.RFC3339),
+	}
+
+	fields := alertFields(event)
+	for i := range fields {
+		fields[i].Name = truncate(fields[i].Name, discordFieldNameMaxChars)
+		fields[i].Value = truncate(fields[i].Value, discordFieldValueMaxChars)
+	}
+	budget := discordEmbedMaxChars - len([]rune(embed.Title)) - len([]rune(embed.Description)) - len([]rune(embed.Footer.Text))
+	for _, field := range fitFields(fields, discordMaxFields, budget) {
+		embed.Fields = append(embed.Fields, DiscordEmbedField{
+			Name:   field.Name,
+			Value:  field.Value,
+			Inline: len([]rune(field.Value)) <= discordInlineMaxChars,
+		})
+	}
+
+	return DiscordMessage{
+		Username:        "UpTrack",
+		Embeds:          []DiscordEmbed{embed},
+		AllowedMentions: discordNoMentions(),
+	}
+}
+
+// discordColor turns the severity hex colour into the integer Discord expects
+func discordColor(severity domain.AlertSeverity) int {
+	color, err := strconv.ParseInt(strings.TrimPrefix(severity.Color(), "#"), 16, 32)
+	if err != nil {
+		return 0
+	}
+	return int(color)
+}
+
+// discordNoMentions keeps an @everyone inside an error message from pinging the whole server
+func discordNoMentions() map[string]interface{} {
+	return map[string]interface{}{"parse": []string{}}
+}
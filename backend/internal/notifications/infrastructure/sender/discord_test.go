@@ -0,0 +1,88 @@
+package sender
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"uptrackai/internal/notifications/domain"
+)
+
+func TestDiscordSender_SendEvent(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := NewDiscordSender("https://app.example.com").SendEvent(server.URL, monitoringAlert()); err != nil {
+		t.Fatalf("Expected delivery, got %v", err)
+	}
+
+	var message DiscordMessage
+	if err := json.Unmarshal(body, &message); err != nil {
+		t.Fatalf("Expected JSON, got %v", err)
+	}
+	if len(message.Embeds) != 1 {
+		t.Fatalf("Expected one embed, got %s", body)
+	}
+	embed := message.Embeds[0]
+	if embed.Color != 0xE01E5A {
+		t.Errorf("Expected the critical colour, got %#x", embed.Color)
+	}
+	if embed.Title != "🚨 Status Change: API" || embed.URL != "https://app.example.com/target/target-123" {
+		t.Errorf("Unexpected title/link %q %q", embed.Title, embed.URL)
+	}
+	if len(embed.Fields) == 0 || embed.Fields[0].Name != "Status" || embed.Fields[0].Value != "DOWN" || !embed.Fields[0].Inline {
+		t.Errorf("Expected the status as first inline field, got %+v", embed.Fields)
+	}
+	if _, ok := message.AllowedMentions["parse"]; !ok {
+		t.Error("Expected mentions to be disabled")
+	}
+}
+
+func TestBuildDiscordMessage_RespectsEmbedLimits(t *testing.T) {
+	metadata := map[string]string{}
+	for i := 0; i < 40; i++ {
+		metadata["key_"+strconv.Itoa(i)] = strings.Repeat("v", 2000)
+	}
+	event := *domain.NewAlertEvent("user-1", strings.Repeat("t", 300), strings.Repeat("m", 5000), domain.SeverityInfo, domain.SeverityOk,
+		"System", domain.AlertTypeSystem, metadata)
+
+	embed := BuildDiscordMessage(event, "").Embeds[0]
+	total := len([]rune(embed.Title)) + len([]rune(embed.Description)) + len([]rune(embed.Footer.Text))
+	for _, field := range embed.Fields {
+		if len([]rune(field.Value)) > discordFieldValueMaxChars {
+			t.Errorf("Field %s over %d chars", field.Name, discordFieldValueMaxChars)
+		}
+		total += len([]rune(field.Name)) + len([]rune(field.Value))
+	}
+	if len([]rune(embed.Title)) > discordTitleMaxChars || len([]rune(embed.Description)) > discordDescriptionMaxChars {
+		t.Errorf("Expected title and description truncated")
+	}
+	if total > discordEmbedMaxChars || len(embed.Fields) > discordMaxFields {
+		t.Errorf("Expected the embed within limits, got %d chars and %d fields", total, len(embed.Fields))
+	}
+	if last := embed.Fields[len(embed.Fields)-1]; !strings.HasSuffix(last.Value, "more fields omitted") {
+		t.Errorf("Expected the last field to summarise the rest, got %+v", last)
+	}
+	if embed.URL != "" {
+		t.Errorf("Expected no link without a target, got %s", embed.URL)
+	}
+}
+
+func TestDiscordSender_Non2xxFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"embeds": ["0"]}`)
+	}))
+	defer server.Close()
+
+	if err := NewDiscordSender("").SendEvent(server.URL, monitoringAlert()); err == nil || !strings.Contains(err.Error(), "embeds") {
+		t.Errorf("Expected the Discord error to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,308 @@
+package sender
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"io"
+	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"uptrackai/internal/notifications/domain"
+)
+
+// SMTP connection security modes
+const (
+	SMTPSecurityStartTLS = "starttls" // Plain connection upgraded with STARTTLS (usually port 587)
+	SMTPSecurityTLS      = "tls"      // Implicit TLS from the first byte (usually port 465)
+	SMTPSecurityNone     = "none"     // Local relays and development only
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // Empty = no AUTH
+	Password string
+	From     string // e.g. "UpTrack <alerts@example.com>"
+	Security string // starttls | tls | none
+	// TLSConfig overrides the default (ServerName = Host), e.g. to trust a private CA
+	TLSConfig *tls.Config
+}
+
+func (c SMTPConfig) address() string {
+	return net.JoinHostPort(c.Host, strconv.Itoa(c.Port))
+}
+
+func (c SMTPConfig) tlsConfig() *tls.Config {
+	if c.TLSConfig != nil {
+		return c.TLSConfig
+	}
+	return &tls.Config{ServerName: c.Host, MinVersion: tls.VersionTLS12}
+}
+
+// Validate checks the configuration once at startup instead of failing on every alert
+func (c SMTPConfig) Validate() error {
+	if c.Host == "" || c.Port <= 0 {
+		return fmt.Errorf("smtp host and port are required")
+	}
+	if _, err := mail.ParseAddress(c.From); err != nil {
+		return fmt.Errorf("invalid smtp from address %q: %w", c.From, err)
+	}
+	switch c.Security {
+	case SMTPSecurityStartTLS, SMTPSecurityTLS, SMTPSecurityNone:
+		return nil
+	}
+	return fmt.Errorf("invalid smtp security mode %q (use starttls, tls or none)", c.Security)
+}
+
+// EmailSender delivers alerts as multipart (plain text + HTML) emails over SMTP
+type EmailSender struct {
+	config  SMTPConfig
+	timeout time.Duration
+}
+
+func NewEmailSender(config SMTPConfig) *EmailSender {
+	return &EmailSender{
+		config:  config,
+		timeout: 15 * time.Second,
+	}
+}
+
+// Send delivers a plain text notification
+func (s *EmailSender) Send(destination string, message string) error {
+	return s.SendMail(destination, "UpTrack notification", message, "")
+}
+
+// SendEvent renders the alert into a plain text and an HTML body
+func (s *EmailSender) SendEvent(destination string, event domain.AlertEvent) error {
+	subject := fmt.Sprintf("[%s] %s", event.Severity, event.Title)
+	text := renderAlertText(event)
+	html, err := renderAlertHTML(event)
+	if err != nil {
+		return fmt.Errorf("failed to render alert email: %w", err)
+	}
+	return s.SendMail(destination, subject, text, html)
+}
+
+// SendMail sends a single message; an empty htmlBody sends a text-only email
+func (s *EmailSender) SendMail(to string, subject string, textBody string, htmlBody string) error {
+	from, err := mail.ParseAddress(s.config.From)
+	if err != nil {
+		return fmt.Errorf("invalid smtp from address: %w", err)
+	}
+	message, err := buildMessage(from, to, subject, textBody, htmlBody)
+	if err != nil {
+		return err
+	}
+
+	client, err := s.dial()
+	if err != nil {
+		log.Printf("❌ Failed to connect to SMTP server %s: %v", s.config.address(), err)
+		return err
+	}
+	defer client.Close()
+
+	if s.config.Security == SMTPSecurityStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return fmt.Errorf("smtp server %s does not support STARTTLS", s.config.Host)
+		}
+		if err := client.StartTLS(s.config.tlsConfig()); err != nil {
+			return fmt.Errorf("smtp starttls failed: %w", err)
+		}
+	}
+	if s.config.Username != "" {
+		auth := smtp.PlainAuth("", s.config.Username, s.config.Password, s.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("smtp auth failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("smtp MAIL FROM rejected: %w", err)
+	}
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("smtp RCPT TO rejected: %w", err)
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("smtp DATA rejected: %w", err)
+	}
+	if _, err := writer.Write(message); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write email body: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("smtp server rejected the message: %w", err)
+	}
+	if err := client.Quit(); err != nil {
+		log.Printf("⚠️ SMTP QUIT failed after delivery to %s: %v", to, err)
+	}
+
+	log.Printf("✅ Email sent to %s", to)
+	return nil
+}
+
+func (s *EmailSender) dial() (*smtp.Client, error) {
+	dialer := &net.Dialer{Timeout: s.timeout}
+
+	var conn net.Conn
+	var err error
+	if s.config.Security == SMTPSecurityTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", s.config.address(), s.config.tlsConfig())
+	} else {
+		conn, err = dialer.Dial("tcp", s.config.address())
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Bound the whole conversation so a stuck server cannot hold the notification worker
+	conn.SetDeadline(time.Now().Add(s.timeout))
+
+	client, err := smtp.NewClient(conn, s.config.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+// buildMessage writes the RFC 5322 message: multipart/alternative when there is an HTML body
+func buildMessage(from *mail.Address, to string, subject string, textBody string, htmlBody string) ([]byte, error) {
+	var buf bytes.Buffer
+	headers := []string{
+		"From: " + from.String(),
+		"To: " + to,
+		"Subject: " + mime.QEncoding.Encode("utf-8", subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"Message-ID: " + messageID(from.Address),
+		"MIME-Version: 1.0",
+	}
+
+	if htmlBody == "" {
+		headers = append(headers, "Content-Type: text/plain; charset=utf-8", "Content-Transfer-Encoding: quoted-printable")
+		buf.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+		if err := writeQuotedPrintable(&buf, textBody); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", textBody},
+		{"text/html; charset=utf-8", htmlBody},
+	} {
+		writer, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(writer, part.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	headers = append(headers, "Content-Type: multipart/alternative; boundary="+parts.Boundary())
+	buf.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(w io.Writer, content string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+func messageID(fromAddress string) string {
+	random := make([]byte, 12)
+	rand.Read(random)
+	domainPart := fromAddress[strings.LastIndex(fromAddress, "@")+1:]
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(random), domainPart)
+}
+
+func renderAlertText(event domain.AlertEvent) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\n\n", event.Title)
+	fmt.Fprintf(&b, "%s\n\n", event.Message)
+	fmt.Fprintf(&b, "Severity: %s -> %s\n", event.PreviousSeverity, event.Severity)
+	fmt.Fprintf(&b, "Source: %s\n", event.Source)
+	fmt.Fprintf(&b, "Type: %s\n", event.Type)
+	fmt.Fprintf(&b, "Time: %s\n", event.Timestamp.UTC().Format(time.RFC1123))
+	for _, key := range sortedKeys(event.Metadata) {
+		fmt.Fprintf(&b, "%s: %s\n", key, event.Metadata[key])
+	}
+	return b.String()
+}
+
+var alertEmailTemplate = template.Must(template.New("alert").Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #1f2933;">
+  <h2 style="border-left: 6px solid {{.Color}}; padding-left: 10px;">{{.Title}}</h2>
+  <p>{{.Message}}</p>
+  <table cellpadding="6" style="border-collapse: collapse;">
+    <tr><td><strong>Severity</strong></td><td>{{.PreviousSeverity}} &rarr; <strong style="color: {{.Color}};">{{.Severity}}</strong></td></tr>
+    <tr><td><strong>Source</strong></td><td>{{.Source}}</td></tr>
+    <tr><td><strong>Type</strong></td><td>{{.Type}}</td></tr>
+    <tr><td><strong>Time</strong></td><td>{{.Time}}</td></tr>
+    {{range .Metadata}}<tr><td><strong>{{.Key}}</strong></td><td>{{.Value}}</td></tr>
+    {{end}}
+  </table>
+  <p style="color: #7b8794; font-size: 12px;">Sent by UpTrack</p>
+</body>
+</html>`))
+
+type alertEmailField struct {
+	Key   string
+	Value string
+}
+
+func renderAlertHTML(event domain.AlertEvent) (string, error) {
+	metadata := make([]alertEmailField, 0, len(event.Metadata))
+	for _, key := range sortedKeys(event.Metadata) {
+		metadata = append(metadata, alertEmailField{Key: key, Value: event.Metadata[key]})
+	}
+
+	var b strings.Builder
+	err := alertEmailTemplate.Execute(&b, map[string]interface{}{
+		"Title":            event.Title,
+		"Message":          event.Message,
+		"Severity":         event.Severity.String(),
+		"PreviousSeverity": event.PreviousSeverity.String(),
+		"Source":           event.Source,
+		"Type":             string(event.Type),
+		"Time":             event.Timestamp.UTC().Format(time.RFC1123),
+		"Color":            event.Severity.Color(),
+		"Metadata":         metadata,
+	})
+	return b.String(), err
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,233 @@
+package sender
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/http/httptest"
+	"net/mail"
+	"strings"
+	"testing"
+	"uptrackai/internal/notifications/domain"
+)
+
+// fakeSMTPServer is a minimal in-process SMTP server: EHLO, STARTTLS, AUTH PLAIN, MAIL, RCPT, DATA, QUIT
+type fakeSMTPServer struct {
+	listener  net.Listener
+	tlsConfig *tls.Config
+	implicit  bool
+
+	messages chan receivedMail
+}
+
+type receivedMail struct {
+	from, to string
+	data     string
+	secure   bool
+	authed   bool
+}
+
+func newFakeSMTPServer(t *testing.T, implicitTLS bool) (*fakeSMTPServer, *tls.Config) {
+	// Borrow the httptest certificate (valid for 127.0.0.1) instead of generating one
+	certServer := httptest.NewTLSServer(nil)
+	t.Cleanup(certServer.Close)
+	serverTLS := &tls.Config{Certificates: certServer.TLS.Certificates}
+	pool := x509.NewCertPool()
+	pool.AddCert(certServer.Certificate())
+	clientTLS := &tls.Config{RootCAs: pool, ServerName: "127.0.0.1"}
+
+	var listener net.Listener
+	var err error
+	if implicitTLS {
+		listener, err = tls.Listen("tcp", "127.0.0.1:0", serverTLS)
+	} else {
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+	}
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	server := &fakeSMTPServer{listener: listener, tlsConfig: serverTLS, implicit: implicitTLS, messages: make(chan receivedMail, 1)}
+	go server.serve()
+	return server, clientTLS
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) serve() {
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	mail := receivedMail{secure: s.implicit}
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+	reply("220 fake.smtp ready")
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"):
+			io.WriteString(conn, "250-fake.smtp\r\n")
+			if !mail.secure {
+				io.WriteString(conn, "250-STARTTLS\r\n")
+			}
+			reply("250 AUTH PLAIN")
+		case command == "STARTTLS":
+			reply("220 go ahead")
+			tlsConn := tls.Server(conn, s.tlsConfig)
+			if err := tlsConn.Handshake(); err != nil {
+				return
+			}
+			conn = tlsConn
+			reader = bufio.NewReader(conn)
+			mail.secure = true
+		case strings.HasPrefix(command, "AUTH PLAIN"):
+			mail.authed = true
+			reply("235 authenticated")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			mail.from = strings.Trim(strings.TrimSpace(line)[len("MAIL FROM:"):], "<>")
+			reply("250 ok")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			mail.to = strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>")
+			reply("250 ok")
+		case command == "DATA":
+			reply("354 end with .")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			mail.data = data.String()
+			reply("250 queued")
+			s.messages <- mail
+		case command == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func testAlertEvent() domain.AlertEvent {
+	return *domain.NewAlertEvent("user-1", "Target Down: API", "connection refused <script>",
+		domain.SeverityCritical, domain.SeverityOk, "Target: API", domain.AlertTypeMonitoring,
+		map[string]string{domain.MetadataErrorClass: "connect_refused"})
+}
+
+func TestEmailSender_StartTLSMultipart(t *testing.T) {
+	server, clientTLS := newFakeSMTPServer(t, false)
+	s := NewEmailSender(SMTPConfig{
+		Host:      "127.0.0.1",
+		Port:      server.port(),
+		Username:  "alerts",
+		Password:  "secret",
+		From:      "UpTrack <alerts@example.com>",
+		Security:  SMTPSecurityStartTLS,
+		TLSConfig: clientTLS,
+	})
+
+	if err := s.SendEvent("oncall@example.com", testAlertEvent()); err != nil {
+		t.Fatalf("Expected delivery, got %v", err)
+	}
+
+	received := <-server.messages
+	if !received.secure || !received.authed {
+		t.Errorf("Expected STARTTLS before AUTH, got secure=%v authed=%v", received.secure, received.authed)
+	}
+	if received.from != "alerts@example.com" || received.to != "oncall@example.com" {
+		t.Errorf("Unexpected envelope %s -> %s", received.from, received.to)
+	}
+
+	message, err := mail.ReadMessage(strings.NewReader(received.data))
+	if err != nil {
+		t.Fatalf("Expected a parseable message, got %v", err)
+	}
+	subject, _ := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if subject != "[CRITICAL] Target Down: API" {
+		t.Errorf("Unexpected subject %q", subject)
+	}
+	mediaType, params, _ := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if mediaType != "multipart/alternative" {
+		t.Fatalf("Expected multipart/alternative, got %s", mediaType)
+	}
+
+	bodies := map[string]string{}
+	parts := multipart.NewReader(message.Body, params["boundary"])
+	for {
+		part, err := parts.NextPart()
+		if err != nil {
+			break
+		}
+		content, _ := io.ReadAll(quotedprintable.NewReader(part))
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		bodies[partType] = string(content)
+	}
+	if !strings.Contains(bodies["text/plain"], "Severity: OK -> CRITICAL") || !strings.Contains(bodies["text/plain"], "error_class: connect_refused") {
+		t.Errorf("Unexpected text body %q", bodies["text/plain"])
+	}
+	if !strings.Contains(bodies["text/html"], "connection refused &lt;script&gt;") || !strings.Contains(bodies["text/html"], "connect_refused") {
+		t.Errorf("Expected an escaped HTML body with metadata, got %q", bodies["text/html"])
+	}
+}
+
+func TestEmailSender_ImplicitTLSPlainText(t *testing.T) {
+	server, clientTLS := newFakeSMTPServer(t, true)
+	s := NewEmailSender(SMTPConfig{
+		Host:      "127.0.0.1",
+		Port:      server.port(),
+		From:      "alerts@example.com",
+		Security:  SMTPSecurityTLS,
+		TLSConfig: clientTLS,
+	})
+
+	if err := s.Send("oncall@example.com", "hello"); err != nil {
+		t.Fatalf("Expected delivery, got %v", err)
+	}
+
+	received := <-server.messages
+	if !received.secure || received.authed {
+		t.Errorf("Expected TLS without AUTH, got secure=%v authed=%v", received.secure, received.authed)
+	}
+	message, _ := mail.ReadMessage(strings.NewReader(received.data))
+	if !strings.HasPrefix(message.Header.Get("Content-Type"), "text/plain") {
+		t.Errorf("Expected a text-only email, got %s", message.Header.Get("Content-Type"))
+	}
+}
+
+func TestSMTPConfig_Validate(t *testing.T) {
+	valid := SMTPConfig{Host: "smtp.example.com", Port: 587, From: "UpTrack <alerts@example.com>", Security: SMTPSecurityStartTLS}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("Expected valid config, got %v", err)
+	}
+	for _, config := range []SMTPConfig{
+		{Port: 587, From: "alerts@example.com", Security: SMTPSecurityTLS},
+		{Host: "smtp.example.com", Port: 587, From: "not an address", Security: SMTPSecurityTLS},
+		{Host: "smtp.example.com", Port: 587, From: "alerts@example.com", Security: "ssl3"},
+	} {
+		if err := config.Validate(); err == nil {
+			t.Errorf("Expected %+v to be rejected", config)
+		}
+	}
+}
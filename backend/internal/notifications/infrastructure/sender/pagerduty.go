@@ -0,0 +1,212 @@
+package sender
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"uptrackai/internal/notifications/domain"
+)
+
+// DefaultPagerDutyEventsURL is the Events API v2 enqueue endpoint
+const DefaultPagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// Events API v2 event actions
+const (
+	PagerDutyActionTrigger     = "trigger"
+	PagerDutyActionAcknowledge = "acknowledge"
+	PagerDutyActionResolve     = "resolve"
+)
+
+const (
+	pagerDutySummaryMaxChars  = 1024
+	pagerDutyDedupKeyMaxChars = 255
+)
+
+var pagerDutySeverities = map[domain.AlertSeverity]string{
+	domain.SeverityOk:       "info",
+	domain.SeverityWarning:  "warning",
+	domain.SeverityCritical: "critical",
+	domain.SeverityInfo:     "info",
+}
+
+// PagerDutySender opens, acknowledges and resolves incidents with Events API v2 payloads.
+// Escalation and on-call routing happen on the receiving side, keyed by the dedup key.
+type PagerDutySender struct {
+	client     *http.Client
+	endpoint   string // Default endpoint; a channel can override it (Opsgenie-compatible receivers)
+	appBaseURL string
+}
+
+func NewPagerDutySender(endpoint string, appBaseURL string) *PagerDutySender {
+	if endpoint == "" {
+		endpoint = DefaultPagerDutyEventsURL
+	}
+	return &PagerDutySender{
+		client:     newHookClient(),
+		endpoint:   endpoint,
+		appBaseURL: appBaseURL,
+	}
+}
+
+// Send triggers a standalone event; the receiver assigns the dedup key
+func (s *PagerDutySender) Send(destination string, message string) error {
+	config, err := domain.ParsePagerDutyConfig(destination)
+	if err != nil {
+		return err
+	}
+	return postJSON(s.client, s.endpointFor(config), PagerDutyEvent{
+		RoutingKey:  config.RoutingKey,
+		EventAction: PagerDutyActionTrigger,
+		Payload: &PagerDutyPayload{
+			Summary:  truncate(message, pagerDutySummaryMaxChars),
+			Source:   "UpTrack",
+			Severity: "info",
+		},
+		Client: "UpTrack",
+	})
+}
+
+func (s *PagerDutySender) SendEvent(destination string, event domain.AlertEvent) error {
+	config, err := domain.ParsePagerDutyConfig(destination)
+	if err != nil {
+		return err
+	}
+
+	pdEvent := BuildPagerDutyEvent(config.RoutingKey, event, s.appBaseURL)
+	if pdEvent.EventAction == "" {
+		log.Printf("ℹ️ PagerDuty skipped informational alert for %s", event.Source)
+		return nil
+	}
+	if err := postJSON(s.client, s.endpointFor(config), pdEvent); err != nil {
+		log.Printf("❌ Failed to send PagerDuty %s event: %v", pdEvent.EventAction, err)
+		return err
+	}
+	log.Printf("✅ PagerDuty %s event sent for %s", pdEvent.EventAction, event.Source)
+	return nil
+}
+
+func (s *PagerDutySender) endpointFor(config domain.PagerDutyConfig) string {
+	if config.URL != "" {
+		return config.URL
+	}
+	return s.endpoint
+}
+
+type PagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key,omitempty"`
+	Payload     *PagerDutyPayload `json:"payload,omitempty"` // Only for trigger events
+	Client      string            `json:"client,omitempty"`
+	ClientURL   string            `json:"client_url,omitempty"`
+	Links       []PagerDutyLink   `json:"links,omitempty"`
+}
+
+type PagerDutyPayload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"` // critical | error | warning | info
+	Timestamp     string            `json:"timestamp,omitempty"`
+	Component     string            `json:"component,omitempty"`
+	Group         string            `json:"group,omitempty"`
+	Class         string            `json:"class,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+type PagerDutyLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+// BuildPagerDutyEvent maps an alert to an Events API v2 event. All the alerts of a target share
+// its dedup key, so DOWN opens the incident, DEGRADED/DOWN updates it and UP resolves it.
+// The action is empty for informational alerts, which are not sent.
+func BuildPagerDutyEvent(routingKey string, event domain.AlertEvent, appBaseURL string) PagerDutyEvent {
+	pdEvent := PagerDutyEvent{
+		RoutingKey:  routingKey,
+		EventAction: PagerDutyEventAction(event),
+		DedupKey:    PagerDutyDedupKey(event),
+		Client:      "UpTrack",
+		ClientURL:   appBaseURL,
+	}
+	if pdEvent.EventAction != PagerDutyActionTrigger {
+		return pdEvent
+	}
+
+	details := make(map[string]string, len(event.Metadata)+1)
+	for key, value := range event.Metadata {
+		details[key] = value
+	}
+	details["message"] = event.Message
+
+	pdEvent.Payload = &PagerDutyPayload{
+		Summary:       truncate(event.Title+": "+event.Message, pagerDutySummaryMaxChars),
+		Source:        pagerDutySource(event),
+		Severity:      pagerDutySeverities[event.Severity],
+		Timestamp:     event.Timestamp.UTC().Format(time.RFC3339),
+		Component:     event.Source,
+		Group:         string(event.Type),
+		Class:         event.ErrorClass(),
+		CustomDetails: details,
+	}
+	if link := targetLink(appBaseURL, event); link != "" {
+		pdEvent.Links = []PagerDutyLink{{Href: link, Text: "Open target in UpTrack"}}
+	}
+	return pdEvent
+}
+
+// PagerDutyEventAction: acknowledgements and manual resolutions come from the incident
+// workflow; otherwise a recovery resolves and a warning or critical alert (re)triggers.
+// Informational alerts (e.g. latency forecasts) must not page anyone: the action is "".
+func PagerDutyEventAction(event domain.AlertEvent) string {
+	switch {
+	case event.IncidentAction() == domain.IncidentActionAcknowledged:
+		return PagerDutyActionAcknowledge
+	case event.IncidentAction() == domain.IncidentActionResolved, event.Severity == domain.SeverityOk:
+		return PagerDutyActionResolve
+	case event.Severity == domain.SeverityInfo:
+		return ""
+	default:
+		return PagerDutyActionTrigger
+	}
+}
+
+// PagerDutyDedupKey derives the key from the target ID. Status alerts share one key per target;
+// other alert types (SLO, forecasts) get their own so resolving one does not close the other,
+// and each SLO of a target has its own incident. Infrastructure incidents have no target: their
+// key is the common cause, so the recovery sent when the outage clears resolves them.
+func PagerDutyDedupKey(event domain.AlertEvent) string {
+	kind := strings.ToLower(string(event.Type))
+	var key string
+	switch {
+	case event.Type == domain.AlertTypeInfrastructure && event.InfrastructureCause() != "":
+		key = "uptrack:infrastructure:" + event.InfrastructureCause()
+	case event.TargetID() != "" && event.Type == domain.AlertTypeMonitoring:
+		key = "uptrack:target:" + event.TargetID()
+	case event.TargetID() != "" && event.SLOID() != "":
+		key = fmt.Sprintf("uptrack:%s:%s:%s", kind, event.TargetID(), event.SLOID())
+	case event.TargetID() != "":
+		key = fmt.Sprintf("uptrack:%s:%s", kind, event.TargetID())
+	case event.IncidentID() != "":
+		key = "uptrack:incident:" + event.IncidentID()
+	default:
+		key = fmt.Sprintf("uptrack:%s:%s", kind, event.Source)
+	}
+	return truncate(key, pagerDutyDedupKeyMaxChars)
+}
+
+// pagerDutySource is the affected system: the target host when known
+func pagerDutySource(event domain.AlertEvent) string {
+	if raw := event.Metadata[domain.MetadataURL]; raw != "" {
+		if parsed, err := url.Parse(raw); err == nil && parsed.Host != "" {
+			return parsed.Host
+		}
+	}
+	if event.Source != "" {
+		return event.Source
+	}
+	return "UpTrack"
+}
@@ -0,0 +1,143 @@
+package sender
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"uptrackai/internal/notifications/domain"
+)
+
+// pagerDutyStandIn records the Events API v2 requests it receives
+func pagerDutyStandIn(t *testing.T) (*httptest.Server, *[]PagerDutyEvent) {
+	var events []PagerDutyEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event PagerDutyEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		events = append(events, event)
+		w.WriteHeader(http.StatusAccepted)
+		io.WriteString(w, `{"status":"success","message":"Event processed","dedup_key":"`+event.DedupKey+`"}`)
+	}))
+	t.Cleanup(server.Close)
+	return server, &events
+}
+
+func TestPagerDutySender_DownUpCycleSharesDedupKey(t *testing.T) {
+	server, events := pagerDutyStandIn(t)
+	s := NewPagerDutySender(server.URL, "https://app.example.com")
+
+	down := monitoringAlert()
+	up := *domain.NewAlertEvent("user-1", "Status Change: API", "Target API is now UP",
+		domain.SeverityOk, domain.SeverityCritical, "Target: API", domain.AlertTypeMonitoring,
+		map[string]string{domain.MetadataTargetID: "target-123", domain.MetadataStatus: "UP"})
+
+	for _, event := range []domain.AlertEvent{down, up} {
+		if err := s.SendEvent("R0ut1ngK3y", event); err != nil {
+			t.Fatalf("Expected delivery, got %v", err)
+		}
+	}
+
+	if len(*events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(*events))
+	}
+	trigger, resolve := (*events)[0], (*events)[1]
+	if trigger.EventAction != PagerDutyActionTrigger || resolve.EventAction != PagerDutyActionResolve {
+		t.Errorf("Expected trigger then resolve, got %s then %s", trigger.EventAction, resolve.EventAction)
+	}
+	if trigger.DedupKey == "" || trigger.DedupKey != resolve.DedupKey || !strings.Contains(trigger.DedupKey, "target-123") {
+		t.Errorf("Expected the same target dedup key, got %q and %q", trigger.DedupKey, resolve.DedupKey)
+	}
+	if trigger.RoutingKey != "R0ut1ngK3y" || trigger.Payload == nil {
+		t.Fatalf("Unexpected trigger %+v", trigger)
+	}
+	if trigger.Payload.Severity != "critical" || trigger.Payload.Source != "api.example.com" || trigger.Payload.Class != "connect_refused" {
+		t.Errorf("Unexpected payload %+v", trigger.Payload)
+	}
+	if len(trigger.Links) != 1 || trigger.Links[0].Href != "https://app.example.com/target/target-123" {
+		t.Errorf("Expected a link to the target, got %+v", trigger.Links)
+	}
+	if resolve.Payload != nil {
+		t.Errorf("Expected no payload on resolve, got %+v", resolve.Payload)
+	}
+}
+
+func TestPagerDutySender_AcknowledgeWithChannelEndpoint(t *testing.T) {
+	server, events := pagerDutyStandIn(t)
+	// The default endpoint is unreachable: the channel URL must win
+	s := NewPagerDutySender("http://127.0.0.1:1/unused", "")
+
+	ack := *domain.NewAlertEvent("user-1", "Incident Acknowledged: API", "The incident on API was acknowledged",
+		domain.SeverityInfo, domain.SeverityCritical, "Target: API", domain.AlertTypeMonitoring,
+		map[string]string{domain.MetadataTargetID: "target-123", domain.MetadataIncidentAction: domain.IncidentActionAcknowledged})
+
+	destination := `{"routing_key":"R0ut1ngK3y","url":"` + server.URL + `"}`
+	if err := s.SendEvent(destination, ack); err != nil {
+		t.Fatalf("Expected delivery, got %v", err)
+	}
+	if len(*events) != 1 || (*events)[0].EventAction != PagerDutyActionAcknowledge || (*events)[0].DedupKey != PagerDutyDedupKey(monitoringAlert()) {
+		t.Errorf("Expected an acknowledge for the target incident, got %+v", *events)
+	}
+}
+
+func TestPagerDutyDedupKey_SeparatesAlertTypes(t *testing.T) {
+	status := monitoringAlert()
+	slo := *domain.NewAlertEvent("user-1", "SLO burn", "burning", domain.SeverityWarning, domain.SeverityOk,
+		"Target: API", domain.AlertTypeSLO, map[string]string{domain.MetadataTargetID: "target-123"})
+	system := *domain.NewAlertEvent("user-1", "Disk", "low", domain.SeverityWarning, domain.SeverityOk,
+		"System", domain.AlertTypeSystem, nil)
+
+	if PagerDutyDedupKey(status) == PagerDutyDedupKey(slo) {
+		t.Error("Expected SLO alerts not to share the status incident")
+	}
+	if key := PagerDutyDedupKey(system); key != "uptrack:system:System" {
+		t.Errorf("Unexpected fallback key %q", key)
+	}
+
+	// Two SLOs of the same target: one recovering must not resolve the other's incident
+	latencySLO := *domain.NewAlertEvent("user-1", "SLO burn", "burning", domain.SeverityWarning, domain.SeverityOk,
+		"Target: API", domain.AlertTypeSLO, map[string]string{domain.MetadataTargetID: "target-123", domain.MetadataSLOID: "slo-1"})
+	availabilitySLO := *domain.NewAlertEvent("user-1", "SLO burn", "burning", domain.SeverityWarning, domain.SeverityOk,
+		"Target: API", domain.AlertTypeSLO, map[string]string{domain.MetadataTargetID: "target-123", domain.MetadataSLOID: "slo-2"})
+	if PagerDutyDedupKey(latencySLO) == PagerDutyDedupKey(availabilitySLO) {
+		t.Errorf("Expected one key per SLO, got %q for both", PagerDutyDedupKey(latencySLO))
+	}
+
+	// Infrastructure incidents are keyed by their cause, whatever the wording of each alert
+	infrastructure := *domain.NewAlertEvent("user-1", "Infrastructure Incident", "3 of your targets", domain.SeverityCritical, domain.SeverityOk,
+		"Infrastructure: asn:13335", domain.AlertTypeInfrastructure, map[string]string{domain.MetadataInfrastructureCause: "asn:13335"})
+	if key := PagerDutyDedupKey(infrastructure); key != "uptrack:infrastructure:asn:13335" {
+		t.Errorf("Unexpected infrastructure key %q", key)
+	}
+}
+
+func TestPagerDutySender_InformationalAlertsDoNotPage(t *testing.T) {
+	server, events := pagerDutyStandIn(t)
+	s := NewPagerDutySender(server.URL, "")
+
+	forecast := *domain.NewAlertEvent("user-1", "Latency Trending Up: API", "p95 crosses 800ms in 5 days",
+		domain.SeverityInfo, domain.SeverityOk, "Target: API", domain.AlertTypeForecast,
+		map[string]string{domain.MetadataTargetID: "target-123"})
+	if err := s.SendEvent("R0ut1ngK3y", forecast); err != nil {
+		t.Fatalf("Expected the alert to be skipped without error, got %v", err)
+	}
+	if len(*events) != 0 {
+		t.Errorf("Expected no PagerDuty event for an informational alert, got %+v", *events)
+	}
+}
+
+func TestPagerDutySender_RejectedEventFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		io.WriteString(w, `{"status":"invalid event","errors":["'routing_key' is invalid"]}`)
+	}))
+	defer server.Close()
+
+	if err := NewPagerDutySender(server.URL, "").SendEvent("bad", monitoringAlert()); err == nil || !strings.Contains(err.Error(), "routing_key") {
+		t.Errorf("Expected the rejection to be reported, got %v", err)
+	}
+}
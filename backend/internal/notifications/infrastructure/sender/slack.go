@@ -0,0 +1,122 @@
+package sender
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"uptrackai/internal/notifications/domain"
+)
+
+// Block Kit limits (https://api.slack.com/reference/block-kit/blocks)
+const (
+	slackHeaderMaxChars  = 150
+	slackSectionMaxChars = 3000
+	slackFieldMaxChars   = 2000
+	slackMaxFields       = 10
+)
+
+// SlackSender posts alerts to a Slack incoming webhook (the channel value) laid out with Block Kit
+type SlackSender struct {
+	client     *http.Client
+	appBaseURL string // Web app URL for the "Open target" button ("" = no button)
+}
+
+func NewSlackSender(appBaseURL string) *SlackSender {
+	return &SlackSender{
+		client:     newHookClient(),
+		appBaseURL: appBaseURL,
+	}
+}
+
+// Send posts a plain text message
+func (s *SlackSender) Send(destination string, message string) error {
+	return postJSON(s.client, destination, map[string]string{"text": message})
+}
+
+func (s *SlackSender) SendEvent(destination string, event domain.AlertEvent) error {
+	if err := postJSON(s.client, destination, BuildSlackMessage(event, s.appBaseURL)); err != nil {
+		log.Printf("❌ Failed to send Slack message: %v", err)
+		return err
+	}
+	log.Printf("✅ Slack message sent for %s", event.Source)
+	return nil
+}
+
+type SlackMessage struct {
+	Text        string            `json:"text"` // Fallback for notifications and clients without blocks
+	Attachments []SlackAttachment `json:"attachments"`
+}
+
+// SlackAttachment only carries the severity colour bar around the blocks
+type SlackAttachment struct {
+	Color  string                   `json:"color"`
+	Blocks []map[string]interface{} `json:"blocks"`
+}
+
+// BuildSlackMessage lays out the alert: header, message, status fields, context and a link to the target
+func BuildSlackMessage(event domain.AlertEvent, appBaseURL string) SlackMessage {
+	emoji := event.Severity.Emoji()
+
+	fields := make([]map[string]interface{}, 0, slackMaxFields)
+	for _, field := range fitFields(alertFields(event), slackMaxFields, slackMaxFields*slackFieldMaxChars) {
+		value := slackEscape(field.Value)
+		switch field.Name {
+		case "Status":
+			value = emoji + " " + value
+		case "Target URL":
+			value = "<" + value + ">"
+		}
+		fields = append(fields, slackField(field.Name, value))
+	}
+
+	blocks := []map[string]interface{}{
+		{
+			"type": "header",
+			"text": map[string]interface{}{"type": "plain_text", "text": truncate(emoji+" "+event.Title, slackHeaderMaxChars), "emoji": true},
+		},
+		{
+			"type": "section",
+			"text": map[string]string{"type": "mrkdwn", "text": truncate(slackEscape(event.Message), slackSectionMaxChars)},
+		},
+		{
+			"type":   "section",
+			"fields": fields,
+		},
+		{
+			"type": "context",
+			"elements": []map[string]string{{
+				"type": "mrkdwn",
+				"text": fmt.Sprintf("%s · %s · <!date^%d^{date_short_pretty} {time}|%s>",
+					event.Type, slackEscape(event.Source), event.Timestamp.Unix(), event.Timestamp.UTC().Format("2006-01-02 15:04 MST")),
+			}},
+		},
+	}
+	if link := targetLink(appBaseURL, event); link != "" {
+		blocks = append(blocks, map[string]interface{}{
+			"type": "actions",
+			"elements": []map[string]interface{}{{
+				"type": "button",
+				"text": map[string]string{"type": "plain_text", "text": "Open target"},
+				"url":  link,
+			}},
+		})
+	}
+
+	return SlackMessage{
+		Text:        fmt.Sprintf("%s [%s] %s", emoji, event.Severity, event.Title),
+		Attachments: []SlackAttachment{{Color: event.Severity.Color(), Blocks: blocks}},
+	}
+}
+
+func slackField(label string, value string) map[string]interface{} {
+	return map[string]interface{}{
+		"type": "mrkdwn",
+		"text": truncate(fmt.Sprintf("*%s*\n%s", label, value), slackFieldMaxChars),
+	}
+}
+
+// slackEscape escapes the control characters of Slack mrkdwn
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
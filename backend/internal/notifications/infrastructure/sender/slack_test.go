@@ -0,0 +1,98 @@
+package sender
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"uptrackai/internal/notifications/domain"
+)
+
+func monitoringAlert() domain.AlertEvent {
+	return *domain.NewAlertEvent("user-1", "Status Change: API", "Target API is now DOWN (connect_refused)",
+		domain.SeverityCritical, domain.SeverityOk, "Target: API", domain.AlertTypeMonitoring,
+		map[string]string{
+			domain.MetadataTargetID:       "target-123",
+			domain.MetadataURL:            "https://api.example.com/health",
+			domain.MetadataResponseTime:   "0ms",
+			domain.MetadataStatus:         "DOWN",
+			domain.MetadataPreviousStatus: "UP",
+			domain.MetadataErrorClass:     "connect_refused",
+		})
+}
+
+func TestSlackSender_SendEvent(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	if err := NewSlackSender("https://app.example.com/").SendEvent(server.URL, monitoringAlert()); err != nil {
+		t.Fatalf("Expected delivery, got %v", err)
+	}
+
+	var message struct {
+		Text        string `json:"text"`
+		Attachments []struct {
+			Color  string                   `json:"color"`
+			Blocks []map[string]interface{} `json:"blocks"`
+		} `json:"attachments"`
+	}
+	if err := json.Unmarshal(body, &message); err != nil {
+		t.Fatalf("Expected JSON, got %v", err)
+	}
+	if message.Text != "🚨 [CRITICAL] Status Change: API" || len(message.Attachments) != 1 {
+		t.Fatalf("Unexpected message %s", body)
+	}
+	attachment := message.Attachments[0]
+	if attachment.Color != domain.SeverityCritical.Color() {
+		t.Errorf("Expected critical colour, got %s", attachment.Color)
+	}
+
+	raw := string(body)
+	for _, expected := range []string{`*Status*\n🚨 DOWN`, `*Previous status*\nUP`, `*Target URL*\n<https://api.example.com/health>`, `*Response time*\n0ms`, `https://app.example.com/target/target-123`} {
+		if !strings.Contains(raw, expected) {
+			t.Errorf("Expected %q in %s", expected, raw)
+		}
+	}
+	if last := attachment.Blocks[len(attachment.Blocks)-1]; last["type"] != "actions" {
+		t.Errorf("Expected an Open target button, got %v", last)
+	}
+}
+
+func TestBuildSlackMessage_WithoutTarget(t *testing.T) {
+	event := *domain.NewAlertEvent("user-1", strings.Repeat("x", 200), "a < b & c", domain.SeverityInfo, domain.SeverityOk,
+		"System", domain.AlertTypeSystem, nil)
+
+	message := BuildSlackMessage(event, "https://app.example.com")
+	blocks := message.Attachments[0].Blocks
+
+	for _, block := range blocks {
+		if block["type"] == "actions" {
+			t.Error("Expected no target button for a non-target alert")
+		}
+	}
+	header := blocks[0]["text"].(map[string]interface{})["text"].(string)
+	if len([]rune(header)) != slackHeaderMaxChars {
+		t.Errorf("Expected the header truncated to %d chars, got %d", slackHeaderMaxChars, len([]rune(header)))
+	}
+	if text := blocks[1]["text"].(map[string]string)["text"]; text != "a &lt; b &amp; c" {
+		t.Errorf("Expected mrkdwn escaping, got %q", text)
+	}
+}
+
+func TestSlackSender_Non2xxFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		io.WriteString(w, "no_service")
+	}))
+	defer server.Close()
+
+	if err := NewSlackSender("").SendEvent(server.URL, monitoringAlert()); err == nil || !strings.Contains(err.Error(), "no_service") {
+		t.Errorf("Expected the Slack error to be reported, got %v", err)
+	}
+}
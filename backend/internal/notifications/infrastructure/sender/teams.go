@@ -0,0 +1,160 @@
+package sender
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+	"uptrackai/internal/notifications/domain"
+)
+
+// Teams limits: incoming webhooks reject messages over ~28 KB; the card stays well below
+// so the JSON envelope and multi-byte characters always fit
+const (
+	teamsMaxPayloadBytes = 25 * 1024
+	teamsTitleMaxChars   = 200
+	teamsMessageMaxChars = 4000
+	teamsFactMaxChars    = 1000
+	teamsMaxFacts        = 20
+	teamsFactsMaxChars   = 12000
+)
+
+// Adaptive Cards only know named colours, so each severity maps to the closest style
+var teamsSeverityStyles = map[domain.AlertSeverity]string{
+	domain.SeverityOk:       "good",
+	domain.SeverityWarning:  "warning",
+	domain.SeverityCritical: "attention",
+	domain.SeverityInfo:     "accent",
+}
+
+// TeamsSender posts alerts to a Microsoft Teams incoming webhook (the channel value) as an Adaptive Card
+type TeamsSender struct {
+	client     *http.Client
+	appBaseURL string // Web app URL for the "Open target" action ("" = no action)
+}
+
+func NewTeamsSender(appBaseURL string) *TeamsSender {
+	return &TeamsSender{
+		client:     newHookClient(),
+		appBaseURL: appBaseURL,
+	}
+}
+
+// Send posts a plain text card
+func (s *TeamsSender) Send(destination string, message string) error {
+	card := newAdaptiveCard([]map[string]interface{}{teamsText(truncate(message, teamsMessageMaxChars))}, nil)
+	return postJSON(s.client, destination, teamsMessage(card))
+}
+
+func (s *TeamsSender) SendEvent(destination string, event domain.AlertEvent) error {
+	if err := postJSON(s.client, destination, BuildTeamsMessage(event, s.appBaseURL)); err != nil {
+		log.Printf("❌ Failed to send Teams message: %v", err)
+		return err
+	}
+	log.Printf("✅ Teams message sent for %s", event.Source)
+	return nil
+}
+
+type TeamsMessage struct {
+	Type        string            `json:"type"` // Always "message"
+	Attachments []TeamsAttachment `json:"attachments"`
+}
+
+type TeamsAttachment struct {
+	ContentType string                 `json:"contentType"`
+	Content     map[string]interface{} `json:"content"`
+}
+
+// BuildTeamsMessage lays out the alert: coloured header, message, facts, context and a link to the target.
+// Metadata facts are dropped from the end (with an "N more fields omitted" fact) until the card fits.
+func BuildTeamsMessage(event domain.AlertEvent, appBaseURL string) TeamsMessage {
+	fields := alertFields(event)
+	for i := range fields {
+		fields[i].Value = truncate(fields[i].Value, teamsFactMaxChars)
+	}
+
+	var message TeamsMessage
+	for budget := teamsFactsMaxChars; ; budget /= 2 {
+		message = buildTeamsCard(event, appBaseURL, fitFields(fields, teamsMaxFacts, budget))
+		if budget < teamsFactMaxChars || teamsPayloadSize(message) <= teamsMaxPayloadBytes {
+			return message
+		}
+	}
+}
+
+func buildTeamsCard(event domain.AlertEvent, appBaseURL string, fields []chatField) TeamsMessage {
+	style := teamsSeverityStyles[event.Severity]
+	if style == "" {
+		style = "default"
+	}
+
+	facts := make([]map[string]string, 0, len(fields))
+	for _, field := range fields {
+		facts = append(facts, map[string]string{"title": field.Name, "value": field.Value})
+	}
+
+	title := teamsText(truncate(event.Severity.Emoji()+" "+event.Title, teamsTitleMaxChars))
+	title["weight"] = "Bolder"
+	title["size"] = "Large"
+	title["color"] = style
+
+	context := teamsText(fmt.Sprintf("%s · %s · {{DATE(%s, SHORT)}} {{TIME(%s)}}",
+		event.Type, event.Source, teamsTimestamp(event.Timestamp), teamsTimestamp(event.Timestamp)))
+	context["isSubtle"] = true
+	context["size"] = "Small"
+
+	body := []map[string]interface{}{
+		{"type": "Container", "style": style, "bleed": true, "items": []map[string]interface{}{title}},
+		teamsText(truncate(event.Message, teamsMessageMaxChars)),
+		{"type": "FactSet", "facts": facts},
+		context,
+	}
+
+	var actions []map[string]interface{}
+	if link := targetLink(appBaseURL, event); link != "" {
+		actions = append(actions, map[string]interface{}{"type": "Action.OpenUrl", "title": "Open target", "url": link})
+	}
+	return teamsMessage(newAdaptiveCard(body, actions))
+}
+
+func newAdaptiveCard(body []map[string]interface{}, actions []map[string]interface{}) map[string]interface{} {
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"msteams": map[string]string{"width": "Full"},
+		"body":    body,
+	}
+	if len(actions) > 0 {
+		card["actions"] = actions
+	}
+	return card
+}
+
+func teamsMessage(card map[string]interface{}) TeamsMessage {
+	return TeamsMessage{
+		Type: "message",
+		Attachments: []TeamsAttachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content:     card,
+		}},
+	}
+}
+
+func teamsText(text string) map[string]interface{} {
+	return map[string]interface{}{"type": "TextBlock", "text": text, "wrap": true}
+}
+
+// teamsTimestamp is the RFC 3339 format the DATE/TIME card functions expect (rendered in the reader's timezone)
+func teamsTimestamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+func teamsPayloadSize(message TeamsMessage) int {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return 0
+	}
+	return len(body)
+}
@@ -0,0 +1,81 @@
+package sender
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"uptrackai/internal/notifications/domain"
+)
+
+func TestTeamsSender_SendEvent(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		io.WriteString(w, "1")
+	}))
+	defer server.Close()
+
+	if err := NewTeamsSender("https://app.example.com").SendEvent(server.URL, monitoringAlert()); err != nil {
+		t.Fatalf("Expected delivery, got %v", err)
+	}
+
+	var message struct {
+		Type        string `json:"type"`
+		Attachments []struct {
+			ContentType string `json:"contentType"`
+			Content     struct {
+				Type    string                   `json:"type"`
+				Body    []map[string]interface{} `json:"body"`
+				Actions []map[string]interface{} `json:"actions"`
+			} `json:"content"`
+		} `json:"attachments"`
+	}
+	if err := json.Unmarshal(body, &message); err != nil {
+		t.Fatalf("Expected JSON, got %v", err)
+	}
+	if message.Type != "message" || len(message.Attachments) != 1 || message.Attachments[0].ContentType != "application/vnd.microsoft.card.adaptive" {
+		t.Fatalf("Unexpected message %s", body)
+	}
+	card := message.Attachments[0].Content
+	if card.Type != "AdaptiveCard" {
+		t.Fatalf("Expected an Adaptive Card, got %s", card.Type)
+	}
+	if header := card.Body[0]; header["style"] != "attention" {
+		t.Errorf("Expected the attention style for a critical alert, got %v", header["style"])
+	}
+
+	raw := string(body)
+	for _, expected := range []string{`"title":"Status","value":"DOWN"`, `"title":"Error class","value":"connect_refused"`, `https://app.example.com/target/target-123`} {
+		if !strings.Contains(raw, expected) {
+			t.Errorf("Expected %q in %s", expected, raw)
+		}
+	}
+	if len(card.Actions) != 1 || card.Actions[0]["type"] != "Action.OpenUrl" {
+		t.Errorf("Expected an Open target action, got %v", card.Actions)
+	}
+}
+
+func TestBuildTeamsMessage_TruncatesLargeMetadata(t *testing.T) {
+	metadata := map[string]string{}
+	for i := 0; i < 60; i++ {
+		metadata["key_"+strconv.Itoa(i)] = strings.Repeat("€", 5000)
+	}
+	event := *domain.NewAlertEvent("user-1", "Noisy", "big", domain.SeverityWarning, domain.SeverityOk,
+		"System", domain.AlertTypeSystem, metadata)
+
+	message := BuildTeamsMessage(event, "")
+	if size := teamsPayloadSize(message); size > teamsMaxPayloadBytes {
+		t.Fatalf("Expected the card under %d bytes, got %d", teamsMaxPayloadBytes, size)
+	}
+	raw, _ := json.Marshal(message)
+	if !strings.Contains(string(raw), "more fields omitted") {
+		t.Error("Expected a note about the omitted metadata")
+	}
+	if strings.Contains(string(raw), "Action.OpenUrl") {
+		t.Error("Expected no action without a target")
+	}
+}
@@ -0,0 +1,150 @@
+package sender
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+	"uptrackai/internal/notifications/domain"
+)
+
+// WebhookPayload is the versioned JSON body POSTed to WEBHOOK channels
+type WebhookPayload struct {
+	Version string              `json:"version"`
+	Event   WebhookEventPayload `json:"event"`
+}
+
+type WebhookEventPayload struct {
+	UserID           string            `json:"user_id"`
+	Type             string            `json:"type"`
+	Title            string            `json:"title"`
+	Message          string            `json:"message"`
+	Severity         string            `json:"severity"`
+	PreviousSeverity string            `json:"previous_severity"`
+	Source           string            `json:"source"`
+	Timestamp        string            `json:"timestamp"`
+	Metadata         map[string]string `json:"metadata"`
+}
+
+func NewWebhookPayload(event domain.AlertEvent) WebhookPayload {
+	metadata := event.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	return WebhookPayload{
+		Version: domain.WebhookPayloadVersion,
+		Event: WebhookEventPayload{
+			UserID:           event.UserID,
+			Type:             string(event.Type),
+			Title:            event.Title,
+			Message:          event.Message,
+			Severity:         event.Severity.String(),
+			PreviousSeverity: event.PreviousSeverity.String(),
+			Source:           event.Source,
+			Timestamp:        event.Timestamp.UTC().Format(time.RFC3339),
+			Metadata:         metadata,
+		},
+	}
+}
+
+// SignWebhookPayload returns the signature header value: "sha256=" + hex(HMAC-SHA256(secret, "<timestamp>.<body>")).
+// Receivers recompute it with the X-UpTrack-Timestamp header and reject stale timestamps to stop replays.
+func SignWebhookPayload(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type WebhookSender struct {
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewWebhookSender() *WebhookSender {
+	return &WebhookSender{
+		client: newHookClient(),
+		now:    time.Now,
+	}
+}
+
+// Send delivers a plain text notification wrapped as a SYSTEM event
+func (s *WebhookSender) Send(destination string, message string) error {
+	event := domain.NewAlertEvent("", "UpTrack notification", message, domain.SeverityInfo, domain.SeverityInfo, "System", domain.AlertTypeSystem, nil)
+	return s.SendEvent(destination, *event)
+}
+
+// SendEvent POSTs the signed event; only a 2xx response counts as delivered
+func (s *WebhookSender) SendEvent(destination string, event domain.AlertEvent) error {
+	config, err := domain.ParseWebhookConfig(destination)
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(NewWebhookPayload(event))
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, config.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build webhook request: %w", hideURL(err))
+	}
+	for name, value := range config.Headers {
+		req.Header.Set(name, value)
+	}
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(domain.WebhookVersionHeader, domain.WebhookPayloadVersion)
+	req.Header.Set(domain.WebhookTimestampHeader, timestamp)
+	if config.Secret != "" {
+		req.Header.Set(domain.WebhookSignatureHeader, SignWebhookPayload(config.Secret, timestamp, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		err = hideURL(err)
+		log.Printf("❌ Failed to send webhook to %s: %v", urlHost(config.URL), err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook rejected: status %d, body: %s", resp.StatusCode, string(respBody))
+	}
+	io.Copy(io.Discard, resp.Body)
+
+	log.Printf("✅ Webhook delivered to %s", urlHost(config.URL))
+	return nil
+}
+
+// hideURL drops the destination from transport errors: *url.Error prints the full URL, and
+// webhook URLs often embed a token (for chat incoming webhooks the URL is the secret).
+// The host is kept for troubleshooting.
+func hideURL(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+	return fmt.Errorf("%s %s: %w", urlErr.Op, urlHost(urlErr.URL), urlErr.Err)
+}
+
+// urlHost is the part of a destination URL that logs and errors may show
+func urlHost(raw string) string {
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return "(invalid URL)"
+	}
+	return parsed.Host
+}
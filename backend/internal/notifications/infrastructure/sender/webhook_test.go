@@ -0,0 +1,114 @@
+package sender
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"uptrackai/internal/notifications/domain"
+)
+
+func TestWebhookSender_SendEvent(t *testing.T) {
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	s := NewWebhookSender()
+	s.now = func() time.Time { return now }
+
+	event := domain.NewAlertEvent("user-1", "Target Down: API", "connection refused",
+		domain.SeverityCritical, domain.SeverityOk, "Target: API", domain.AlertTypeMonitoring,
+		map[string]string{domain.MetadataErrorClass: "connect_refused"})
+	event.Timestamp = now
+	destination := `{"url":"` + server.URL + `/hook","secret":"s3cr3t","headers":{"X-Api-Key":"abc"}}`
+
+	if err := s.SendEvent(destination, *event); err != nil {
+		t.Fatalf("Expected delivery, got %v", err)
+	}
+
+	if received.Method != http.MethodPost || received.URL.Path != "/hook" {
+		t.Errorf("Expected POST /hook, got %s %s", received.Method, received.URL.Path)
+	}
+	if received.Header.Get("X-Api-Key") != "abc" || received.Header.Get(domain.WebhookVersionHeader) != domain.WebhookPayloadVersion {
+		t.Errorf("Expected custom and version headers, got %v", received.Header)
+	}
+	timestamp := received.Header.Get(domain.WebhookTimestampHeader)
+	if timestamp != strconv.FormatInt(now.Unix(), 10) {
+		t.Errorf("Expected timestamp %d, got %s", now.Unix(), timestamp)
+	}
+	if received.Header.Get(domain.WebhookSignatureHeader) != SignWebhookPayload("s3cr3t", timestamp, body) {
+		t.Error("Expected the signature to match the received body")
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("Expected JSON payload, got %v", err)
+	}
+	e := payload.Event
+	if payload.Version != "1" || e.Severity != "CRITICAL" || e.PreviousSeverity != "OK" || e.Type != "MONITORING" ||
+		e.Source != "Target: API" || e.Timestamp != "2024-03-10T12:00:00Z" || e.Metadata["error_class"] != "connect_refused" {
+		t.Errorf("Unexpected payload %+v", payload)
+	}
+}
+
+func TestWebhookSender_Non2xxFails(t *testing.T) {
+	for _, status := range []int{http.StatusMovedPermanently, http.StatusBadRequest, http.StatusInternalServerError} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+
+		err := NewWebhookSender().Send(server.URL, "hello")
+		server.Close()
+		if err == nil {
+			t.Errorf("Expected status %d to count as a failed delivery", status)
+		}
+	}
+}
+
+func TestWebhookSender_TransportErrorHidesURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close() // Connection refused
+	destination := server.URL + "/services/T000/B000/s3cr3tT0k3n"
+
+	errs := map[string]error{
+		"webhook":  NewWebhookSender().Send(destination, "hello"),
+		"postJSON": postJSON(newHookClient(), destination, map[string]string{"text": "hello"}),
+	}
+	for name, err := range errs {
+		if err == nil {
+			t.Fatalf("%s: expected a transport error", name)
+		}
+		if strings.Contains(err.Error(), "s3cr3tT0k3n") {
+			t.Errorf("%s: expected the URL to be hidden, got %q", name, err)
+		}
+		if !strings.Contains(err.Error(), strings.TrimPrefix(server.URL, "http://")) {
+			t.Errorf("%s: expected the host to be kept, got %q", name, err)
+		}
+	}
+}
+
+func TestWebhookSender_UnsignedWithoutSecret(t *testing.T) {
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature = r.Header.Get(domain.WebhookSignatureHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	if err := NewWebhookSender().Send(server.URL, "hello"); err != nil {
+		t.Fatalf("Expected delivery, got %v", err)
+	}
+	if signature != "" {
+		t.Errorf("Expected no signature without a secret, got %s", signature)
+	}
+}
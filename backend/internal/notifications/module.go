@@ -3,22 +3,33 @@ package notifications
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 	"uptrackai/internal/notifications/application"
 	"uptrackai/internal/notifications/domain"
 	"uptrackai/internal/notifications/infrastructure/postgres"
 	"uptrackai/internal/notifications/infrastructure/sender"
 	"uptrackai/internal/notifications/presentation"
+	"uptrackai/internal/notifications/scheduler"
 
 	"gorm.io/gorm"
 )
 
+const (
+	outboxPollInterval = 5 * time.Second
+	outboxBatchSize    = 50
+)
+
 type Module struct {
 	ConfigHandler  *presentation.NotificationConfigHandler
 	LinkingHandler *presentation.TelegramLinkingHandler
 	WebhookHandler *presentation.TelegramWebhookHandler
+	OutboxHandler  *presentation.OutboxHandler
 	Service        *application.NotificationService
 	LinkingService *application.TelegramLinkingService
 	PollingService *application.TelegramPollingService
+	outboxWorker   *scheduler.OutboxWorker
 	stopPoller     func()
 }
 
@@ -43,14 +54,42 @@ func NewModule(db *gorm.DB) *Module {
 		registry.Register(domain.ChannelTypeTelegram, telegramSender)
 	}
 
+	// Webhooks need no global configuration: URL, secret and headers live in each channel
+	registry.Register(domain.ChannelTypeWebhook, sender.NewWebhookSender())
+
+	// Chat integrations link back to the target page when the web app URL is known
+	appBaseURL := os.Getenv("APP_BASE_URL")
+	registry.Register(domain.ChannelTypeSlack, sender.NewSlackSender(appBaseURL))
+	registry.Register(domain.ChannelTypeTeams, sender.NewTeamsSender(appBaseURL))
+	registry.Register(domain.ChannelTypeDiscord, sender.NewDiscordSender(appBaseURL))
+
+	// Incident management: the routing key lives in each channel, the endpoint can point to
+	// any Events API v2 compatible receiver
+	registry.Register(domain.ChannelTypePagerDuty, sender.NewPagerDutySender(os.Getenv("PAGERDUTY_EVENTS_URL"), appBaseURL))
+
+	emailSender := newEmailSender()
+	if emailSender != nil {
+		registry.Register(domain.ChannelTypeEmail, emailSender)
+	}
+
 	// 2. Setup Repositories
 	tokenRepo := postgres.NewLinkingTokenRepository(db)
 	channelRepo := postgres.NewPostgresNotificationChannelRepository(db)
 	notificationRepo := postgres.NewPostgresNotificationRepository(db)
+	outboxRepo := postgres.NewPostgresOutboxRepository(db)
 
 	// 3. Setup Services
 	linkingService := application.NewTelegramLinkingService(tokenRepo, telegramBotName)
-	notificationService := application.NewNotificationService(channelRepo, notificationRepo, registry)
+	notificationService := application.NewNotificationService(channelRepo, notificationRepo, outboxRepo, registry, newRetryPolicy())
+
+	var emailVerification *application.EmailVerificationService
+	if emailSender != nil {
+		verifyURL := os.Getenv("EMAIL_VERIFICATION_URL")
+		if verifyURL == "" {
+			verifyURL = "http://localhost:8080/api/v1/notifications/email/verify"
+		}
+		emailVerification = application.NewEmailVerificationService(tokenRepo, channelRepo, emailSender, verifyURL)
+	}
 
 	// Polling Service (for local development without webhook)
 	var pollingService *application.TelegramPollingService
@@ -72,9 +111,10 @@ func NewModule(db *gorm.DB) *Module {
 	}
 
 	// 4. Setup Handlers
-	configHandler := presentation.NewNotificationConfigHandler(channelRepo, notificationRepo)
+	configHandler := presentation.NewNotificationConfigHandler(channelRepo, notificationRepo, emailVerification)
 	linkingHandler := presentation.NewTelegramLinkingHandler(linkingService)
 	webhookHandler := presentation.NewTelegramWebhookHandler(linkingService, channelRepo, telegramSender)
+	outboxHandler := presentation.NewOutboxHandler(notificationService)
 
 	// 5. Setup Webhook (if configured)
 	webhookURL := os.Getenv("TELEGRAM_WEBHOOK_URL") // e.g., "https://yourdomain.com/api/webhooks/telegram"
@@ -92,9 +132,81 @@ func NewModule(db *gorm.DB) *Module {
 		ConfigHandler:  configHandler,
 		LinkingHandler: linkingHandler,
 		WebhookHandler: webhookHandler,
+		OutboxHandler:  outboxHandler,
 		LinkingService: linkingService,
 		PollingService: pollingService,
 		stopPoller:     stopPollerFunc,
 		Service:        notificationService,
+		outboxWorker:   scheduler.NewOutboxWorker(notificationService, outboxBatchSize, outboxPollInterval),
+	}
+}
+
+// Start launches the outbox worker that delivers queued alerts (non-blocking)
+func (m *Module) Start() {
+	m.outboxWorker.Start()
+}
+
+// newRetryPolicy reads NOTIFICATION_MAX_ATTEMPTS, NOTIFICATION_RETRY_BASE_DELAY and
+// NOTIFICATION_RETRY_MAX_DELAY (Go durations, e.g. "30s", "1h") over the defaults
+func newRetryPolicy() domain.RetryPolicy {
+	policy := domain.DefaultRetryPolicy()
+	if raw := os.Getenv("NOTIFICATION_MAX_ATTEMPTS"); raw != "" {
+		if attempts, err := strconv.Atoi(raw); err == nil && attempts > 0 {
+			policy.MaxAttempts = attempts
+		} else {
+			log.Printf("⚠️  NOTIFICATION_MAX_ATTEMPTS ignored: %q is not a positive number", raw)
+		}
+	}
+	for env, target := range map[string]*time.Duration{
+		"NOTIFICATION_RETRY_BASE_DELAY": &policy.BaseDelay,
+		"NOTIFICATION_RETRY_MAX_DELAY":  &policy.MaxDelay,
+	} {
+		if raw := os.Getenv(env); raw != "" {
+			if delay, err := time.ParseDuration(raw); err == nil && delay > 0 {
+				*target = delay
+			} else {
+				log.Printf("⚠️  %s ignored: %q is not a positive duration", env, raw)
+			}
+		}
+	}
+	return policy
+}
+
+// newEmailSender builds the SMTP sender from the environment (.env or system variables).
+// Returns nil when SMTP_HOST is not set or the configuration is invalid: email channels are disabled.
+func newEmailSender() *sender.EmailSender {
+	host := os.Getenv("SMTP_HOST")
+	if host == "" {
+		log.Println("⚠️  SMTP_HOST not found. Email notifications disabled.")
+		return nil
+	}
+
+	config := sender.SMTPConfig{
+		Host:     host,
+		Port:     587,
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+		Security: strings.ToLower(os.Getenv("SMTP_SECURITY")),
+	}
+	if raw := os.Getenv("SMTP_PORT"); raw != "" {
+		port, err := strconv.Atoi(raw)
+		if err != nil {
+			log.Printf("⚠️  SMTP_PORT is not a number: %q. Email notifications disabled.", raw)
+			return nil
+		}
+		config.Port = port
+	}
+	if config.Security == "" {
+		config.Security = sender.SMTPSecurityStartTLS
+		if config.Port == 465 {
+			config.Security = sender.SMTPSecurityTLS
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		log.Printf("⚠️  Invalid SMTP configuration: %v. Email notifications disabled.", err)
+		return nil
 	}
+	return sender.NewEmailSender(config)
 }
@@ -1,10 +1,12 @@
 package presentation
 
 import (
+	"errors"
 	"net/http"
 	"sort"
 	"time"
 	"uptrackai/internal/app"
+	"uptrackai/internal/notifications/application"
 	"uptrackai/internal/notifications/domain"
 	"uptrackai/internal/server/middleware"
 
@@ -13,17 +15,20 @@ import (
 )
 
 type NotificationConfigHandler struct {
-	channelRepo      domain.NotificationChannelRepository
-	notificationRepo domain.NotificationRepository
+	channelRepo       domain.NotificationChannelRepository
+	notificationRepo  domain.NotificationRepository
+	emailVerification *application.EmailVerificationService
 }
 
 func NewNotificationConfigHandler(
 	channelRepo domain.NotificationChannelRepository,
 	notificationRepo domain.NotificationRepository,
+	emailVerification *application.EmailVerificationService,
 ) *NotificationConfigHandler {
 	return &NotificationConfigHandler{
-		channelRepo:      channelRepo,
-		notificationRepo: notificationRepo,
+		channelRepo:       channelRepo,
+		notificationRepo:  notificationRepo,
+		emailVerification: emailVerification,
 	}
 }
 
@@ -32,6 +37,8 @@ func (h *NotificationConfigHandler) RegisterRoutes(router *gin.RouterGroup) {
 	router.GET("/notifications/methods/:id", h.GetNotificationMethod)
 	router.POST("/notifications/methods", h.CreateNotificationMethod)
 	router.GET("/notifications/channels", h.GetNotificationChannels)
+	router.POST("/notifications/channels", h.CreateNotificationChannel)
+	router.PUT("/notifications/channels/:id/rules", h.UpdateChannelRules)
 
 	// New endpoints for in-app notifications
 	router.GET("/notifications/history", h.GetNotifications)
@@ -39,6 +46,11 @@ func (h *NotificationConfigHandler) RegisterRoutes(router *gin.RouterGroup) {
 	router.PUT("/notifications/:id/read", h.MarkAsRead)
 }
 
+// RegisterPublicRoutes exposes the email verification link (opened from the inbox, without a session)
+func (h *NotificationConfigHandler) RegisterPublicRoutes(router *gin.RouterGroup) {
+	router.GET("/notifications/email/verify", h.VerifyEmailChannel)
+}
+
 // GetNotificationMethods retorna la lista de métodos de notificación del usuario
 // @Summary Get notification methods
 // @Description Retrieve the list of notification methods configured by the authenticated user
@@ -196,9 +208,11 @@ func (h *NotificationConfigHandler) GetNotificationChannels(c *gin.Context) {
 			ID:       ch.ID().String(),
 			UserID:   ch.UserID(),
 			Type:     ch.Type().String(),
-			Value:    ch.Value().String(),
+			Value:    channelValue(ch),
 			Priority: ch.Priority().Int(),
 			IsActive: ch.IsActive(),
+
+			MutedErrorClasses: mutedErrorClassesOrEmpty(ch),
 		})
 	}
 
@@ -206,6 +220,236 @@ func (h *NotificationConfigHandler) GetNotificationChannels(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// UpdateChannelRules replaces the delivery rules of a notification channel
+// @Summary Update notification channel rules
+// @Description Choose which monitoring error classes (dns, connect_refused, connect_timeout, tls, read_timeout, http_5xx, http_4xx, assertion) this channel ignores. Recoveries are always delivered.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param id path string true "Channel ID"
+// @Param request body UpdateChannelRulesRequest true "Channel rules"
+// @Success 200 {object} app.APIResponse{data=NotificationChannelResponse} "Channel rules updated"
+// @Failure 400 {object} app.APIResponse "Invalid request"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Failure 404 {object} app.APIResponse "Channel not found"
+// @Failure 500 {object} app.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /notifications/channels/{id}/rules [put]
+func (h *NotificationConfigHandler) UpdateChannelRules(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, app.BuildErrorResponse("Unauthorized", false))
+		return
+	}
+
+	if h.channelRepo == nil {
+		c.JSON(http.StatusInternalServerError, app.BuildErrorResponse("Channel repository not available", false))
+		return
+	}
+
+	var req UpdateChannelRulesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, app.BuildErrorResponse("Invalid request body", false))
+		return
+	}
+
+	channelId, err := domain.NewChannelId(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, app.BuildErrorResponse("Invalid channel ID", false))
+		return
+	}
+
+	channel, err := h.channelRepo.FindById(channelId)
+	if err != nil {
+		c.JSON(http.StatusNotFound, app.BuildErrorResponse("Channel not found", false))
+		return
+	}
+	if channel.UserID() != string(userID) {
+		c.JSON(http.StatusForbidden, app.BuildErrorResponse("Channel does not belong to user", false))
+		return
+	}
+
+	if err := channel.MuteErrorClasses(req.MutedErrorClasses); err != nil {
+		c.JSON(http.StatusBadRequest, app.BuildErrorResponse(err.Error(), false))
+		return
+	}
+
+	if err := h.channelRepo.Update(channel); err != nil {
+		c.JSON(http.StatusInternalServerError, app.BuildErrorResponse("Failed to update channel", false))
+		return
+	}
+
+	response := app.BuildOKResponse("channel_rules_updated", true, NotificationChannelResponse{
+		ID:                channel.ID().String(),
+		UserID:            channel.UserID(),
+		Type:              channel.Type().String(),
+		Value:             channelValue(channel),
+		Priority:          channel.Priority().Int(),
+		IsActive:          channel.IsActive(),
+		MutedErrorClasses: mutedErrorClassesOrEmpty(channel),
+	}).WithLink("channels", "/api/v1/notifications/channels")
+	c.JSON(http.StatusOK, response)
+}
+
+// CreateNotificationChannel creates a channel whose destination needs no linking flow
+// @Summary Create notification channel
+// @Description Create a WEBHOOK, SLACK, TEAMS, DISCORD, PAGERDUTY or EMAIL channel. For SLACK, TEAMS and DISCORD the value is the incoming webhook URL. For PAGERDUTY the value is the integration routing key or {"routing_key": "...", "url": "..."} to use another Events API v2 compatible endpoint; alerts of a target trigger, acknowledge and resolve the same incident. EMAIL channels are not created right away: a one-time verification link is sent to the address (valid 24h) and the channel appears once it is opened (202 Accepted). For WEBHOOK the value is either the URL or a JSON object {"url": "...", "secret": "...", "headers": {"X-Api-Key": "..."}}. Each alert is POSTed as a versioned JSON payload; with a secret the body is signed in X-UpTrack-Signature (sha256=HMAC-SHA256(secret, "<X-UpTrack-Timestamp>.<body>")). Only 2xx responses count as delivered. Telegram channels are created through the Telegram linking flow.
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param request body CreateNotificationChannelRequest true "Channel data"
+// @Success 201 {object} app.APIResponse{data=NotificationChannelResponse} "Notification channel created"
+// @Success 202 {object} app.APIResponse{data=EmailVerificationResponse} "Verification email sent"
+// @Failure 400 {object} app.APIResponse "Invalid request"
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 409 {object} app.APIResponse "Channel already exists"
+// @Failure 500 {object} app.APIResponse "Internal server error"
+// @Failure 503 {object} app.APIResponse "Email delivery not configured"
+// @Security BearerAuth
+// @Router /notifications/channels [post]
+func (h *NotificationConfigHandler) CreateNotificationChannel(c *gin.Context) {
+	userID, exists := middleware.GetUserID(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, app.BuildErrorResponse("Unauthorized", false))
+		return
+	}
+
+	if h.channelRepo == nil {
+		c.JSON(http.StatusInternalServerError, app.BuildErrorResponse("Channel repository not available", false))
+		return
+	}
+
+	var req CreateNotificationChannelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, app.BuildErrorResponse("Invalid request body", false))
+		return
+	}
+
+	chType, err := domain.NewChannelType(req.Type)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, app.BuildErrorResponse(err.Error(), false))
+		return
+	}
+	priority := req.Priority
+	if priority == 0 {
+		priority = 5
+	}
+
+	switch chType {
+	case domain.ChannelTypeWebhook, domain.ChannelTypeSlack, domain.ChannelTypeTeams, domain.ChannelTypeDiscord, domain.ChannelTypePagerDuty:
+	case domain.ChannelTypeEmail:
+		h.requestEmailVerification(c, string(userID), req.Value, priority)
+		return
+	default:
+		c.JSON(http.StatusBadRequest, app.BuildErrorResponse("Channel type "+chType.String()+" cannot be created directly", false))
+		return
+	}
+	channelId, _ := uuid.NewV7()
+	channel, err := domain.NewNotificationChannel(channelId.String(), string(userID), chType.String(), req.Value, priority)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, app.BuildErrorResponse(err.Error(), false))
+		return
+	}
+
+	if err := h.channelRepo.Save(channel); err != nil {
+		c.JSON(http.StatusInternalServerError, app.BuildErrorResponse("Failed to save channel", false))
+		return
+	}
+
+	response := app.BuildOKResponse("channel_created", true, NotificationChannelResponse{
+		ID:                channel.ID().String(),
+		UserID:            channel.UserID(),
+		Type:              channel.Type().String(),
+		Value:             channelValue(channel),
+		Priority:          channel.Priority().Int(),
+		IsActive:          channel.IsActive(),
+		MutedErrorClasses: mutedErrorClassesOrEmpty(channel),
+	}).WithLink("channels", "/api/v1/notifications/channels").
+		WithLink("rules", "/api/v1/notifications/channels/"+channel.ID().String()+"/rules")
+	c.JSON(http.StatusCreated, response)
+}
+
+// requestEmailVerification sends the one-time link; the EMAIL channel is created by VerifyEmailChannel
+func (h *NotificationConfigHandler) requestEmailVerification(c *gin.Context, userID string, address string, priority int) {
+	if h.emailVerification == nil {
+		c.JSON(http.StatusServiceUnavailable, app.BuildErrorResponse(domain.ErrEmailDeliveryDisabled.Error(), false))
+		return
+	}
+
+	err := h.emailVerification.RequestVerification(userID, address, priority)
+	switch {
+	case errors.Is(err, domain.ErrInvalidEmailAddress), errors.Is(err, domain.ErrInvalidPriority):
+		c.JSON(http.StatusBadRequest, app.BuildErrorResponse(err.Error(), false))
+		return
+	case errors.Is(err, domain.ErrChannelAlreadyExists):
+		c.JSON(http.StatusConflict, app.BuildErrorResponse(err.Error(), false))
+		return
+	case errors.Is(err, domain.ErrEmailDeliveryDisabled):
+		c.JSON(http.StatusServiceUnavailable, app.BuildErrorResponse(err.Error(), false))
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, app.BuildErrorResponse("Failed to send verification email", false))
+		return
+	}
+
+	response := app.BuildOKResponse("email_verification_sent", true, EmailVerificationResponse{
+		Email:     address,
+		ExpiresIn: int(application.EmailVerificationTTL.Seconds()),
+		Message:   "Open the link sent to this address to start receiving alerts there.",
+	}).WithLink("channels", "/api/v1/notifications/channels")
+	c.JSON(http.StatusAccepted, response)
+}
+
+// VerifyEmailChannel consumes the one-time link and creates the EMAIL channel
+// @Summary Verify email channel
+// @Description Opened from the verification email. Consumes the one-time token and creates the EMAIL notification channel. No authentication required: the token identifies the user.
+// @Tags notifications
+// @Produce json
+// @Param token query string true "Verification token"
+// @Success 201 {object} app.APIResponse{data=NotificationChannelResponse} "Email channel created"
+// @Failure 400 {object} app.APIResponse "Invalid, expired or used token"
+// @Failure 409 {object} app.APIResponse "Channel already exists"
+// @Failure 500 {object} app.APIResponse "Internal server error"
+// @Router /notifications/email/verify [get]
+func (h *NotificationConfigHandler) VerifyEmailChannel(c *gin.Context) {
+	if h.emailVerification == nil {
+		c.JSON(http.StatusServiceUnavailable, app.BuildErrorResponse(domain.ErrEmailDeliveryDisabled.Error(), false))
+		return
+	}
+
+	channel, err := h.emailVerification.Verify(c.Query("token"))
+	switch {
+	case errors.Is(err, domain.ErrInvalidLinkingToken), errors.Is(err, domain.ErrLinkingTokenExpired), errors.Is(err, domain.ErrInvalidEmailAddress):
+		c.JSON(http.StatusBadRequest, app.BuildErrorResponse(err.Error(), false))
+		return
+	case errors.Is(err, domain.ErrChannelAlreadyExists):
+		c.JSON(http.StatusConflict, app.BuildErrorResponse(err.Error(), false))
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, app.BuildErrorResponse("Failed to create email channel", false))
+		return
+	}
+
+	c.JSON(http.StatusCreated, app.BuildOKResponse("email_channel_verified", true, NotificationChannelResponse{
+		ID:                channel.ID().String(),
+		UserID:            channel.UserID(),
+		Type:              channel.Type().String(),
+		Value:             channelValue(channel),
+		Priority:          channel.Priority().Int(),
+		IsActive:          channel.IsActive(),
+		MutedErrorClasses: mutedErrorClassesOrEmpty(channel),
+	}))
+}
+
+// mutedErrorClassesOrEmpty always returns an array (never null) for the frontend
+func mutedErrorClassesOrEmpty(channel *domain.NotificationChannel) []string {
+	if muted := channel.MutedErrorClasses(); muted != nil {
+		return muted
+	}
+	return []string{}
+}
+
 // GetNotifications returns the history of notifications for the user
 // @Summary Get notification history
 // @Description Retrieve the history of all notifications sent to the authenticated user
@@ -239,12 +483,14 @@ func (h *NotificationConfigHandler) GetNotifications(c *gin.Context) {
 	var responseData []NotificationResponse
 	for _, n := range notifications {
 		responseData = append(responseData, NotificationResponse{
-			ID:        string(n.ID()),
-			Title:     n.Title(),
-			Message:   n.Message(),
-			Severity:  string(n.Severity()),
-			IsRead:    n.IsRead(),
-			CreatedAt: n.CreatedAt().Format(time.RFC3339),
+			ID:                string(n.ID()),
+			Title:             n.Title(),
+			Message:           n.Message(),
+			Severity:          n.Severity().String(),
+			IsRead:            n.IsRead(),
+			CreatedAt:         n.CreatedAt().Format(time.RFC3339),
+			IncidentID:        n.IncidentID(),
+			DeliveredChannels: n.DeliveredChannels(),
 		})
 	}
 
@@ -291,12 +537,14 @@ func (h *NotificationConfigHandler) GetNotification(c *gin.Context) {
 	}
 
 	responseData := NotificationResponse{
-		ID:        string(notification.ID()),
-		Title:     notification.Title(),
-		Message:   notification.Message(),
-		Severity:  string(notification.Severity()),
-		IsRead:    notification.IsRead(),
-		CreatedAt: notification.CreatedAt().Format(time.RFC3339),
+		ID:                string(notification.ID()),
+		Title:             notification.Title(),
+		Message:           notification.Message(),
+		Severity:          notification.Severity().String(),
+		IsRead:            notification.IsRead(),
+		CreatedAt:         notification.CreatedAt().Format(time.RFC3339),
+		IncidentID:        notification.IncidentID(),
+		DeliveredChannels: notification.DeliveredChannels(),
 	}
 
 	c.JSON(http.StatusOK, app.BuildOKResponse("notification_retrieved", true, responseData))
@@ -339,4 +587,6 @@ type NotificationChannelResponse struct {
 	Value    string `json:"value"`
 	Priority int    `json:"priority"`
 	IsActive bool   `json:"is_active"`
+
+	MutedErrorClasses []string `json:"muted_error_classes"`
 }
@@ -1,3 +1,21 @@
 package presentation
 
+import "uptrackai/internal/notifications/domain"
+
 // Mappers (converts between presentation models and DTOs)
+
+// channelValue is the destination shown back to the user; webhook secrets, header values
+// and PagerDuty routing keys are masked
+func channelValue(channel *domain.NotificationChannel) string {
+	switch channel.Type() {
+	case domain.ChannelTypeWebhook:
+		if config, err := domain.ParseWebhookConfig(channel.Value().String()); err == nil {
+			return config.Redacted()
+		}
+	case domain.ChannelTypePagerDuty:
+		if config, err := domain.ParsePagerDutyConfig(channel.Value().String()); err == nil {
+			return config.Redacted()
+		}
+	}
+	return channel.Value().String()
+}
@@ -19,6 +19,25 @@ type CreateNotificationMethodRequest struct {
 	Priority int    `json:"priority" binding:"required,min=1,max=10" example:"10"`
 }
 
+// CreateNotificationChannelRequest creates a WEBHOOK, SLACK, TEAMS, DISCORD or PAGERDUTY channel, or starts the verification of an EMAIL one
+type CreateNotificationChannelRequest struct {
+	Type     string `json:"type" binding:"required" example:"WEBHOOK"` // WEBHOOK, SLACK, TEAMS, DISCORD, PAGERDUTY, EMAIL
+	Value    string `json:"value" binding:"required" example:"{\"url\":\"https://automation.example.com/uptrack\",\"secret\":\"s3cr3t\",\"headers\":{\"X-Api-Key\":\"abc\"}}"`
+	Priority int    `json:"priority" binding:"omitempty,min=1,max=10" example:"5"`
+}
+
+// EmailVerificationResponse is returned while an EMAIL channel waits for its address to be verified
+type EmailVerificationResponse struct {
+	Email     string `json:"email" example:"oncall@example.com"`
+	ExpiresIn int    `json:"expires_in" example:"86400"`
+	Message   string `json:"message" example:"Open the link sent to this address to start receiving alerts there."`
+}
+
+// UpdateChannelRulesRequest lists the error classes a channel should ignore (empty = deliver everything)
+type UpdateChannelRulesRequest struct {
+	MutedErrorClasses []string `json:"muted_error_classes" example:"http_4xx,assertion"`
+}
+
 // NotificationResponse representa una notificación en la interfaz
 type NotificationResponse struct {
 	ID        string `json:"id"`
@@ -27,4 +46,8 @@ type NotificationResponse struct {
 	Severity  string `json:"severity"`
 	IsRead    bool   `json:"is_read"`
 	CreatedAt string `json:"created_at"`
+	// Incident the alert was raised for; see GET /incidents/{id}
+	IncidentID string `json:"incident_id,omitempty"`
+	// External channels the alert was delivered to
+	DeliveredChannels []string `json:"delivered_channels,omitempty" example:"TELEGRAM"`
 }
@@ -0,0 +1,111 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+	"uptrackai/internal/app"
+	"uptrackai/internal/notifications/application"
+	"uptrackai/internal/notifications/domain"
+	securitypresentation "uptrackai/internal/security/presentation"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxDeadLettersPerPage = 200
+
+func buildOutboxErrorResponse(c *gin.Context, status int, code, msg string) {
+	resp := app.BuildErrorResponse(msg, false).
+		WithMeta("error_code", code).
+		WithMeta("timestamp", time.Now().Format(time.RFC3339))
+	c.JSON(status, resp)
+}
+
+// OutboxHandler exposes the notification outbox dead letters to admins
+type OutboxHandler struct {
+	service *application.NotificationService
+}
+
+func NewOutboxHandler(service *application.NotificationService) *OutboxHandler {
+	return &OutboxHandler{service: service}
+}
+
+// RegisterRoutes registers the admin routes (ADMIN only)
+func (h *OutboxHandler) RegisterRoutes(router *gin.RouterGroup) {
+	admin := router.Group("/admin/notifications/dead-letters", securitypresentation.AuthMiddleware(securitypresentation.RoleAdmin))
+	admin.GET("", h.ListDeadLetters)
+	admin.POST("/:id/replay", h.ReplayDeadLetter)
+}
+
+// ListDeadLetters lists the alerts that could not be delivered
+// @Summary List notification dead letters
+// @Description Outbox messages that ran out of delivery attempts (or whose channel was deleted or disabled), newest first, with the attempt count and the last error. Channel destinations are not included.
+// @Tags admin
+// @Produce json
+// @Param page query int false "Page (1-based)" default(1)
+// @Param limit query int false "Items per page (max 200)" default(50)
+// @Success 200 {object} app.APIResponse{data=[]application.OutboxMessageDTO}
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Failure 500 {object} app.APIResponse "Internal server error"
+// @Security BearerAuth
+// @Router /admin/notifications/dead-letters [get]
+func (h *OutboxHandler) ListDeadLetters(c *gin.Context) {
+	page := 1
+	if pageParam := c.Query("page"); pageParam != "" {
+		if parsedPage, err := strconv.Atoi(pageParam); err == nil && parsedPage > 0 {
+			page = parsedPage
+		}
+	}
+	limit := 50
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if parsedLimit, err := strconv.Atoi(limitParam); err == nil && parsedLimit > 0 {
+			limit = min(parsedLimit, maxDeadLettersPerPage)
+		}
+	}
+
+	deadLetters, total, err := h.service.ListDeadLetters(limit, (page-1)*limit)
+	if err != nil {
+		buildOutboxErrorResponse(c, http.StatusInternalServerError, "fetch_dead_letters_failed", "Failed to fetch dead letters")
+		return
+	}
+
+	const baseURL = "/api/v1/admin/notifications/dead-letters"
+	response := app.BuildOKResponse("dead_letters_retrieved", true, deadLetters).
+		WithLink("self", baseURL).
+		WithPagination(baseURL, page, limit, int(total))
+	c.JSON(http.StatusOK, response)
+}
+
+// ReplayDeadLetter queues a dead letter again
+// @Summary Replay a notification dead letter
+// @Description Put the message back in the outbox with a fresh attempt budget. It is delivered with the channel's current configuration on the next worker pass.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Outbox message ID"
+// @Success 200 {object} app.APIResponse{data=application.OutboxMessageDTO}
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Failure 404 {object} app.APIResponse "Dead letter not found"
+// @Failure 409 {object} app.APIResponse "The message is not a dead letter"
+// @Security BearerAuth
+// @Router /admin/notifications/dead-letters/{id}/replay [post]
+func (h *OutboxHandler) ReplayDeadLetter(c *gin.Context) {
+	message, err := h.service.ReplayDeadLetter(domain.OutboxMessageId(c.Param("id")))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrOutboxMessageNotFound):
+			buildOutboxErrorResponse(c, http.StatusNotFound, "dead_letter_not_found", err.Error())
+		case errors.Is(err, domain.ErrOutboxMessageNotDead):
+			buildOutboxErrorResponse(c, http.StatusConflict, "not_a_dead_letter", err.Error())
+		default:
+			buildOutboxErrorResponse(c, http.StatusInternalServerError, "replay_failed", "Failed to replay dead letter")
+		}
+		return
+	}
+
+	response := app.BuildOKResponse("dead_letter_replayed", true, message).
+		WithLink("dead_letters", "/api/v1/admin/notifications/dead-letters")
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,73 @@
+package scheduler
+
+import (
+	"log"
+	"time"
+	"uptrackai/internal/notifications/application"
+)
+
+// OutboxWorker delivers the notification outbox: every tick it drains the due messages
+// batch by batch, so a burst of alerts does not wait for several ticks
+type OutboxWorker struct {
+	service   *application.NotificationService
+	batchSize int
+	interval  time.Duration
+	stopChan  chan struct{}
+}
+
+func NewOutboxWorker(service *application.NotificationService, batchSize int, interval time.Duration) *OutboxWorker {
+	return &OutboxWorker{
+		service:   service,
+		batchSize: batchSize,
+		interval:  interval,
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// Start delivers whatever was left pending (e.g. before a restart) and then polls every `interval` (non-blocking)
+func (w *OutboxWorker) Start() {
+	log.Printf("📤 Notification outbox worker started (Interval: %s, Batch: %d)", w.interval, w.batchSize)
+	go w.runLoop()
+}
+
+func (w *OutboxWorker) Stop() {
+	close(w.stopChan)
+}
+
+func (w *OutboxWorker) runLoop() {
+	w.drain()
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.drain()
+		case <-w.stopChan:
+			return
+		}
+	}
+}
+
+func (w *OutboxWorker) drain() {
+	for {
+		select {
+		case <-w.stopChan:
+			return
+		default:
+		}
+
+		report, err := w.service.DeliverPending(w.batchSize)
+		if err != nil {
+			log.Printf("❌ Error delivering notification outbox: %v", err)
+			return
+		}
+		if report.Claimed > 0 {
+			log.Printf("📤 Outbox: %d delivered, %d retrying, %d dead-lettered", report.Delivered, report.Retrying, report.DeadLettered)
+		}
+		if report.Claimed < w.batchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package application
+
+import (
+	"time"
+	"uptrackai/internal/retention/domain"
+)
+
+// RetentionStatusDTO - Política y última purga
+type RetentionStatusDTO struct {
+	Policy     RetentionPolicyDTO `json:"policy"`
+	LastReport *PurgeReportDTO    `json:"last_report"`
+}
+
+// RetentionPolicyDTO - Días de retención por tabla y por plan
+type RetentionPolicyDTO struct {
+	TableDays map[string]int `json:"table_days"`
+	PlanDays  map[string]int `json:"plan_days"`
+	BatchSize int            `json:"batch_size"`
+}
+
+func ToRetentionPolicyDTO(policy *domain.Policy) RetentionPolicyDTO {
+	dto := RetentionPolicyDTO{
+		TableDays: make(map[string]int, len(domain.Tables)),
+		PlanDays:  make(map[string]int, len(domain.PlanRetentionDays)),
+		BatchSize: policy.BatchSize(),
+	}
+	for _, table := range domain.Tables {
+		dto.TableDays[table.String()] = int(policy.MaxAge(table).Hours() / 24)
+	}
+	for plan := range domain.PlanRetentionDays {
+		dto.PlanDays[plan.String()] = int(policy.PlanRetention(plan).Hours() / 24)
+	}
+	return dto
+}
+
+// PurgeReportDTO - Qué borró una pasada
+type PurgeReportDTO struct {
+	StartedAt    time.Time        `json:"started_at"`
+	FinishedAt   time.Time        `json:"finished_at"`
+	DurationMs   int64            `json:"duration_ms"`
+	TotalDeleted int64            `json:"total_deleted"`
+	Deleted      map[string]int64 `json:"deleted"` // Por tabla
+	Cohorts      []CohortPurgeDTO `json:"cohorts"`
+}
+
+// CohortPurgeDTO - Resultado de una cohorte (tabla + plan)
+type CohortPurgeDTO struct {
+	Table   string    `json:"table"`
+	Plan    string    `json:"plan,omitempty"` // Vacío = máximo de la tabla
+	Cutoff  time.Time `json:"cutoff"`
+	Deleted int64     `json:"deleted"`
+	Batches int       `json:"batches"`
+	Error   string    `json:"error,omitempty"`
+}
+
+func ToPurgeReportDTO(report domain.PurgeReport) PurgeReportDTO {
+	dto := PurgeReportDTO{
+		StartedAt:    report.StartedAt,
+		FinishedAt:   report.FinishedAt,
+		DurationMs:   report.FinishedAt.Sub(report.StartedAt).Milliseconds(),
+		TotalDeleted: report.TotalDeleted(),
+		Deleted:      make(map[string]int64, len(domain.Tables)),
+		Cohorts:      make([]CohortPurgeDTO, 0, len(report.Cohorts)),
+	}
+	for table, deleted := range report.DeletedByTable() {
+		dto.Deleted[table.String()] = deleted
+	}
+	for _, c := range report.Cohorts {
+		cohort := CohortPurgeDTO{
+			Table:   c.Table.String(),
+			Plan:    c.Plan.String(),
+			Cutoff:  c.Cutoff,
+			Deleted: c.Deleted,
+			Batches: c.Batches,
+		}
+		if c.Err != nil {
+			cohort.Error = c.Err.Error()
+		}
+		dto.Cohorts = append(dto.Cohorts, cohort)
+	}
+	return dto
+}
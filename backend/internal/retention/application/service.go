@@ -0,0 +1,96 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"uptrackai/internal/retention/domain"
+)
+
+var ErrPurgeInProgress = errors.New("retention purge already running")
+
+// RetentionService - Purga por lotes las tablas que crecen sin límite.
+// Cada lote es una transacción corta: nunca se bloquea una tabla caliente por mucho tiempo.
+type RetentionService struct {
+	purger     domain.Purger
+	plans      domain.PlanDirectory
+	policy     *domain.Policy
+	pause      time.Duration // Respiro entre lotes para no competir con las escrituras del scheduler
+	running    sync.Mutex
+	reportMu   sync.RWMutex
+	lastReport *domain.PurgeReport
+}
+
+func NewRetentionService(purger domain.Purger, plans domain.PlanDirectory, policy *domain.Policy, pause time.Duration) *RetentionService {
+	return &RetentionService{
+		purger: purger,
+		plans:  plans,
+		policy: policy,
+		pause:  pause,
+	}
+}
+
+// Purge ejecuta una pasada completa y guarda el reporte (una sola pasada a la vez)
+func (s *RetentionService) Purge() (*PurgeReportDTO, error) {
+	if !s.running.TryLock() {
+		return nil, ErrPurgeInProgress
+	}
+	defer s.running.Unlock()
+
+	// Sin el plan de cada usuario no se purga: el corte por defecto borraría datos de ENTERPRISE
+	plans, err := s.plans.UserPlans()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user plans: %w", err)
+	}
+
+	report := domain.PurgeReport{StartedAt: time.Now()}
+	for _, table := range domain.Tables {
+		for _, cohort := range s.policy.Cohorts(table, plans, report.StartedAt) {
+			report.Cohorts = append(report.Cohorts, s.purgeCohort(cohort))
+		}
+	}
+	report.FinishedAt = time.Now()
+
+	s.reportMu.Lock()
+	s.lastReport = &report
+	s.reportMu.Unlock()
+
+	dto := ToPurgeReportDTO(report)
+	return &dto, nil
+}
+
+// purgeCohort borra lote a lote hasta que un lote vuelve incompleto
+func (s *RetentionService) purgeCohort(cohort domain.Cohort) domain.CohortPurge {
+	result := domain.CohortPurge{Table: cohort.Table, Plan: cohort.Plan, Cutoff: cohort.Cutoff}
+	batchSize := s.policy.BatchSize()
+
+	for {
+		deleted, err := s.purger.PurgeBatch(cohort, batchSize)
+		if err != nil {
+			result.Err = err
+			return result
+		}
+		result.Deleted += deleted
+		result.Batches++
+		if deleted < int64(batchSize) {
+			return result
+		}
+		if s.pause > 0 {
+			time.Sleep(s.pause)
+		}
+	}
+}
+
+// GetStatus - Política vigente y reporte de la última pasada (nil si aún no corrió)
+func (s *RetentionService) GetStatus() RetentionStatusDTO {
+	status := RetentionStatusDTO{Policy: ToRetentionPolicyDTO(s.policy)}
+
+	s.reportMu.RLock()
+	defer s.reportMu.RUnlock()
+	if s.lastReport != nil {
+		report := ToPurgeReportDTO(*s.lastReport)
+		status.LastReport = &report
+	}
+	return status
+}
@@ -0,0 +1,116 @@
+package application
+
+import (
+	"errors"
+	"testing"
+	"uptrackai/internal/retention/domain"
+)
+
+// MockPurger - Simula filas vencidas por tabla y registra cada lote
+type MockPurger struct {
+	pending map[domain.Table]int64
+	fail    map[domain.Table]error
+	batches []domain.Cohort
+}
+
+func (m *MockPurger) PurgeBatch(cohort domain.Cohort, limit int) (int64, error) {
+	m.batches = append(m.batches, cohort)
+	if err := m.fail[cohort.Table]; err != nil {
+		return 0, err
+	}
+	deleted := m.pending[cohort.Table]
+	if deleted > int64(limit) {
+		deleted = int64(limit)
+	}
+	m.pending[cohort.Table] -= deleted
+	return deleted, nil
+}
+
+// MockPlanDirectory - Planes fijados por el test
+type MockPlanDirectory struct {
+	plans map[string]domain.Plan
+	err   error
+}
+
+func (m *MockPlanDirectory) UserPlans() (map[string]domain.Plan, error) {
+	return m.plans, m.err
+}
+
+// cohortOf devuelve la primera cohorte de la tabla en el reporte
+func cohortOf(report *PurgeReportDTO, table domain.Table) CohortPurgeDTO {
+	for _, cohort := range report.Cohorts {
+		if cohort.Table == table.String() {
+			return cohort
+		}
+	}
+	return CohortPurgeDTO{}
+}
+
+func TestPurge_BatchesUntilDrained(t *testing.T) {
+	purger := &MockPurger{
+		pending: map[domain.Table]int64{domain.TableMetrics: 25, domain.TableLinkingTokens: 3},
+		fail:    map[domain.Table]error{domain.TableNotifications: errors.New("statement timeout")},
+	}
+	policy := domain.NewDefaultPolicy()
+	_ = policy.SetBatchSize(10)
+	service := NewRetentionService(purger, &MockPlanDirectory{}, policy, 0)
+
+	report, err := service.Purge()
+	if err != nil {
+		t.Fatalf("Expected purge report, got %v", err)
+	}
+	if report.TotalDeleted != 28 || report.Deleted["metrics"] != 25 || report.Deleted["telegram_linking_tokens"] != 3 {
+		t.Errorf("Unexpected deleted counts: %d %v", report.TotalDeleted, report.Deleted)
+	}
+	// 10 + 10 + 5: el lote incompleto corta la cohorte
+	if report.Cohorts[0].Batches != 3 {
+		t.Errorf("Expected 3 metrics batches, got %d", report.Cohorts[0].Batches)
+	}
+	// Un error corta solo su tabla
+	if cohortOf(report, domain.TableNotifications).Error == "" || cohortOf(report, domain.TableLinkingTokens).Deleted != 3 {
+		t.Errorf("Expected notifications to fail without stopping the tokens purge, got %+v", report.Cohorts)
+	}
+
+	status := service.GetStatus()
+	if status.LastReport == nil || status.LastReport.TotalDeleted != 28 || status.Policy.TableDays["metrics"] != 90 {
+		t.Errorf("Expected the last report in the status, got %+v", status)
+	}
+}
+
+func TestPurge_SkipsWithoutPlans(t *testing.T) {
+	purger := &MockPurger{pending: map[domain.Table]int64{domain.TableMetrics: 5}}
+	service := NewRetentionService(purger, &MockPlanDirectory{err: errors.New("connection refused")}, domain.NewDefaultPolicy(), 0)
+
+	if _, err := service.Purge(); err == nil {
+		t.Fatal("Expected an error when plans cannot be loaded")
+	}
+	if len(purger.batches) != 0 {
+		t.Errorf("Expected no rows purged without plans, got %d batches", len(purger.batches))
+	}
+}
+
+func TestPurge_PlanCohorts(t *testing.T) {
+	purger := &MockPurger{pending: map[domain.Table]int64{}}
+	plans := &MockPlanDirectory{plans: map[string]domain.Plan{"user-1": domain.PlanFree}}
+	service := NewRetentionService(purger, plans, domain.NewDefaultPolicy(), 0)
+
+	report, err := service.Purge()
+	if err != nil {
+		t.Fatalf("Expected purge report, got %v", err)
+	}
+	// Tablas con dueño x (FREE + defecto) + una cohorte por tabla sin dueño
+	expected := 0
+	for _, table := range domain.Tables {
+		if table.IsUserScoped() {
+			expected += 2
+		} else {
+			expected++
+		}
+	}
+	if len(report.Cohorts) != expected {
+		t.Errorf("Expected %d cohorts, got %d", expected, len(report.Cohorts))
+	}
+	if report.Cohorts[0].Plan != "FREE" || report.Cohorts[1].Plan != "" {
+		t.Errorf("Expected the FREE cohort before the default one, got %+v", report.Cohorts[:2])
+	}
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "errors"
+
+// Domain Errors - Retención
+var (
+	ErrUnknownTable          = errors.New("tabla sin política de retención")
+	ErrInvalidPlan           = errors.New("plan inválido (FREE, PRO o ENTERPRISE)")
+	ErrInvalidRetentionDays  = errors.New("la retención debe ser de al menos 1 día")
+	ErrInvalidPurgeBatchSize = errors.New("el tamaño de lote debe estar entre 1 y 50000 filas")
+)
@@ -0,0 +1,212 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Enum: Table - Tablas que crecen sin límite y tienen política de retención.
+// Una tabla con filas de distinta vida útil se divide (metric_rollups por resolución,
+// notification_outbox por estado; los mensajes pendientes nunca se purgan).
+type Table string
+
+const (
+	TableMetrics         Table = "metrics"
+	TableRollups1m       Table = "metric_rollups_1m"
+	TableRollups1h       Table = "metric_rollups_1h"
+	TableRollups1d       Table = "metric_rollups_1d"
+	TableCheckResults    Table = "check_results"
+	TablePingSamples     Table = "ping_samples" // Cada target fija su retención; la de la tabla es el tope
+	TableNotifications   Table = "notifications"
+	TableLinkingTokens   Table = "telegram_linking_tokens"       // Tokens vencidos o ya usados
+	TableOutboxDelivered Table = "notification_outbox_delivered" // También los cancelados
+	TableOutboxDead      Table = "notification_outbox_dead"      // Se guardan más: el admin puede reintentarlos
+)
+
+// Tables - Orden de purga
+var Tables = []Table{
+	TableMetrics,
+	TableRollups1m,
+	TableRollups1h,
+	TableRollups1d,
+	TableCheckResults,
+	TablePingSamples,
+	TableNotifications,
+	TableLinkingTokens,
+	TableOutboxDelivered,
+	TableOutboxDead,
+}
+
+func (t Table) String() string {
+	return string(t)
+}
+
+// IsUserScoped indica si las filas pertenecen a un usuario (y aplica la retención de su plan).
+// Los rollups no: su resolución ya fija cuánto duran, y son los que sirven los rangos largos.
+func (t Table) IsUserScoped() bool {
+	switch t {
+	case TableMetrics, TableCheckResults, TableNotifications:
+		return true
+	}
+	return false
+}
+
+// Enum: Plan - Planes de membresía (ROADMAP, fase 4)
+type Plan string
+
+const (
+	PlanFree       Plan = "FREE"
+	PlanPro        Plan = "PRO"
+	PlanEnterprise Plan = "ENTERPRISE"
+)
+
+func NewPlan(value string) (Plan, error) {
+	plan := Plan(strings.ToUpper(strings.TrimSpace(value)))
+	switch plan {
+	case PlanFree, PlanPro, PlanEnterprise:
+		return plan, nil
+	}
+	return "", ErrInvalidPlan
+}
+
+func (p Plan) String() string {
+	return string(p)
+}
+
+// PlanRetentionDays - retention_days de cada plan
+var PlanRetentionDays = map[Plan]int{
+	PlanFree:       7,
+	PlanPro:        30,
+	PlanEnterprise: 365,
+}
+
+// DefaultTableRetentionDays - Máximo por tabla para usuarios sin plan.
+// metrics cubre el uptime/SLO de 90 días; los rangos más largos se sirven desde metric_rollups.
+// Los buckets de 1m solo se eligen para rangos de hasta ~1 día, los de 1h y 1d son los del histórico.
+var DefaultTableRetentionDays = map[Table]int{
+	TableMetrics:         90,
+	TableRollups1m:       7,
+	TableRollups1h:       400,
+	TableRollups1d:       1825,
+	TableCheckResults:    365,
+	TablePingSamples:     14, // Máximo que un target puede pedir para sus pings crudos
+	TableNotifications:   90,
+	TableLinkingTokens:   1, // Margen para depurar una vinculación fallida
+	TableOutboxDelivered: 7, // El historial de notifications ya registra la entrega
+	TableOutboxDead:      30,
+}
+
+const (
+	DefaultPurgeBatchSize = 5000
+	MaxPurgeBatchSize     = 50000
+)
+
+// Policy - Antigüedad máxima por tabla, override por plan y tamaño de lote
+type Policy struct {
+	maxAge        map[Table]time.Duration
+	planRetention map[Plan]time.Duration
+	batchSize     int
+}
+
+func NewDefaultPolicy() *Policy {
+	policy := &Policy{
+		maxAge:        make(map[Table]time.Duration, len(DefaultTableRetentionDays)),
+		planRetention: make(map[Plan]time.Duration, len(PlanRetentionDays)),
+		batchSize:     DefaultPurgeBatchSize,
+	}
+	for table, days := range DefaultTableRetentionDays {
+		policy.maxAge[table] = time.Duration(days) * 24 * time.Hour
+	}
+	for plan, days := range PlanRetentionDays {
+		policy.planRetention[plan] = time.Duration(days) * 24 * time.Hour
+	}
+	return policy
+}
+
+// SetMaxAge cambia la antigüedad máxima de una tabla
+func (p *Policy) SetMaxAge(table Table, days int) error {
+	if _, known := p.maxAge[table]; !known {
+		return ErrUnknownTable
+	}
+	if days < 1 {
+		return ErrInvalidRetentionDays
+	}
+	p.maxAge[table] = time.Duration(days) * 24 * time.Hour
+	return nil
+}
+
+// SetPlanRetention cambia la retención de un plan (reemplaza el máximo de las tablas del usuario)
+func (p *Policy) SetPlanRetention(plan Plan, days int) error {
+	if _, known := p.planRetention[plan]; !known {
+		return ErrInvalidPlan
+	}
+	if days < 1 {
+		return ErrInvalidRetentionDays
+	}
+	p.planRetention[plan] = time.Duration(days) * 24 * time.Hour
+	return nil
+}
+
+func (p *Policy) SetBatchSize(size int) error {
+	if size < 1 || size > MaxPurgeBatchSize {
+		return ErrInvalidPurgeBatchSize
+	}
+	p.batchSize = size
+	return nil
+}
+
+func (p *Policy) MaxAge(table Table) time.Duration {
+	return p.maxAge[table]
+}
+
+func (p *Policy) PlanRetention(plan Plan) time.Duration {
+	return p.planRetention[plan]
+}
+
+func (p *Policy) BatchSize() int {
+	return p.batchSize
+}
+
+// Cohort - Filas de una tabla que comparten fecha de corte
+type Cohort struct {
+	Table          Table
+	Plan           Plan // Vacío = máximo de la tabla
+	Cutoff         time.Time
+	UserIds        []string // Solo estos usuarios (nil = todos)
+	ExcludeUserIds []string // Usuarios que ya purga la cohorte de su plan
+}
+
+// Cohorts reparte la tabla por plan: una cohorte por plan con usuarios y una por defecto
+// para el resto. Sin planes conocidos (o en tablas sin dueño) queda una sola cohorte.
+func (p *Policy) Cohorts(table Table, plans map[string]Plan, now time.Time) []Cohort {
+	fallback := Cohort{Table: table, Cutoff: now.Add(-p.maxAge[table])}
+	if !table.IsUserScoped() || len(plans) == 0 {
+		return []Cohort{fallback}
+	}
+
+	usersByPlan := make(map[Plan][]string)
+	for userId, plan := range plans {
+		if _, ok := p.planRetention[plan]; ok {
+			usersByPlan[plan] = append(usersByPlan[plan], userId)
+		}
+	}
+
+	cohorts := make([]Cohort, 0, len(usersByPlan)+1)
+	for _, plan := range []Plan{PlanFree, PlanPro, PlanEnterprise} {
+		users := usersByPlan[plan]
+		if len(users) == 0 {
+			continue
+		}
+		sort.Strings(users)
+		cohorts = append(cohorts, Cohort{
+			Table:   table,
+			Plan:    plan,
+			Cutoff:  now.Add(-p.planRetention[plan]),
+			UserIds: users,
+		})
+		fallback.ExcludeUserIds = append(fallback.ExcludeUserIds, users...)
+	}
+	sort.Strings(fallback.ExcludeUserIds)
+	return append(cohorts, fallback)
+}
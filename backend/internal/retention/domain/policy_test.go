@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPolicy_Cohorts(t *testing.T) {
+	policy := NewDefaultPolicy()
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	plans := map[string]Plan{
+		"user-b": PlanPro,
+		"user-a": PlanPro,
+		"user-c": PlanEnterprise,
+	}
+
+	cohorts := policy.Cohorts(TableMetrics, plans, now)
+	if len(cohorts) != 3 {
+		t.Fatalf("Expected PRO, ENTERPRISE and default cohorts, got %d", len(cohorts))
+	}
+	if cohorts[0].Plan != PlanPro || len(cohorts[0].UserIds) != 2 || !cohorts[0].Cutoff.Equal(now.Add(-30*24*time.Hour)) {
+		t.Errorf("Unexpected PRO cohort: %+v", cohorts[0])
+	}
+	if cohorts[1].Plan != PlanEnterprise || !cohorts[1].Cutoff.Equal(now.Add(-365*24*time.Hour)) {
+		t.Errorf("Unexpected ENTERPRISE cohort: %+v", cohorts[1])
+	}
+	fallback := cohorts[2]
+	if fallback.Plan != "" || fallback.UserIds != nil || len(fallback.ExcludeUserIds) != 3 {
+		t.Errorf("Expected a default cohort excluding the 3 users with a plan, got %+v", fallback)
+	}
+	if !fallback.Cutoff.Equal(now.Add(-90 * 24 * time.Hour)) {
+		t.Errorf("Expected the 90-day metrics default, got %s", fallback.Cutoff)
+	}
+
+	// Los tokens no tienen dueño: una sola cohorte aunque haya planes
+	tokens := policy.Cohorts(TableLinkingTokens, plans, now)
+	if len(tokens) != 1 || tokens[0].ExcludeUserIds != nil {
+		t.Errorf("Expected a single unscoped cohort for linking tokens, got %+v", tokens)
+	}
+
+	// Los rollups duran según su resolución, no según el plan
+	minutes := policy.Cohorts(TableRollups1m, plans, now)
+	if len(minutes) != 1 || !minutes[0].Cutoff.Equal(now.Add(-7*24*time.Hour)) {
+		t.Errorf("Expected a single 7-day cohort for 1m rollups, got %+v", minutes)
+	}
+	if policy.MaxAge(TableRollups1h) <= policy.MaxAge(TableMetrics) || policy.MaxAge(TableRollups1d) <= policy.MaxAge(TableRollups1h) {
+		t.Error("Expected coarser rollups to outlive raw metrics and finer rollups")
+	}
+
+	// El outbox no tiene user_id: las cartas muertas duran más que las entregadas
+	if TableOutboxDead.IsUserScoped() || policy.MaxAge(TableOutboxDead) <= policy.MaxAge(TableOutboxDelivered) {
+		t.Error("Expected unscoped outbox tables keeping dead letters longer than delivered messages")
+	}
+
+	// Los pings crudos siguen la retención de su target: el plan no la cambia
+	pings := policy.Cohorts(TablePingSamples, plans, now)
+	if len(pings) != 1 || !pings[0].Cutoff.Equal(now.Add(-14*24*time.Hour)) {
+		t.Errorf("Expected a single 14-day cap cohort for ping samples, got %+v", pings)
+	}
+}
+
+func TestPolicy_EveryTableHasDefault(t *testing.T) {
+	policy := NewDefaultPolicy()
+	for _, table := range Tables {
+		if policy.MaxAge(table) <= 0 {
+			t.Errorf("Expected a default max age for %s", table)
+		}
+	}
+}
+
+func TestPolicy_Overrides(t *testing.T) {
+	policy := NewDefaultPolicy()
+
+	if err := policy.SetMaxAge(TableNotifications, 0); err != ErrInvalidRetentionDays {
+		t.Errorf("Expected ErrInvalidRetentionDays, got %v", err)
+	}
+	if err := policy.SetMaxAge(Table("users"), 30); err != ErrUnknownTable {
+		t.Errorf("Expected ErrUnknownTable, got %v", err)
+	}
+	if err := policy.SetBatchSize(MaxPurgeBatchSize + 1); err != ErrInvalidPurgeBatchSize {
+		t.Errorf("Expected ErrInvalidPurgeBatchSize, got %v", err)
+	}
+	if err := policy.SetPlanRetention(PlanFree, 14); err != nil {
+		t.Fatalf("Expected plan override, got %v", err)
+	}
+	if policy.PlanRetention(PlanFree) != 14*24*time.Hour {
+		t.Errorf("Expected 14 days for FREE, got %s", policy.PlanRetention(PlanFree))
+	}
+	if _, err := NewPlan("gold"); err != ErrInvalidPlan {
+		t.Errorf("Expected ErrInvalidPlan, got %v", err)
+	}
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// CohortPurge - Resultado de purgar una cohorte
+type CohortPurge struct {
+	Table   Table
+	Plan    Plan
+	Cutoff  time.Time
+	Deleted int64
+	Batches int
+	Err     error // La purga de la cohorte se corta en el primer error; las demás siguen
+}
+
+// PurgeReport - Qué se borró en una pasada
+type PurgeReport struct {
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Cohorts    []CohortPurge
+}
+
+func (r PurgeReport) TotalDeleted() int64 {
+	var total int64
+	for _, c := range r.Cohorts {
+		total += c.Deleted
+	}
+	return total
+}
+
+func (r PurgeReport) DeletedByTable() map[Table]int64 {
+	deleted := make(map[Table]int64, len(Tables))
+	for _, c := range r.Cohorts {
+		deleted[c.Table] += c.Deleted
+	}
+	return deleted
+}
+
+func (r PurgeReport) HasErrors() bool {
+	for _, c := range r.Cohorts {
+		if c.Err != nil {
+			return true
+		}
+	}
+	return false
+}
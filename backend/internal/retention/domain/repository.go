@@ -0,0 +1,11 @@
+package domain
+
+type Purger interface {
+	// PurgeBatch borra hasta `limit` filas de la cohorte anteriores a su corte y devuelve cuántas borró
+	PurgeBatch(cohort Cohort, limit int) (int64, error)
+}
+
+type PlanDirectory interface {
+	// UserPlans devuelve el plan vigente de cada usuario (los ausentes usan el máximo de la tabla)
+	UserPlans() (map[string]Plan, error)
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"log"
+	"uptrackai/internal/retention/domain"
+
+	"gorm.io/gorm"
+)
+
+// PostgresPlanDirectory lee el plan de cada usuario de la tabla memberships (ROADMAP, fase 4).
+// Mientras esa tabla no exista nadie tiene plan y rige el máximo de cada tabla.
+type PostgresPlanDirectory struct {
+	db *gorm.DB
+}
+
+func NewPostgresPlanDirectory(db *gorm.DB) *PostgresPlanDirectory {
+	return &PostgresPlanDirectory{db: db}
+}
+
+func (d *PostgresPlanDirectory) UserPlans() (map[string]domain.Plan, error) {
+	var exists bool
+	if err := d.db.Raw(`SELECT to_regclass('memberships') IS NOT NULL`).Scan(&exists).Error; err != nil {
+		return nil, err
+	}
+	if !exists {
+		return map[string]domain.Plan{}, nil
+	}
+
+	var rows []struct {
+		UserID   string
+		PlanName string
+	}
+	err := d.db.Raw(`SELECT user_id::text AS user_id, plan_name FROM memberships WHERE status = 'ACTIVE'`).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make(map[string]domain.Plan, len(rows))
+	for _, row := range rows {
+		plan, err := domain.NewPlan(row.PlanName)
+		if err != nil {
+			log.Printf("⚠️  Plan desconocido %q para el usuario %s: se usa la retención por defecto", row.PlanName, row.UserID)
+			continue
+		}
+		plans[row.UserID] = plan
+	}
+	return plans, nil
+}
@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"uptrackai/internal/retention/domain"
+
+	"gorm.io/gorm"
+)
+
+// rollupResolutions - Valor de metric_rollups.resolution de cada tabla de rollups
+var rollupResolutions = map[domain.Table]string{
+	domain.TableRollups1m: "1m",
+	domain.TableRollups1h: "1h",
+	domain.TableRollups1d: "1d",
+}
+
+type PostgresPurger struct {
+	db *gorm.DB
+}
+
+func NewPostgresPurger(db *gorm.DB) *PostgresPurger {
+	return &PostgresPurger{db: db}
+}
+
+// PurgeBatch borra un lote por ctid: la subconsulta elige hasta `limit` filas vencidas y el
+// DELETE las toma por dirección física, así cada lote es una transacción corta con locks de fila.
+func (p *PostgresPurger) PurgeBatch(cohort domain.Cohort, limit int) (int64, error) {
+	var (
+		table     = cohort.Table.String()
+		selection string
+		args      []interface{}
+	)
+
+	switch cohort.Table {
+	case domain.TableMetrics:
+		selection = `SELECT m.ctid FROM metrics m`
+		if scoped(cohort) {
+			selection += ` JOIN monitoring_targets t ON t.id = m.monitoring_target_id`
+		}
+		selection += ` WHERE m.timestamp < ?`
+		args = append(args, cohort.Cutoff)
+		selection, args = withUserScope(selection, args, "t.user_id::text", cohort)
+
+	case domain.TableRollups1m, domain.TableRollups1h, domain.TableRollups1d:
+		table = "metric_rollups"
+		selection = `SELECT ctid FROM metric_rollups WHERE resolution = ? AND bucket_start < ?`
+		args = append(args, rollupResolutions[cohort.Table], cohort.Cutoff)
+
+	case domain.TableCheckResults:
+		// Se conserva el último cambio de estado anterior al corte: sin él no se sabe en qué
+		// estado arrancaba el historial que queda (uptime, SLOs). Solo sobra si hay otro más
+		// nuevo que también es anterior al corte; los posteriores no lo reemplazan.
+		selection = `SELECT c.ctid FROM check_results c`
+		if scoped(cohort) {
+			selection += ` JOIN monitoring_targets t ON t.id = c.monitoring_target_id`
+		}
+		selection += ` WHERE c.timestamp < ?
+			AND EXISTS (SELECT 1 FROM check_results n
+				WHERE n.monitoring_target_id = c.monitoring_target_id
+					AND n.timestamp > c.timestamp AND n.timestamp < ?)`
+		args = append(args, cohort.Cutoff, cohort.Cutoff)
+		selection, args = withUserScope(selection, args, "t.user_id::text", cohort)
+
+	case domain.TablePingSamples:
+		// Retención de cada target (aunque ya no guarde pings), con la de la tabla como tope;
+		// los pings de targets eliminados se van en la primera pasada
+		selection = `SELECT ps.ctid FROM ping_samples ps
+			LEFT JOIN monitoring_targets t ON t.id = ps.target_id
+			WHERE t.id IS NULL OR ps.timestamp < ?
+				OR ps.timestamp < NOW() - make_interval(days => GREATEST(t.raw_samples_retention_days, 1))`
+		args = append(args, cohort.Cutoff)
+
+	case domain.TableNotifications:
+		selection = `SELECT ctid FROM notifications WHERE created_at < ?`
+		args = append(args, cohort.Cutoff)
+		selection, args = withUserScope(selection, args, "user_id", cohort)
+
+	case domain.TableLinkingTokens:
+		selection = `SELECT ctid FROM telegram_linking_tokens WHERE expires_at < ? OR (used AND created_at < ?)`
+		args = append(args, cohort.Cutoff, cohort.Cutoff)
+
+	case domain.TableOutboxDelivered:
+		// Los cancelados (alertas agrupadas antes de salir) nunca se entregan: cuentan desde que se crearon
+		table = "notification_outbox"
+		selection = `SELECT ctid FROM notification_outbox
+			WHERE (status = 'DELIVERED' AND delivered_at < ?) OR (status = 'CANCELLED' AND created_at < ?)`
+		args = append(args, cohort.Cutoff, cohort.Cutoff)
+
+	case domain.TableOutboxDead:
+		// next_attempt_at de una carta muerta es su último intento (el lease del reclamo)
+		table = "notification_outbox"
+		selection = `SELECT ctid FROM notification_outbox WHERE status = 'DEAD' AND next_attempt_at < ?`
+		args = append(args, cohort.Cutoff)
+
+	default:
+		return 0, domain.ErrUnknownTable
+	}
+
+	query := fmt.Sprintf(`DELETE FROM %s WHERE ctid = ANY(ARRAY(%s LIMIT ?))`, table, selection)
+	args = append(args, limit)
+
+	result := p.db.Exec(query, args...)
+	return result.RowsAffected, result.Error
+}
+
+func scoped(cohort domain.Cohort) bool {
+	return cohort.UserIds != nil || len(cohort.ExcludeUserIds) > 0
+}
+
+func withUserScope(selection string, args []interface{}, column string, cohort domain.Cohort) (string, []interface{}) {
+	var conditions []string
+	if cohort.UserIds != nil {
+		conditions = append(conditions, column+" IN ?")
+		args = append(args, cohort.UserIds)
+	}
+	if len(cohort.ExcludeUserIds) > 0 {
+		conditions = append(conditions, column+" NOT IN ?")
+		args = append(args, cohort.ExcludeUserIds)
+	}
+	if len(conditions) == 0 {
+		return selection, args
+	}
+	return selection + " AND " + strings.Join(conditions, " AND "), args
+}
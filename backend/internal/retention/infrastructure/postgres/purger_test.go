@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"uptrackai/internal/retention/domain"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunPurger - Purger sin conexión que guarda la última sentencia y sus argumentos
+func dryRunPurger(t *testing.T) (*PostgresPurger, func() (string, []interface{})) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 port=1"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	if err != nil {
+		t.Fatalf("Expected a dry-run connection, got %v", err)
+	}
+	var (
+		sql  string
+		vars []interface{}
+	)
+	err = db.Callback().Raw().After("gorm:raw").Register("test:capture", func(tx *gorm.DB) {
+		sql, vars = tx.Statement.SQL.String(), tx.Statement.Vars
+	})
+	if err != nil {
+		t.Fatalf("Expected the capture callback registered, got %v", err)
+	}
+	return NewPostgresPurger(db), func() (string, []interface{}) { return sql, vars }
+}
+
+func TestPurgeBatch_CheckResultsKeepsLastChangeBeforeCutoff(t *testing.T) {
+	purger, last := dryRunPurger(t)
+	cutoff := time.Now().AddDate(0, 0, -30)
+
+	if _, err := purger.PurgeBatch(domain.Cohort{Table: domain.TableCheckResults, Cutoff: cutoff}, 500); err != nil {
+		t.Fatalf("Expected the batch built, got %v", err)
+	}
+	sql, vars := last()
+
+	// Un cambio posterior al corte no reemplaza al último anterior: el reemplazo también tiene que estar vencido
+	if !strings.Contains(sql, "n.timestamp > c.timestamp AND n.timestamp < $2") {
+		t.Errorf("Expected the newer change restricted to the purged range, got %s", sql)
+	}
+	if len(vars) != 3 || vars[0] != cutoff || vars[1] != cutoff || vars[2] != 500 {
+		t.Errorf("Expected (cutoff, cutoff, limit) as arguments, got %v", vars)
+	}
+}
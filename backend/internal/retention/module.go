@@ -0,0 +1,83 @@
+package retention
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"uptrackai/internal/retention/application"
+	"uptrackai/internal/retention/domain"
+	"uptrackai/internal/retention/infrastructure/postgres"
+	"uptrackai/internal/retention/presentation"
+	"uptrackai/internal/retention/scheduler"
+
+	"gorm.io/gorm"
+)
+
+const (
+	purgeInterval   = 6 * time.Hour
+	purgeBatchPause = 200 * time.Millisecond
+)
+
+// Module encapsula la purga de datos vencidos
+type Module struct {
+	Handler *presentation.RetentionHandler
+	Service *application.RetentionService
+}
+
+// NewModule arma la política desde el entorno:
+// RETENTION_<TABLA>_DAYS, RETENTION_PLAN_<PLAN>_DAYS y RETENTION_BATCH_SIZE
+func NewModule(db *gorm.DB) *Module {
+	policy := domain.NewDefaultPolicy()
+
+	for _, table := range domain.Tables {
+		if days, ok := envInt("RETENTION_" + strings.ToUpper(table.String()) + "_DAYS"); ok {
+			if err := policy.SetMaxAge(table, days); err != nil {
+				log.Printf("⚠️  Retención de %s ignorada: %v", table, err)
+			}
+		}
+	}
+	for plan := range domain.PlanRetentionDays {
+		if days, ok := envInt("RETENTION_PLAN_" + plan.String() + "_DAYS"); ok {
+			if err := policy.SetPlanRetention(plan, days); err != nil {
+				log.Printf("⚠️  Retención del plan %s ignorada: %v", plan, err)
+			}
+		}
+	}
+	if size, ok := envInt("RETENTION_BATCH_SIZE"); ok {
+		if err := policy.SetBatchSize(size); err != nil {
+			log.Printf("⚠️  RETENTION_BATCH_SIZE ignorado: %v", err)
+		}
+	}
+
+	service := application.NewRetentionService(
+		postgres.NewPostgresPurger(db),
+		postgres.NewPostgresPlanDirectory(db),
+		policy,
+		purgeBatchPause,
+	)
+
+	return &Module{
+		Handler: presentation.NewRetentionHandler(service),
+		Service: service,
+	}
+}
+
+// Start lanza la purga periódica (non-blocking)
+func (m *Module) Start() {
+	scheduler.NewPurgeScheduler(m.Service, purgeInterval).Start()
+}
+
+func envInt(key string) (int, bool) {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return 0, false
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		log.Printf("⚠️  %s no es un número: %q", key, raw)
+		return 0, false
+	}
+	return value, true
+}
@@ -0,0 +1,78 @@
+package presentation
+
+import (
+	"errors"
+	"net/http"
+	"time"
+	"uptrackai/internal/app"
+	"uptrackai/internal/retention/application"
+	securitypresentation "uptrackai/internal/security/presentation"
+
+	"github.com/gin-gonic/gin"
+)
+
+func buildRetentionErrorResponse(c *gin.Context, status int, code, msg string) {
+	resp := app.BuildErrorResponse(msg, false).
+		WithMeta("error_code", code).
+		WithMeta("timestamp", time.Now().Format(time.RFC3339))
+	c.JSON(status, resp)
+}
+
+type RetentionHandler struct {
+	service *application.RetentionService
+}
+
+func NewRetentionHandler(service *application.RetentionService) *RetentionHandler {
+	return &RetentionHandler{service: service}
+}
+
+// RegisterRoutes registra las rutas de administración (solo ADMIN)
+func (h *RetentionHandler) RegisterRoutes(router *gin.RouterGroup) {
+	admin := router.Group("/admin/retention", securitypresentation.AuthMiddleware(securitypresentation.RoleAdmin))
+	admin.GET("", h.GetStatus)
+	admin.POST("/purge", h.Purge)
+}
+
+// GetStatus devuelve la política de retención y el reporte de la última purga
+// @Summary Get retention status
+// @Description Retention days per table and per plan, plus what the last purge deleted
+// @Tags admin
+// @Produce json
+// @Success 200 {object} app.APIResponse{data=application.RetentionStatusDTO}
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Security BearerAuth
+// @Router /admin/retention [get]
+func (h *RetentionHandler) GetStatus(c *gin.Context) {
+	response := app.BuildOKResponse("retention_status_retrieved", true, h.service.GetStatus()).
+		WithLink("self", "/api/v1/admin/retention").
+		WithLink("purge", "/api/v1/admin/retention/purge")
+	c.JSON(http.StatusOK, response)
+}
+
+// Purge ejecuta una purga inmediata y devuelve el reporte
+// @Summary Run a retention purge
+// @Description Purge expired rows now (batched) and return what was deleted
+// @Tags admin
+// @Produce json
+// @Success 200 {object} app.APIResponse{data=application.PurgeReportDTO}
+// @Failure 401 {object} app.APIResponse "Unauthorized"
+// @Failure 403 {object} app.APIResponse "Forbidden"
+// @Failure 409 {object} app.APIResponse "A purge is already running"
+// @Security BearerAuth
+// @Router /admin/retention/purge [post]
+func (h *RetentionHandler) Purge(c *gin.Context) {
+	report, err := h.service.Purge()
+	if err != nil {
+		if errors.Is(err, application.ErrPurgeInProgress) {
+			buildRetentionErrorResponse(c, http.StatusConflict, "purge_in_progress", err.Error())
+			return
+		}
+		buildRetentionErrorResponse(c, http.StatusInternalServerError, "purge_failed", "Failed to run retention purge")
+		return
+	}
+
+	response := app.BuildOKResponse("retention_purged", true, report).
+		WithLink("status", "/api/v1/admin/retention")
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"errors"
+	"log"
+	"time"
+	"uptrackai/internal/retention/application"
+)
+
+// PurgeScheduler ejecuta la purga de retención periódicamente y deja el reporte en el log
+type PurgeScheduler struct {
+	service  *application.RetentionService
+	interval time.Duration
+	stopChan chan struct{}
+}
+
+func NewPurgeScheduler(service *application.RetentionService, interval time.Duration) *PurgeScheduler {
+	return &PurgeScheduler{
+		service:  service,
+		interval: interval,
+		stopChan: make(chan struct{}),
+	}
+}
+
+// Start purga una vez al arrancar y luego cada `interval` (non-blocking)
+func (s *PurgeScheduler) Start() {
+	log.Printf("🗑️  Retention Purger iniciado (Intervalo: %s)", s.interval)
+	go s.runLoop()
+}
+
+func (s *PurgeScheduler) Stop() {
+	close(s.stopChan)
+}
+
+func (s *PurgeScheduler) runLoop() {
+	s.purge()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.purge()
+		case <-s.stopChan:
+			return
+		}
+	}
+}
+
+func (s *PurgeScheduler) purge() {
+	report, err := s.service.Purge()
+	if errors.Is(err, application.ErrPurgeInProgress) {
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Error en la purga de retención: %v", err)
+		return
+	}
+
+	for _, cohort := range report.Cohorts {
+		if cohort.Error != "" {
+			log.Printf("❌ Purga de %s (plan %q) cortada tras %d filas: %s", cohort.Table, cohort.Plan, cohort.Deleted, cohort.Error)
+		}
+	}
+	log.Printf("🗑️  Retención: %d filas purgadas en %dms %v", report.TotalDeleted, report.DurationMs, report.Deleted)
+}
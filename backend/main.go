@@ -8,6 +8,7 @@ import (
 	_ "uptrackai/docs" // This is required for swagger
 	"uptrackai/internal/monitoring"
 	"uptrackai/internal/notifications"
+	"uptrackai/internal/retention"
 	"uptrackai/internal/security"
 	"uptrackai/internal/user"
 
@@ -65,6 +66,7 @@ func main() {
 	monitoringModule := monitoring.NewModule(db, notificationsModule.Service)
 	securityModule := security.NewModule(db)
 	userModule := user.NewModule(db)
+	retentionModule := retention.NewModule(db)
 
 	// 4. HTTP Server en goroutine separada
 	go config.StartHTTPServer("8080", telemetry,
@@ -74,8 +76,14 @@ func main() {
 		notificationsModule.ConfigHandler,
 		notificationsModule.LinkingHandler,
 		notificationsModule.WebhookHandler,
+		notificationsModule.OutboxHandler,
+		retentionModule.Handler,
 	)
 
-	// 5. Scheduler bloquea el main thread
+	// 5. Purga de datos vencidos y entrega de alertas encoladas (non-blocking)
+	retentionModule.Start()
+	notificationsModule.Start()
+
+	// 6. Scheduler bloquea el main thread
 	monitoringModule.StartScheduler()
 }